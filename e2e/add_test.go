@@ -0,0 +1,62 @@
+package e2e_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAddForceLeavesOtherCopiesAlone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	env := newE2EEnv(t, "copy")
+	createSkill(t, filepath.Join(env.agentsDir, "skills", "mine"), "mine")
+	if out, err := runSkillet(t, env, "sync", "--global"); err != nil {
+		t.Fatalf("sync failed: %v\noutput:\n%s", err, out)
+	}
+	// A copy of another skill, edited in the target past the store's version.
+	edited := filepath.Join(env.root, ".claude", "skills", "mine", "SKILL.md")
+	handEdit := "---\nname: mine\ndescription: e2e test skill\nversion: 2.0.0\n---\n\nHand edited\n"
+	if err := os.WriteFile(edited, []byte(handEdit), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := filepath.Join(env.root, "source")
+	createSkill(t, filepath.Join(repo, "x"), "x")
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "--quiet")
+	git("add", ".")
+	git("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "init")
+
+	source := "file://" + filepath.ToSlash(repo) + "//x"
+	if out, err := runSkillet(t, env, "add", "--global", source); err != nil {
+		t.Fatalf("add failed: %v\noutput:\n%s", err, out)
+	}
+	// The source changes, so add --force replaces the skill.
+	if err := os.WriteFile(filepath.Join(repo, "x", "NOTES.md"), []byte("new\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "update")
+	out, err := runSkillet(t, env, "add", "--global", "--force", source)
+	if err != nil || !strings.Contains(out, "Replaced x") {
+		t.Fatalf("add --force failed: %v\noutput:\n%s", err, out)
+	}
+
+	if _, err := os.Stat(filepath.Join(env.root, ".claude", "skills", "x", "NOTES.md")); err != nil {
+		t.Fatalf("expected the replaced skill to be synced: %v\noutput:\n%s", err, out)
+	}
+	if got, err := os.ReadFile(edited); err != nil || string(got) != handEdit {
+		t.Fatalf("copy of mine = %q (err=%v), want the hand-edited copy kept", got, err)
+	}
+}
@@ -0,0 +1,22 @@
+package e2e_test
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAssertExitCodes(t *testing.T) {
+	env := newE2EEnv(t, "copy")
+	skillName := "assert-e2e-skill"
+	createSkill(t, filepath.Join(env.agentsDir, "skills", skillName), skillName)
+
+	if code, out := runSkilletExitCode(t, env, "assert", "exists", skillName); code != 0 || out != "" {
+		t.Fatalf("assert exists = %d with output %q, want 0 and no output", code, out)
+	}
+	if code, out := runSkilletExitCode(t, env, "assert", "exists", "no-such-skill"); code != 2 || out != "" {
+		t.Fatalf("failed assertion = %d with output %q, want 2 and no output", code, out)
+	}
+	if code, out := runSkilletExitCode(t, env, "assert", "installed", skillName, "--target", "no-such-target"); code != 1 {
+		t.Fatalf("assert with an unknown target = %d, want 1\noutput:\n%s", code, out)
+	}
+}
@@ -0,0 +1,29 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncHonorsDryRunEnv(t *testing.T) {
+	env := newE2EEnv(t, "copy")
+	skillName := "dry-run-env-skill"
+	createSkill(t, filepath.Join(env.agentsDir, "skills", skillName), skillName)
+
+	t.Setenv("SKILLET_DRY_RUN", "1")
+	out, err := runSkillet(t, env, "sync", "--global")
+	if err != nil {
+		t.Fatalf("sync failed: %v\noutput:\n%s", err, out)
+	}
+
+	if !strings.Contains(out, "SKILLET_DRY_RUN is set") {
+		t.Fatalf("expected dry-run notice in output:\n%s", out)
+	}
+
+	installed := filepath.Join(env.root, ".claude", "skills", skillName)
+	if _, err := os.Lstat(installed); !os.IsNotExist(err) {
+		t.Fatalf("expected %s not to be installed under SKILLET_DRY_RUN (err=%v)", installed, err)
+	}
+}
@@ -0,0 +1,106 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProjectSyncInstallsToProjectTargets(t *testing.T) {
+	env := newE2EEnv(t, "copy")
+
+	if out, err := runSkilletInProject(t, env, "init", "--project", "--yes"); err != nil {
+		t.Fatalf("init failed: %v\noutput:\n%s", err, out)
+	}
+
+	skillName := "project-e2e-skill"
+	createSkill(t, filepath.Join(env.projectDir, ".agents", "skills", skillName), skillName)
+	globalSkill := "global-e2e-skill"
+	createSkill(t, filepath.Join(env.agentsDir, "skills", globalSkill), globalSkill)
+
+	out, err := runSkilletInProject(t, env, "sync", "--project")
+	if err != nil {
+		t.Fatalf("sync failed: %v\noutput:\n%s", err, out)
+	}
+
+	for _, target := range []string{".claude", ".codex"} {
+		installed := filepath.Join(env.projectDir, target, "skills", skillName, "SKILL.md")
+		if _, err := os.Stat(installed); err != nil {
+			t.Fatalf("expected project skill at %s: %v\noutput:\n%s", installed, err, out)
+		}
+		if _, err := os.Lstat(filepath.Join(env.root, target, "skills", skillName)); !os.IsNotExist(err) {
+			t.Fatalf("project skill must not be installed in global %s (err=%v)", target, err)
+		}
+		if _, err := os.Lstat(filepath.Join(env.projectDir, target, "skills", globalSkill)); !os.IsNotExist(err) {
+			t.Fatalf("--project sync must not install global skills (err=%v)", err)
+		}
+	}
+
+	out, err = runSkilletInProject(t, env, "status", "--project")
+	if err != nil {
+		t.Fatalf("status failed: %v\noutput:\n%s", err, out)
+	}
+	if !strings.Contains(out, skillName) {
+		t.Fatalf("expected status to list %s:\n%s", skillName, out)
+	}
+
+	if out, err := runSkilletInProject(t, env, "remove", "--project", skillName); err != nil {
+		t.Fatalf("remove failed: %v\noutput:\n%s", err, out)
+	}
+	for _, target := range []string{".claude", ".codex"} {
+		installed := filepath.Join(env.projectDir, target, "skills", skillName)
+		if _, err := os.Lstat(installed); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be removed (err=%v)", installed, err)
+		}
+	}
+}
+
+func TestProjectRootRequiresInitializedProject(t *testing.T) {
+	env := newE2EEnv(t, "copy")
+
+	out, err := runSkilletInProject(t, env, "sync", "--project")
+	if err == nil {
+		t.Fatalf("expected sync --project to fail for uninitialized project root\noutput:\n%s", out)
+	}
+	if !strings.Contains(out, "not in a project directory") {
+		t.Fatalf("unexpected error output:\n%s", out)
+	}
+}
+
+func TestBootstrapDevcontainerReplacesHostLinks(t *testing.T) {
+	env := newE2EEnv(t, "symlink")
+
+	if out, err := runSkilletInProject(t, env, "init", "--project", "--yes"); err != nil {
+		t.Fatalf("init failed: %v\noutput:\n%s", err, out)
+	}
+	skillName := "container-skill"
+	createSkill(t, filepath.Join(env.projectDir, ".agents", "skills", skillName), skillName)
+
+	// A link left by a sync on the host, pointing into a home that does not exist here.
+	hostLink := filepath.Join(env.projectDir, ".claude", "skills", skillName)
+	if err := os.MkdirAll(filepath.Dir(hostLink), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/nonexistent/host/.agents/skills/"+skillName, hostLink); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runSkilletInProject(t, env, "bootstrap", "--devcontainer")
+	if err != nil {
+		t.Fatalf("bootstrap failed: %v\noutput:\n%s", err, out)
+	}
+
+	for _, target := range []string{".claude", ".codex"} {
+		installed := filepath.Join(env.projectDir, target, "skills", skillName)
+		info, err := os.Lstat(installed)
+		if err != nil || info.Mode()&os.ModeSymlink != 0 || !info.IsDir() {
+			t.Fatalf("expected a copied directory at %s (err=%v)\noutput:\n%s", installed, err, out)
+		}
+	}
+
+	out, err = runSkilletInProject(t, env, "bootstrap", "--devcontainer", "--print-snippet")
+	if err != nil || !strings.Contains(out, `"postCreateCommand": "skillet bootstrap --devcontainer"`) {
+		t.Fatalf("unexpected snippet (err=%v):\n%s", err, out)
+	}
+}
@@ -0,0 +1,30 @@
+package e2e_test
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStatusExitCodes(t *testing.T) {
+	env := newE2EEnv(t, "copy")
+	skillName := "status-e2e-skill"
+	createSkill(t, filepath.Join(env.agentsDir, "skills", skillName), skillName)
+
+	exitCode := func(args ...string) (int, string) {
+		t.Helper()
+		return runSkilletExitCode(t, env, args...)
+	}
+
+	if code, out := exitCode("status", "--global", "--quiet"); code != 1 || out != "" {
+		t.Fatalf("status before sync = %d with output %q, want 1 and no output", code, out)
+	}
+	if out, err := runSkillet(t, env, "sync", "--global"); err != nil {
+		t.Fatalf("sync failed: %v\noutput:\n%s", err, out)
+	}
+	if code, out := exitCode("status", "--global", "--quiet"); code != 0 || out != "" {
+		t.Fatalf("status after sync = %d with output %q, want 0 and no output", code, out)
+	}
+	if code, out := exitCode("status", "--global", "--no-such-flag"); code != 2 {
+		t.Fatalf("status with a bad flag = %d, want 2\noutput:\n%s", code, out)
+	}
+}
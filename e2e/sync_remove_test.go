@@ -2,6 +2,7 @@ package e2e_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -75,6 +76,7 @@ type e2eEnv struct {
 	agentsDir  string
 	configPath string
 	homeDir    string
+	projectDir string
 }
 
 func newE2EEnv(t *testing.T, strategy string) *e2eEnv {
@@ -85,10 +87,12 @@ func newE2EEnv(t *testing.T, strategy string) *e2eEnv {
 	agentsDir := filepath.Join(root, ".agents")
 	configPath := filepath.Join(root, "config.yaml")
 	homeDir := filepath.Join(root, "home")
+	projectDir := filepath.Join(root, "project")
 	binaryPath := buildSkilletBinary(t, moduleRoot, root)
 
 	for _, dir := range []string{
 		homeDir,
+		projectDir,
 		agentsDir,
 		filepath.Join(agentsDir, "skills"),
 		filepath.Join(agentsDir, "skills", "optional"),
@@ -127,6 +131,7 @@ targets:
 		agentsDir:  agentsDir,
 		configPath: configPath,
 		homeDir:    homeDir,
+		projectDir: projectDir,
 	}
 }
 
@@ -184,6 +189,31 @@ func runSkillet(t *testing.T, env *e2eEnv, args ...string) (string, error) {
 	return out.String(), err
 }
 
+// runSkilletExitCode runs skillet and returns its exit code with its output.
+func runSkilletExitCode(t *testing.T, env *e2eEnv, args ...string) (int, string) {
+	t.Helper()
+
+	out, err := runSkillet(t, env, args...)
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0, out
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode(), out
+	default:
+		t.Fatalf("failed to run skillet: %v", err)
+		return -1, out
+	}
+}
+
+// runSkilletInProject runs skillet with env.projectDir as the explicit project root,
+// independent of the working directory.
+func runSkilletInProject(t *testing.T, env *e2eEnv, args ...string) (string, error) {
+	t.Helper()
+
+	return runSkillet(t, env, append([]string{"--project-root", env.projectDir}, args...)...)
+}
+
 func mustModuleRoot(t *testing.T) string {
 	t.Helper()
 
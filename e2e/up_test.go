@@ -0,0 +1,42 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpSyncsAndPrunesOrphans(t *testing.T) {
+	env := newE2EEnv(t, "symlink")
+	kept := filepath.Join(env.agentsDir, "skills", "up-kept")
+	removed := filepath.Join(env.agentsDir, "skills", "up-removed")
+	createSkill(t, kept, "up-kept")
+	createSkill(t, removed, "up-removed")
+
+	if out, err := runSkillet(t, env, "up", "--yes"); err != nil {
+		t.Fatalf("up failed: %v\noutput:\n%s", err, out)
+	}
+	for _, target := range []string{".claude", ".codex"} {
+		for _, name := range []string{"up-kept", "up-removed"} {
+			if _, err := os.Stat(filepath.Join(env.root, target, "skills", name, "SKILL.md")); err != nil {
+				t.Fatalf("expected %s to be installed in %s: %v", name, target, err)
+			}
+		}
+	}
+
+	if err := os.RemoveAll(removed); err != nil {
+		t.Fatalf("failed to remove skill from store: %v", err)
+	}
+	out, err := runSkillet(t, env, "up", "--yes")
+	if err != nil {
+		t.Fatalf("second up failed: %v\noutput:\n%s", err, out)
+	}
+	for _, target := range []string{".claude", ".codex"} {
+		if _, err := os.Lstat(filepath.Join(env.root, target, "skills", "up-removed")); !os.IsNotExist(err) {
+			t.Fatalf("expected orphaned link in %s to be pruned (err=%v)\noutput:\n%s", target, err, out)
+		}
+		if _, err := os.Stat(filepath.Join(env.root, target, "skills", "up-kept")); err != nil {
+			t.Fatalf("expected up-kept to stay installed in %s: %v", target, err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newAddCmd creates the add command.
+func newAddCmd(a *app) *cobra.Command {
+	var (
+		name   string
+		noSync bool
+		force  bool
+	)
+	scopeFlags := NewScopeFlags(skill.ScopeGlobal)
+
+	cmd := &cobra.Command{
+		Use:   "add <source>",
+		Short: "Add a skill from a Git repository",
+		Long: `Fetch a skill directory from a Git repository into the skill store,
+then sync it to targets.
+
+The source is host/owner/repo followed by the skill's path in the repository,
+optionally pinned to a branch or tag with @ref:
+
+  skillet add github.com/org/skills-repo/my-skill
+  skillet add github.com/org/skills-repo/my-skill@v1.2.0
+  skillet add https://git.example.com/team/skills.git//tools/my-skill
+
+Use "//" to separate the repository from the path when the repository URL has
+more than three elements. Skills are added to the global store by default;
+use --project to add to the project instead. The source and checksum are
+recorded in skillet.lock in the store's agents directory.
+
+Adding the same source again leaves the skill unchanged. Use --force to fetch
+it again, replacing local edits or a skill of the same name from elsewhere.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := a.rejectForcedDryRun("add"); err != nil {
+				return err
+			}
+
+			scope, err := scopeFlags.GetScope()
+			if err != nil {
+				return err
+			}
+			root, rootErr := a.findProjectRoot()
+			if rootErr != nil {
+				root = ""
+				if scope == skill.ScopeProject {
+					return fmt.Errorf("not in a project directory")
+				}
+			}
+
+			fetcher := fetch.Guard(fetch.GitFetcher{}, a.offline)
+			result, err := usecase.NewAddService(a.fs, a.config, root, fetcher).Add(cmd.Context(), usecase.AddOptions{
+				Source: args[0],
+				Name:   name,
+				Scope:  scope,
+				Force:  force,
+			})
+			if err != nil {
+				return fmt.Errorf("add failed: %w", err)
+			}
+			switch result.Action {
+			case usecase.AddActionUnchanged:
+				fmt.Printf("%s already added from %s (unchanged)\n", result.SkillName, args[0])
+				return nil
+			case usecase.AddActionReplaced:
+				fmt.Printf("Replaced %s in %s scope: %s\n", result.SkillName, result.Scope, result.Path)
+			default:
+				fmt.Printf("Added %s to %s scope: %s\n", result.SkillName, result.Scope, result.Path)
+			}
+
+			if noSync {
+				return nil
+			}
+			// Copies of a replaced skill are stale, so reinstall them. Only the
+			// added skill is forced, so copies of other skills edited in
+			// targets are kept.
+			results, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{
+				Scope: &scope,
+				Names: []string{result.SkillName},
+				Force: result.Action == usecase.AddActionReplaced,
+			})
+			if err != nil {
+				return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+			}
+			printMigrateSyncResults(results)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Skill name (default: last element of the source path)")
+	cmd.Flags().BoolVar(&force, "force", false, "Fetch again and replace an existing skill")
+	cmd.Flags().BoolVar(&noSync, "no-sync", false, "Do not sync to targets after adding")
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// assertExitFailed is the exit code of an assertion that does not hold. An
+// assertion that holds exits with 0, and one that cannot be checked, for
+// example because of a config error, with 1.
+const assertExitFailed = 2
+
+// newAssertCmd creates the assert command group.
+func newAssertCmd(a *app) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "assert",
+		Short: "Verify environment state via exit codes",
+		Long: `Verify the state of the skill store and targets for scripts and CI.
+
+Each assertion prints nothing and exits 0 when it holds, or exits 2 when it
+does not. An assertion that cannot be checked, for example because of a
+config error or an unknown target, exits 1. Use --json to print the result
+as a JSON object.`,
+	}
+
+	cmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Print the result as JSON")
+
+	cmd.AddCommand(newAssertInSyncCmd(a, &jsonOutput))
+	cmd.AddCommand(newAssertInstalledCmd(a, &jsonOutput))
+	cmd.AddCommand(newAssertExistsCmd(a, &jsonOutput))
+
+	return cmd
+}
+
+func newAssertInSyncCmd(a *app, jsonOutput *bool) *cobra.Command {
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+
+	cmd := &cobra.Command{
+		Use:   "in-sync",
+		Short: "Assert that all targets are in sync",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, rootErr := a.findProjectRoot()
+			if rootErr != nil {
+				root = ""
+			}
+			if scopeFlags.Project && rootErr != nil {
+				return fmt.Errorf("not in a project directory")
+			}
+
+			var opts usecase.StatusOptions
+			if scopeFlags.IsSet() {
+				scope, err := scopeFlags.GetScope()
+				if err != nil {
+					return err
+				}
+				opts.Scope = &scope
+			}
+
+			result, err := usecase.NewAssertService(a.fs, a.config, root).InSync(opts)
+			if err != nil {
+				return err
+			}
+			return reportAssertion(cmd, result, *jsonOutput)
+		},
+	}
+
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
+
+func newAssertInstalledCmd(a *app, jsonOutput *bool) *cobra.Command {
+	var targetName string
+
+	cmd := &cobra.Command{
+		Use:   "installed <skill>",
+		Short: "Assert that a skill is installed in targets",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, _ := a.findProjectRoot()
+			result, err := usecase.NewAssertService(a.fs, a.config, root).Installed(args[0], targetName)
+			if err != nil {
+				return withTargetSuggestion(err)
+			}
+			return reportAssertion(cmd, result, *jsonOutput)
+		},
+	}
+
+	cmd.Flags().StringVar(&targetName, "target", "", "Only check this target (default: all enabled targets)")
+
+	return cmd
+}
+
+func newAssertExistsCmd(a *app, jsonOutput *bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   "exists <skill>",
+		Short: "Assert that a skill exists in the store",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, _ := a.findProjectRoot()
+			result, err := usecase.NewAssertService(a.fs, a.config, root).Exists(args[0])
+			if err != nil {
+				return err
+			}
+			return reportAssertion(cmd, result, *jsonOutput)
+		},
+	}
+}
+
+// reportAssertion prints the result if requested and converts a failed
+// assertion into a silent exit code.
+func reportAssertion(cmd *cobra.Command, result *usecase.AssertResult, jsonOutput bool) error {
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+	}
+
+	if !result.Passed {
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &exitError{code: assertExitFailed, err: fmt.Errorf("assertion failed: %s", result.Message)}
+	}
+	return nil
+}
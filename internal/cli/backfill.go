@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newBackfillDescriptionsCmd creates the backfill-descriptions command.
+func newBackfillDescriptionsCmd(a *app) *cobra.Command {
+	var (
+		dryRun      bool
+		skipPrompts bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "backfill-descriptions",
+		Short: "Fill in missing skill descriptions",
+		Long: `Write a description into the frontmatter of every skill that has none.
+
+Agents pick skills by their description, so an empty one makes a skill hard to find.
+Each description is derived from the first sentence of the skill's first paragraph,
+falling back to its first heading and then to its directory name.
+
+You can edit or skip each description before anything is written, then confirm.
+Use --dry-run to only print the derived descriptions, or --yes to accept them all.
+System and vendored skills are not changed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+
+			root, _ := a.findProjectRoot()
+			svc := usecase.NewBackfillService(a.fs, a.config, root)
+
+			proposals, err := svc.Propose()
+			if err != nil {
+				return fmt.Errorf("backfill failed: %w", err)
+			}
+			if len(proposals) == 0 {
+				fmt.Println("Every skill has a description.")
+				return nil
+			}
+
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+				for _, p := range proposals {
+					fmt.Printf("  %s (%s, from %s): %s\n", p.Skill.Name, p.Skill.Scope, p.Source, p.Description)
+				}
+				return nil
+			}
+
+			p := a.prompterFor(skipPrompts)
+			var accepted []usecase.BackfillProposal
+			for _, proposal := range proposals {
+				desc, err := p.Input(fmt.Sprintf("Description for %s (%s, empty to skip):", proposal.Skill.Name, proposal.Skill.Scope), proposal.Description)
+				if err != nil {
+					return err
+				}
+				if desc = strings.TrimSpace(desc); desc == "" {
+					continue
+				}
+				proposal.Description = desc
+				accepted = append(accepted, proposal)
+			}
+			if len(accepted) == 0 {
+				fmt.Println("No descriptions written.")
+				return nil
+			}
+
+			ok, err := p.Confirm(fmt.Sprintf("Write %d description(s)?", len(accepted)), true)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Aborted.")
+				return nil
+			}
+
+			var failed int
+			for _, proposal := range accepted {
+				if err := svc.Apply(proposal.Skill, proposal.Description); err != nil {
+					fmt.Printf("  ! %s (error: %v)\n", proposal.Skill.Name, err)
+					failed++
+					continue
+				}
+				fmt.Printf("  ~ %s: %s\n", proposal.Skill.Name, proposal.Description)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d description(s) could not be written", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show derived descriptions without writing them")
+	cmd.Flags().BoolVarP(&skipPrompts, "yes", "y", false, "Write every derived description without prompting")
+
+	return cmd
+}
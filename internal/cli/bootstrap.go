@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// devcontainerSnippet is merged into .devcontainer/devcontainer.json so each
+// container syncs the project's skills after it is created.
+const devcontainerSnippet = `{
+  // Requires skillet on PATH in the container, e.g.
+  // go install github.com/wwwyo/skillet/cmd/skillet@latest
+  "postCreateCommand": "skillet bootstrap --devcontainer"
+}`
+
+// newBootstrapCmd creates the bootstrap command.
+func newBootstrapCmd(a *app) *cobra.Command {
+	var (
+		devcontainer bool
+		printSnippet bool
+		dryRun       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bootstrap --devcontainer",
+		Short: "Install project skills inside a container",
+		Long: `Install the project's skills into its targets inside a devcontainer or codespace.
+
+Skills are copied rather than symlinked, and links left by a host sync (which point
+into a home directory that does not exist in the container) are replaced. Only
+project skills, including vendored ones, are installed; no config file is needed.
+
+Use --print-snippet to print the devcontainer.json setting that runs this command
+after the container is created.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !devcontainer {
+				return errors.New("specify the environment to bootstrap (--devcontainer)")
+			}
+			if printSnippet {
+				fmt.Println(devcontainerSnippet)
+				return nil
+			}
+			dryRun = dryRun || a.dryRun
+
+			root, err := a.findProjectRoot()
+			if err != nil {
+				return fmt.Errorf("not in a project directory")
+			}
+
+			scope := skill.ScopeProject
+			results, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{
+				DryRun:   dryRun,
+				Force:    true,
+				Scope:    &scope,
+				Strategy: config.StrategyCopy,
+			})
+			if err != nil {
+				return fmt.Errorf("bootstrap failed: %w", err)
+			}
+
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+			var installed, failed int
+			for _, r := range results {
+				switch r.Action {
+				case usecase.SyncActionInstall, usecase.SyncActionUpdate:
+					installed++
+				case usecase.SyncActionError:
+					fmt.Printf("  ! %s/%s (error: %v)\n", r.Target, r.SkillName, r.Error)
+					failed++
+				}
+			}
+			fmt.Printf("Installed %d skill copies into project targets\n", installed)
+
+			if failed > 0 {
+				return fmt.Errorf("%d skill(s) failed to install", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&devcontainer, "devcontainer", false, "Bootstrap a devcontainer or codespace")
+	cmd.Flags().BoolVar(&printSnippet, "print-snippet", false, "Print the devcontainer.json snippet instead of installing")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+
+	return cmd
+}
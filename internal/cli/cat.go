@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newCatCmd creates the cat command.
+func newCatCmd(a *app) *cobra.Command {
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+	var frontmatter bool
+
+	cmd := &cobra.Command{
+		Use:   "cat <name>",
+		Short: "Print a skill's SKILL.md",
+		Long: `Print the body of a skill's SKILL.md to stdout, so scripts and other agents
+can read skill content without knowing where the store is.
+
+By default, prints the highest-priority skill with that name and leaves out the
+frontmatter. Use --frontmatter to print the file as is, and --global, --org,
+--system, or --project to pick a scope.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSkillNames(a, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, scope, err := a.resolveScope(&scopeFlags)
+			if err != nil {
+				return err
+			}
+
+			content, err := usecase.NewLocateService(a.fs, a.config, root).Content(usecase.ContentOptions{
+				Name:        args[0],
+				Scope:       scope,
+				Frontmatter: frontmatter,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stdout.Write(content); err != nil {
+				return fmt.Errorf("failed to write skill: %w", err)
+			}
+			return nil
+		},
+	}
+
+	AddScopeFlags(cmd, &scopeFlags)
+	cmd.Flags().BoolVar(&frontmatter, "frontmatter", false, "Include the YAML frontmatter")
+
+	return cmd
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newCompletionCmd creates the completion command.
+func newCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish|powershell",
+		Short: "Generate a shell completion script",
+		Long: `Print a completion script for the given shell. Besides commands and flags,
+it completes skill names for remove, rename, enable, disable, promote, demote,
+diff, cat, open, and which, and target names for --target, from the store and
+config in effect.
+
+  bash:       source <(skillet completion bash)
+  zsh:        skillet completion zsh > "${fpath[1]}/_skillet"
+  fish:       skillet completion fish > ~/.config/fish/completions/skillet.fish
+  powershell: skillet completion powershell | Out-String | Invoke-Expression`,
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				return root.GenZshCompletion(os.Stdout)
+			case "fish":
+				return root.GenFishCompletion(os.Stdout, true)
+			default:
+				return root.GenPowerShellCompletionWithDesc(os.Stdout)
+			}
+		},
+	}
+}
+
+// isCompletionRequest reports whether cmd is the hidden command shells call
+// for completions.
+func isCompletionRequest(cmd *cobra.Command) bool {
+	return cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd
+}
+
+// completionRoot prepares a.config for a completion function and returns the
+// project root ("" outside a project). Completion requests skip the config
+// loading of PersistentPreRunE, which may prompt or migrate, so the config is
+// read here as is, falling back to the defaults.
+func (a *app) completionRoot(cmd *cobra.Command) string {
+	if a.config == nil {
+		cfg := config.DefaultConfig()
+		if path, err := a.configPath(cmd); err == nil {
+			if loaded, err := a.configStore.Load(path); err == nil {
+				cfg = loaded
+			}
+		}
+		a.config = cfg
+		a.configStore.SetProjectDiscovery(cfg.Discovery)
+	}
+	root, err := a.findProjectRoot()
+	if err != nil {
+		return ""
+	}
+	return root
+}
+
+// completeSkillNames completes the first argument with the names of the skills
+// in effect that keep returns true for (nil keeps every skill), described by
+// their descriptions.
+func completeSkillNames(a *app, keep func(*skill.Skill) bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		// Conflicting skills still resolve to one copy each.
+		skills, err := skill.NewStore(a.fs, a.config, a.completionRoot(cmd)).GetResolved()
+		var conflictErr *skill.ConflictError
+		if err != nil && !errors.As(err, &conflictErr) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var completions []cobra.Completion
+		for _, sk := range skills {
+			if !strings.HasPrefix(sk.Name, toComplete) || keep != nil && !keep(sk) {
+				continue
+			}
+			completion := sk.Name
+			if desc, _, _ := strings.Cut(sk.Description(), "\n"); desc != "" {
+				completion = cobra.CompletionWithDesc(sk.Name, desc)
+			}
+			completions = append(completions, completion)
+		}
+		slices.Sort(completions)
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeTargetNames completes the names of enabled targets.
+func completeTargetNames(a *app) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		root := a.completionRoot(cmd)
+		var completions []cobra.Completion
+		for _, name := range usecase.NewTargetRegistry(a.fs, root, a.config).Names() {
+			if strings.HasPrefix(name, toComplete) {
+				completions = append(completions, name)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// registerTargetCompletions completes --target with target names on cmd and
+// every command below it that has the flag.
+func registerTargetCompletions(a *app, cmd *cobra.Command) {
+	if cmd.Flags().Lookup("target") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("target", completeTargetNames(a))
+	}
+	for _, sub := range cmd.Commands() {
+		registerTargetCompletions(a, sub)
+	}
+}
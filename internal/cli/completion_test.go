@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+func TestCompletions(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	mock.Files["/home/test/.config/skillet/config.yaml"] = []byte("version: 1\ntargets:\n  claude:\n    enabled: true\n  codex:\n    enabled: false\n")
+	for dir, desc := range map[string]string{
+		"/home/test/.local/share/skillet/skills/review":         "Review code",
+		"/home/test/.local/share/skillet/skills/optional/extra": "|\n  Extra checks\n  more",
+		"/project/.agents/skills/review":                        "Project review",
+		"/project/.agents/skills/lint":                          "",
+	} {
+		mock.Dirs[dir] = true
+		mock.Files[dir+"/SKILL.md"] = []byte("---\nname: " + mock.Base(dir) + "\ndescription: " + desc + "\n---\n")
+	}
+	for _, dir := range []string{"/project", "/project/.agents", "/project/.agents/skills", "/home/test/.local/share/skillet/skills", "/home/test/.local/share/skillet/skills/optional"} {
+		mock.Dirs[dir] = true
+	}
+	newApp := func() *app {
+		return &app{fs: mock, configStore: config.NewStore(mock), projectRoot: "/project"}
+	}
+
+	complete := func(fn cobra.CompletionFunc, args []string, toComplete string) []string {
+		t.Helper()
+		got, directive := fn(&cobra.Command{}, args, toComplete)
+		if directive != cobra.ShellCompDirectiveNoFileComp {
+			t.Errorf("directive = %v, want no file completion", directive)
+		}
+		return got
+	}
+
+	got := complete(completeSkillNames(newApp(), nil), nil, "")
+	want := []string{"extra\tExtra checks", "lint", "review\tProject review"}
+	if !slices.Equal(got, want) {
+		t.Errorf("skill completions = %q, want %q", got, want)
+	}
+	if got := complete(completeSkillNames(newApp(), nil), nil, "re"); len(got) != 1 {
+		t.Errorf("skill completions for %q = %q", "re", got)
+	}
+	if got := complete(completeSkillNames(newApp(), nil), []string{"review"}, ""); len(got) != 0 {
+		t.Errorf("completions after the skill = %q, want none", got)
+	}
+	if got := complete(completeSkillNames(newApp(), isOptionalSkill), nil, ""); !slices.Equal(got, []string{"extra\tExtra checks"}) {
+		t.Errorf("optional skill completions = %q", got)
+	}
+	if got := complete(completeTargetNames(newApp()), nil, ""); !slices.Equal(got, []string{"claude"}) {
+		t.Errorf("target completions = %q, want enabled targets", got)
+	}
+}
+
+func TestTargetFlagsComplete(t *testing.T) {
+	rootCmd := newRootCmd(&app{})
+	for _, path := range [][]string{{"sync"}, {"enable"}, {"diff"}, {"prune"}} {
+		cmd, _, err := rootCmd.Find(path)
+		if err != nil {
+			t.Fatalf("Find(%v) error = %v", path, err)
+		}
+		if _, ok := cmd.GetFlagCompletionFunc("target"); !ok {
+			t.Errorf("%s --target has no completion", cmd.CommandPath())
+		}
+	}
+}
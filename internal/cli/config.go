@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// newConfigCmd creates the config command group.
+func newConfigCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the skillet config file",
+	}
+
+	cmd.AddCommand(newConfigGetCmd(a))
+	cmd.AddCommand(newConfigSetCmd(a))
+	cmd.AddCommand(newConfigListCmd(a))
+	cmd.AddCommand(newConfigEditCmd(a))
+	cmd.AddCommand(newConfigMigrateCmd(a))
+
+	return cmd
+}
+
+// newConfigGetCmd creates the config get command.
+func newConfigGetCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print a config setting",
+		Long: `Print the value of a config setting, addressed by its dot-separated key
+(e.g. defaultStrategy or targets.claude.enabled). Lists are printed in flow
+style and mappings as YAML. Exits with an error when the key is not set.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := config.Get(a.config, args[0])
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			fmt.Println(value)
+			return nil
+		},
+	}
+}
+
+// newConfigSetCmd creates the config set command.
+func newConfigSetCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change a config setting",
+		Long: `Set a config setting, addressed by its dot-separated key, in the config file.
+The value is read as YAML, so false is a boolean and [a, b] a list. Missing
+parent keys are created, and comments in the file are kept.
+
+The changed config is validated before it is written: unknown keys and values
+skillet cannot use are rejected and leave the file unchanged.`,
+		Example: `  skillet config set defaultStrategy copy
+  skillet config set targets.codex.enabled false`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := a.configPath(cmd)
+			if err != nil {
+				return err
+			}
+			data, err := a.fs.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read config file: %w", err)
+			}
+			cmd.SilenceUsage = true
+			data, err = config.Set(data, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if err := validateConfig(data); err != nil {
+				return fmt.Errorf("%s not set: %w", args[0], err)
+			}
+			if err := a.fs.WriteFile(path, data, 0o644); err != nil {
+				return fmt.Errorf("failed to write config file: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// newConfigListCmd creates the config list command.
+func newConfigListCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List config settings",
+		Long: `List the config settings as key=value, one per line, with dot-separated keys
+as accepted by config get and config set.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings, err := config.Settings(a.config)
+			if err != nil {
+				return err
+			}
+			for _, s := range settings {
+				fmt.Printf("%s=%s\n", s.Key, s.Value)
+			}
+			return nil
+		},
+	}
+}
+
+// newConfigEditCmd creates the config edit command.
+func newConfigEditCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the config file in your editor",
+		Long: `Open a copy of the config file in $VISUAL or $EDITOR and save it back when
+the editor exits. The edited config is validated first; when it is invalid,
+skillet offers to edit it again and otherwise leaves the file unchanged.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := a.configPath(cmd)
+			if err != nil {
+				return err
+			}
+			original, err := a.fs.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read config file: %w", err)
+			}
+			cmd.SilenceUsage = true
+
+			tmp, err := os.CreateTemp("", "skillet-config-*.yaml")
+			if err != nil {
+				return err
+			}
+			defer func() { _ = os.Remove(tmp.Name()) }()
+			if _, err := tmp.Write(original); err != nil {
+				_ = tmp.Close()
+				return fmt.Errorf("failed to write temporary config file: %w", err)
+			}
+			if err := tmp.Close(); err != nil {
+				return fmt.Errorf("failed to write temporary config file: %w", err)
+			}
+
+			for {
+				if err := openInEditor(tmp.Name()); err != nil {
+					return err
+				}
+				edited, err := os.ReadFile(tmp.Name())
+				if err != nil {
+					return err
+				}
+				if bytes.Equal(edited, original) {
+					fmt.Println("Config unchanged")
+					return nil
+				}
+
+				err = validateConfig(edited)
+				if err == nil {
+					if err := a.fs.WriteFile(path, edited, 0o644); err != nil {
+						return fmt.Errorf("failed to write config file: %w", err)
+					}
+					fmt.Printf("Saved %s\n", path)
+					return nil
+				}
+				fmt.Fprintf(os.Stderr, "invalid config: %v\n", err)
+				// The auto prompter always confirms, which would reopen the
+				// editor forever.
+				if a.prompter == nil || a.assumeYes {
+					return fmt.Errorf("config not saved: %w", err)
+				}
+				again, perr := a.prompter.Confirm("Edit again?", true)
+				if perr != nil {
+					return perr
+				}
+				if !again {
+					return fmt.Errorf("config not saved: %w", err)
+				}
+			}
+		},
+	}
+}
+
+// validateConfig checks config data before it is saved. The resolution policy
+// is checked here since the skill package defines it.
+func validateConfig(data []byte) error {
+	cfg, err := config.Validate(data)
+	if err != nil {
+		return err
+	}
+	if _, err := skill.ParseResolutionPolicy(cfg.Resolution); err != nil {
+		return fmt.Errorf("resolution: %w", err)
+	}
+	return nil
+}
+
+// newConfigMigrateCmd creates the config migrate command.
+func newConfigMigrateCmd(a *app) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade the config file to the current schema version",
+		Long: `Upgrade the config file (--config, or the global config) to the current
+schema version, one version at a time, and print each step applied. The
+original file is kept next to it as <file>.v<version>.bak.
+
+Other commands upgrade an outdated config the same way before they run; use
+this command to upgrade explicitly, or with --dry-run to see the steps first.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+
+			path, err := a.configPath(cmd)
+			if err != nil {
+				return err
+			}
+			result, err := a.configStore.MigrateFile(path, dryRun)
+			if err != nil {
+				return fmt.Errorf("config migration failed: %w", err)
+			}
+
+			if len(result.Steps) == 0 {
+				fmt.Printf("Config %s is up to date (version %d)\n", result.Path, result.To)
+				return nil
+			}
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+			fmt.Printf("Config %s: version %d -> %d\n", result.Path, result.From, result.To)
+			for i, step := range result.Steps {
+				fmt.Printf("  %d -> %d: %s\n", result.From+i, result.From+i+1, step)
+			}
+			if result.Backup != "" {
+				fmt.Printf("Original saved as %s\n", result.Backup)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the migration steps without writing the config")
+
+	return cmd
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newConflictsCmd creates the conflicts command.
+func newConflictsCmd(a *app) *cobra.Command {
+	var (
+		jsonOutput     bool
+		failOnConflict bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "conflicts",
+		Short: "List skill names claimed by more than one skill",
+		Long: `List every skill name defined in more than one scope, with the copy that wins
+under the resolution policy and the paths of the copies it overrides. Under
+error-on-conflict no copy wins.
+
+Skills in collections that a target would install under the same name (see
+collections in the config) are listed too, with the skill sync installs.
+
+Use --json to print the conflicts as a JSON array, and --fail-on-conflict to
+exit with an error when there are any, e.g. in CI.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, _ := a.findProjectRoot()
+			conflicts, err := usecase.NewLocateService(a.fs, a.config, root).Conflicts()
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case jsonOutput:
+				if conflicts == nil {
+					conflicts = []usecase.NameConflict{}
+				}
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(conflicts); err != nil {
+					return fmt.Errorf("failed to encode result: %w", err)
+				}
+			case len(conflicts) == 0:
+				fmt.Println("No conflicts found.")
+			default:
+				printConflicts(a, conflicts)
+			}
+
+			if failOnConflict && len(conflicts) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d conflict(s) found", len(conflicts))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the conflicts as JSON")
+	cmd.Flags().BoolVar(&failOnConflict, "fail-on-conflict", false, "Exit with an error when any conflict is found")
+
+	return cmd
+}
+
+// printConflicts prints each conflict with its winning and losing copies.
+func printConflicts(a *app, conflicts []usecase.NameConflict) {
+	for i, c := range conflicts {
+		if i > 0 {
+			fmt.Println()
+		}
+		if c.Target == "" {
+			fmt.Println(c.Name)
+		} else {
+			fmt.Printf("%s (installed name in %s)\n", c.Name, c.Target)
+		}
+		if c.Winner == nil {
+			fmt.Println("  wins:  none (resolution policy is error-on-conflict)")
+		} else {
+			fmt.Printf("  wins:  %s\n", describeConflictCopy(a, c, *c.Winner))
+		}
+		for _, loser := range c.Losers {
+			fmt.Printf("  loses: %s\n", describeConflictCopy(a, c, loser))
+		}
+	}
+}
+
+// describeConflictCopy formats a copy in a conflict as its path, with the
+// skill name for target clashes, its scope, and its version.
+func describeConflictCopy(a *app, c usecase.NameConflict, cp usecase.ConflictCopy) string {
+	s := config.ContractPath(a.fs, cp.Path) + " (" + cp.Scope
+	if cp.Version != "" {
+		s += ", version " + cp.Version
+	}
+	if cp.Vendored {
+		s += ", vendored"
+	}
+	s += ")"
+	if c.Target != "" {
+		s = cp.Skill + " " + s
+	}
+	return s
+}
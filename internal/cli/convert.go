@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newConvertCommandsCmd creates the convert-commands command.
+func newConvertCommandsCmd(a *app) *cobra.Command {
+	scopeFlags := NewScopeFlags(skill.ScopeGlobal)
+	var (
+		from     string
+		keepShim bool
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "convert-commands",
+		Short: "Convert legacy slash commands into skills",
+		Long: `Convert legacy slash commands (e.g., ~/.claude/commands/*.md) into skills.
+
+Each command file becomes a skill directory in the agents store with a SKILL.md
+whose frontmatter is generated from the command name. The description is taken
+from the command's frontmatter, or from its first line. Other frontmatter fields
+are carried over: allowed-tools as is, and fields the skill schema does not know,
+such as argument-hint and model, under metadata.
+
+Commands in subdirectories are named <dir>-<name>. Commands whose skill already
+exists in the store are skipped.
+
+By default, converted commands are removed. Use --keep-shim to replace them with
+a short stub that points to the new skill.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+
+			root, rootErr := a.findProjectRoot()
+			if rootErr != nil {
+				root = ""
+			}
+			if scopeFlags.Project && rootErr != nil {
+				return fmt.Errorf("not in a project directory")
+			}
+			svc := usecase.NewConvertService(a.fs, a.config, root)
+
+			scope, err := scopeFlags.GetScope()
+			if err != nil {
+				return err
+			}
+
+			results, err := svc.Convert(usecase.ConvertOptions{
+				Target:   from,
+				Scope:    scope,
+				KeepShim: keepShim,
+				DryRun:   dryRun,
+			})
+			if err != nil {
+				return fmt.Errorf("convert failed: %w", withTargetSuggestion(err))
+			}
+
+			if len(results) == 0 {
+				fmt.Println("No commands found to convert.")
+				return nil
+			}
+
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+
+			var converted, failed int
+			for _, r := range results {
+				switch r.Action {
+				case usecase.ConvertActionConverted:
+					if r.Message != "" {
+						fmt.Printf("  ✓ %s -> %s (%s)\n", r.Command, r.SkillName, r.Message)
+					} else {
+						fmt.Printf("  ✓ %s -> %s\n", r.Command, r.SkillName)
+					}
+					converted++
+				case usecase.ConvertActionSkipped:
+					fmt.Printf("  - %s (skipped: %s)\n", r.Command, r.Message)
+				case usecase.ConvertActionError:
+					fmt.Printf("  ✗ %s: %v\n", r.Command, r.Error)
+					failed++
+				}
+			}
+
+			fmt.Printf("\n%d command(s) converted to %s scope\n", converted, scope)
+			if failed > 0 {
+				return fmt.Errorf("%d command(s) failed to convert", failed)
+			}
+			return nil
+		},
+	}
+
+	AddScopeFlags(cmd, &scopeFlags)
+	cmd.Flags().StringVar(&from, "from", "claude", "Target whose commands directory to convert")
+	cmd.Flags().BoolVar(&keepShim, "keep-shim", false, "Replace each command with a stub pointing to the new skill")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+
+	return cmd
+}
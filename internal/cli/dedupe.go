@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// dedupeSkipOption is the merge helper choice that leaves a cluster untouched.
+const dedupeSkipOption = "(skip this group)"
+
+// newDedupeCmd creates the dedupe command.
+func newDedupeCmd(a *app) *cobra.Command {
+	var (
+		report      bool
+		threshold   float64
+		skipPrompts bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "Find duplicate and near-duplicate skills",
+		Long: `Find skills whose SKILL.md bodies are identical or similar and suggest merges.
+
+Bodies are compared without frontmatter, case, or whitespace differences.
+Skills sharing a name across scopes are overrides and are not reported.
+Use --threshold to set the minimum similarity (0-1) for two skills to be grouped.
+
+With --report, only the groups and suggested skills to keep are printed.
+Otherwise, for each group you choose one skill to keep and the rest are moved
+to the .archive/ directory of their store.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report = report || a.dryRun
+
+			root, _ := a.findProjectRoot()
+			svc := usecase.NewDedupeService(a.fs, a.config, root)
+
+			clusters, err := svc.Find(usecase.DedupeOptions{Threshold: threshold})
+			if err != nil {
+				return fmt.Errorf("dedupe failed: %w", err)
+			}
+			if len(clusters) == 0 {
+				fmt.Println("No duplicate skills found.")
+				return nil
+			}
+
+			for i, c := range clusters {
+				kind := fmt.Sprintf("similar, %.0f%%", c.Similarity*100)
+				if c.Exact {
+					kind = "identical"
+				}
+				fmt.Printf("\nGroup %d (%s):\n", i+1, kind)
+				for _, sk := range c.Skills {
+					fmt.Printf("  %s (%s) %s\n", sk.Name, sk.Scope, sk.Path)
+				}
+				fmt.Printf("  Suggestion: keep %s (%s)\n", c.Keep().Name, c.Keep().Scope)
+			}
+			if report {
+				return nil
+			}
+
+			p := a.prompterFor(skipPrompts)
+			var archived int
+			for i, c := range clusters {
+				options := make([]string, 0, len(c.Skills)+1)
+				for _, sk := range c.Skills {
+					options = append(options, dedupeLabel(sk))
+				}
+				options = append(options, dedupeSkipOption)
+
+				fmt.Println()
+				choice, err := p.Select(fmt.Sprintf("Group %d: which skill should be kept?", i+1), options, options[0])
+				if err != nil {
+					return err
+				}
+				if choice == dedupeSkipOption {
+					continue
+				}
+
+				var others []*skill.Skill
+				for _, sk := range c.Skills {
+					if dedupeLabel(sk) != choice {
+						others = append(others, sk)
+					}
+				}
+				ok, err := p.Confirm(fmt.Sprintf("Archive %d other skill(s)?", len(others)), false)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+
+				for _, sk := range others {
+					dst, err := svc.Archive(sk)
+					if err != nil {
+						fmt.Printf("  ! %s (error: %v)\n", sk.Name, err)
+						continue
+					}
+					fmt.Printf("  - %s (archived to %s)\n", sk.Name, dst)
+					archived++
+				}
+			}
+
+			if archived > 0 {
+				fmt.Printf("\nArchived %d skill(s). Run 'skillet sync' to update targets.\n", archived)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&report, "report", false, "Only print duplicate groups and merge suggestions")
+	cmd.Flags().Float64Var(&threshold, "threshold", usecase.DefaultDedupeThreshold, "Minimum similarity (0-1) to group skills")
+	cmd.Flags().BoolVarP(&skipPrompts, "yes", "y", false, "Keep the suggested skill in every group without prompting")
+
+	return cmd
+}
+
+// dedupeLabel identifies a skill in the merge helper's choices.
+func dedupeLabel(sk *skill.Skill) string {
+	return fmt.Sprintf("%s (%s)", sk.Name, sk.Scope)
+}
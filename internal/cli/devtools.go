@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newDevtoolsCmd creates the hidden devtools command group.
+func newDevtoolsCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "devtools",
+		Short:  "Developer utilities",
+		Hidden: true,
+	}
+
+	cmd.AddCommand(newDevtoolsFixturesCmd(a))
+
+	return cmd
+}
+
+func newDevtoolsFixturesCmd(a *app) *cobra.Command {
+	var (
+		opts     usecase.FixtureOptions
+		strategy string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "fixtures",
+		Short: "Generate a store and target tree for testing",
+		Long: `Generate a realistic store and target tree for manual testing and e2e scenarios.
+
+The tree contains a global store, a project store, claude/codex target
+directories, and a config.yaml pointing at them. Run skillet against it with:
+
+  HOME=<root>/home skillet --config <root>/config.yaml status`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := a.rejectForcedDryRun("devtools fixtures"); err != nil {
+				return err
+			}
+
+			opts.Strategy = config.Strategy(strategy)
+			result, err := usecase.NewFixtureService(a.fs).Generate(opts)
+			if err != nil {
+				return fmt.Errorf("failed to generate fixtures: %w", err)
+			}
+
+			fmt.Printf("Generated fixtures at %s\n", result.Root)
+			fmt.Printf("  Config:  %s\n", result.ConfigPath)
+			fmt.Printf("  Home:    %s\n", result.HomeDir)
+			fmt.Printf("  Store:   %s\n", result.AgentsDir)
+			fmt.Printf("  Project: %s\n", result.ProjectRoot)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Dir, "dir", "", "Output directory (default: new temp directory)")
+	cmd.Flags().IntVar(&opts.Skills, "skills", 3, "Number of global skills")
+	cmd.Flags().IntVar(&opts.OptionalSkills, "optional-skills", 1, "Number of global optional skills")
+	cmd.Flags().IntVar(&opts.ProjectSkills, "project-skills", 1, "Number of project skills")
+	cmd.Flags().BoolVar(&opts.Nested, "nested", false, "Add a skill with SKILL.md in a subdirectory")
+	cmd.Flags().BoolVar(&opts.BrokenFrontmatter, "broken-frontmatter", false, "Add a skill with invalid frontmatter")
+	cmd.Flags().BoolVar(&opts.DanglingLinks, "dangling-links", false, "Add dangling symlinks in targets")
+	cmd.Flags().StringVar(&strategy, "strategy", string(config.StrategySymlink), "Strategy written to the generated config")
+
+	return cmd
+}
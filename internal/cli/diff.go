@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newDiffCmd creates the diff command.
+func newDiffCmd(a *app) *cobra.Command {
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+	var (
+		target   string
+		nameOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff <skill>",
+		Short: "Show how installed copies of a skill differ from the store",
+		Long: `Compare the copy of a skill installed in each target with the files the store
+would install there, and print a unified diff per differing file: the changes
+skillet sync --force would make. Symlinks to the store never differ.
+
+Use --target to compare a single target and --name-only to print only the
+paths of the differing installed files. Notes about targets without a copy go
+to stderr, so stdout is a patch.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSkillNames(a, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, scope, err := a.resolveScope(&scopeFlags)
+			if err != nil {
+				return err
+			}
+
+			results, err := usecase.NewDiffService(a.fs, a.config, root).Diff(usecase.DiffOptions{
+				Name:   args[0],
+				Scope:  scope,
+				Target: target,
+			})
+			if err != nil {
+				return err
+			}
+
+			var failed int
+			for _, r := range results {
+				switch {
+				case r.Error != nil:
+					fmt.Fprintf(os.Stderr, "%s: %v\n", r.Target, r.Error)
+					failed++
+				case !r.Installed:
+					fmt.Fprintf(os.Stderr, "%s: not installed\n", r.Target)
+				case r.Strategy == config.StrategySymlink && len(r.Files) == 0:
+					fmt.Fprintf(os.Stderr, "%s: symlink to the store\n", r.Target)
+				case len(r.Files) == 0:
+					fmt.Fprintf(os.Stderr, "%s: copy matches the store\n", r.Target)
+				}
+				for _, f := range r.Files {
+					switch {
+					case nameOnly:
+						fmt.Println(a.fs.Join(r.Path, f.Path))
+					case f.Binary:
+						fmt.Printf("Binary files differ: %s\n", a.fs.Join(r.Path, f.Path))
+					default:
+						fmt.Print(f.Diff)
+					}
+				}
+			}
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("diff failed for %d target(s)", failed)
+			}
+			return nil
+		},
+	}
+
+	AddScopeFlags(cmd, &scopeFlags)
+	cmd.Flags().StringVar(&target, "target", "", "Compare only the copy in this target")
+	cmd.Flags().BoolVar(&nameOnly, "name-only", false, "Print only the paths of differing files")
+
+	return cmd
+}
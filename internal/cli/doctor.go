@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newDoctorCmd creates the doctor command.
+func newDoctorCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the config, skill store, and targets",
+		Long: `Check the environment skillet runs in and print a fix for each problem.
+
+Checks that the config file loads and holds usable settings, that the agents
+directories exist, that symlinks in target skill directories resolve and point
+into the store, that every SKILL.md has valid frontmatter, that no skill name
+is defined in several scopes, and that skillet can write to the store and
+target directories.
+
+Exits with an error when any check fails, so it can gate CI. Warnings, such
+as frontmatter outside the schema without --strict, are printed but do not
+fail the command.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, rootErr := a.findProjectRoot()
+			if rootErr != nil {
+				a.logf("no project found: %v", rootErr)
+				root = ""
+			}
+			configPath, err := a.configPath(cmd)
+			if err != nil {
+				return err
+			}
+
+			findings, err := usecase.NewDoctorService(a.fs, a.config, root).Diagnose(usecase.DoctorOptions{
+				ConfigPath: configPath,
+				ConfigErr:  a.configErr,
+			})
+			if err != nil {
+				return fmt.Errorf("doctor failed: %w", err)
+			}
+			if len(findings) == 0 {
+				fmt.Println("No problems found")
+				return nil
+			}
+
+			var errors, warnings int
+			for _, f := range findings {
+				mark := "!"
+				if f.Severity == usecase.DoctorWarning {
+					mark = "⚠"
+					warnings++
+				} else {
+					errors++
+				}
+				fmt.Printf("  %s [%s] %s: %s\n", mark, f.Check, f.Path, f.Message)
+				if f.Fix != "" {
+					fmt.Printf("      fix: %s\n", f.Fix)
+				}
+			}
+
+			fmt.Printf("\n%d error(s), %d warning(s)\n", errors, warnings)
+			if errors > 0 {
+				// The findings already explain the failure.
+				cmd.SilenceUsage = true
+				return fmt.Errorf("doctor found %d error(s)", errors)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
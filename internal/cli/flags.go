@@ -10,7 +10,9 @@ import (
 
 // ScopeFlags holds the scope-related flags for commands.
 type ScopeFlags struct {
+	System       bool
 	Global       bool
+	Org          bool
 	Project      bool
 	DefaultScope skill.Scope
 }
@@ -20,21 +22,35 @@ func NewScopeFlags(defaultScope skill.Scope) ScopeFlags {
 	return ScopeFlags{DefaultScope: defaultScope}
 }
 
-// AddScopeFlags adds --global and --project flags to a command.
+// AddScopeFlags adds --system, --global, --org and --project flags to a command.
 func AddScopeFlags(cmd *cobra.Command, flags *ScopeFlags) {
+	cmd.Flags().BoolVar(&flags.System, "system", false, "Use system scope")
 	cmd.Flags().BoolVarP(&flags.Global, "global", "g", false, "Use global scope")
+	cmd.Flags().BoolVar(&flags.Org, "org", false, "Use organization scope")
 	cmd.Flags().BoolVarP(&flags.Project, "project", "p", false, "Use project scope")
 }
 
 // GetScope returns the scope based on the flags.
 func (f *ScopeFlags) GetScope() (skill.Scope, error) {
-	if f.Global && f.Project {
-		return 0, fmt.Errorf("cannot specify both --global and --project")
+	set := 0
+	for _, v := range []bool{f.System, f.Global, f.Org, f.Project} {
+		if v {
+			set++
+		}
+	}
+	if set > 1 {
+		return 0, fmt.Errorf("only one of --system, --global, --org, or --project can be specified")
 	}
 
+	if f.System {
+		return skill.ScopeSystem, nil
+	}
 	if f.Global {
 		return skill.ScopeGlobal, nil
 	}
+	if f.Org {
+		return skill.ScopeOrg, nil
+	}
 	if f.Project {
 		return skill.ScopeProject, nil
 	}
@@ -42,7 +58,20 @@ func (f *ScopeFlags) GetScope() (skill.Scope, error) {
 	return f.DefaultScope, nil
 }
 
-// IsSet returns true if either flag is explicitly set.
+// IsSet returns true if any scope flag is explicitly set.
 func (f *ScopeFlags) IsSet() bool {
-	return f.Global || f.Project
+	return f.System || f.Global || f.Org || f.Project
+}
+
+// Infer returns the scope for commands that act on a single scope.
+// An explicit flag always wins; otherwise project scope is used when a
+// project root was found and global scope when it was not.
+func (f *ScopeFlags) Infer(hasProject bool) (skill.Scope, error) {
+	if f.IsSet() {
+		return f.GetScope()
+	}
+	if hasProject {
+		return skill.ScopeProject, nil
+	}
+	return skill.ScopeGlobal, nil
 }
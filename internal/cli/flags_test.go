@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+func TestScopeFlagsInfer(t *testing.T) {
+	tests := []struct {
+		name       string
+		flags      ScopeFlags
+		hasProject bool
+		want       skill.Scope
+		wantErr    bool
+	}{
+		{"project found", ScopeFlags{}, true, skill.ScopeProject, false},
+		{"no project", ScopeFlags{}, false, skill.ScopeGlobal, false},
+		{"explicit global in project", ScopeFlags{Global: true}, true, skill.ScopeGlobal, false},
+		{"explicit project outside project", ScopeFlags{Project: true}, false, skill.ScopeProject, false},
+		{"conflicting flags", ScopeFlags{Global: true, Project: true}, true, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.flags.Infer(tt.hasProject)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Infer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Infer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
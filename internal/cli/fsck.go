@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newFsckCmd creates the fsck command.
+func newFsckCmd(a *app) *cobra.Command {
+	var fix bool
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+
+	cmd := &cobra.Command{
+		Use:   "fsck",
+		Short: "Verify and repair the store directory layout",
+		Long: `Verify the layout of the skill store itself.
+
+Reports skills placed outside the skills/ directory, stray files at the skills
+root, misuse of the optional/ directory, broken symlinks inside the store, and
+names that are not valid skill names.
+
+Use --fix to repair what can be fixed safely (missing directories, broken
+symlinks, and misplaced skills). Other issues are reported only.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, rootErr := a.findProjectRoot()
+			if rootErr != nil {
+				root = ""
+			}
+			if scopeFlags.Project && rootErr != nil {
+				return fmt.Errorf("not in a project directory")
+			}
+			svc := usecase.NewFsckService(a.fs, a.config, root)
+
+			opts := usecase.FsckOptions{Fix: fix && !a.dryRun}
+			if scopeFlags.IsSet() {
+				scope, err := scopeFlags.GetScope()
+				if err != nil {
+					return err
+				}
+				opts.Scope = &scope
+			}
+
+			issues, err := svc.Check(opts)
+			if err != nil {
+				return fmt.Errorf("fsck failed: %w", err)
+			}
+
+			if len(issues) == 0 {
+				fmt.Println("Store layout OK")
+				return nil
+			}
+
+			var remaining int
+			for _, issue := range issues {
+				switch {
+				case issue.Fixed:
+					fmt.Printf("  ✓ [%s] %s: %s (fixed)\n", issue.Scope, issue.Path, issue.Message)
+				case issue.Error != nil:
+					fmt.Printf("  ⚠ [%s] %s: %s (%v)\n", issue.Scope, issue.Path, issue.Message, issue.Error)
+					remaining++
+				default:
+					hint := ""
+					if issue.Fixable {
+						hint = " (fixable with --fix)"
+					}
+					fmt.Printf("  ! [%s] %s: %s%s\n", issue.Scope, issue.Path, issue.Message, hint)
+					remaining++
+				}
+			}
+
+			if remaining > 0 {
+				return fmt.Errorf("%d store issue(s) found", remaining)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Repair issues that can be fixed safely")
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
@@ -1,15 +1,16 @@
 package cli
 
 import (
-	"bufio"
+	"context"
 	"fmt"
-	"os"
+	"maps"
+	"slices"
 	"strings"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/prompt"
 	"github.com/wwwyo/skillet/internal/skill"
 	"github.com/wwwyo/skillet/internal/usecase"
 )
@@ -18,6 +19,7 @@ var initGlobal bool
 var initProject bool
 var initPath string
 var initYes bool
+var initForce bool
 
 // newInitCmd creates the init command.
 func newInitCmd(a *app) *cobra.Command {
@@ -26,13 +28,23 @@ func newInitCmd(a *app) *cobra.Command {
 		Short: "Initialize skillet configuration",
 		Long: `Initialize skillet for global or project use.
 
-Use --global to initialize global skills (default: ~/.agents/)
+Use --global to initialize global skills (default: ~/.local/share/skillet/,
+  or ~/.agents/ when a store from an older version is there)
   Config is stored at ~/.config/skillet/config.yaml
   Use --path to specify a custom location (e.g., for dotfiles)
 Use --project to initialize project-level configuration at ./.agents/
+  (refused in the home directory; use --global there)
 
-If neither flag is specified, project initialization is assumed.`,
+If neither flag is specified, project initialization is assumed.
+
+Running init again leaves an existing setup unchanged without prompting.
+Use --force to go through the setup again.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := a.rejectForcedDryRun("init"); err != nil {
+				return err
+			}
+			p := a.prompterFor(initYes)
+
 			if !initGlobal && !initProject {
 				initProject = true
 			}
@@ -42,13 +54,13 @@ If neither flag is specified, project initialization is assumed.`,
 			}
 
 			if initGlobal {
-				if err := initializeGlobal(a, initPath, initYes); err != nil {
+				if err := initializeGlobal(cmd.Context(), a, initPath, p, initForce); err != nil {
 					return err
 				}
 			}
 
 			if initProject {
-				if err := initializeProject(a, initYes); err != nil {
+				if err := initializeProject(cmd.Context(), a, p, initForce); err != nil {
 					return err
 				}
 			}
@@ -61,19 +73,39 @@ If neither flag is specified, project initialization is assumed.`,
 	cmd.Flags().BoolVarP(&initProject, "project", "p", false, "Initialize project configuration")
 	cmd.Flags().StringVar(&initPath, "path", "", "Custom path for initialization (only with --global)")
 	cmd.Flags().BoolVarP(&initYes, "yes", "y", false, "Skip confirmation prompts")
+	cmd.Flags().BoolVar(&initForce, "force", false, "Set up again even if already initialized")
 
 	return cmd
 }
 
-func initializeGlobal(a *app, customPath string, skipPrompts bool) error {
-	reader := bufio.NewReader(os.Stdin)
+func initializeGlobal(ctx context.Context, a *app, customPath string, p prompt.Prompter, force bool) error {
+	setupSvc := usecase.NewSetupService(a.fs)
+	if !force {
+		configPath, err := config.GlobalConfigPath(a.fs)
+		if err != nil {
+			return err
+		}
+		if cfg := setupSvc.InitializedGlobal(configPath); cfg != nil && samePath(a, cfg.GlobalPath, customPath) {
+			fmt.Printf("Global skillet already initialized at %s (unchanged)\n", configPath)
+			return nil
+		}
+	}
 
-	globalPath := promptGlobalPath(reader, customPath, skipPrompts)
-	enabledTargets := promptTargets(skipPrompts)
+	globalPath, err := promptGlobalPath(p, customPath, config.DetectGlobalPath(a.fs))
+	if err != nil {
+		return err
+	}
+	enabledTargets, err := promptTargets(p)
+	if err != nil {
+		return err
+	}
 	if err := validateTargets(enabledTargets); err != nil {
 		return err
 	}
-	strategy := promptStrategy(skipPrompts)
+	strategy, err := promptStrategy(p)
+	if err != nil {
+		return err
+	}
 
 	agentsDir, err := config.ExpandPath(a.fs, globalPath)
 	if err != nil {
@@ -85,14 +117,17 @@ func initializeGlobal(a *app, customPath string, skipPrompts bool) error {
 		return err
 	}
 
-	if !skipPrompts && !confirmCreation(reader, configPath, agentsDir, enabledTargets, strategy) {
+	confirmed, err := confirmCreation(p, configPath, agentsDir, enabledTargets, strategy)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		fmt.Println("Aborted.")
 		return nil
 	}
 
 	existed := a.fs.Exists(configPath)
 
-	setupSvc := usecase.NewSetupService(a.fs)
 	cfg, err := setupSvc.SetupGlobal(usecase.SetupGlobalParams{
 		GlobalPath:     globalPath,
 		EnabledTargets: enabledTargets,
@@ -108,10 +143,10 @@ func initializeGlobal(a *app, customPath string, skipPrompts bool) error {
 	} else {
 		fmt.Printf("\n✓ Created global configuration at %s\n", configPath)
 	}
-	fmt.Printf("✓ Initialized global skills at %s\n", strings.Replace(globalPath, "~", "$HOME", 1))
+	fmt.Printf("✓ Initialized global skills at %s\n", strings.Replace(config.ContractPath(a.fs, agentsDir), "~", "$HOME", 1))
 
-	if err := runMigrate(a, cfg, migrateRunOptions{
-		skipPrompts:    skipPrompts,
+	if err := runMigrate(ctx, a, cfg, migrateRunOptions{
+		prompter:       p,
 		defaultConfirm: false,
 		scope:          skill.ScopeGlobal,
 		projectRoot:    "",
@@ -122,82 +157,72 @@ func initializeGlobal(a *app, customPath string, skipPrompts bool) error {
 	return nil
 }
 
-func promptGlobalPath(reader *bufio.Reader, customPath string, skipPrompts bool) string {
-	if customPath != "" {
-		return customPath
+// samePath reports whether a requested global path (empty for no request)
+// refers to the configured one.
+func samePath(a *app, configured, requested string) bool {
+	if requested == "" {
+		return true
 	}
-	if skipPrompts {
-		return config.DefaultGlobalPath
+	if configured == "" {
+		configured = config.DetectGlobalPath(a.fs)
 	}
-
-	fmt.Printf("\nGlobal skills path [%s]: ", config.DefaultGlobalPath)
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
-	if input == "" {
-		return config.DefaultGlobalPath
+	want, err := config.ExpandPath(a.fs, requested)
+	if err != nil {
+		return false
 	}
-	return input
+	got, err := config.ExpandPath(a.fs, configured)
+	return err == nil && a.fs.Join(got) == a.fs.Join(want)
 }
 
-func promptTargets(skipPrompts bool) map[string]bool {
-	defaultCfg := config.DefaultConfig()
-	enabledTargets := make(map[string]bool)
-
-	if skipPrompts {
-		for name := range defaultCfg.Targets {
-			enabledTargets[name] = true
-		}
-		return enabledTargets
+func promptGlobalPath(p prompt.Prompter, customPath, defaultPath string) (string, error) {
+	if customPath != "" {
+		return customPath, nil
 	}
 
-	var options []string
-	var defaults []string
-	for name := range defaultCfg.Targets {
-		options = append(options, name)
-		defaults = append(defaults, name)
+	input, err := p.Input("Global skills path:", defaultPath)
+	if err != nil {
+		return "", err
 	}
-
-	var selected []string
-	prompt := &survey.MultiSelect{
-		Message: "Select targets (Space: toggle, Enter: confirm):",
-		Options: options,
-		Default: defaults,
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return defaultPath, nil
 	}
+	return input, nil
+}
+
+func promptTargets(p prompt.Prompter) (map[string]bool, error) {
+	defaultCfg := config.DefaultConfig()
 
-	if err := survey.AskOne(prompt, &selected); err != nil {
-		os.Exit(1)
+	options := slices.Sorted(maps.Keys(defaultCfg.Targets))
+	defaults := slices.DeleteFunc(slices.Clone(options), func(name string) bool {
+		return !defaultCfg.Targets[name].Enabled
+	})
+	selected, err := p.MultiSelect("Select targets (Space: toggle, Enter: confirm):", options, defaults)
+	if err != nil {
+		return nil, err
 	}
 
+	enabledTargets := make(map[string]bool, len(selected))
 	for _, name := range selected {
 		enabledTargets[name] = true
 	}
 
-	return enabledTargets
+	return enabledTargets, nil
 }
 
-func promptStrategy(skipPrompts bool) config.Strategy {
-	if skipPrompts {
-		return config.StrategySymlink
-	}
-
+func promptStrategy(p prompt.Prompter) (config.Strategy, error) {
 	options := []string{
 		string(config.StrategySymlink),
 		string(config.StrategyCopy),
+		string(config.StrategyHardlink),
 	}
 
-	var selected string
-	prompt := &survey.Select{
-		Message: "Select sync strategy:",
-		Options: options,
-		Default: string(config.StrategySymlink),
-		Help:    "symlink: creates symbolic links (recommended), copy: copies files",
-	}
-
-	if err := survey.AskOne(prompt, &selected); err != nil {
-		os.Exit(1)
+	selected, err := p.Select("Select sync strategy (symlink recommended, copy copies files, hardlink links each file):", options, string(config.StrategySymlink))
+	if err != nil {
+		return "", err
 	}
 
-	return config.Strategy(selected)
+	return config.Strategy(selected), nil
 }
 
 func validateTargets(enabledTargets map[string]bool) error {
@@ -209,7 +234,7 @@ func validateTargets(enabledTargets map[string]bool) error {
 	return fmt.Errorf("at least one target must be selected")
 }
 
-func confirmCreation(reader *bufio.Reader, configPath, agentsDir string, enabledTargets map[string]bool, strategy config.Strategy) bool {
+func confirmCreation(p prompt.Prompter, configPath, agentsDir string, enabledTargets map[string]bool, strategy config.Strategy) (bool, error) {
 	fmt.Println()
 	fmt.Println("This will create:")
 	fmt.Printf("  Config: %s\n", configPath)
@@ -217,7 +242,7 @@ func confirmCreation(reader *bufio.Reader, configPath, agentsDir string, enabled
 	fmt.Print("  Targets: ")
 
 	var targetNames []string
-	for name := range enabledTargets {
+	for _, name := range slices.Sorted(maps.Keys(enabledTargets)) {
 		if enabledTargets[name] {
 			targetNames = append(targetNames, name)
 		}
@@ -225,36 +250,43 @@ func confirmCreation(reader *bufio.Reader, configPath, agentsDir string, enabled
 	fmt.Println(strings.Join(targetNames, ", "))
 	fmt.Printf("  Strategy: %s\n", strategy)
 	fmt.Println()
-	fmt.Print("Continue? [Y/n]: ")
 
-	confirm, _ := reader.ReadString('\n')
-	confirm = strings.TrimSpace(strings.ToLower(confirm))
-	return confirm == "" || confirm == "y" || confirm == "yes"
+	return p.Confirm("Continue?", true)
 }
 
-func initializeProject(a *app, skipPrompts bool) error {
-	cwd, err := os.Getwd()
+func initializeProject(ctx context.Context, a *app, p prompt.Prompter, force bool) error {
+	root, err := a.projectDir()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
+	}
+
+	// The project store would be ~/.agents/skills, which project discovery
+	// never finds and which is taken for a legacy global store.
+	if home, err := a.fs.UserHomeDir(); err == nil && a.fs.Join(home) == a.fs.Join(root) {
+		return fmt.Errorf("cannot initialize a project in the home directory %s; use 'skillet init --global' instead", root)
 	}
 
 	setupSvc := usecase.NewSetupService(a.fs)
-	if err := setupSvc.SetupProject(cwd); err != nil {
+	if !force && setupSvc.ProjectInitialized(root) {
+		fmt.Printf("Project skillet already initialized at %s (unchanged)\n", config.ProjectAgentsDir(root, a.fs))
+		return nil
+	}
+	if err := setupSvc.SetupProject(root); err != nil {
 		return err
 	}
 
-	fmt.Printf("Initialized project skillet at %s\n", config.ProjectAgentsDir(cwd, a.fs))
+	fmt.Printf("Initialized project skillet at %s\n", config.ProjectAgentsDir(root, a.fs))
 
 	cfg, err := a.configStore.Load("")
 	if err != nil {
 		return nil
 	}
 
-	if err := runMigrate(a, cfg, migrateRunOptions{
-		skipPrompts:    skipPrompts,
+	if err := runMigrate(ctx, a, cfg, migrateRunOptions{
+		prompter:       p,
 		defaultConfirm: false,
 		scope:          skill.ScopeProject,
-		projectRoot:    cwd,
+		projectRoot:    root,
 	}); err != nil {
 		return err
 	}
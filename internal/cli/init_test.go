@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+// scriptedPrompter returns fixed answers for interactive prompts.
+type scriptedPrompter struct {
+	confirm     bool
+	selected    string
+	multiSelect []string
+	input       string
+}
+
+func (p *scriptedPrompter) Confirm(string, bool) (bool, error) {
+	return p.confirm, nil
+}
+
+func (p *scriptedPrompter) Select(string, []string, string) (string, error) {
+	return p.selected, nil
+}
+
+func (p *scriptedPrompter) MultiSelect(string, []string, []string) ([]string, error) {
+	return p.multiSelect, nil
+}
+
+func (p *scriptedPrompter) Input(string, string) (string, error) {
+	return p.input, nil
+}
+
+func TestInitializeGlobalUsesPromptAnswers(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	a := &app{fs: mock, configStore: config.NewStore(mock)}
+
+	p := &scriptedPrompter{
+		confirm:     true,
+		selected:    string(config.StrategyCopy),
+		multiSelect: []string{"claude"},
+		input:       "~/dotfiles/.agents",
+	}
+	if err := initializeGlobal(t.Context(), a, "", p, false); err != nil {
+		t.Fatalf("initializeGlobal() error = %v", err)
+	}
+
+	cfg, err := a.configStore.Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.GlobalPath != "~/dotfiles/.agents" {
+		t.Errorf("GlobalPath = %q, want %q", cfg.GlobalPath, "~/dotfiles/.agents")
+	}
+	if cfg.DefaultStrategy != config.StrategyCopy {
+		t.Errorf("DefaultStrategy = %q, want copy", cfg.DefaultStrategy)
+	}
+	if !cfg.Targets["claude"].Enabled || cfg.Targets["codex"].Enabled {
+		t.Errorf("Targets = %+v, want only claude enabled", cfg.Targets)
+	}
+	if !mock.IsDir("/home/test/dotfiles/.agents/skills") {
+		t.Error("initializeGlobal() should create the skills directory")
+	}
+}
+
+func TestInitializeGlobalAbortsWhenNotConfirmed(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	a := &app{fs: mock, configStore: config.NewStore(mock)}
+
+	p := &scriptedPrompter{
+		confirm:     false,
+		selected:    string(config.StrategySymlink),
+		multiSelect: []string{"claude", "codex"},
+	}
+	if err := initializeGlobal(t.Context(), a, "", p, false); err != nil {
+		t.Fatalf("initializeGlobal() error = %v", err)
+	}
+
+	if mock.Exists("/home/test/.config/skillet/config.yaml") {
+		t.Error("initializeGlobal() should not write config when not confirmed")
+	}
+}
+
+func TestInitializeGlobalIsIdempotent(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	a := &app{fs: mock, configStore: config.NewStore(mock)}
+
+	p := &scriptedPrompter{
+		confirm:     true,
+		selected:    string(config.StrategyCopy),
+		multiSelect: []string{"claude"},
+	}
+	if err := initializeGlobal(t.Context(), a, "", p, false); err != nil {
+		t.Fatalf("initializeGlobal() error = %v", err)
+	}
+
+	// A second run must not apply new prompt answers.
+	p.selected = string(config.StrategySymlink)
+	if err := initializeGlobal(t.Context(), a, "", p, false); err != nil {
+		t.Fatalf("initializeGlobal() second run error = %v", err)
+	}
+	if err := initializeGlobal(t.Context(), a, "~/.local/share/skillet", p, false); err != nil {
+		t.Fatalf("initializeGlobal() with the configured path error = %v", err)
+	}
+	cfg, err := a.configStore.Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DefaultStrategy != config.StrategyCopy {
+		t.Errorf("DefaultStrategy = %q, want copy to be unchanged", cfg.DefaultStrategy)
+	}
+
+	if err := initializeGlobal(t.Context(), a, "", p, true); err != nil {
+		t.Fatalf("initializeGlobal() with force error = %v", err)
+	}
+	if cfg, _ := a.configStore.Load(""); cfg.DefaultStrategy != config.StrategySymlink {
+		t.Errorf("DefaultStrategy = %q, want symlink after --force", cfg.DefaultStrategy)
+	}
+}
+
+func TestInitInHomeThenGlobalKeepsStore(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "")
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	mock.Dirs["/home/test"] = true
+	a := &app{fs: mock, configStore: config.NewStore(mock), projectRoot: "/home/test"}
+	p := &scriptedPrompter{
+		confirm:     true,
+		selected:    string(config.StrategySymlink),
+		multiSelect: []string{"claude"},
+	}
+
+	if err := initializeProject(t.Context(), a, p, false); err == nil || !strings.Contains(err.Error(), "home directory") {
+		t.Fatalf("initializeProject() error = %v, want a home directory error", err)
+	}
+	if mock.Exists("/home/test/.agents/skills") {
+		t.Fatal("init in the home directory must not create ~/.agents/skills")
+	}
+
+	if err := initializeGlobal(t.Context(), a, "", p, false); err != nil {
+		t.Fatalf("initializeGlobal() error = %v", err)
+	}
+	// A skills directory appearing in ~/.agents later does not move the store.
+	mock.Dirs["/home/test/.agents/skills"] = true
+	cfg, err := a.configStore.Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if dir, err := cfg.AgentsDir(mock); err != nil || dir != "/home/test/.local/share/skillet" {
+		t.Errorf("AgentsDir() = %q, %v, want the XDG data directory", dir, err)
+	}
+}
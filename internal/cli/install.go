@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newInstallCmd creates the install command.
+func newInstallCmd(a *app) *cobra.Command {
+	var (
+		dryRun bool
+		force  bool
+	)
+	scopeFlags := NewScopeFlags(skill.ScopeGlobal)
+
+	cmd := &cobra.Command{
+		Use:   "install [registry-skill...]",
+		Short: "Install registry skills, or the project's skillset.yaml and skillet.lock",
+		Long: `With skill names, install those skills from the registry configured under
+registry.index in the config file (see skillet search). Each skill is fetched
+from the source listed in the registry index and checked against its checksum,
+then added to the global store, or the project store with --project, and
+synced to targets. Use --force to replace an existing skill of the same name.
+
+Without names, install the skills a project lists in .agents/skillset.yaml, reproduce the
+skill set recorded in skillet.lock, then sync them to targets.
+
+Each skillset.yaml entry has a name, a source, or both. Remote sources (as for
+skillet add) are fetched into the project store, and local paths (starting with
+./, ../, /, or ~) are linked into it. Entries with only a name must resolve from
+the global, org, or system store. Commit skillset.yaml instead of whole skill
+directories so teammates can run install to get the same skills.
+
+Every sync records the resolved skills with their source and checksum in
+.agents/skillet.lock (in the global store outside a project). Commit the project
+lock file so teammates can run install to get identical skills.
+
+Skills added with skillet add are fetched again from their recorded source when
+missing or different. Skills from local paths cannot be fetched: install reports
+them when they are missing or differ, and syncs nothing until they are fixed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+			if len(args) > 0 {
+				return a.installFromRegistry(cmd.Context(), args, &scopeFlags, force, dryRun)
+			}
+			if scopeFlags.IsSet() || force {
+				return fmt.Errorf("--force and scope flags only apply when installing registry skills")
+			}
+
+			root, err := a.findProjectRoot()
+			if err != nil {
+				a.logf("no project root found: %v", err)
+				root = ""
+			}
+
+			fetcher := fetch.Guard(fetch.GitFetcher{}, a.offline)
+			results, err := usecase.NewLockInstallService(a.fs, a.config, root, fetcher).Install(cmd.Context(), usecase.LockInstallOptions{
+				DryRun: dryRun,
+			})
+			if err != nil {
+				return fmt.Errorf("install failed: %w", err)
+			}
+
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+			var failed int
+			for _, r := range results {
+				switch r.Action {
+				case usecase.LockInstallActionInstalled:
+					fmt.Printf("  + %s (%s)\n", r.SkillName, r.Source)
+				case usecase.LockInstallActionUpdated:
+					fmt.Printf("  ~ %s (%s, updated)\n", r.SkillName, r.Source)
+				case usecase.LockInstallActionError:
+					fmt.Printf("  ! %s (error: %v)\n", r.SkillName, r.Error)
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d skill(s) could not be installed", failed)
+			}
+			if dryRun {
+				return nil
+			}
+
+			syncResults, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{})
+			if err != nil {
+				return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+			}
+			printMigrateSyncResults(syncResults)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing skill with the registry skill")
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
+
+// installFromRegistry adds the named registry skills to the scope's store and
+// syncs them.
+func (a *app) installFromRegistry(ctx context.Context, names []string, scopeFlags *ScopeFlags, force, dryRun bool) error {
+	scope, err := scopeFlags.GetScope()
+	if err != nil {
+		return err
+	}
+	root, rootErr := a.findProjectRoot()
+	if rootErr != nil {
+		root = ""
+		if scope == skill.ScopeProject {
+			return fmt.Errorf("not in a project directory")
+		}
+	}
+
+	svc := a.registryService(root)
+	index, err := svc.Index(ctx, false)
+	if err != nil {
+		return fmt.Errorf("install failed: %w", err)
+	}
+	warnStaleIndex(index)
+
+	if dryRun {
+		fmt.Println("Dry run - no changes made:")
+	}
+	var installed []string
+	var failed int
+	for _, name := range names {
+		entry, result, err := svc.Install(ctx, index, usecase.RegistryInstallOptions{
+			Name:   name,
+			Scope:  scope,
+			Force:  force,
+			DryRun: dryRun,
+		})
+		switch {
+		case err != nil:
+			fmt.Printf("  ! %s (error: %v)\n", name, err)
+			failed++
+		case dryRun:
+			fmt.Printf("  + %s (%s)\n", entry.Name, entry.Source)
+		case result.Action == usecase.AddActionUnchanged:
+			fmt.Printf("  = %s (%s, unchanged)\n", entry.Name, entry.Source)
+		case result.Action == usecase.AddActionReplaced:
+			fmt.Printf("  ~ %s (%s, replaced)\n", entry.Name, entry.Source)
+			installed = append(installed, entry.Name)
+		default:
+			fmt.Printf("  + %s (%s)\n", entry.Name, entry.Source)
+			installed = append(installed, entry.Name)
+		}
+	}
+
+	if len(installed) > 0 {
+		results, err := usecase.NewSyncService(a.fs, a.config, root).Sync(ctx, usecase.SyncOptions{
+			Scope: &scope,
+			Names: installed,
+			Force: force,
+		})
+		if err != nil {
+			return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+		}
+		printMigrateSyncResults(results)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d skill(s) could not be installed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+)
+
+// loadConfig loads the config file for cmd. When the default config path is in
+// use and a legacy ~/.agents/skillet.yaml exists, it offers a one-time move to
+// the new location, or reads the legacy file as is under --legacy-config.
+// A config file older than config.CurrentVersion is upgraded on the way.
+func (a *app) loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	if cmd.Flags().Changed("config") {
+		return a.loadCurrentConfig(cmd, cfgFile)
+	}
+	legacyPath, err := a.configStore.FindLegacyConfig()
+	if err != nil || legacyPath == "" {
+		return a.loadCurrentConfig(cmd, cfgFile)
+	}
+
+	if a.legacyConfig {
+		a.logf("reading legacy config (read-only): %s", legacyPath)
+		return a.configStore.Load(legacyPath)
+	}
+
+	newPath, err := a.configStore.GlobalConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	if a.dryRun {
+		fmt.Fprintf(os.Stderr, "legacy config found at %s; not migrating in dry-run mode\n", legacyPath)
+		if a.fs.Exists(newPath) {
+			return a.configStore.Load(cfgFile)
+		}
+		return a.configStore.Load(legacyPath)
+	}
+
+	action := "Move it to"
+	if a.fs.Exists(newPath) {
+		action = "Merge it into"
+	}
+	migrate, err := a.prompterFor(false).Confirm(
+		fmt.Sprintf("Found legacy config %s. %s %s?", legacyPath, action, newPath), true)
+	if err != nil {
+		return nil, err
+	}
+	if !migrate {
+		if a.fs.Exists(newPath) {
+			fmt.Fprintf(os.Stderr, "legacy config %s is ignored\n", legacyPath)
+			return a.configStore.Load(cfgFile)
+		}
+		return nil, fmt.Errorf("legacy config %s was not migrated; pass --legacy-config to read it as is", legacyPath)
+	}
+
+	if _, err := a.configStore.MigrateLegacy(); err != nil {
+		return nil, fmt.Errorf("failed to migrate legacy config: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Migrated legacy config to %s\n", newPath)
+	return a.loadCurrentConfig(cmd, cfgFile)
+}
+
+// loadCurrentConfig loads the config at path, first upgrading the file to
+// config.CurrentVersion when it is older. Dry runs and skillet config migrate
+// leave the file as is; Load still migrates it in memory.
+func (a *app) loadCurrentConfig(cmd *cobra.Command, path string) (*config.Config, error) {
+	if cmd.CommandPath() != "skillet config migrate" {
+		dryRun := a.dryRun
+		if f := cmd.Flags().Lookup("dry-run"); f != nil && f.Value.String() == "true" {
+			dryRun = true
+		}
+		result, err := a.configStore.MigrateFile(path, dryRun)
+		switch {
+		case err != nil:
+			// Load reports the problem.
+		case len(result.Steps) == 0:
+		case dryRun:
+			fmt.Fprintf(os.Stderr, "config %s is version %d; not migrating to %d in dry-run mode\n", result.Path, result.From, result.To)
+		default:
+			fmt.Fprintf(os.Stderr, "Migrated config %s from version %d to %d (original saved as %s)\n", result.Path, result.From, result.To, result.Backup)
+		}
+	}
+	return a.configStore.Load(path)
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+func TestLoadConfigLegacy(t *testing.T) {
+	newLegacyApp := func(confirm bool) (*app, *platformfs.MockFileSystem) {
+		mock := platformfs.NewMockFileSystem()
+		mock.Dirs["/home/test/.agents"] = true
+		mock.Files["/home/test/.agents/skillet.yaml"] = []byte("version: 1\ndefaultStrategy: copy\n")
+		return &app{
+			fs:          mock,
+			configStore: config.NewStore(mock),
+			prompter:    &scriptedPrompter{confirm: confirm},
+		}, mock
+	}
+
+	t.Run("declined without new config", func(t *testing.T) {
+		a, _ := newLegacyApp(false)
+		if _, err := a.loadConfig(&cobra.Command{}); err == nil {
+			t.Fatal("loadConfig() expected error when migration is declined")
+		}
+	})
+
+	t.Run("read-only flag", func(t *testing.T) {
+		a, mock := newLegacyApp(false)
+		a.legacyConfig = true
+		cfg, err := a.loadConfig(&cobra.Command{})
+		if err != nil {
+			t.Fatalf("loadConfig() error = %v", err)
+		}
+		if cfg.DefaultStrategy != config.StrategyCopy {
+			t.Errorf("DefaultStrategy = %q, want copy", cfg.DefaultStrategy)
+		}
+		if !mock.Exists("/home/test/.agents/skillet.yaml") || mock.Exists("/home/test/.config/skillet/config.yaml") {
+			t.Error("--legacy-config should not migrate")
+		}
+	})
+
+	t.Run("migrate", func(t *testing.T) {
+		a, mock := newLegacyApp(true)
+		cfg, err := a.loadConfig(&cobra.Command{})
+		if err != nil {
+			t.Fatalf("loadConfig() error = %v", err)
+		}
+		if cfg.DefaultStrategy != config.StrategyCopy {
+			t.Errorf("DefaultStrategy = %q, want copy", cfg.DefaultStrategy)
+		}
+		if mock.Exists("/home/test/.agents/skillet.yaml") {
+			t.Error("legacy config should be moved")
+		}
+	})
+}
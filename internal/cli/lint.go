@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newLintCmd creates the lint command.
+func newLintCmd(a *app) *cobra.Command {
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+
+	cmd := &cobra.Command{
+		Use:   "lint [skill...]",
+		Short: "Check SKILL.md files for broken references",
+		Long: `Check SKILL.md files for relative links and images that point to missing
+files or outside the skill directory.
+
+By default, lints every skill in all scopes. Pass skill names to lint only those.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, rootErr := a.findProjectRoot()
+			if rootErr != nil {
+				root = ""
+			}
+			if scopeFlags.Project && rootErr != nil {
+				return fmt.Errorf("not in a project directory")
+			}
+			svc := usecase.NewLintService(a.fs, a.config, root)
+
+			opts := usecase.LintOptions{Names: args}
+			if scopeFlags.IsSet() {
+				scope, err := scopeFlags.GetScope()
+				if err != nil {
+					return err
+				}
+				opts.Scope = &scope
+			}
+
+			results, err := svc.Lint(opts)
+			if err != nil {
+				return fmt.Errorf("lint failed: %w", err)
+			}
+
+			var problems int
+			for _, r := range results {
+				if r.Error != nil {
+					fmt.Printf("%s (%s): error: %v\n", r.SkillName, r.Scope, r.Error)
+					problems++
+					continue
+				}
+				for _, issue := range r.Issues {
+					fmt.Printf("%s (%s): line %d: %s: %s\n", r.SkillName, r.Scope, issue.Line, issue.Link, issue.Message)
+					problems++
+				}
+			}
+
+			if problems > 0 {
+				return fmt.Errorf("%d problem(s) found", problems)
+			}
+			fmt.Printf("%d skill(s) checked, no problems found\n", len(results))
+			return nil
+		},
+	}
+
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
@@ -1,51 +1,89 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
 	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
 )
 
 // newListCmd creates the list command.
 func newListCmd(a *app) *cobra.Command {
 	scopeFlags := NewScopeFlags(skill.ScopeProject)
+	var (
+		category   string
+		tags       []string
+		jsonOutput bool
+		quiet      bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List available skills",
-		Long: `List all available skills.
+		Long: `List all available skills with their scope, category, description, and the
+targets they are installed in.
 
-Use --global or --project to filter by scope.
-If neither is specified, shows all skills.`,
+Use --global, --org, or --project to filter by scope, --category to show
+only default or optional skills, and --tag to show only skills with a tag
+(repeat it to require several). If no scope is specified, shows all skills.
+Draft skills, which sync does not install, are marked [draft].
+
+Use --json to print the skills as a JSON array for tooling, or --quiet to
+print only their names.`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			store, _, rootErr := a.newSkillStore()
+			if jsonOutput && quiet {
+				return fmt.Errorf("--json and --quiet cannot be used together")
+			}
 
+			root, rootErr := a.findProjectRoot()
 			if scopeFlags.Project && rootErr != nil {
 				return fmt.Errorf("not in a project directory")
 			}
 
-			var skills []*skill.Skill
-			var err error
-
-			if !scopeFlags.IsSet() {
-				skills, err = store.GetAll()
-			} else {
-				scope, scopeErr := scopeFlags.GetScope()
-				if scopeErr != nil {
-					return scopeErr
+			var opts usecase.ListOptions
+			if scopeFlags.IsSet() {
+				scope, err := scopeFlags.GetScope()
+				if err != nil {
+					return err
 				}
-				skills, err = store.GetByScope(scope)
+				opts.Scope = &scope
 			}
+			if category != "" {
+				c, err := skill.ParseCategory(category)
+				if err != nil {
+					return err
+				}
+				opts.Category = &c
+			}
+			opts.Tags = tags
 
+			skills, err := usecase.NewListService(a.fs, a.config, root).ListSkills(opts)
 			if err != nil {
 				return fmt.Errorf("failed to list skills: %w", err)
 			}
 
+			switch {
+			case jsonOutput:
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(skills); err != nil {
+					return fmt.Errorf("failed to encode skills: %w", err)
+				}
+				return nil
+			case quiet:
+				for _, s := range skills {
+					fmt.Println(s.Name)
+				}
+				return nil
+			}
+
 			if len(skills) == 0 {
 				fmt.Println("No skills found")
 				return nil
@@ -59,28 +97,40 @@ If neither is specified, shows all skills.`,
 	}
 
 	AddScopeFlags(cmd, &scopeFlags)
+	cmd.Flags().StringVar(&category, "category", "", "Only list skills of this category (default or optional)")
+	cmd.Flags().StringArrayVar(&tags, "tag", nil, "Only list skills with this tag (repeatable)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the skills as JSON")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Print only skill names")
 
 	return cmd
 }
 
 // printSkillsByScope displays skills in a table format grouped by scope.
-func printSkillsByScope(skills []*skill.Skill) error {
+func printSkillsByScope(skills []usecase.SkillInfo) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
-	if _, err := fmt.Fprintf(w, "NAME\tSCOPE\tCATEGORY\tDESCRIPTION\n"); err != nil {
+	if _, err := fmt.Fprintf(w, "NAME\tVERSION\tSCOPE\tCATEGORY\tTARGETS\tDESCRIPTION\n"); err != nil {
 		return fmt.Errorf("failed to write table header: %w", err)
 	}
-	if _, err := fmt.Fprintf(w, "----\t-----\t--------\t-----------\n"); err != nil {
+	if _, err := fmt.Fprintf(w, "----\t-------\t-----\t--------\t-------\t-----------\n"); err != nil {
 		return fmt.Errorf("failed to write table separator: %w", err)
 	}
 
 	for _, s := range skills {
-		category := "default"
-		if s.Category == skill.CategoryOptional {
-			category = "optional"
+		name := s.Name
+		if s.Draft {
+			name += " [draft]"
+		}
+		targets := strings.Join(s.Targets, ",")
+		if targets == "" {
+			targets = "-"
+		}
+		version := "-"
+		if s.Version != "" {
+			version = versionLabel(s.Version)
 		}
 		desc := truncate(s.Description, 60)
-		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Name, s.Scope, category, desc); err != nil {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", name, version, s.Scope, s.Category, targets, desc); err != nil {
 			return fmt.Errorf("failed to write skill row: %w", err)
 		}
 	}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger(&buf, true, logFormatText)
+	if err != nil {
+		t.Fatalf("newLogger() error = %v", err)
+	}
+	logger.Debug("created symlink", "link", "/home/test/.claude/skills/pdf")
+	if got := buf.String(); got != "level=DEBUG msg=\"created symlink\" link=/home/test/.claude/skills/pdf\n" {
+		t.Errorf("text record = %q, want debug level without time", got)
+	}
+
+	buf.Reset()
+	logger, err = newLogger(&buf, true, logFormatJSON)
+	if err != nil {
+		t.Fatalf("newLogger() error = %v", err)
+	}
+	logger.Debug("skipping skill", "skill", "pdf", "reason", "copy matches the store")
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("JSON record %q: %v", buf.String(), err)
+	}
+	if record["msg"] != "skipping skill" || record["reason"] != "copy matches the store" || record["time"] == nil {
+		t.Errorf("JSON record = %v", record)
+	}
+
+	buf.Reset()
+	logger, err = newLogger(&buf, false, logFormatText)
+	if err != nil {
+		t.Fatalf("newLogger() error = %v", err)
+	}
+	logger.Debug("hidden")
+	logger.Warn("shown")
+	if got := buf.String(); strings.Contains(got, "hidden") || !strings.Contains(got, "shown") {
+		t.Errorf("records without --verbose = %q, want warnings only", got)
+	}
+
+	if _, err := newLogger(&buf, true, "xml"); err == nil {
+		t.Error("newLogger() should reject an unknown format")
+	}
+}
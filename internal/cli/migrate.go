@@ -1,18 +1,27 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/prompt"
 	"github.com/wwwyo/skillet/internal/skill"
 	"github.com/wwwyo/skillet/internal/usecase"
 )
 
 func newMigrateCmd(a *app) *cobra.Command {
-	var skipPrompts bool
+	var (
+		skipPrompts bool
+		dryRun      bool
+		conflict    string
+		allSources  bool
+	)
 	scopeFlags := NewScopeFlags(skill.ScopeProject)
 
 	cmd := &cobra.Command{
@@ -24,39 +33,78 @@ This command finds skills in target directories (e.g., .claude/skills/) that are
 symlinks, moves them to the agents directory, and creates links back to the targets.
 
 Use --global or --project to specify which scope to migrate:
-  --global  - Migrate from global targets (e.g., ~/.claude/skills/) to ~/.agents/
-  --project - Migrate from project targets (e.g., .claude/skills/) to .agents/ (default)
+  --global  - Migrate from global targets (e.g., ~/.claude/skills/) to the global store
+  --project - Migrate from project targets (e.g., .claude/skills/) to .agents/
 
-Use this after setting up skillet to consolidate existing skills.`,
+Without a flag, project scope is used inside a project and global scope otherwise.
+
+A target copy identical to the store copy of the same name is adopted: it is
+removed and linked to the store copy. A copy that differs is a conflict, which
+you resolve for each skill when asked, or for all of them with --conflict:
+  keep-store   delete the target copy
+  keep-target  archive the store copy and move the target copy into the store
+  rename       move the target copy into the store as <name>-from-<target>
+With --yes and no --conflict, conflicts are resolved with rename.
+
+With --all-sources, each target's migrate sources are moved too: for claude,
+commands/*.md and agents/*.md become skills in the commands and agents
+collections (e.g., commands/review.md becomes commands/review). Set
+migrateSources on a target in the config to choose other directories.
+
+Use this after setting up skillet to consolidate existing skills. With
+--dry-run, the skills that would be moved and the links that would be created
+are listed without changing anything.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			scope, err := scopeFlags.GetScope()
+			dryRun = dryRun || a.dryRun
+			var resolution usecase.MigrateConflict
+			if conflict != "" {
+				var err error
+				if resolution, err = usecase.ParseMigrateConflict(conflict); err != nil {
+					return err
+				}
+			}
+
+			projectRoot, rootErr := a.findProjectRoot()
+			scope, err := scopeFlags.Infer(rootErr == nil)
 			if err != nil {
 				return err
 			}
+			if scope == skill.ScopeOrg || scope == skill.ScopeSystem {
+				return fmt.Errorf("migrate does not support --%s scope", scope)
+			}
+			if scopeFlags.IsSet() {
+				a.logf("scope: %s (from flag)", scope)
+			} else {
+				a.logf("scope: %s (inferred from working directory)", scope)
+			}
 
 			cfg, err := a.configStore.Load("")
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w (run 'skillet init -g' first)", err)
 			}
 
-			projectRoot := ""
-			if scope == skill.ScopeProject {
-				projectRoot, err = a.configStore.FindProjectRoot()
-				if err != nil {
-					return fmt.Errorf("failed to find project root: %w", err)
-				}
+			if scope != skill.ScopeProject {
+				projectRoot = ""
+			} else if rootErr != nil {
+				return fmt.Errorf("failed to find project root: %w", rootErr)
 			}
 
-			return runMigrate(a, cfg, migrateRunOptions{
-				skipPrompts:    skipPrompts,
+			return runMigrate(cmd.Context(), a, cfg, migrateRunOptions{
+				prompter:       a.prompterFor(skipPrompts),
 				defaultConfirm: true,
 				scope:          scope,
 				projectRoot:    projectRoot,
+				dryRun:         dryRun,
+				conflict:       resolution,
+				allSources:     allSources,
 			})
 		},
 	}
 
 	cmd.Flags().BoolVarP(&skipPrompts, "yes", "y", false, "Skip confirmation prompts")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be migrated without making changes")
+	cmd.Flags().StringVar(&conflict, "conflict", "", "Resolve copies that differ from the store: keep-store, keep-target, or rename")
+	cmd.Flags().BoolVar(&allSources, "all-sources", false, "Also migrate the targets' commands, agents, and other configured sources")
 	AddScopeFlags(cmd, &scopeFlags)
 
 	return cmd
@@ -64,69 +112,111 @@ Use this after setting up skillet to consolidate existing skills.`,
 
 // migrateRunOptions contains CLI-specific options for migration.
 type migrateRunOptions struct {
-	skipPrompts    bool
+	prompter       prompt.Prompter
 	defaultConfirm bool
 	scope          skill.Scope
 	projectRoot    string
+	dryRun         bool
+	// conflict resolves every conflict; empty asks about each one
+	conflict usecase.MigrateConflict
+	// allSources also migrates the targets' migrate sources
+	allSources bool
 }
 
 // runMigrate executes the migration logic.
-func runMigrate(a *app, cfg *config.Config, opts migrateRunOptions) error {
+func runMigrate(ctx context.Context, a *app, cfg *config.Config, opts migrateRunOptions) error {
+	startedAt := time.Now()
 	syncSvc := usecase.NewSyncService(a.fs, cfg, opts.projectRoot)
 	svc := usecase.NewMigrateService(a.fs, cfg, opts.projectRoot, syncSvc)
 
 	migrateOpts := usecase.MigrateOptions{
 		Scope:       opts.scope,
 		ProjectRoot: opts.projectRoot,
+		DryRun:      opts.dryRun,
+		Conflict:    opts.conflict,
+		AllSources:  opts.allSources,
 	}
 
 	existingSkills := svc.FindSkillsToMigrate(migrateOpts)
-	if len(existingSkills) == 0 {
+	var sources []usecase.MigrateSourceEntry
+	if opts.allSources {
+		sources = svc.FindSourcesToMigrate(migrateOpts)
+	}
+	if len(existingSkills) == 0 && len(sources) == 0 {
 		fmt.Println("No skills to migrate.")
 		return nil
 	}
 
-	printFoundSkills(existingSkills)
+	printFoundSkills(existingSkills, sources)
 
-	if !opts.skipPrompts {
-		confirmed, err := promptMigrateConfirmation(opts.defaultConfirm)
+	if !opts.dryRun {
+		confirmed, err := opts.prompter.Confirm("Migrate existing skills to agents directory?", opts.defaultConfirm)
 		if err != nil || !confirmed {
 			return nil
 		}
+		if migrateOpts.Conflict == "" {
+			if migrateOpts.Resolutions, err = resolveMigrateConflicts(svc, migrateOpts, existingSkills, opts.prompter); err != nil {
+				return err
+			}
+		}
 	}
 
-	result, err := svc.Migrate(migrateOpts, existingSkills)
+	result, err := svc.Migrate(ctx, migrateOpts, existingSkills)
 	if err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
 
+	if opts.dryRun {
+		fmt.Println("\nDry run - no changes made:")
+		printMovePlan(result.MoveResults)
+		printSyncResults(result.SyncResults)
+		return nil
+	}
 	printMoveResults(result.MoveResults)
 	printMigrateSyncResults(result.SyncResults)
 
+	report := usecase.NewRunReport("migrate", startedAt)
+	report.AddMigrateResult(result)
+	writeRunReport(a, cfg, opts.projectRoot, report)
+
 	return nil
 }
 
-// printFoundSkills prints the skills found for migration.
-func printFoundSkills(found map[string][]string) {
-	fmt.Println("\nFound existing skills:")
-	for targetName, skills := range found {
-		for _, skillName := range skills {
-			fmt.Printf("  %s: %s\n", targetName, skillName)
+// resolveMigrateConflicts asks how to resolve each target copy that differs
+// from the store copy, defaulting to rename, which keeps both.
+func resolveMigrateConflicts(svc *usecase.MigrateService, opts usecase.MigrateOptions, existing map[string][]string, p prompt.Prompter) (map[string]usecase.MigrateConflict, error) {
+	conflicts, err := svc.Conflicts(opts, existing)
+	if err != nil || len(conflicts) == 0 {
+		return nil, err
+	}
+	options := make([]string, 0, len(usecase.MigrateConflicts))
+	for _, c := range usecase.MigrateConflicts {
+		options = append(options, string(c))
+	}
+
+	resolutions := make(map[string]usecase.MigrateConflict, len(conflicts))
+	for _, c := range conflicts {
+		answer, err := p.Select(fmt.Sprintf("%s in %s differs from the store copy. Keep which?", c.SkillName, c.FromTarget), options, string(usecase.MigrateConflictRename))
+		if err != nil {
+			return nil, err
 		}
+		resolutions[c.FromTarget+"/"+c.SkillName] = usecase.MigrateConflict(answer)
 	}
+	return resolutions, nil
 }
 
-// promptMigrateConfirmation asks the user to confirm migration.
-func promptMigrateConfirmation(defaultValue bool) (bool, error) {
-	var confirmed bool
-	prompt := &survey.Confirm{
-		Message: "Migrate existing skills to agents directory?",
-		Default: defaultValue,
+// printFoundSkills prints the skills and migrate source entries found for
+// migration.
+func printFoundSkills(found map[string][]string, sources []usecase.MigrateSourceEntry) {
+	fmt.Println("\nFound existing skills:")
+	for _, targetName := range slices.Sorted(maps.Keys(found)) {
+		for _, skillName := range found[targetName] {
+			fmt.Printf("  %s: %s\n", targetName, skillName)
+		}
 	}
-	if err := survey.AskOne(prompt, &confirmed); err != nil {
-		return false, err
+	for _, e := range sources {
+		fmt.Printf("  %s: %s\n", e.Target, e.Source)
 	}
-	return confirmed, nil
 }
 
 // printMoveResults prints the results of moving skills.
@@ -138,17 +228,58 @@ func printMoveResults(results []usecase.MigrateMoveResult) {
 	for _, r := range results {
 		switch r.Action {
 		case usecase.MigrateActionMoved:
+			if r.Source != "" {
+				fmt.Printf("  ✓ Moved %s/%s to agents as %s\n", r.FromTarget, r.Source, r.SkillName)
+				continue
+			}
 			fmt.Printf("  ✓ Moved %s to agents\n", r.SkillName)
 		case usecase.MigrateActionSkipped:
 			fmt.Printf("  • Skipping %s (%s)\n", r.SkillName, r.Message)
 		case usecase.MigrateActionRemoved:
 			// Silent for duplicates.
+		case usecase.MigrateActionAdopted:
+			fmt.Printf("  ✓ Adopted %s (identical to the store copy)\n", r.SkillName)
+		case usecase.MigrateActionReplaced, usecase.MigrateActionRenamed:
+			fmt.Printf("  ✓ Moved %s from %s to agents (%s)\n", r.SkillName, r.FromTarget, r.Message)
 		case usecase.MigrateActionError:
 			fmt.Printf("  ⚠ Failed to process %s: %v\n", r.SkillName, r.Error)
 		}
 	}
 }
 
+// printMovePlan prints what a dry-run migration would do with each skill.
+func printMovePlan(results []usecase.MigrateMoveResult) {
+	for _, r := range results {
+		if r.Source != "" {
+			switch r.Action {
+			case usecase.MigrateActionMoved:
+				fmt.Printf("  ~ %s/%s (move to agents as %s)\n", r.FromTarget, r.Source, r.SkillName)
+			case usecase.MigrateActionSkipped:
+				fmt.Printf("  • %s/%s (skip, %s is already in agents)\n", r.FromTarget, r.Source, r.SkillName)
+			case usecase.MigrateActionError:
+				fmt.Printf("  ! %s/%s (%s: %v)\n", r.FromTarget, r.Source, r.Message, r.Error)
+			}
+			continue
+		}
+		switch r.Action {
+		case usecase.MigrateActionMoved:
+			fmt.Printf("  ~ %s/%s (move to agents)\n", r.FromTarget, r.SkillName)
+		case usecase.MigrateActionSkipped:
+			fmt.Printf("  - %s/%s (remove, already in agents)\n", r.FromTarget, r.SkillName)
+		case usecase.MigrateActionRemoved:
+			fmt.Printf("  - %s/%s (remove, duplicate)\n", r.FromTarget, r.SkillName)
+		case usecase.MigrateActionAdopted:
+			fmt.Printf("  - %s/%s (remove, identical to the store copy)\n", r.FromTarget, r.SkillName)
+		case usecase.MigrateActionReplaced:
+			fmt.Printf("  ~ %s/%s (move to agents, archive the store copy)\n", r.FromTarget, r.SkillName)
+		case usecase.MigrateActionRenamed:
+			fmt.Printf("  ~ %s/%s (move to agents as %s)\n", r.FromTarget, r.SkillName, r.StoreName)
+		case usecase.MigrateActionConflict:
+			fmt.Printf("  ! %s/%s (conflict: %s; choose with --conflict)\n", r.FromTarget, r.SkillName, r.Message)
+		}
+	}
+}
+
 // printMigrateSyncResults prints the sync results after migration.
 func printMigrateSyncResults(results []usecase.SyncResult) {
 	fmt.Println("\nSynced to targets:")
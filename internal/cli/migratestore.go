@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newMigrateStoreCmd creates the migrate-store command.
+func newMigrateStoreCmd(a *app) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate-store",
+		Short: "Move the global store from ~/.agents to the XDG data directory",
+		Long: `Move a global store kept at ~/.agents, where older versions created it, to
+$XDG_DATA_HOME/skillet (~/.local/share/skillet by default).
+
+Skills, templates, reports, the archive, the lock file, and the skill set are
+moved; other files in ~/.agents are left alone. Target symlinks into the old
+store are pointed at the new one.
+
+Without a globalPath in the config, skillet keeps using ~/.agents for as long
+as a store is there, so this only needs to run once. It refuses when the
+config sets globalPath or a store already exists at the destination.
+Use --dry-run to see what would be moved.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+
+			result, err := usecase.NewMigrateStoreService(a.fs, a.config).Migrate(usecase.MigrateStoreOptions{DryRun: dryRun})
+			if err != nil {
+				return fmt.Errorf("migrate-store failed: %w", err)
+			}
+
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+			from, to := config.ContractPath(a.fs, result.From), config.ContractPath(a.fs, result.To)
+			for _, name := range result.Moved {
+				fmt.Printf("  %s/%s -> %s/%s\n", from, name, to, name)
+			}
+			var failed int
+			for _, r := range result.Relinked {
+				if r.Error != nil {
+					fmt.Printf("  ! %s/%s (error: %v)\n", r.Target, r.SkillName, r.Error)
+					failed++
+					continue
+				}
+				fmt.Printf("  ~ %s/%s (relinked)\n", r.Target, r.SkillName)
+			}
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d link(s) could not be updated; run 'skillet verify-links --fix'", failed)
+			}
+			if !dryRun {
+				fmt.Printf("\n✓ Moved the global store to %s\n", to)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be moved without moving it")
+
+	return cmd
+}
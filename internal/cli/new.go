@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newNewCmd creates the new command.
+func newNewCmd(a *app) *cobra.Command {
+	var (
+		description string
+		category    string
+		template    string
+		skipPrompts bool
+		noSync      bool
+	)
+	scopeFlags := NewScopeFlags(skill.ScopeGlobal)
+
+	cmd := &cobra.Command{
+		Use:   "new <name>",
+		Short: "Create a new skill from a template",
+		Long: `Create a skill directory with a SKILL.md in the skill store, then sync it.
+
+The skill is created in the global store by default; use --project to create
+it in the project instead, and --category optional to place it under
+skills/optional/. Without --description, you are prompted for one.
+
+Use --template <name> to start from a directory under templates/ in the
+global store (e.g. ~/.local/share/skillet/templates/). Every file in it is
+copied into the new skill. Files ending in .tmpl are rendered with Go
+templates and lose the extension; they can use {{.Name}}, {{.Description}},
+{{.Scope}}, and {{.Category}}. A SKILL.md is generated when the template has none.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := a.rejectForcedDryRun("new"); err != nil {
+				return err
+			}
+
+			scope, err := scopeFlags.GetScope()
+			if err != nil {
+				return err
+			}
+			root, rootErr := a.findProjectRoot()
+			if rootErr != nil {
+				root = ""
+				if scope == skill.ScopeProject {
+					return fmt.Errorf("not in a project directory")
+				}
+			}
+			cat, err := skill.ParseCategory(category)
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("description") {
+				input, err := a.prompterFor(skipPrompts).Input("Description:", "")
+				if err != nil {
+					return err
+				}
+				description = strings.TrimSpace(input)
+			}
+
+			result, err := usecase.NewScaffoldService(a.fs, a.config, root).Create(usecase.ScaffoldOptions{
+				Name:        args[0],
+				Description: description,
+				Scope:       scope,
+				Category:    cat,
+				Template:    template,
+			})
+			if err != nil {
+				return fmt.Errorf("new failed: %w", err)
+			}
+			fmt.Printf("Created %s in %s scope (%s): %s\n", result.SkillName, result.Scope, result.Category, result.Path)
+			for _, file := range result.Files {
+				fmt.Printf("  + %s\n", file)
+			}
+
+			if noSync {
+				return nil
+			}
+			results, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{
+				Scope: &scope,
+				Names: []string{result.SkillName},
+			})
+			if err != nil {
+				return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+			}
+			printMigrateSyncResults(results)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&description, "description", "", "Skill description (prompted for when omitted)")
+	cmd.Flags().StringVar(&category, "category", skill.CategoryDefault.String(), "Skill category: default or optional")
+	cmd.Flags().StringVar(&template, "template", "", "Template directory name under templates/ in the global store")
+	cmd.Flags().BoolVarP(&skipPrompts, "yes", "y", false, "Skip prompts and leave the description empty when not given")
+	cmd.Flags().BoolVar(&noSync, "no-sync", false, "Do not sync to targets after creating")
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newOpenCmd creates the open command.
+func newOpenCmd(a *app) *cobra.Command {
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+	var (
+		target   string
+		pathOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "open <name>",
+		Short: "Open a skill in your editor",
+		Long: `Open a skill's SKILL.md in $VISUAL or $EDITOR.
+
+By default, opens the highest-priority skill with that name in the store.
+Use --global, --org, --system, or --project to pick a scope, and --target to open
+the installed copy in a target instead. Use --path-only to print the skill
+directory without opening it.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSkillNames(a, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, scope, err := a.resolveScope(&scopeFlags)
+			if err != nil {
+				return err
+			}
+
+			result, err := usecase.NewLocateService(a.fs, a.config, root).Locate(usecase.LocateOptions{
+				Name:   args[0],
+				Scope:  scope,
+				Target: target,
+			})
+			if err != nil {
+				return withTargetSuggestion(err)
+			}
+
+			if pathOnly {
+				fmt.Println(result.Dir)
+				return nil
+			}
+
+			path := result.File
+			if !a.fs.Exists(path) {
+				path = result.Dir
+			}
+			err = openInEditor(path)
+			if errors.Is(err, errNoEditor) {
+				return fmt.Errorf("%w or use --path-only", err)
+			}
+			return err
+		},
+	}
+
+	AddScopeFlags(cmd, &scopeFlags)
+	cmd.Flags().StringVar(&target, "target", "", "Open the copy installed in this target")
+	cmd.Flags().BoolVar(&pathOnly, "path-only", false, "Print the skill directory instead of opening it")
+
+	return cmd
+}
+
+// errNoEditor is returned by openInEditor when neither $VISUAL nor $EDITOR is set.
+var errNoEditor = errors.New("no editor configured: set $EDITOR")
+
+// openInEditor opens path in $VISUAL or $EDITOR, which may include arguments.
+func openInEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		return errNoEditor
+	}
+
+	c := exec.Command(fields[0], append(fields[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("failed to run editor %s: %w", fields[0], err)
+	}
+	return nil
+}
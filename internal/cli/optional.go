@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newEnableCmd creates the enable command.
+func newEnableCmd(a *app) *cobra.Command {
+	return newOptionalCmd(a, true)
+}
+
+// newDisableCmd creates the disable command.
+func newDisableCmd(a *app) *cobra.Command {
+	return newOptionalCmd(a, false)
+}
+
+// newOptionalCmd creates the enable or disable command.
+func newOptionalCmd(a *app, enable bool) *cobra.Command {
+	var (
+		target      string
+		noSync      bool
+		interactive bool
+	)
+
+	use, short, long := "enable", "Install an optional skill into targets", `Install an optional skill (one under skills/optional/) into every enabled
+target, or only into the target given with --target.
+
+Optional skills stay in the store until enabled. The choice is saved in the
+target's "optional" list in the config file, and sync installs the skill
+into those targets only.
+
+With --interactive and no skill, choose the optional skills of each target
+from a list that starts from the ones enabled now. Skills unselected there
+are disabled.`
+	if !enable {
+		use, short, long = "disable", "Remove an optional skill from targets", `Remove an optional skill from every target, or only from the target given
+with --target.
+
+The skill is removed from the target's "optional" list in the config file,
+and sync uninstalls it from those targets. The skill stays in the store.`
+	}
+
+	cmd := &cobra.Command{
+		Use:   use + " <skill>",
+		Short: short,
+		Long:  long,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if interactive && len(args) > 0 {
+				return fmt.Errorf("--interactive chooses skills from a list; do not name one")
+			}
+			if interactive {
+				return nil
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		ValidArgsFunction: completeSkillNames(a, isOptionalSkill),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := a.rejectForcedDryRun(use); err != nil {
+				return err
+			}
+			if a.legacyConfig {
+				return fmt.Errorf("%s cannot change a config read with --legacy-config", use)
+			}
+			configPath, err := a.configPath(cmd)
+			if err != nil {
+				return err
+			}
+
+			root, err := a.findProjectRoot()
+			if err != nil {
+				a.logf("no project root found: %v", err)
+				root = ""
+			}
+			if interactive {
+				return pickOptionalSkills(cmd.Context(), a, root, target, configPath, noSync)
+			}
+
+			result, err := usecase.NewOptionalService(a.fs, a.config, root).SetEnabled(usecase.OptionalOptions{
+				Name:   args[0],
+				Target: target,
+				Enable: enable,
+			}, configPath)
+			if err != nil {
+				return fmt.Errorf("%s failed: %w", use, withTargetSuggestion(err))
+			}
+
+			state := "enabled"
+			if !enable {
+				state = "disabled"
+			}
+			if len(result.Changed) == 0 {
+				fmt.Printf("%s already %s for %s (unchanged)\n", result.Skill.Name, state, strings.Join(result.Unchanged, ", "))
+			} else {
+				fmt.Printf("%s %s for %s\n", result.Skill.Name, state, strings.Join(result.Changed, ", "))
+			}
+
+			if noSync {
+				return nil
+			}
+			results, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{
+				Names:  []string{result.Skill.Name},
+				Target: target,
+			})
+			if err != nil {
+				return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+			}
+			printSyncResults(results)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&target, "target", "", "Only change this target")
+	cmd.Flags().BoolVar(&noSync, "no-sync", false, "Only update the config; do not sync")
+	if enable {
+		cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Choose the optional skills of each target from a list")
+	}
+
+	return cmd
+}
+
+// pickOptionalSkills asks which optional skills to enable for each target,
+// defaulting to the ones enabled now, saves the answers, and syncs the skills
+// that changed unless noSync is set.
+func pickOptionalSkills(ctx context.Context, a *app, root, target, configPath string, noSync bool) error {
+	svc := usecase.NewOptionalService(a.fs, a.config, root)
+	choices, err := svc.Choices(target)
+	if err != nil {
+		return fmt.Errorf("enable failed: %w", withTargetSuggestion(err))
+	}
+	if len(choices) == 0 || len(choices[0].Skills) == 0 {
+		fmt.Println("No optional skills in the store")
+		return nil
+	}
+
+	selection := make(map[string][]string, len(choices))
+	for _, choice := range choices {
+		selected, err := a.prompterFor(false).MultiSelect(fmt.Sprintf("Optional skills for %s (Space: toggle, Enter: confirm):", choice.Target), choice.Skills, choice.Enabled)
+		if err != nil {
+			return err
+		}
+		selection[choice.Target] = selected
+	}
+	changes, err := svc.Select(selection, configPath)
+	if err != nil {
+		return fmt.Errorf("enable failed: %w", err)
+	}
+	if len(changes) == 0 {
+		fmt.Println("No changes")
+		return nil
+	}
+
+	var names []string
+	for _, c := range changes {
+		state := "enabled"
+		if !c.Enable {
+			state = "disabled"
+		}
+		fmt.Printf("%s %s for %s\n", c.Skill, state, c.Target)
+		names = append(names, c.Skill)
+	}
+	if noSync {
+		return nil
+	}
+	results, err := usecase.NewSyncService(a.fs, a.config, root).Sync(ctx, usecase.SyncOptions{
+		Names:  slices.Compact(names),
+		Target: target,
+	})
+	if err != nil {
+		return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+	}
+	printSyncResults(results)
+	return nil
+}
+
+// isOptionalSkill reports whether sk can be enabled and disabled per target.
+func isOptionalSkill(sk *skill.Skill) bool {
+	return sk.Category == skill.CategoryOptional
+}
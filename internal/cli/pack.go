@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newPackCmd creates the pack command.
+func newPackCmd(a *app) *cobra.Command {
+	var (
+		all    bool
+		output string
+	)
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+
+	cmd := &cobra.Command{
+		Use:   "pack [skill...]",
+		Short: "Export skills into an archive",
+		Long: `Write skills into a .tar.gz or .zip archive, with a skillet-pack.yaml manifest
+recording each skill's scope, category, and checksum. Use skillet unpack to
+import the archive on another machine.
+
+Pass skill names to pack only those, or --all to pack every skill that takes
+effect here. Use --global, --org, --system, or --project to pack from a single
+scope. The archive is written to <skill>.tar.gz for a single skill and
+skills.tar.gz otherwise; use --output to choose the file, and its extension
+(.tar.gz, .tgz, or .zip) to choose the format.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && !all {
+				return errors.New("specify skills to pack or use --all")
+			}
+			if len(args) > 0 && all {
+				return errors.New("--all cannot be combined with skill names")
+			}
+			if err := a.rejectForcedDryRun("pack"); err != nil {
+				return err
+			}
+			root, scope, err := a.resolveScope(&scopeFlags)
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				output = "skills.tar.gz"
+				if len(args) == 1 {
+					output = args[0] + ".tar.gz"
+				}
+			}
+
+			result, err := usecase.NewPackService(a.fs, a.config, root).Pack(usecase.PackOptions{
+				Names:  args,
+				Scope:  scope,
+				Output: output,
+			})
+			if err != nil {
+				return fmt.Errorf("pack failed: %w", err)
+			}
+			for _, sk := range result.Skills {
+				fmt.Printf("  + %s (%s, %s)\n", sk.Name, sk.Scope, sk.Category)
+			}
+			fmt.Printf("Packed %d skill(s) into %s\n", len(result.Skills), result.Path)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Pack every skill")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Archive file (default: <skill>.tar.gz or skills.tar.gz)")
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
+
+// newUnpackCmd creates the unpack command.
+func newUnpackCmd(a *app) *cobra.Command {
+	var (
+		force  bool
+		dryRun bool
+		noSync bool
+	)
+	scopeFlags := NewScopeFlags(skill.ScopeGlobal)
+
+	cmd := &cobra.Command{
+		Use:   "unpack <archive> [skill...]",
+		Short: "Import skills from an archive",
+		Long: `Import the skills in an archive written by skillet pack into the skill store,
+then sync them to targets.
+
+Skills are unpacked into the global store by default; use --project to unpack
+into the project instead. Optional skills go under skills/optional/. Pass
+skill names after the archive to unpack only those.
+
+Every skill is checked against the checksum in the archive's manifest, and a
+skill that does not match is not written. A skill that already exists in the
+scope is left alone when identical; when it differs, it is reported and kept
+unless --force is given.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+
+			scope, err := scopeFlags.GetScope()
+			if err != nil {
+				return err
+			}
+			root, rootErr := a.findProjectRoot()
+			if rootErr != nil {
+				root = ""
+				if scope == skill.ScopeProject {
+					return fmt.Errorf("not in a project directory")
+				}
+			}
+
+			results, err := usecase.NewPackService(a.fs, a.config, root).Unpack(usecase.UnpackOptions{
+				Archive: args[0],
+				Scope:   scope,
+				Names:   args[1:],
+				Force:   force,
+				DryRun:  dryRun,
+			})
+			if err != nil {
+				return fmt.Errorf("unpack failed: %w", err)
+			}
+
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+			var changed []string
+			var failed int
+			for _, r := range results {
+				switch r.Action {
+				case usecase.UnpackActionUnpacked:
+					fmt.Printf("  + %s (%s)\n", r.SkillName, r.Path)
+					changed = append(changed, r.SkillName)
+				case usecase.UnpackActionReplaced:
+					fmt.Printf("  ~ %s (%s, replaced)\n", r.SkillName, r.Path)
+					changed = append(changed, r.SkillName)
+				case usecase.UnpackActionUnchanged:
+					fmt.Printf("  = %s (unchanged)\n", r.SkillName)
+				case usecase.UnpackActionError:
+					fmt.Printf("  ! %s (error: %v)\n", r.SkillName, r.Error)
+					failed++
+				}
+			}
+
+			if !dryRun && !noSync && len(changed) > 0 {
+				// Copies of a replaced skill are stale, so reinstall them.
+				syncResults, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{
+					Scope: &scope,
+					Names: changed,
+					Force: force,
+				})
+				if err != nil {
+					return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+				}
+				printMigrateSyncResults(syncResults)
+			}
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d skill(s) could not be unpacked", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Replace existing skills whose content differs")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+	cmd.Flags().BoolVar(&noSync, "no-sync", false, "Do not sync to targets after unpacking")
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
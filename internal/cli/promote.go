@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newPromoteCmd creates the promote command.
+func newPromoteCmd(a *app) *cobra.Command {
+	return newMoveScopeCmd(a, skill.ScopeGlobal)
+}
+
+// newDemoteCmd creates the demote command.
+func newDemoteCmd(a *app) *cobra.Command {
+	return newMoveScopeCmd(a, skill.ScopeProject)
+}
+
+// newMoveScopeCmd creates the promote or demote command, which moves a skill
+// into the scope to.
+func newMoveScopeCmd(a *app, to skill.Scope) *cobra.Command {
+	var (
+		dryRun bool
+		force  bool
+	)
+
+	from := skill.ScopeProject
+	use, short, long := "promote", "Move a project skill to the global store", `Move a skill from the current project's store to the global store, so every
+project gets it.`
+	if to == skill.ScopeProject {
+		from = skill.ScopeGlobal
+		use, short, long = "demote", "Move a global skill into the current project", `Move a skill from the global store to the current project's store, so only
+this project gets it.`
+	}
+	long += `
+
+The skill directory keeps its category and collection. It is uninstalled from
+the targets' ` + from.String() + ` skill directories, then synced, which installs it for its
+new scope and re-resolves it against skills of the same name in other scopes.
+
+When the destination already has a skill with the same name, the command
+fails; use --force to move that skill to the .archive/ directory of its
+store and continue.`
+
+	cmd := &cobra.Command{
+		Use:               use + " <skill>",
+		Short:             short,
+		Long:              long,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSkillNames(a, func(sk *skill.Skill) bool { return sk.Scope == from && !sk.Vendored }),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+
+			root, err := a.findProjectRoot()
+			if err != nil {
+				return fmt.Errorf("%s must be run inside a project: %w", use, err)
+			}
+
+			result, err := usecase.NewMoveScopeService(a.fs, a.config, root).Move(cmd.Context(), usecase.MoveScopeOptions{
+				Name:   args[0],
+				To:     to,
+				Force:  force,
+				DryRun: dryRun,
+			})
+			if err != nil {
+				return fmt.Errorf("%s failed: %w", use, err)
+			}
+
+			sk := result.Skill
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+				if result.Replaced != nil {
+					fmt.Printf("  - %s (%s, archive %s)\n", sk.Name, to, config.ContractPath(a.fs, result.Replaced.Path))
+				}
+				fmt.Printf("  ~ %s (%s -> %s, %s)\n", sk.Name, result.From, to, config.ContractPath(a.fs, sk.Path))
+				printSyncResults(result.Uninstalled)
+				return nil
+			}
+			if result.Replaced != nil {
+				fmt.Printf("Archived the %s copy of %s to %s\n", to, sk.Name, config.ContractPath(a.fs, result.ArchivedTo))
+			}
+			fmt.Printf("Moved %s from %s to %s scope (%s)\n", sk.Name, result.From, to, config.ContractPath(a.fs, sk.Path))
+
+			synced, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{
+				Names: []string{sk.Name},
+				Force: true,
+			})
+			printSyncResults(append(result.Uninstalled, synced...))
+			if err != nil {
+				return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+	cmd.Flags().BoolVar(&force, "force", false, "Archive a skill with the same name in the destination scope")
+
+	return cmd
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newPruneCmd creates the prune command.
+func newPruneCmd(a *app) *cobra.Command {
+	var (
+		dryRun bool
+		target string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove broken links and orphaned installs from targets",
+		Long: `Scan the skill directories of every target and remove installs that no
+longer match the store:
+
+  - symlinks whose destination no longer exists, such as a skill deleted
+    straight from the store
+  - symlinks that resolve outside every skill store, such as links into a
+    store that moved
+  - copies recorded by an earlier sync whose skill has left the store
+
+Other entries, such as directories copied in by hand, are never removed.
+Use --dry-run to list what would be removed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+			root, rootErr := a.findProjectRoot()
+			if rootErr != nil {
+				a.logf("no project found: %v", rootErr)
+				root = ""
+			}
+
+			results, err := usecase.NewPruneService(a.fs, a.config, root).Prune(usecase.PruneOptions{
+				DryRun: dryRun,
+				Target: target,
+			})
+			if err != nil {
+				return fmt.Errorf("prune failed: %w", withTargetSuggestion(err))
+			}
+
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+			if len(results) == 0 {
+				fmt.Println("Nothing to prune")
+				return nil
+			}
+			if problems := printPruneResults(results); problems > 0 {
+				return fmt.Errorf("%d install(s) could not be removed", problems)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without removing it")
+	cmd.Flags().StringVar(&target, "target", "", "Prune only the named target")
+
+	return cmd
+}
+
+// printPruneResults prints one line per pruned install and returns the number
+// of installs that could not be removed.
+func printPruneResults(results []usecase.PruneResult) int {
+	var problems int
+	for _, r := range results {
+		switch {
+		case r.Error != nil:
+			fmt.Printf("  ! %s/%s (error: %v)\n", r.Target, r.SkillName, r.Error)
+			problems++
+		case r.Removed:
+			fmt.Printf("  - %s/%s (%s, removed)\n", r.Target, r.SkillName, r.Reason)
+		default:
+			fmt.Printf("  - %s/%s (%s)\n", r.Target, r.SkillName, r.Reason)
+		}
+	}
+	return problems
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newPublishCmd creates the publish command.
+func newPublishCmd(a *app) *cobra.Command {
+	var (
+		dryRun bool
+		noSync bool
+	)
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+
+	cmd := &cobra.Command{
+		Use:   "publish <name>",
+		Short: "Publish a draft skill and sync it to targets",
+		Long: `Remove "draft: true" from a skill's SKILL.md frontmatter, then sync it.
+
+Draft skills stay in the store and appear in list, but sync never installs
+them. By default, publishes the highest-priority draft with that name; use
+--global, --org, or --project to pick a scope.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+
+			root, scope, err := a.resolveScope(&scopeFlags)
+			if err != nil {
+				return err
+			}
+
+			sk, err := usecase.NewPublishService(a.fs, a.config, root).Publish(usecase.PublishOptions{
+				Name:   args[0],
+				Scope:  scope,
+				DryRun: dryRun,
+			})
+			if err != nil {
+				return fmt.Errorf("publish failed: %w", err)
+			}
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+				fmt.Printf("  ~ %s (%s, publish)\n", sk.Name, sk.Scope)
+				return nil
+			}
+			fmt.Printf("Published %s (%s)\n", sk.Name, sk.Scope)
+
+			if noSync {
+				return nil
+			}
+			results, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{Scope: &sk.Scope})
+			if err != nil {
+				return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+			}
+			printMigrateSyncResults(results)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+	cmd.Flags().BoolVar(&noSync, "no-sync", false, "Do not sync to targets after publishing")
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
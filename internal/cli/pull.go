@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newPullCmd creates the pull command.
+func newPullCmd(a *app) *cobra.Command {
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+	var (
+		target      string
+		force       bool
+		skipPrompts bool
+		dryRun      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pull [skill...]",
+		Short: "Copy changes made in installed copies back into the store",
+		Long: `Find copies of skills installed in targets that were edited after skillet
+last synced them, show how each differs from the store, and offer to copy the
+changes back into the store. The pulled skills are then synced, so the other
+targets get the changes too. Symlinks show the store itself and never need
+pulling, and copies that are only behind the store are left to sync.
+
+A copy whose store skill also changed since the sync is skipped, since pulling
+it would discard the store's changes; use --force to pull it anyway. When
+several targets edited the same skill, the first copy pulled wins and the sync
+replaces the others.
+
+Files the copy did not change keep their store content, and the skillet
+banner is removed from SKILL.md. Copies in targets with a transform type
+cannot be pulled.
+
+Give skill names to limit the search, and --target to search one target.
+With --dry-run, the edited copies and their diffs are listed without asking or
+changing anything.`,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			return completeSkillNames(a, func(sk *skill.Skill) bool { return !sk.ReadOnly() && !sk.Vendored })(cmd, nil, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+
+			root, scope, err := a.resolveScope(&scopeFlags)
+			if err != nil {
+				return err
+			}
+			svc := usecase.NewPullService(a.fs, a.config, root)
+			changes, err := svc.Changes(usecase.PullOptions{Names: args, Scope: scope, Target: target})
+			if err != nil {
+				return fmt.Errorf("pull failed: %w", withTargetSuggestion(err))
+			}
+			if len(changes) == 0 {
+				fmt.Println("No edited copies to pull.")
+				return nil
+			}
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+
+			prompter := a.prompterFor(skipPrompts)
+			pulledFrom := make(map[string]string)
+			var pulled []string
+			var failed int
+			for _, c := range changes {
+				fmt.Printf("\n%s/%s (edited %s, synced %s)\n", c.Target, c.SkillName, c.EditedAt.Local().Format("2006-01-02 15:04"), c.SyncedAt.Local().Format("2006-01-02 15:04"))
+				if c.Error != nil {
+					fmt.Printf("  ! %v\n", c.Error)
+					failed++
+					continue
+				}
+				for _, f := range c.Files {
+					if f.Binary {
+						fmt.Printf("Binary files differ: %s\n", a.fs.Join(c.Path, f.Path))
+					} else {
+						fmt.Print(f.Diff)
+					}
+				}
+
+				switch {
+				case dryRun:
+					continue
+				case pulledFrom[c.SkillName] != "":
+					fmt.Printf("  • Skipping (already pulled from %s)\n", pulledFrom[c.SkillName])
+					continue
+				case c.StoreChanged && !force:
+					fmt.Println("  • Skipping (the store copy changed too; use --force to pull anyway)")
+					continue
+				}
+				ok, err := prompter.Confirm(fmt.Sprintf("Copy the changes in %s/%s into the store?", c.Target, c.SkillName), true)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+				if err := svc.Pull(c); err != nil {
+					fmt.Printf("  ⚠ Failed to pull: %v\n", err)
+					failed++
+					continue
+				}
+				fmt.Printf("  ✓ Pulled into %s\n", config.ContractPath(a.fs, c.StorePath))
+				pulledFrom[c.SkillName] = c.Target
+				pulled = append(pulled, c.SkillName)
+			}
+
+			if len(pulled) > 0 {
+				synced, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{Names: pulled, Scope: scope, Force: true})
+				printSyncResults(synced)
+				if err != nil {
+					return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+				}
+			}
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d skill(s) could not be pulled", failed)
+			}
+			return nil
+		},
+	}
+
+	AddScopeFlags(cmd, &scopeFlags)
+	cmd.Flags().StringVar(&target, "target", "", "Pull only copies in this target")
+	cmd.Flags().BoolVar(&force, "force", false, "Pull copies whose store skill also changed since they were synced")
+	cmd.Flags().BoolVarP(&skipPrompts, "yes", "y", false, "Skip confirmation prompts")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List edited copies without changing anything")
+
+	return cmd
+}
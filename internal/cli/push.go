@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newPushCmd creates the push command.
+func newPushCmd(a *app) *cobra.Command {
+	var (
+		host   string
+		port   int
+		dest   string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "push --host <host>",
+		Short: "Mirror skills to a remote host over SSH (experimental)",
+		Long: `Mirror the resolved skill set to a directory on a remote host over SSH.
+
+The host is anything the ssh client accepts, such as an alias from ~/.ssh/config.
+The remote needs a POSIX shell; skillet does not have to be installed there.
+
+Skills are copied into --dest (default ~/.agents/skills), so running 'skillet sync'
+on the remote installs them into its targets. Point --dest at a target's skills
+directory to skip that step. Only skills that changed since the last push are
+transferred, and skills removed locally are deleted from the remote.
+
+This command is experimental.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+			if a.offline {
+				return fmt.Errorf("cannot push to %s: %w", host, fetch.ErrOffline)
+			}
+
+			var sshArgs []string
+			if port != 0 {
+				sshArgs = append(sshArgs, "-p", strconv.Itoa(port))
+			}
+			remote := platformfs.NewSSHFileSystem(host, sshArgs...)
+
+			root, _ := a.findProjectRoot()
+			results, err := usecase.NewPushService(a.fs, a.config, root).Push(usecase.PushOptions{
+				Remote: remote,
+				Dest:   dest,
+				DryRun: dryRun,
+			})
+			if err != nil {
+				return fmt.Errorf("push failed: %w", err)
+			}
+
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+
+			var changed, failed int
+			for _, r := range results {
+				switch r.Action {
+				case usecase.PushActionUpload:
+					fmt.Printf("  + %s (upload)\n", r.SkillName)
+					changed++
+				case usecase.PushActionUpdate:
+					fmt.Printf("  ~ %s (update)\n", r.SkillName)
+					changed++
+				case usecase.PushActionDelete:
+					fmt.Printf("  - %s (delete)\n", r.SkillName)
+					changed++
+				case usecase.PushActionError:
+					fmt.Printf("  ! %s (error: %v)\n", r.SkillName, r.Error)
+					failed++
+				}
+			}
+			fmt.Printf("Summary: %d changed, %d unchanged", changed, len(results)-changed-failed)
+			if failed > 0 {
+				fmt.Printf(", %d errors", failed)
+			}
+			fmt.Println()
+
+			if failed > 0 {
+				return fmt.Errorf("%d skill(s) failed to push", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&host, "host", "", "Remote host to push to (ssh destination)")
+	cmd.Flags().IntVar(&port, "port", 0, "SSH port (default from ssh config)")
+	cmd.Flags().StringVar(&dest, "dest", usecase.DefaultPushDest, "Remote directory to mirror skills into")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+	_ = cmd.MarkFlagRequired("host")
+
+	return cmd
+}
@@ -12,63 +12,171 @@ import (
 // newRemoveCmd creates the remove command.
 func newRemoveCmd(a *app) *cobra.Command {
 	scopeFlags := NewScopeFlags(skill.ScopeProject)
+	var (
+		target      string
+		keepStore   bool
+		all         bool
+		skipPrompts bool
+		dryRun      bool
+	)
 
 	cmd := &cobra.Command{
-		Use:   "remove <name>",
-		Short: "Remove a skill from the store and targets",
-		Long: `Remove a skill from the skill store and all targets.
+		Use:   "remove <name|pattern>... | --all <scope flag>",
+		Short: "Remove skills from the store and targets",
+		Long: `Remove skills from the skill store and all targets.
 
 By default, attempts to find the skill in any scope (project scope takes priority).
-Use --global or --project to specify a particular scope.
+Use --global, --org, or --project to specify a particular scope.
 
 This removes the skill from both the skillet store and all configured targets
-(e.g., ~/.claude/skills).`,
+(e.g., ~/.claude/skills).
+
+Use --target to uninstall the skill from one target only, keeping it in the
+store and the other targets, or --keep-store to uninstall it from every
+target. Either way, the next sync installs it again while it is in the store;
+to keep an optional skill out of a target, use 'skillet disable' instead.
+
+Several names can be given at once, and glob patterns select every skill whose
+name matches (quote them so the shell does not expand them):
+
+  skillet remove "legacy-*"
+  skillet remove --all --project
+
+--all removes every skill in the scope given with a scope flag. Skills chosen
+by a pattern or --all are listed and removed after confirmation (or --yes).
+With --dry-run, the skills and installs that would be removed are listed
+without asking or changing anything.`,
 		Aliases: []string{"rm"},
-		Args:    cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			root, rootErr := a.findProjectRoot()
-			if rootErr != nil {
-				root = ""
+		Args: func(cmd *cobra.Command, args []string) error {
+			switch {
+			case all && len(args) > 0:
+				return fmt.Errorf("--all cannot be combined with skill names")
+			case all && !scopeFlags.IsSet():
+				return fmt.Errorf("--all needs a scope flag (--project, --global, or --org)")
+			case !all && len(args) == 0:
+				return fmt.Errorf("requires at least 1 skill name or pattern, or --all")
 			}
-			if scopeFlags.Project && rootErr != nil {
-				return fmt.Errorf("not in a project directory")
+			return nil
+		},
+		// Every argument is a skill, so each completes like the first.
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			return completeSkillNames(a, nil)(cmd, nil, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+
+			root, scope, err := a.resolveScope(&scopeFlags)
+			if err != nil {
+				return err
 			}
 			svc := usecase.NewRemoveService(a.fs, a.config, root)
 
-			opts := usecase.RemoveOptions{Name: args[0]}
-			if scopeFlags.IsSet() {
-				scope, err := scopeFlags.GetScope()
-				if err != nil {
+			opts := usecase.RemoveOptions{Names: args, All: all, Scope: scope, Target: target, KeepStore: keepStore, DryRun: dryRun}
+
+			if opts.Bulk() && !dryRun {
+				ok, err := confirmRemove(a, svc, opts, skipPrompts)
+				if err != nil || !ok {
 					return err
 				}
-				opts.Scope = &scope
 			}
 
-			result := svc.Remove(opts)
-			if result.Error != nil {
-				return result.Error
+			results, err := svc.Remove(opts)
+			if err != nil {
+				return withTargetSuggestion(err)
+			}
+			if len(results) == 1 && results[0].Error != nil {
+				return results[0].Error
 			}
 
-			printRemoveResult(result)
-
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+			var failed int
+			for _, result := range results {
+				if result.Error != nil {
+					fmt.Printf("! %s (error: %v)\n", result.SkillName, result.Error)
+					failed++
+					continue
+				}
+				if dryRun {
+					printRemovePlan(result)
+				} else {
+					printRemoveResult(result)
+				}
+			}
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d skill(s) could not be removed", failed)
+			}
 			return nil
 		},
 	}
 
 	AddScopeFlags(cmd, &scopeFlags)
+	cmd.Flags().StringVar(&target, "target", "", "Uninstall from this target only, keeping the skill in the store")
+	cmd.Flags().BoolVar(&keepStore, "keep-store", false, "Uninstall from targets without removing the skill from the store")
+	cmd.Flags().BoolVar(&all, "all", false, "Remove every skill in the scope given with a scope flag")
+	cmd.Flags().BoolVarP(&skipPrompts, "yes", "y", false, "Skip the confirmation before removing skills chosen by a pattern or --all")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without making changes")
 
 	return cmd
 }
 
+// confirmRemove lists the skills opts selects and asks whether to remove them.
+func confirmRemove(a *app, svc *usecase.RemoveService, opts usecase.RemoveOptions, skipPrompts bool) (bool, error) {
+	skills, err := svc.Match(opts)
+	if err != nil {
+		return false, err
+	}
+	fmt.Printf("Skills to remove:\n")
+	for _, sk := range skills {
+		fmt.Printf("  - %s (%s)\n", sk.Name, sk.Scope)
+	}
+
+	question := fmt.Sprintf("Remove %d skill(s) from the store and targets?", len(skills))
+	if opts.KeepStore || opts.Target != "" {
+		question = fmt.Sprintf("Uninstall %d skill(s) from targets?", len(skills))
+	}
+	ok, err := a.prompterFor(skipPrompts).Confirm(question, false)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		fmt.Println("Aborted.")
+	}
+	return ok, nil
+}
+
+// printRemovePlan prints what a dry-run remove operation would remove.
+func printRemovePlan(result *usecase.RemoveResult) {
+	if result.StoreKept {
+		fmt.Printf("  ~ %s (kept in %s scope)\n", result.SkillName, result.Scope)
+	} else {
+		fmt.Printf("  - %s (%s scope)\n", result.SkillName, result.Scope)
+	}
+	for _, tr := range result.TargetResults {
+		if tr.Removed {
+			fmt.Printf("  - %s/%s\n", tr.Target, result.SkillName)
+		}
+	}
+}
+
 // printRemoveResult prints the result of a remove operation.
 func printRemoveResult(result *usecase.RemoveResult) {
-	fmt.Printf("Removed skill '%s' from %s scope\n", result.SkillName, result.Scope)
+	if result.StoreKept {
+		fmt.Printf("Uninstalled skill '%s' (kept in %s scope)\n", result.SkillName, result.Scope)
+	} else {
+		fmt.Printf("Removed skill '%s' from %s scope\n", result.SkillName, result.Scope)
+	}
 
 	for _, tr := range result.TargetResults {
-		if tr.Removed {
+		switch {
+		case tr.Removed:
 			fmt.Printf("  Removed from target '%s'\n", tr.Target)
-		} else if tr.Error != nil {
+		case tr.Error != nil:
 			fmt.Printf("  Warning: failed to remove from %s: %v\n", tr.Target, tr.Error)
+		case result.StoreKept:
+			fmt.Printf("  Not installed in target '%s'\n", tr.Target)
 		}
 	}
 }
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newRenameCmd creates the rename command.
+func newRenameCmd(a *app) *cobra.Command {
+	var dryRun bool
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+
+	cmd := &cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename a skill in the store and every target",
+		Long: `Rename a skill: its store directory, the name in its SKILL.md frontmatter,
+and its installs in every target, which are recreated under the new name with
+the same strategy. Targets that enable it as an optional skill are updated in
+the config file. Give the new name as collection/name to move the skill into
+another collection.
+
+If a step fails before the old installs are removed, the steps already taken
+are undone. Skills that require the old name are listed so their requires can
+be updated.
+
+By default, renames the skill that takes effect; use --global, --org, or
+--project to pick a scope.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeSkillNames(a, func(sk *skill.Skill) bool { return !sk.ReadOnly() && !sk.Vendored }),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+			if a.legacyConfig && !dryRun {
+				return fmt.Errorf("rename cannot change a config read with --legacy-config")
+			}
+			configPath, err := a.configPath(cmd)
+			if err != nil {
+				return err
+			}
+
+			root, scope, err := a.resolveScope(&scopeFlags)
+			if err != nil {
+				return err
+			}
+
+			result, err := usecase.NewRenameService(a.fs, a.config, root).Rename(usecase.RenameOptions{
+				Name:    args[0],
+				NewName: args[1],
+				Scope:   scope,
+				DryRun:  dryRun,
+			}, configPath)
+			if err != nil {
+				return fmt.Errorf("rename failed: %w", err)
+			}
+
+			sk := result.Skill
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+				fmt.Printf("  ~ %s -> %s (%s, %s)\n", result.OldName, sk.Name, sk.Scope, config.ContractPath(a.fs, sk.Path))
+				for _, target := range result.Targets {
+					fmt.Printf("  ~ %s/%s -> %s/%s (reinstall)\n", target, result.OldName, target, sk.Name)
+				}
+				for _, target := range result.OptionalTargets {
+					fmt.Printf("  ~ targets.%s.optional (%s -> %s)\n", target, result.OldName, sk.Name)
+				}
+			} else {
+				fmt.Printf("Renamed %s to %s (%s, %s)\n", result.OldName, sk.Name, sk.Scope, config.ContractPath(a.fs, sk.Path))
+				if len(result.Targets) > 0 {
+					fmt.Printf("  Reinstalled in %s\n", strings.Join(result.Targets, ", "))
+				}
+				if len(result.OptionalTargets) > 0 {
+					fmt.Printf("  Updated optional skills of %s\n", strings.Join(result.OptionalTargets, ", "))
+				}
+			}
+			for _, w := range result.Warnings {
+				fmt.Fprintf(os.Stderr, "warning: could not remove the old install in %s; run 'skillet prune'\n", w)
+			}
+			if len(result.Dependents) > 0 {
+				fmt.Printf("\nThese skills require %s; update their requires to %s: %s\n", result.OldName, sk.Name, strings.Join(result.Dependents, ", "))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
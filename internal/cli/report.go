@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// writeRunReport writes a run report when reports are enabled and prints its path.
+// Failing to write a report is a warning; it never fails the run.
+func writeRunReport(a *app, cfg *config.Config, root string, report *usecase.RunReport) {
+	path, err := usecase.NewReportService(a.fs, cfg, root).Write(report)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to write report: %v\n", err)
+		return
+	}
+	if path != "" {
+		fmt.Printf("\nReport written to %s\n", path)
+	}
+}
@@ -1,14 +1,23 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/mod/semver"
 
 	"github.com/wwwyo/skillet/internal/config"
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/platform/notify"
+	"github.com/wwwyo/skillet/internal/platform/prompt"
 	"github.com/wwwyo/skillet/internal/skill"
 )
 
@@ -24,11 +33,34 @@ func init() {
 	}
 }
 
+const (
+	// envDryRun forces dry-run mode for every command when set to a true value.
+	envDryRun = "SKILLET_DRY_RUN"
+	// envYes auto-confirms every prompt when set to a true value.
+	envYes = "SKILLET_YES"
+	// envOffline disables all network access when set to a true value.
+	envOffline = "SKILLET_OFFLINE"
+)
+
 // app represents the CLI application with its dependencies.
 type app struct {
-	fs          platformfs.FileSystem
-	config      *config.Config
-	configStore *config.Store
+	fs           platformfs.FileSystem
+	config       *config.Config
+	configErr    error // set when a command in configOptional runs with the default config
+	configStore  *config.Store
+	prompter     prompt.Prompter
+	dryRun       bool   // forced by SKILLET_DRY_RUN
+	assumeYes    bool   // forced by SKILLET_YES
+	offline      bool   // set by --offline or SKILLET_OFFLINE; guards fetchers via fetch.Guard
+	projectRoot  string // set by --project-root; skips discovery
+	strict       bool   // set by --strict; enforces the frontmatter schema
+	legacyConfig bool   // set by --legacy-config; reads ~/.agents/skillet.yaml without migrating
+	verbose      bool   // set by --verbose
+	logFormat    string // set by --log-format
+	notifier     notify.Notifier
+	// projectConfigDone is set once findProjectRoot has merged the project
+	// config into config
+	projectConfigDone bool
 }
 
 // newApp creates a new app instance.
@@ -37,64 +69,359 @@ func newApp() *app {
 	return &app{
 		fs:          fsys,
 		configStore: config.NewStore(fsys),
+		prompter:    prompt.NewSurveyPrompter(),
+		notifier:    notify.NewNotifier(),
+		dryRun:      envBool(envDryRun),
+		assumeYes:   envBool(envYes),
+		offline:     envBool(envOffline),
 	}
 }
 
+// envBool reports whether the environment variable is set to a true value.
+func envBool(name string) bool {
+	v, err := strconv.ParseBool(os.Getenv(name))
+	return err == nil && v
+}
+
+// prompterFor returns a non-interactive prompter when prompts should be
+// skipped (via --yes or SKILLET_YES), and the app's prompter otherwise.
+func (a *app) prompterFor(skipPrompts bool) prompt.Prompter {
+	if skipPrompts || a.assumeYes || a.prompter == nil {
+		return prompt.NewAutoPrompter()
+	}
+	return a.prompter
+}
+
+// rejectForcedDryRun returns an error when SKILLET_DRY_RUN is active for a
+// command that cannot run without making changes.
+func (a *app) rejectForcedDryRun(cmdName string) error {
+	if a.dryRun {
+		return fmt.Errorf("%s does not support dry-run; unset %s to continue", cmdName, envDryRun)
+	}
+	return nil
+}
+
 // findProjectRoot returns project root path when available.
+// An explicit --project-root takes precedence over discovery from the working directory.
+// The project's .agents/skillet.yaml, if any, is merged into a.config.
 func (a *app) findProjectRoot() (root string, rootErr error) {
+	if a.projectRoot != "" {
+		root, err := a.projectDir()
+		if err != nil {
+			return "", err
+		}
+		if !a.fs.IsDir(config.ProjectAgentsDir(root, a.fs)) {
+			return "", fmt.Errorf("no .agents directory found in project root: %s", root)
+		}
+		a.applyProjectConfig(root)
+		return root, nil
+	}
+
 	root, rootErr = a.configStore.FindProjectRoot()
 	if rootErr != nil {
 		return "", rootErr
 	}
+	a.applyProjectConfig(root)
+	return root, nil
+}
+
+// applyProjectConfig merges the project config of root over a.config, once
+// per run. A project config that cannot be read is reported and ignored.
+func (a *app) applyProjectConfig(root string) {
+	if a.config == nil || a.projectConfigDone {
+		return
+	}
+	a.projectConfigDone = true
+
+	// With allowHome, the home directory can be the project, and its
+	// .agents/skillet.yaml is the legacy global config.
+	if agentsDir, err := a.config.AgentsDir(a.fs); err == nil && filepath.Clean(agentsDir) == filepath.Clean(config.ProjectAgentsDir(root, a.fs)) {
+		return
+	}
+	projectCfg, err := a.configStore.LoadProject(root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ignoring project config: %v\n", err)
+		return
+	}
+	if projectCfg == nil {
+		return
+	}
+	a.logf("project config: %s", config.ProjectConfigPath(root, a.fs))
+	a.config = a.config.WithProject(projectCfg)
+}
+
+// resolveScope finds the project root and applies the scope flags to it.
+// An explicit flag always wins and a nil scope means every available scope,
+// which includes project scope only when a project root was found.
+// --project outside a project is an error.
+func (a *app) resolveScope(flags *ScopeFlags) (root string, scope *skill.Scope, err error) {
+	root, rootErr := a.findProjectRoot()
+	if rootErr != nil {
+		root = ""
+		a.logf("no project root found: %v", rootErr)
+	} else {
+		a.logf("project root: %s", root)
+	}
+
+	if !flags.IsSet() {
+		if root == "" {
+			a.logf("scope: all (no flag given; project scope unavailable)")
+		} else {
+			a.logf("scope: all (no flag given)")
+		}
+		return root, nil, nil
+	}
+
+	s, err := flags.GetScope()
+	if err != nil {
+		return "", nil, err
+	}
+	if s == skill.ScopeProject && root == "" {
+		return "", nil, fmt.Errorf("not in a project directory")
+	}
+	a.logf("scope: %s (from flag)", s)
+	return root, &s, nil
+}
+
+// logf logs a diagnostic message at debug level, shown in verbose mode.
+func (a *app) logf(format string, args ...any) {
+	slog.Debug(fmt.Sprintf(format, args...))
+}
+
+// projectDir returns the directory to treat as the project: --project-root
+// when set, otherwise the working directory. Unlike findProjectRoot, it does
+// not require the directory to be initialized.
+func (a *app) projectDir() (string, error) {
+	if a.projectRoot == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get current directory: %w", err)
+		}
+		return cwd, nil
+	}
+
+	expanded, err := config.ExpandPath(a.fs, a.projectRoot)
+	if err != nil {
+		return "", err
+	}
+	root, err := a.fs.Abs(expanded)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve project root: %w", err)
+	}
+	if !a.fs.IsDir(root) {
+		return "", fmt.Errorf("project root is not a directory: %s", root)
+	}
 	return root, nil
 }
 
-// newSkillStore creates a skill.Store and returns the project root.
-// The caller can decide how to handle a missing project root.
-func (a *app) newSkillStore() (*skill.Store, string, error) {
-	root, err := a.findProjectRoot()
-	return skill.NewStore(a.fs, a.config, root), root, err
+// configPath returns the path of the config file cmd reads: --config when
+// given, otherwise the global config path.
+func (a *app) configPath(cmd *cobra.Command) (string, error) {
+	if cmd.Flags().Changed("config") {
+		return config.ExpandPath(a.fs, cfgFile)
+	}
+	return config.GlobalConfigPath(a.fs)
+}
+
+// configOptional lists commands that can run without a config file.
+var configOptional = map[string]bool{
+	"skillet init":              true,
+	"skillet migrate":           true,
+	"skillet migrate-store":     true,
+	"skillet devtools fixtures": true,
+	"skillet schema print":      true,
+	"skillet completion":        true,
+	"skillet config migrate":    true,
+	"skillet config edit":       true,
+	"skillet bootstrap":         true,
+	"skillet up":                true,
+	"skillet doctor":            true,
+	"skillet validate":          true,
+}
+
+// setupExitCodes lists commands that reserve exit code 1 for a result, with
+// the code they exit with when the config cannot be loaded.
+var setupExitCodes = map[string]int{
+	"skillet status": statusExitError,
 }
 
 // newRootCmd creates the root command for skillet.
 func newRootCmd(a *app) *cobra.Command {
 	rootCmd := &cobra.Command{
-		Use:     "skillet",
-		Short:   "AI Agent Skills Manager",
-		Long:    `Skillet manages AI agent skills as a Single Source of Truth (SSOT) for distribution and synthesis.`,
+		Use:   "skillet",
+		Short: "AI Agent Skills Manager",
+		Long: `Skillet manages AI agent skills as a Single Source of Truth (SSOT) for distribution and synthesis.
+
+Environment:
+  SKILLET_DRY_RUN  force dry-run mode for all commands
+  SKILLET_YES      confirm all prompts automatically
+  SKILLET_OFFLINE  disable all network access, like --offline`,
 		Version: version,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := a.configStore.Load(cfgFile)
+			// Completion functions load what they need without side effects.
+			if isCompletionRequest(cmd) {
+				return nil
+			}
+
+			// Services log through the default logger.
+			logger, err := newLogger(os.Stderr, a.verbose, a.logFormat)
 			if err != nil {
-				if cmd.Name() != "init" && cmd.Name() != "migrate" {
-					return fmt.Errorf("failed to load config: %w", err)
+				return err
+			}
+			slog.SetDefault(logger)
+
+			if a.dryRun {
+				fmt.Fprintf(os.Stderr, "%s is set: running in dry-run mode\n", envDryRun)
+			}
+			if a.assumeYes {
+				fmt.Fprintf(os.Stderr, "%s is set: confirming all prompts\n", envYes)
+			}
+			if envBool(envOffline) {
+				fmt.Fprintf(os.Stderr, "%s is set: network access is disabled\n", envOffline)
+			}
+
+			cfg, err := a.loadConfig(cmd)
+			if err != nil {
+				if !configOptional[cmd.CommandPath()] {
+					err = fmt.Errorf("failed to load config: %w", err)
+					if code, ok := setupExitCodes[cmd.CommandPath()]; ok {
+						return &exitError{code: code, err: err}
+					}
+					return err
 				}
+				a.configErr = err
 				cfg = config.DefaultConfig()
 			}
+			if a.strict {
+				cfg.Frontmatter.Strict = true
+			}
 			a.config = cfg
+			a.configStore.SetProjectDiscovery(cfg.Discovery)
 			return nil
 		},
 	}
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "~/.config/skillet/config.yaml", "config file path")
+	rootCmd.PersistentFlags().BoolVar(&a.offline, "offline", a.offline, "never access the network; use only local and cached data")
+	rootCmd.PersistentFlags().BoolVar(&a.verbose, "verbose", false, "log what each command does to stderr")
+	rootCmd.PersistentFlags().StringVar(&a.logFormat, "log-format", logFormatText, "format of log records: text or json")
+	rootCmd.PersistentFlags().BoolVar(&a.strict, "strict", false, "reject skills whose frontmatter does not match the schema")
+	rootCmd.PersistentFlags().BoolVar(&a.legacyConfig, "legacy-config", false, "read a legacy ~/.agents/skillet.yaml as is instead of migrating it")
+	rootCmd.PersistentFlags().StringVar(&a.projectRoot, "project-root", "", "project root directory (default: discovered from the working directory)")
 
 	rootCmd.AddCommand(newInitCmd(a))
+	rootCmd.AddCommand(newAddCmd(a))
+	rootCmd.AddCommand(newNewCmd(a))
+	rootCmd.AddCommand(newInstallCmd(a))
+	rootCmd.AddCommand(newSearchCmd(a))
+	rootCmd.AddCommand(newUpdateCmd(a))
 	rootCmd.AddCommand(newRemoveCmd(a))
+	rootCmd.AddCommand(newEnableCmd(a))
+	rootCmd.AddCommand(newDisableCmd(a))
+	rootCmd.AddCommand(newPublishCmd(a))
+	rootCmd.AddCommand(newPromoteCmd(a))
+	rootCmd.AddCommand(newDemoteCmd(a))
+	rootCmd.AddCommand(newRenameCmd(a))
 	rootCmd.AddCommand(newListCmd(a))
 	rootCmd.AddCommand(newSyncCmd(a))
 	rootCmd.AddCommand(newStatusCmd(a))
+	rootCmd.AddCommand(newUICmd(a))
+	rootCmd.AddCommand(newPruneCmd(a))
 	rootCmd.AddCommand(newMigrateCmd(a))
+	rootCmd.AddCommand(newMigrateStoreCmd(a))
+	rootCmd.AddCommand(newFsckCmd(a))
+	rootCmd.AddCommand(newVerifyLinksCmd(a))
+	rootCmd.AddCommand(newDoctorCmd(a))
+	rootCmd.AddCommand(newAssertCmd(a))
+	rootCmd.AddCommand(newLintCmd(a))
+	rootCmd.AddCommand(newValidateCmd(a))
+	rootCmd.AddCommand(newConvertCommandsCmd(a))
+	rootCmd.AddCommand(newOpenCmd(a))
+	rootCmd.AddCommand(newCatCmd(a))
+	rootCmd.AddCommand(newWhichCmd(a))
+	rootCmd.AddCommand(newConflictsCmd(a))
+	rootCmd.AddCommand(newDiffCmd(a))
+	rootCmd.AddCommand(newPullCmd(a))
+	rootCmd.AddCommand(newVendorCmd(a))
+	rootCmd.AddCommand(newPackCmd(a))
+	rootCmd.AddCommand(newUnpackCmd(a))
+	rootCmd.AddCommand(newDedupeCmd(a))
+	rootCmd.AddCommand(newBackfillDescriptionsCmd(a))
+	rootCmd.AddCommand(newPushCmd(a))
+	rootCmd.AddCommand(newBootstrapCmd(a))
+	rootCmd.AddCommand(newUpCmd(a))
+	rootCmd.AddCommand(newSchemaCmd())
+	rootCmd.AddCommand(newConfigCmd(a))
+	rootCmd.AddCommand(newDevtoolsCmd(a))
+	rootCmd.AddCommand(newCompletionCmd())
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	registerTargetCompletions(a, rootCmd)
 
 	return rootCmd
 }
 
+// exitError carries a specific process exit code for a failed command.
+// Execute does not print its message; commands report their own output or
+// leave it to cobra.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
 // Execute runs the CLI application.
 func Execute() {
 	a := newApp()
 	rootCmd := newRootCmd(a)
 
-	if err := rootCmd.Execute(); err != nil {
+	// The first interrupt cancels the command's context, which stops it between
+	// skills and kills running fetches and hooks. Signal handling is then
+	// restored, so a second interrupt exits at once.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	silenceInterrupted(rootCmd)
+
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			fmt.Fprintln(os.Stderr, "interrupted")
+			os.Exit(130)
+		}
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
+
+// silenceInterrupted keeps cobra from printing the error and usage of cmd, or
+// of any command below it, when the command stops because it was interrupted;
+// Execute reports that instead.
+func silenceInterrupted(cmd *cobra.Command) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(c *cobra.Command, args []string) error {
+			err := run(c, args)
+			if errors.Is(err, context.Canceled) {
+				c.SilenceErrors = true
+				c.SilenceUsage = true
+			}
+			return err
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		silenceInterrupted(sub)
+	}
+}
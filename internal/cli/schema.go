@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// newSchemaCmd creates the schema command group.
+func newSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Show the SKILL.md frontmatter schema",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "print",
+		Short: "Print the JSON Schema for SKILL.md frontmatter",
+		Long: `Print the versioned JSON Schema for SKILL.md frontmatter.
+
+Point your editor's YAML or JSON Schema support at the output to get completion
+and validation. Run skillet with --strict (or set frontmatter.strict in config)
+to reject skills that do not match it.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := cmd.OutOrStdout().Write(skill.FrontmatterSchema)
+			return err
+		},
+	})
+
+	return cmd
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newSearchCmd creates the search command.
+func newSearchCmd(a *app) *cobra.Command {
+	var refresh bool
+
+	cmd := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Search the skill registry",
+		Long: `Search the registry configured under registry.index in the config file for
+skills whose name, description, or tags contain the query. Without a query,
+every skill in the registry is listed. Install a result with skillet install <name>.
+
+The index is cached in ~/.cache/skillet and downloaded again after
+registry.cacheHours (24 by default). Use --refresh to download it now. With
+--offline, or when the download fails, the cached index is used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := a.findProjectRoot()
+			if err != nil {
+				a.logf("no project root found: %v", err)
+				root = ""
+			}
+
+			index, err := a.registryService(root).Index(cmd.Context(), refresh)
+			if err != nil {
+				return fmt.Errorf("search failed: %w", err)
+			}
+			warnStaleIndex(index)
+
+			query := strings.Join(args, " ")
+			results := usecase.Search(index, query)
+			if len(results) == 0 {
+				fmt.Printf("No skills found for %q.\n", query)
+				return nil
+			}
+			for _, sk := range results {
+				fmt.Printf("%s\n", sk.Name)
+				if sk.Description != "" {
+					fmt.Printf("  %s\n", sk.Description)
+				}
+				fmt.Printf("  source: %s\n", sk.Source)
+				if len(sk.Tags) > 0 {
+					fmt.Printf("  tags: %s\n", strings.Join(sk.Tags, ", "))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Download the registry index even when the cached copy is recent")
+
+	return cmd
+}
+
+// registryService creates a registry service honoring --offline.
+func (a *app) registryService(root string) *usecase.RegistryService {
+	return usecase.NewRegistryService(a.fs, a.config, root,
+		fetch.GuardDownloader(fetch.HTTPDownloader{}, a.offline),
+		fetch.Guard(fetch.GitFetcher{}, a.offline))
+}
+
+// warnStaleIndex warns when an expired cached registry index is used.
+func warnStaleIndex(index *usecase.RegistryIndex) {
+	if index.Stale {
+		fmt.Fprintln(os.Stderr, "warning: could not download the registry index; using the cached copy")
+	}
+}
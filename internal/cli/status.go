@@ -1,9 +1,13 @@
 package cli
 
 import (
-	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	"os"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -13,8 +17,21 @@ import (
 
 const statusSeparator = "----------------------------------------"
 
+// Exit codes of the status command. A successful run exits with 0.
+const (
+	statusExitOutOfSync = 1
+	statusExitError     = 2
+)
+
 // newStatusCmd creates the status command.
 func newStatusCmd(a *app) *cobra.Command {
+	var (
+		fix         bool
+		pruneExtra  bool
+		skipPrompts bool
+		dryRun      bool
+		quiet       bool
+	)
 	scopeFlags := NewScopeFlags(skill.ScopeProject)
 
 	cmd := &cobra.Command{
@@ -23,48 +40,167 @@ func newStatusCmd(a *app) *cobra.Command {
 		Long: `Show the synchronization status between the skill store and targets.
 
 Displays which skills are installed, missing, or extra for each target.
-By default, shows status for all scopes. Use --global or --project to filter.`,
+The summary counts each target's entries that are symlinks into the store,
+copies matching the store, copies or links that diverge from it, and entries
+for skills skillet does not manage.
+By default, shows status for all scopes. Use --global, --org, or --project to filter.
+
+Use --fix to repair drift: missing skills and broken links are installed,
+stale copies and installs made with the wrong strategy are reinstalled, and
+optional skills that are not enabled are uninstalled. Extra skills that are
+not in the store are only uninstalled with --prune-extra, after confirming
+(or --yes to skip the confirmation). --fix prints every change it makes and
+fails when a target is still out of sync afterwards. Add --dry-run to only
+print the changes.
+
+The exit code tells CI pipelines and pre-commit hooks the result without
+parsing the output: 0 when every target is in sync, 1 when a target is out of
+sync, and 2 when the status could not be determined. --quiet prints nothing
+but errors, for scripts that only check the exit code.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			root, rootErr := a.findProjectRoot()
-			if rootErr != nil {
-				root = ""
-			}
-			if scopeFlags.Project && rootErr != nil {
-				return fmt.Errorf("not in a project directory")
-			}
-			svc := usecase.NewStatusService(a.fs, a.config, root)
-
-			var opts usecase.StatusOptions
-			if scopeFlags.IsSet() {
-				scope, err := scopeFlags.GetScope()
-				if err != nil {
-					return err
-				}
-				opts.Scope = &scope
+			err := runStatus(cmd, a, &scopeFlags, fix, pruneExtra, dryRun, quiet, skipPrompts)
+			var exitErr *exitError
+			if err != nil && !errors.As(err, &exitErr) {
+				cmd.SilenceUsage = true
+				return &exitError{code: statusExitError, err: err}
 			}
+			return err
+		},
+	}
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &exitError{code: statusExitError, err: err}
+	})
 
-			statuses, err := svc.GetStatus(opts)
-			if err != nil {
-				return fmt.Errorf("failed to get status: %w", err)
-			}
+	cmd.Flags().BoolVar(&fix, "fix", false, "Repair drift through the sync engine")
+	cmd.Flags().BoolVar(&pruneExtra, "prune-extra", false, "With --fix, also uninstall skills in targets that are not in the store")
+	cmd.Flags().BoolVarP(&skipPrompts, "yes", "y", false, "Skip the confirmation before uninstalling extra skills")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "With --fix, show what would be changed without making changes")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Print nothing but errors; only set the exit code")
+	AddScopeFlags(cmd, &scopeFlags)
 
-			slices.SortFunc(statuses, func(a, b *usecase.StatusResult) int {
-				return cmp.Compare(a.Target, b.Target)
-			})
+	return cmd
+}
 
-			for _, status := range statuses {
-				printTargetStatus(status)
+// runStatus prints the status of the targets, or repairs them with fix. It
+// fails with statusExitOutOfSync when a target is out of sync and with
+// statusExitError when a target's status cannot be determined.
+func runStatus(cmd *cobra.Command, a *app, scopeFlags *ScopeFlags, fix, pruneExtra, dryRun, quiet, skipPrompts bool) error {
+	if (pruneExtra || dryRun) && !fix {
+		return fmt.Errorf("--prune-extra and --dry-run require --fix")
+	}
+	if quiet && fix {
+		return fmt.Errorf("--quiet cannot be used with --fix")
+	}
+	dryRun = dryRun || a.dryRun
+	root, scope, err := a.resolveScope(scopeFlags)
+	if err != nil {
+		return err
+	}
+	svc := usecase.NewStatusService(a.fs, a.config, root)
+
+	opts := usecase.StatusOptions{Scope: scope, RemoveExtras: pruneExtra}
+
+	statuses, err := svc.GetStatus(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+
+	if fix {
+		cmd.SilenceUsage = true
+		return fixStatus(cmd.Context(), a, root, svc, opts, statuses, dryRun, skipPrompts)
+	}
+
+	if quiet {
+		for _, status := range statuses {
+			if status.Error != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", status.Target, status.Error)
 			}
+		}
+	} else {
+		for _, status := range statuses {
+			printTargetStatus(status)
+		}
 
-			printStatusSummary(statuses)
+		printStatusSummary(statuses)
+		if slices.ContainsFunc(statuses, func(s *usecase.StatusResult) bool { return len(s.Plan) > 0 }) {
+			fmt.Println("Run 'skillet status --fix' to repair.")
+		}
+	}
 
-			return nil
-		},
+	// The output already explains the result; only the exit code remains.
+	code := 0
+	for _, status := range statuses {
+		switch {
+		case status.Error != nil:
+			code = statusExitError
+		case !status.InSync && code == 0:
+			code = statusExitOutOfSync
+		}
+	}
+	if code != 0 {
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &exitError{code: code, err: fmt.Errorf("status exited with code %d", code)}
 	}
+	return nil
+}
 
-	AddScopeFlags(cmd, &scopeFlags)
+// fixStatus carries out the remediation plans of statuses and prints what
+// changed. It fails when a target is still out of sync afterwards.
+func fixStatus(ctx context.Context, a *app, root string, svc *usecase.StatusService, opts usecase.StatusOptions, statuses []*usecase.StatusResult, dryRun, skipPrompts bool) error {
+	var extras int
+	for _, status := range statuses {
+		for _, step := range status.Plan {
+			if step.Extra {
+				extras++
+			}
+		}
+	}
+	if extras > 0 && !dryRun {
+		ok, err := a.prompterFor(skipPrompts).Confirm(fmt.Sprintf("Uninstall %d extra skill(s)? They are deleted from the targets.", extras), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Extra skills kept")
+			for _, status := range statuses {
+				status.Plan = slices.DeleteFunc(status.Plan, func(step usecase.FixStep) bool { return step.Extra })
+			}
+		}
+	}
 
-	return cmd
+	results, err := usecase.NewSyncService(a.fs, a.config, root).Fix(ctx, statuses, usecase.SyncOptions{DryRun: dryRun})
+	if dryRun {
+		fmt.Println("Dry run - no changes made:")
+	}
+	if len(results) == 0 && err == nil {
+		fmt.Println("Nothing to fix.")
+	}
+	printSyncResults(results)
+	if err != nil {
+		return fmt.Errorf("fix failed: %w", err)
+	}
+	if dryRun {
+		return nil
+	}
+
+	after, err := svc.GetStatus(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+	var drifted []string
+	for _, status := range after {
+		if status.Error != nil || !status.InSync {
+			drifted = append(drifted, status.Target)
+		}
+	}
+	if len(drifted) > 0 {
+		return &exitError{
+			code: statusExitOutOfSync,
+			err:  fmt.Errorf("still out of sync: %s (run skillet status for details)", strings.Join(drifted, ", ")),
+		}
+	}
+	return nil
 }
 
 // printTargetStatus prints the status for a single target.
@@ -85,7 +221,77 @@ func printTargetStatus(status *usecase.StatusResult) {
 
 	printSkillList("Installed", status.Installed, "+")
 	printSkillList("Missing", status.Missing, "-")
+	printSkillList("Excluded", status.Excluded, "x")
 	printSkillList("Extra", status.Extra, "?")
+	printCurrentList(status.Current)
+	printStaleList(status.Stale)
+	printMismatchList(status.Mismatched)
+}
+
+// printMismatchList prints installs made with another strategy than their scope expects.
+func printMismatchList(mismatched []usecase.StrategyMismatch) {
+	if len(mismatched) == 0 {
+		return
+	}
+	fmt.Printf("  Wrong strategy (%d):\n", len(mismatched))
+	for _, m := range mismatched {
+		fmt.Printf("    ~ %s (%s, expected %s)\n", m.SkillName, m.Got, m.Want)
+	}
+}
+
+// printCurrentList prints copied installs that match the store.
+func printCurrentList(current []string) {
+	if len(current) == 0 {
+		return
+	}
+	fmt.Printf("  Copies (%d):\n", len(current))
+	for _, name := range current {
+		fmt.Printf("    = %s (current)\n", name)
+	}
+}
+
+// printStaleList prints copied installs that differ from the store.
+func printStaleList(stale []usecase.StaleCopy) {
+	if len(stale) == 0 {
+		return
+	}
+	fmt.Printf("  Stale (%d):\n", len(stale))
+	var overdue int
+	for _, c := range stale {
+		var notes []string
+		if c.Version != "" || c.StoreVersion != "" {
+			notes = append(notes, fmt.Sprintf("%s, store has %s", versionLabel(c.Version), versionLabel(c.StoreVersion)))
+		}
+		switch {
+		case c.Run != "":
+			notes = append(notes, "stale since "+c.Run)
+		case !c.Since.IsZero():
+			notes = append(notes, "stale since "+c.Since.Format(time.DateTime))
+		}
+		if len(c.DiffersFrom) > 0 {
+			notes = append(notes, "differs from "+strings.Join(c.DiffersFrom, ", "))
+		}
+		if len(notes) == 0 {
+			fmt.Printf("    ~ %s\n", c.SkillName)
+		} else {
+			fmt.Printf("    ~ %s (%s)\n", c.SkillName, strings.Join(notes, "; "))
+		}
+		if c.Overdue {
+			fmt.Printf("      ⚠ %d days behind the store\n", int(c.Behind.Hours()/24))
+			overdue++
+		}
+	}
+	if overdue > 0 {
+		fmt.Println("  Run 'skillet sync --force' on this machine to refresh old copies.")
+	}
+}
+
+// versionLabel formats a skill version for display.
+func versionLabel(v string) string {
+	if v == "" {
+		return "no version"
+	}
+	return "v" + strings.TrimPrefix(v, "v")
 }
 
 // printSkillList prints a list of skills with a header and prefix.
@@ -124,4 +330,12 @@ func printStatusSummary(statuses []*usecase.StatusResult) {
 		fmt.Printf(", %d error(s)", errorCount)
 	}
 	fmt.Println()
+
+	for _, s := range statuses {
+		if s.Error != nil {
+			continue
+		}
+		fmt.Printf("  %s: %d symlinked, %d copied, %d diverged, %d unmanaged\n",
+			s.Target, s.Stats.Symlinks, s.Stats.Copies, s.Stats.Diverged, s.Stats.Unmanaged)
+	}
 }
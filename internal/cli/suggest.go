@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// maxSuggestionDistance is the largest edit distance offered as a suggestion.
+const maxSuggestionDistance = 2
+
+// withTargetSuggestion adds a "did you mean" hint to unknown target errors.
+// Other errors are returned unchanged.
+func withTargetSuggestion(err error) error {
+	var unknown *usecase.ErrUnknownTarget
+	if !errors.As(err, &unknown) || unknown.Disabled {
+		return err
+	}
+
+	suggestions := suggestNames(unknown.Name, unknown.Valid)
+	if len(suggestions) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w\n\nDid you mean this?\n\t%s", err, strings.Join(suggestions, "\n\t"))
+}
+
+// suggestNames returns candidates within maxSuggestionDistance of name,
+// or that name is a prefix of, in candidate order. Names are compared
+// ignoring case, and an empty name has no suggestions.
+func suggestNames(name string, candidates []string) []string {
+	if name == "" {
+		return nil
+	}
+	var suggestions []string
+	lower := strings.ToLower(name)
+	for _, c := range candidates {
+		candidate := strings.ToLower(c)
+		if levenshtein(lower, candidate) <= maxSuggestionDistance || strings.HasPrefix(candidate, lower) {
+			suggestions = append(suggestions, c)
+		}
+	}
+	return suggestions
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
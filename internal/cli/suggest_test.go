@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestSuggestNames(t *testing.T) {
+	candidates := []string{"claude", "codex", "Gemini"}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"clade", []string{"claude"}},
+		{"Claude", []string{"claude"}},
+		{"codx", []string{"codex"}},
+		{"cl", []string{"claude"}},
+		{"CL", []string{"claude"}},
+		{"gem", []string{"Gemini"}},
+		{"cursor", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestNames(tt.name, candidates); !slices.Equal(got, tt.want) {
+				t.Errorf("suggestNames(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTargetSuggestion(t *testing.T) {
+	err := withTargetSuggestion(&usecase.ErrUnknownTarget{Name: "clade", Valid: []string{"claude", "codex"}})
+	if !strings.Contains(err.Error(), "Did you mean this?\n\tclaude") {
+		t.Errorf("expected suggestion in error, got: %v", err)
+	}
+
+	var unknown *usecase.ErrUnknownTarget
+	if !errors.As(err, &unknown) {
+		t.Error("expected wrapped error to remain an ErrUnknownTarget")
+	}
+
+	disabled := &usecase.ErrUnknownTarget{Name: "codex", Valid: []string{"claude"}, Disabled: true}
+	if got := withTargetSuggestion(disabled); got != error(disabled) {
+		t.Errorf("expected disabled target error unchanged, got: %v", got)
+	}
+}
@@ -1,20 +1,41 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/notify"
+	"github.com/wwwyo/skillet/internal/platform/watch"
 	"github.com/wwwyo/skillet/internal/skill"
 	"github.com/wwwyo/skillet/internal/usecase"
 )
 
+// watchDebounce is how long sync --watch waits for changes to settle before syncing.
+const watchDebounce = 300 * time.Millisecond
+
 // newSyncCmd creates the sync command.
 func newSyncCmd(a *app) *cobra.Command {
 	var (
-		dryRun bool
-		force  bool
+		dryRun              bool
+		force               bool
+		skipMissingCommands bool
+		target              string
+		watchStore          bool
+		prune               bool
+		pruneExtra          bool
+		skipPrompts         bool
+		noCache             bool
+		interactive         bool
+		atomic              bool
+		wait                bool
 	)
 	scopeFlags := NewScopeFlags(skill.ScopeProject)
 
@@ -24,113 +45,402 @@ func newSyncCmd(a *app) *cobra.Command {
 		Long: `Synchronize skills from the skill store to AI agent targets.
 
 By default, syncs all skills to all enabled targets.
-Use --global or --project to sync only skills from a specific scope.
-Use --dry-run to see what would be done without making changes.`,
+Use --target to sync to a single target.
+Use --global, --org, or --project to sync only skills from a specific scope.
+Use --dry-run to see what would be done without making changes.
+Use --prune to also remove broken links and orphaned installs from the
+targets afterwards, like skillet prune.
+
+Skills in a target that are not in the store, such as ones written by hand
+in ~/.claude/skills, are reported as extra and never removed by default.
+Use --prune-extra to uninstall them after confirming (or --yes to skip the
+confirmation).
+Use --watch to keep running and re-sync skills as they change in the store,
+until interrupted with Ctrl+C. Set notifications.onChange and
+notifications.onError in the config for desktop notifications of these syncs.
+Use --interactive to choose the skills to install into each target, starting
+from the ones installed there now. Skills left unselected are not installed,
+but installed ones are not removed; use skillet remove --keep-store for that.
+
+Copies are compared with the store by content. A cache in
+~/.cache/skillet/state.json remembers which copies matched, along with the
+sizes and modification times of their files, so unchanged skills are skipped
+without reading them again. Use --no-cache to compare every copy and rebuild
+the cache.
+
+Use --atomic to stop at the first skill that fails, or on Ctrl+C, and undo
+the changes made to the targets so far, so no target is left half-updated.
+Installs being replaced are moved into a .skillet-rollback-* directory beside
+the skills directory until the sync finishes. Hooks that already ran are not
+undone.
+
+A sync holds a lock file (.skillet-run.lock) in the agents directories while
+it runs, so syncs from two terminals, or a sync --watch and a manual sync, do
+not change the same targets at once. A second sync fails while the lock is
+held; use --wait to wait for the first one to finish instead. Locks left by a
+run that no longer exists are taken over.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			root, rootErr := a.findProjectRoot()
-			if rootErr != nil {
-				root = ""
+			startedAt := time.Now()
+			dryRun = dryRun || a.dryRun
+			if watchStore && dryRun {
+				return fmt.Errorf("--watch cannot be used with --dry-run")
 			}
-			if scopeFlags.Project && rootErr != nil {
-				return fmt.Errorf("not in a project directory")
+
+			root, scope, err := a.resolveScope(&scopeFlags)
+			if err != nil {
+				return err
 			}
 			svc := usecase.NewSyncService(a.fs, a.config, root)
 
 			opts := usecase.SyncOptions{
-				DryRun: dryRun,
-				Force:  force,
+				DryRun:              dryRun,
+				Force:               force,
+				SkipMissingCommands: skipMissingCommands,
+				Target:              target,
+				Scope:               scope,
+				NoCache:             noCache,
+				Atomic:              atomic,
+				WaitForLock:         wait,
 			}
-
-			if scopeFlags.IsSet() {
-				scope, err := scopeFlags.GetScope()
+			if interactive {
+				ok, err := pickSyncSkills(a, svc, &opts, skipPrompts)
 				if err != nil {
 					return err
 				}
-				opts.Scope = &scope
+				if !ok {
+					fmt.Println("No skills selected")
+					return nil
+				}
 			}
 
-			results, err := svc.Sync(opts)
+			results, err := svc.Sync(cmd.Context(), opts)
+			if errors.Is(err, usecase.ErrSyncRolledBack) {
+				printSyncResults(results)
+				cmd.SilenceUsage = true
+				return err
+			}
+			var locked *usecase.RunLockedError
+			if errors.As(err, &locked) {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%w\n\nUse --wait to wait for it to finish, or delete the file if no skillet run is active.", err)
+			}
 			if err != nil {
-				return fmt.Errorf("sync failed: %w", err)
+				return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
 			}
 
 			if dryRun {
 				fmt.Println("Dry run - no changes made:")
 			}
 
-			// Group results by target.
-			byTarget := make(map[string][]usecase.SyncResult)
-			for _, r := range results {
-				byTarget[r.Target] = append(byTarget[r.Target], r)
-			}
-
-			targetNames := make([]string, 0, len(byTarget))
-			for name := range byTarget {
-				targetNames = append(targetNames, name)
-			}
-			slices.Sort(targetNames)
-
-			for _, tName := range targetNames {
-				targetResults := byTarget[tName]
-				fmt.Printf("\nTarget: %s\n", tName)
-
-				var installs, updates, uninstalls, skips, errors int
-
-				for _, r := range targetResults {
-					switch r.Action {
-					case usecase.SyncActionInstall:
-						fmt.Printf("  + %s (install)\n", r.SkillName)
-						installs++
-					case usecase.SyncActionUpdate:
-						fmt.Printf("  ~ %s (update)\n", r.SkillName)
-						updates++
-					case usecase.SyncActionUninstall:
-						fmt.Printf("  - %s (uninstall)\n", r.SkillName)
-						uninstalls++
-					case usecase.SyncActionSkip:
-						skips++
-					case usecase.SyncActionError:
-						fmt.Printf("  ! %s (error: %v)\n", r.SkillName, r.Error)
-						errors++
-					}
-				}
+			printSyncResults(results)
 
-				summary := []string{}
-				if installs > 0 {
-					summary = append(summary, fmt.Sprintf("%d installed", installs))
-				}
-				if updates > 0 {
-					summary = append(summary, fmt.Sprintf("%d updated", updates))
-				}
-				if uninstalls > 0 {
-					summary = append(summary, fmt.Sprintf("%d uninstalled", uninstalls))
-				}
-				if skips > 0 {
-					summary = append(summary, fmt.Sprintf("%d skipped", skips))
+			var pruneProblems int
+			if prune {
+				pruned, err := usecase.NewPruneService(a.fs, a.config, root).Prune(usecase.PruneOptions{
+					DryRun: dryRun,
+					Target: target,
+				})
+				if err != nil {
+					return fmt.Errorf("prune failed: %w", err)
 				}
-				if errors > 0 {
-					summary = append(summary, fmt.Sprintf("%d errors", errors))
+				if len(pruned) > 0 {
+					fmt.Println("\nPrune:")
+					pruneProblems = printPruneResults(pruned)
 				}
+			}
 
-				if len(summary) > 0 {
-					fmt.Printf("  Summary: ")
-					for i, s := range summary {
-						if i > 0 {
-							fmt.Print(", ")
-						}
-						fmt.Print(s)
-					}
-					fmt.Println()
+			if pruneExtra {
+				removed, err := pruneExtraSkills(cmd.Context(), a, svc, opts, skipPrompts)
+				if err != nil {
+					return err
 				}
+				results = append(results, removed...)
+			}
+
+			if !dryRun {
+				report := usecase.NewRunReport("sync", startedAt)
+				report.AddSyncResults(results)
+				writeRunReport(a, a.config, root, report)
 			}
 
+			if pruneProblems > 0 {
+				return fmt.Errorf("%d install(s) could not be pruned", pruneProblems)
+			}
+			if watchStore {
+				return watchSync(cmd.Context(), svc, opts, syncNotifier{notifier: a.notifier, config: a.config.Notifications})
+			}
 			return nil
 		},
 	}
 
+	cmd.Flags().StringVar(&target, "target", "", "Sync only to the named target")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
 	cmd.Flags().BoolVar(&force, "force", false, "Force update even if already installed")
+	cmd.Flags().BoolVar(&skipMissingCommands, "skip-missing-commands", false, "Skip skills whose requiresCommands are not on PATH")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Also remove broken links and orphaned installs from targets")
+	cmd.Flags().BoolVar(&pruneExtra, "prune-extra", false, "Uninstall skills in targets that are not in the store")
+	cmd.Flags().BoolVarP(&skipPrompts, "yes", "y", false, "Skip the confirmation before uninstalling extra skills")
+	cmd.Flags().BoolVar(&watchStore, "watch", false, "Keep running and re-sync skills when they change in the store")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Choose the skills to install into each target")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Compare every copy with the store instead of trusting the sync state cache")
+	cmd.Flags().BoolVar(&atomic, "atomic", false, "Undo every change of the sync when a skill fails")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for another skillet run to finish instead of failing")
 	AddScopeFlags(cmd, &scopeFlags)
 
 	return cmd
 }
+
+// pickSyncSkills asks which skills to install into each target opts selects,
+// defaulting to the ones installed there now, and limits opts to the answers.
+// It returns false when no skill was selected for any target.
+func pickSyncSkills(a *app, svc *usecase.SyncService, opts *usecase.SyncOptions, skipPrompts bool) (bool, error) {
+	choices, err := svc.Choices(*opts)
+	if err != nil {
+		return false, fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+	}
+
+	p := a.prompterFor(skipPrompts)
+	opts.Selection = make(map[string][]string, len(choices))
+	for _, choice := range choices {
+		if len(choice.Skills) == 0 {
+			continue
+		}
+		selected, err := p.MultiSelect(fmt.Sprintf("Skills to install into %s (Space: toggle, Enter: confirm):", choice.Target), choice.Skills, choice.Installed)
+		if err != nil {
+			return false, err
+		}
+		opts.Selection[choice.Target] = selected
+		opts.Names = append(opts.Names, selected...)
+	}
+	slices.Sort(opts.Names)
+	opts.Names = slices.Compact(opts.Names)
+	return len(opts.Names) > 0, nil
+}
+
+// pruneExtraSkills uninstalls the skills in targets that are not in the store,
+// after listing them and asking for confirmation.
+func pruneExtraSkills(ctx context.Context, a *app, svc *usecase.SyncService, opts usecase.SyncOptions, skipPrompts bool) ([]usecase.SyncResult, error) {
+	extras, err := svc.Extras(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find extra skills: %w", err)
+	}
+	if len(extras) == 0 {
+		return nil, nil
+	}
+
+	fmt.Printf("\nExtra skills not in the store:\n")
+	for _, extra := range extras {
+		fmt.Printf("  - %s/%s (%s)\n", extra.Target, extra.SkillName, extra.Path)
+	}
+	if opts.DryRun {
+		fmt.Printf("Would uninstall %d extra skill(s)\n", len(extras))
+		return nil, nil
+	}
+	ok, err := a.prompterFor(skipPrompts).Confirm(fmt.Sprintf("Uninstall %d extra skill(s)? They are deleted from the targets.", len(extras)), false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		fmt.Println("Extra skills kept")
+		return nil, nil
+	}
+
+	removed := svc.RemoveExtras(ctx, extras)
+	printSyncResults(removed)
+	return removed, nil
+}
+
+// watchSync re-syncs skills changed in the store with opts until ctx is
+// canceled, as it is on interrupt.
+// Changed skills are always reinstalled, so copies pick up edits.
+func watchSync(ctx context.Context, svc *usecase.SyncService, opts usecase.SyncOptions, n syncNotifier) error {
+	dirs, err := svc.StoreDirs()
+	if err != nil {
+		return fmt.Errorf("failed to find store directories: %w", err)
+	}
+	w, err := watch.New(dirs, watchDebounce)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = w.Close() }()
+
+	fmt.Printf("\nWatching %s for changes (Ctrl+C to stop)\n", strings.Join(dirs, ", "))
+	err = w.Run(ctx, func(paths []string) {
+		syncChanged(ctx, svc, opts, paths, n)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("\nStopped watching.")
+	return nil
+}
+
+// syncChanged re-syncs the store skills containing paths, notifying n of the outcome.
+func syncChanged(ctx context.Context, svc *usecase.SyncService, opts usecase.SyncOptions, paths []string, n syncNotifier) {
+	// Each batch is a run of its own, so parses of SKILL.md files from the
+	// previous ones do not pile up while watching.
+	skill.ResetParseCache()
+	names, err := svc.SkillsAt(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return
+	}
+	if len(names) == 0 {
+		return
+	}
+	fmt.Printf("\n[%s] Changed: %s\n", time.Now().Format(time.TimeOnly), strings.Join(names, ", "))
+	changed := opts
+	changed.Names = names
+	changed.Force = true
+	// A manual sync started meanwhile finishes first.
+	changed.WaitForLock = true
+	results, err := svc.Sync(ctx, changed)
+	if err != nil && ctx.Err() == nil {
+		fmt.Fprintf(os.Stderr, "warning: sync failed: %v\n", err)
+	}
+	printSyncResults(results)
+	// A sync interrupted by stopping the watch is not reported.
+	if ctx.Err() == nil {
+		n.synced(results, err)
+	}
+}
+
+// syncNotifier sends desktop notifications for the syncs of sync --watch,
+// for the events the notification config enables.
+type syncNotifier struct {
+	notifier notify.Notifier
+	config   config.NotificationConfig
+}
+
+// synced notifies of a sync that failed with err or returned results.
+func (n syncNotifier) synced(results []usecase.SyncResult, err error) {
+	var changed, failed []string
+	for _, r := range results {
+		switch {
+		case r.Action == usecase.SyncActionError:
+			failed = append(failed, r.Target+"/"+r.SkillName)
+		case r.RolledBack:
+		case r.Action == usecase.SyncActionInstall, r.Action == usecase.SyncActionUpdate, r.Action == usecase.SyncActionUninstall:
+			changed = append(changed, r.Target+"/"+r.SkillName)
+		}
+	}
+
+	if n.config.OnError {
+		switch {
+		case err != nil:
+			n.send("skillet sync failed", err.Error())
+		case len(failed) > 0:
+			n.send("skillet sync failed", fmt.Sprintf("%d skill(s) failed: %s", len(failed), strings.Join(failed, ", ")))
+		}
+	}
+	if n.config.OnChange && len(changed) > 0 {
+		n.send("skillet synced", fmt.Sprintf("%d change(s): %s", len(changed), strings.Join(changed, ", ")))
+	}
+}
+
+func (n syncNotifier) send(title, message string) {
+	if n.notifier == nil {
+		return
+	}
+	if err := n.notifier.Notify(title, message); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+}
+
+// printSyncResults prints sync results grouped by target, with a summary per target.
+func printSyncResults(results []usecase.SyncResult) {
+	// Group results by target.
+	byTarget := make(map[string][]usecase.SyncResult)
+	for _, r := range results {
+		byTarget[r.Target] = append(byTarget[r.Target], r)
+	}
+
+	targetNames := make([]string, 0, len(byTarget))
+	for name := range byTarget {
+		targetNames = append(targetNames, name)
+	}
+	slices.Sort(targetNames)
+
+	for _, tName := range targetNames {
+		targetResults := byTarget[tName]
+		fmt.Printf("\nTarget: %s\n", tName)
+
+		var installs, updates, uninstalls, skips, extras, excluded, errors int
+
+		for _, r := range targetResults {
+			for _, w := range r.Warnings {
+				fmt.Printf("  ⚠ %s: %s\n", r.SkillName, w)
+			}
+			switch r.Action {
+			case usecase.SyncActionInstall:
+				fmt.Printf("  + %s (install%s)\n", r.SkillName, rolledBackLabel(r))
+				installs++
+			case usecase.SyncActionUpdate:
+				if r.FromVersion != "" {
+					fmt.Printf("  ~ %s (update %s -> %s%s)\n", r.SkillName, versionLabel(r.FromVersion), versionLabel(r.ToVersion), rolledBackLabel(r))
+				} else {
+					fmt.Printf("  ~ %s (update%s)\n", r.SkillName, rolledBackLabel(r))
+				}
+				updates++
+			case usecase.SyncActionUninstall:
+				fmt.Printf("  - %s (uninstall%s)\n", r.SkillName, rolledBackLabel(r))
+				uninstalls++
+			case usecase.SyncActionSkip:
+				skips++
+			case usecase.SyncActionManifest:
+				fmt.Printf("  * %s (manifest updated%s)\n", r.SkillName, rolledBackLabel(r))
+			case usecase.SyncActionExtra:
+				fmt.Printf("  ? %s (extra, not in store)\n", r.SkillName)
+				extras++
+			case usecase.SyncActionExcluded:
+				fmt.Printf("  x %s (excluded)\n", r.SkillName)
+				excluded++
+			case usecase.SyncActionError:
+				fmt.Printf("  ! %s (error: %v)\n", r.SkillName, r.Error)
+				errors++
+			}
+		}
+
+		summary := []string{}
+		if installs > 0 {
+			summary = append(summary, fmt.Sprintf("%d installed", installs))
+		}
+		if updates > 0 {
+			summary = append(summary, fmt.Sprintf("%d updated", updates))
+		}
+		if uninstalls > 0 {
+			summary = append(summary, fmt.Sprintf("%d uninstalled", uninstalls))
+		}
+		if skips > 0 {
+			summary = append(summary, fmt.Sprintf("%d skipped", skips))
+		}
+		if extras > 0 {
+			summary = append(summary, fmt.Sprintf("%d extra", extras))
+		}
+		if excluded > 0 {
+			summary = append(summary, fmt.Sprintf("%d excluded", excluded))
+		}
+		if errors > 0 {
+			summary = append(summary, fmt.Sprintf("%d errors", errors))
+		}
+
+		if len(summary) > 0 {
+			fmt.Printf("  Summary: ")
+			for i, s := range summary {
+				if i > 0 {
+					fmt.Print(", ")
+				}
+				fmt.Print(s)
+			}
+			fmt.Println()
+		}
+	}
+}
+
+// rolledBackLabel notes a change that an atomic sync undid.
+func rolledBackLabel(r usecase.SyncResult) string {
+	if r.RolledBack {
+		return ", rolled back"
+	}
+	return ""
+}
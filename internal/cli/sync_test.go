@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// recordingNotifier records the notifications sent to it.
+type recordingNotifier struct {
+	titles   []string
+	messages []string
+}
+
+func (n *recordingNotifier) Notify(title, message string) error {
+	n.titles = append(n.titles, title)
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func TestSyncChangedNotifies(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config config.NotificationConfig
+		want   []string
+	}{
+		{"on change", config.NotificationConfig{OnChange: true}, []string{"skillet synced"}},
+		{"errors only", config.NotificationConfig{OnError: true}, nil},
+		{"disabled", config.NotificationConfig{}, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := platformfs.NewMockFileSystem()
+			mock.Dirs["/home/test/.agents"] = true
+			mock.Dirs["/home/test/.agents/skills"] = true
+			mock.Dirs["/home/test/.agents/skills/alpha"] = true
+			mock.Files["/home/test/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\n---\n")
+			mock.Dirs["/home/test/.claude"] = true
+			mock.Dirs["/home/test/.claude/skills"] = true
+			cfg := config.DefaultConfig()
+			svc := usecase.NewSyncService(mock, cfg, "")
+
+			notifier := &recordingNotifier{}
+			syncChanged(t.Context(), svc, usecase.SyncOptions{Target: "claude"}, []string{"/home/test/.agents/skills/alpha/SKILL.md"}, syncNotifier{notifier: notifier, config: tt.config})
+
+			if !mock.Exists("/home/test/.claude/skills/alpha") {
+				t.Fatal("expected alpha to be synced")
+			}
+			if strings.Join(notifier.titles, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("notifications = %q, want %q", notifier.titles, tt.want)
+			}
+			if len(tt.want) > 0 && !strings.Contains(notifier.messages[0], "claude/alpha") {
+				t.Errorf("message = %q, want it to name claude/alpha", notifier.messages[0])
+			}
+		})
+	}
+}
+
+func TestSyncNotifierErrors(t *testing.T) {
+	failed := []usecase.SyncResult{
+		{SkillName: "alpha", Target: "claude", Action: usecase.SyncActionInstall},
+		{SkillName: "beta", Target: "claude", Action: usecase.SyncActionError, Error: errors.New("boom")},
+	}
+	rolledBack := []usecase.SyncResult{
+		{SkillName: "alpha", Target: "claude", Action: usecase.SyncActionInstall, RolledBack: true},
+	}
+	for _, tt := range []struct {
+		name    string
+		config  config.NotificationConfig
+		results []usecase.SyncResult
+		err     error
+		want    []string
+	}{
+		{"failed skill", config.NotificationConfig{OnError: true}, failed, nil, []string{"skillet sync failed"}},
+		{"failed sync", config.NotificationConfig{OnError: true}, nil, errors.New("locked"), []string{"skillet sync failed"}},
+		{"both events", config.NotificationConfig{OnChange: true, OnError: true}, failed, nil, []string{"skillet sync failed", "skillet synced"}},
+		{"changes only", config.NotificationConfig{OnChange: true}, failed, nil, []string{"skillet synced"}},
+		{"rolled back", config.NotificationConfig{OnChange: true}, rolledBack, nil, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &recordingNotifier{}
+			syncNotifier{notifier: notifier, config: tt.config}.synced(tt.results, tt.err)
+			if strings.Join(notifier.titles, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("notifications = %q, want %q", notifier.titles, tt.want)
+			}
+		})
+	}
+}
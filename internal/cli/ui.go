@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/tui"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newUICmd creates the ui command.
+func newUICmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ui",
+		Short: "Open an interactive dashboard",
+		Long: `Open a terminal dashboard over the skill store and targets.
+
+The dashboard lists every skill in the store with the targets it is installed
+in, and shows whether each target is in sync. From the list you can:
+  space   enable or disable the selected optional skill for every target
+  enter   view the selected skill's SKILL.md
+  s       sync the store to the targets
+  r       reload skills and status
+  q       quit
+
+Enabling or disabling an optional skill saves the config file; press s to
+sync the change to the targets.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := a.rejectForcedDryRun("ui"); err != nil {
+				return err
+			}
+			configPath, err := a.configPath(cmd)
+			if err != nil {
+				return err
+			}
+			root, err := a.findProjectRoot()
+			if err != nil {
+				a.logf("no project root found: %v", err)
+				root = ""
+			}
+
+			return tui.Run(&uiBackend{ctx: cmd.Context(), a: a, root: root, configPath: configPath})
+		},
+	}
+
+	return cmd
+}
+
+// uiBackend runs the dashboard's actions with the usecase services.
+type uiBackend struct {
+	// ctx is the command's context, which ends the dashboard's syncs when
+	// skillet is interrupted
+	ctx        context.Context
+	a          *app
+	root       string
+	configPath string
+}
+
+func (b *uiBackend) Skills() ([]tui.Skill, error) {
+	infos, err := usecase.NewListService(b.a.fs, b.a.config, b.root).ListSkills(usecase.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]string, 0, len(b.a.config.Targets))
+	for name, tc := range b.a.config.Targets {
+		if tc.Enabled {
+			targets = append(targets, name)
+		}
+	}
+	slices.Sort(targets)
+
+	skills := make([]tui.Skill, 0, len(infos))
+	for _, info := range infos {
+		sk := tui.Skill{SkillInfo: info}
+		for _, name := range targets {
+			if slices.Contains(b.a.config.Targets[name].Optional, info.Name) {
+				sk.Enabled = append(sk.Enabled, name)
+			}
+		}
+		skills = append(skills, sk)
+	}
+	return skills, nil
+}
+
+func (b *uiBackend) Status() ([]*usecase.StatusResult, error) {
+	return usecase.NewStatusService(b.a.fs, b.a.config, b.root).GetStatus()
+}
+
+func (b *uiBackend) SetOptional(name string, enable bool) error {
+	if b.a.legacyConfig {
+		return fmt.Errorf("cannot change a config read with --legacy-config")
+	}
+	_, err := usecase.NewOptionalService(b.a.fs, b.a.config, b.root).SetEnabled(usecase.OptionalOptions{
+		Name:   name,
+		Enable: enable,
+	}, b.configPath)
+	return withTargetSuggestion(err)
+}
+
+func (b *uiBackend) Sync() ([]usecase.SyncResult, error) {
+	results, err := usecase.NewSyncService(b.a.fs, b.a.config, b.root).Sync(b.ctx, usecase.SyncOptions{})
+	return results, withTargetSuggestion(err)
+}
+
+func (b *uiBackend) Content(sk tui.Skill) ([]byte, error) {
+	for _, scope := range []skill.Scope{skill.ScopeSystem, skill.ScopeGlobal, skill.ScopeOrg, skill.ScopeProject} {
+		if scope.String() == sk.Scope {
+			return usecase.NewLocateService(b.a.fs, b.a.config, b.root).Content(usecase.ContentOptions{
+				Name:        sk.Name,
+				Scope:       &scope,
+				Frontmatter: true,
+			})
+		}
+	}
+	return nil, fmt.Errorf("unknown scope %q for skill %s", sk.Scope, sk.Name)
+}
@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/platform/prompt"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// upSteps is the number of steps reported by the up command.
+const upSteps = 6
+
+// newUpCmd creates the up command.
+func newUpCmd(a *app) *cobra.Command {
+	var (
+		skipPrompts bool
+		dryRun      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Set up, check, and sync everything in one step",
+		Long: `Bring this machine up to date with the skill store in one command.
+
+up runs, in order:
+  1. load the config, creating one with the defaults when missing (requires --yes)
+  2. check the store layout, like 'skillet fsck'
+  3. offer to migrate unmanaged skills found in targets, like 'skillet migrate'
+  4. sync all skills to all enabled targets
+  5. remove installs whose skill is no longer in the store
+  6. print a status summary
+
+Global scope is always included, and project scope when run inside a project.
+up exits with an error when any step reports a problem, so it can be used in
+a machine bootstrap script with --yes.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			startedAt := time.Now()
+			dryRun = dryRun || a.dryRun
+			p := a.prompterFor(skipPrompts)
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+
+			printUpStep(1, "Config")
+			if err := upConfig(a, cmd, skipPrompts || a.assumeYes, dryRun); err != nil {
+				return err
+			}
+
+			root, err := a.findProjectRoot()
+			if err != nil {
+				a.logf("no project root found: %v", err)
+				root = ""
+			}
+			var problems int
+
+			printUpStep(2, "Store")
+			issues, err := usecase.NewFsckService(a.fs, a.config, root).Check(usecase.FsckOptions{})
+			if err != nil {
+				return fmt.Errorf("fsck failed: %w", err)
+			}
+			for _, issue := range issues {
+				fmt.Printf("  ! [%s] %s: %s\n", issue.Scope, issue.Path, issue.Message)
+			}
+			if len(issues) == 0 {
+				fmt.Println("  Store layout OK")
+			} else {
+				fmt.Println("  Run 'skillet fsck --fix' to repair what can be fixed safely.")
+				problems += len(issues)
+			}
+
+			printUpStep(3, "Migrate")
+			scopes := []skill.Scope{skill.ScopeGlobal}
+			if root != "" {
+				scopes = append(scopes, skill.ScopeProject)
+			}
+			for _, scope := range scopes {
+				if err := upMigrate(cmd.Context(), a, p, scope, root, dryRun); err != nil {
+					return err
+				}
+			}
+
+			printUpStep(4, "Sync")
+			results, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{DryRun: dryRun})
+			if err != nil {
+				return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+			}
+			var synced int
+			for _, r := range results {
+				switch r.Action {
+				case usecase.SyncActionInstall:
+					fmt.Printf("  + %s/%s (install)\n", r.Target, r.SkillName)
+					synced++
+				case usecase.SyncActionUpdate:
+					fmt.Printf("  ~ %s/%s (update)\n", r.Target, r.SkillName)
+					synced++
+				case usecase.SyncActionError:
+					fmt.Printf("  ! %s/%s (error: %v)\n", r.Target, r.SkillName, r.Error)
+					problems++
+				}
+			}
+			if synced == 0 {
+				fmt.Println("  All skills already synced")
+			}
+
+			printUpStep(5, "Prune")
+			pruned, err := usecase.NewPruneService(a.fs, a.config, root).Prune(usecase.PruneOptions{DryRun: dryRun})
+			if err != nil {
+				return fmt.Errorf("prune failed: %w", withTargetSuggestion(err))
+			}
+			problems += printPruneResults(pruned)
+			if len(pruned) == 0 {
+				fmt.Println("  Nothing to prune")
+			}
+
+			printUpStep(6, "Status")
+			statuses, err := usecase.NewStatusService(a.fs, a.config, root).GetStatus()
+			if err != nil {
+				return fmt.Errorf("failed to get status: %w", err)
+			}
+			printStatusSummary(statuses)
+
+			if !dryRun {
+				report := usecase.NewRunReport("up", startedAt)
+				report.AddSyncResults(results)
+				writeRunReport(a, a.config, root, report)
+			}
+
+			if problems > 0 {
+				return fmt.Errorf("up finished with %d problem(s)", problems)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&skipPrompts, "yes", "y", false, "Skip confirmation prompts and create a missing config")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+
+	return cmd
+}
+
+// printUpStep prints the heading of a step of the up command.
+func printUpStep(n int, name string) {
+	fmt.Printf("\n[%d/%d] %s\n", n, upSteps, name)
+}
+
+// upConfig makes sure a config is loaded, creating the global config with the
+// defaults when there is none and creation is confirmed by yes.
+func upConfig(a *app, cmd *cobra.Command, yes, dryRun bool) error {
+	if a.configErr == nil {
+		fmt.Println("  = config loaded")
+		return nil
+	}
+
+	configPath, err := a.configPath(cmd)
+	if err != nil {
+		return err
+	}
+	switch {
+	case a.fs.Exists(configPath):
+		return fmt.Errorf("failed to load config: %w", a.configErr)
+	case cmd.Flags().Changed("config"):
+		return fmt.Errorf("config file not found: %s", configPath)
+	case !yes:
+		return fmt.Errorf("no config found at %s (run 'skillet init -g' first, or rerun with --yes to create one with the defaults)", configPath)
+	case dryRun:
+		fmt.Printf("  + %s (would be created with the defaults)\n", configPath)
+		return nil
+	}
+
+	if err := initializeGlobal(cmd.Context(), a, "", a.prompterFor(true), false); err != nil {
+		return err
+	}
+	cfg, err := a.configStore.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if a.strict {
+		cfg.Frontmatter.Strict = true
+	}
+	a.config = cfg
+	a.configStore.SetProjectDiscovery(cfg.Discovery)
+	a.configErr = nil
+	return nil
+}
+
+// upMigrate offers to migrate unmanaged skills found in the targets of scope.
+// A dry run only lists them.
+func upMigrate(ctx context.Context, a *app, p prompt.Prompter, scope skill.Scope, root string, dryRun bool) error {
+	if scope != skill.ScopeProject {
+		root = ""
+	}
+	if !dryRun {
+		return runMigrate(ctx, a, a.config, migrateRunOptions{
+			prompter:       p,
+			defaultConfirm: false,
+			scope:          scope,
+			projectRoot:    root,
+		})
+	}
+
+	syncSvc := usecase.NewSyncService(a.fs, a.config, root)
+	found := usecase.NewMigrateService(a.fs, a.config, root, syncSvc).FindSkillsToMigrate(usecase.MigrateOptions{
+		Scope:       scope,
+		ProjectRoot: root,
+	})
+	if len(found) == 0 {
+		fmt.Println("No skills to migrate.")
+		return nil
+	}
+	printFoundSkills(found, nil)
+	return nil
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// fileChangeMarks maps file changes to the markers printed by update.
+var fileChangeMarks = map[usecase.FileChangeKind]string{
+	usecase.FileAdded:    "+",
+	usecase.FileRemoved:  "-",
+	usecase.FileModified: "~",
+}
+
+// newUpdateCmd creates the update command.
+func newUpdateCmd(a *app) *cobra.Command {
+	var (
+		all    bool
+		dryRun bool
+		force  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update [name...]",
+		Short: "Refresh skills added from remote sources",
+		Long: `Fetch the recorded source of skills added with skillet add, replace the
+ones whose content changed, and sync them to targets.
+
+Pass skill names to update only those, or --all to update every added skill
+in the project and global stores. Each updated skill is listed with the files
+that were added (+), removed (-), or modified (~). Use --dry-run to see what
+would change without replacing anything.
+
+Skills edited since they were added are not replaced unless --force is given.
+Sources pinned with @ref only change when the ref is moved.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && !all {
+				return errors.New("specify skills to update or use --all")
+			}
+			if len(args) > 0 && all {
+				return errors.New("--all cannot be combined with skill names")
+			}
+			dryRun = dryRun || a.dryRun
+
+			root, err := a.findProjectRoot()
+			if err != nil {
+				a.logf("no project root found: %v", err)
+				root = ""
+			}
+
+			fetcher := fetch.Guard(fetch.GitFetcher{}, a.offline)
+			results, err := usecase.NewUpdateService(a.fs, a.config, root, fetcher).Update(cmd.Context(), usecase.UpdateOptions{
+				Names:  args,
+				DryRun: dryRun,
+				Force:  force,
+			})
+			if err != nil {
+				return fmt.Errorf("update failed: %w", err)
+			}
+
+			if len(results) == 0 {
+				fmt.Println("No skills added from remote sources.")
+				return nil
+			}
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+
+			var updated []string
+			var failed int
+			for _, r := range results {
+				switch r.Action {
+				case usecase.UpdateActionUpdated:
+					fmt.Printf("  ~ %s (%s, updated from %s)\n", r.SkillName, r.Scope, r.Source)
+					for _, c := range r.Changes {
+						fmt.Printf("      %s %s\n", fileChangeMarks[c.Kind], c.Path)
+					}
+					updated = append(updated, r.SkillName)
+				case usecase.UpdateActionUnchanged:
+					fmt.Printf("  = %s (%s, unchanged)\n", r.SkillName, r.Scope)
+				case usecase.UpdateActionError:
+					fmt.Printf("  ! %s (error: %v)\n", r.SkillName, r.Error)
+					failed++
+				}
+			}
+
+			if !dryRun && len(updated) > 0 {
+				// Copies of updated skills are stale, so reinstall them.
+				syncResults, err := usecase.NewSyncService(a.fs, a.config, root).Sync(cmd.Context(), usecase.SyncOptions{
+					Names: updated,
+					Force: true,
+				})
+				if err != nil {
+					return fmt.Errorf("sync failed: %w", withTargetSuggestion(err))
+				}
+				printMigrateSyncResults(syncResults)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d skill(s) failed to update", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Update every skill added from a remote source")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without making changes")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace skills edited since they were added")
+
+	return cmd
+}
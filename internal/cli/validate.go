@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newValidateCmd creates the validate command.
+func newValidateCmd(a *app) *cobra.Command {
+	var maxAssetKiB int64
+	scopeFlags := NewScopeFlags(skill.ScopeProject)
+
+	cmd := &cobra.Command{
+		Use:   "validate [path|name...]",
+		Short: "Validate skills against the frontmatter schema",
+		Long: `Validate skill directories before they are synced or published.
+
+Each skill's SKILL.md must start with frontmatter matching the schema printed
+by skillet schema print: name and description are required, no other fields
+are allowed, the name must be a valid skill name matching the directory, and
+the description must be at most 1024 characters. Relative links in the
+skill's markdown files must point to files inside the skill directory, and no
+file may be larger than --max-asset-size KiB.
+
+Arguments are skill directories (or their SKILL.md files), which need not be
+in a store, or names of skills in the store. Without arguments, every skill in
+the store is validated. Problems are printed as file:line: message, and the
+command exits with 1 when any are found, so it can run in CI.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := a.findProjectRoot()
+			if err != nil {
+				a.logf("no project root found: %v", err)
+				root = ""
+			}
+			if scopeFlags.Project && root == "" {
+				return fmt.Errorf("not in a project directory")
+			}
+			opts := usecase.ValidateOptions{Args: args, MaxAssetSize: maxAssetKiB << 10}
+			if scopeFlags.IsSet() {
+				scope, err := scopeFlags.GetScope()
+				if err != nil {
+					return err
+				}
+				opts.Scope = &scope
+			}
+
+			results, err := usecase.NewValidateService(a.fs, a.config, root).Validate(opts)
+			if err != nil {
+				return fmt.Errorf("validate failed: %w", err)
+			}
+
+			var problems, failed int
+			for _, r := range results {
+				if r.Error != nil || len(r.Problems) > 0 {
+					failed++
+				}
+				if r.Error != nil {
+					fmt.Printf("%s: error: %v\n", r.Path, r.Error)
+					problems++
+					continue
+				}
+				for _, p := range r.Problems {
+					location := a.fs.Join(r.Path, p.File)
+					if p.Line > 0 {
+						location = fmt.Sprintf("%s:%d", location, p.Line)
+					}
+					fmt.Printf("%s: %s\n", location, p.Message)
+					problems++
+				}
+			}
+
+			if problems > 0 {
+				// The problems already explain the failure.
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d problem(s) found in %d skill(s)", problems, failed)
+			}
+			fmt.Printf("%d skill(s) validated, no problems found\n", len(results))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&maxAssetKiB, "max-asset-size", skill.DefaultMaxAssetSize>>10, "Largest file allowed in a skill, in KiB")
+	AddScopeFlags(cmd, &scopeFlags)
+
+	return cmd
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newVendorCmd creates the vendor command.
+func newVendorCmd(a *app) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "vendor [skill...]",
+		Short: "Copy global skills into the project for offline and CI use",
+		Long: `Copy resolved global, org, and system skills into <project>/.agents/vendor/
+so CI containers and teammates without your global store get the same skills.
+
+By default, vendors every non-project skill. Pass skill names to vendor only those.
+Each vendored skill's source and checksum are recorded in .agents/skillet.lock.
+
+Vendored skills are loaded as project skills, so sync installs them into project
+targets and prefers them over the global copies. Skills defined by the project
+itself take precedence over vendored copies. Run vendor again to refresh.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun = dryRun || a.dryRun
+
+			root, err := a.findProjectRoot()
+			if err != nil {
+				return fmt.Errorf("not in a project directory")
+			}
+
+			results, err := usecase.NewVendorService(a.fs, a.config, root).Vendor(usecase.VendorOptions{
+				Names:  args,
+				DryRun: dryRun,
+			})
+			if err != nil {
+				return fmt.Errorf("vendor failed: %w", err)
+			}
+
+			if len(results) == 0 {
+				fmt.Println("No skills to vendor.")
+				return nil
+			}
+			if dryRun {
+				fmt.Println("Dry run - no changes made:")
+			}
+
+			var failed int
+			for _, r := range results {
+				switch r.Action {
+				case usecase.VendorActionVendored:
+					fmt.Printf("  + %s (%s)\n", r.SkillName, r.Scope)
+				case usecase.VendorActionUpdated:
+					fmt.Printf("  ~ %s (%s, updated)\n", r.SkillName, r.Scope)
+				case usecase.VendorActionUnchanged:
+					fmt.Printf("  = %s (%s, unchanged)\n", r.SkillName, r.Scope)
+				case usecase.VendorActionError:
+					fmt.Printf("  ! %s (error: %v)\n", r.SkillName, r.Error)
+					failed++
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d skill(s) failed to vendor", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+
+	return cmd
+}
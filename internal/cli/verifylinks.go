@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newVerifyLinksCmd creates the verify-links command.
+func newVerifyLinksCmd(a *app) *cobra.Command {
+	var (
+		fix    bool
+		target string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify-links",
+		Short: "Check that installed skills use their configured strategy",
+		Long: `Check how each installed skill is deployed against the strategy configured
+for its scope, grouped by target.
+
+When a symlink cannot be created (for example across devices or without
+permission), sync silently falls back to a copy. verify-links lists those
+copies, symlinks where a copy is configured, and links that no longer point
+at the store. Use --fix to reinstall them all with the configured strategy.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fix && a.dryRun {
+				fmt.Println("Dry run - no changes made:")
+				fix = false
+			}
+
+			root, err := a.findProjectRoot()
+			if err != nil {
+				a.logf("no project root found: %v", err)
+				root = ""
+			}
+
+			checks, err := usecase.NewVerifyLinksService(a.fs, a.config, root).VerifyLinks(usecase.VerifyLinksOptions{
+				Fix:    fix,
+				Target: target,
+			})
+			if err != nil {
+				return fmt.Errorf("verify failed: %w", withTargetSuggestion(err))
+			}
+
+			var targets []string
+			byTarget := make(map[string][]usecase.LinkCheck)
+			for _, c := range checks {
+				if _, seen := byTarget[c.Target]; !seen {
+					targets = append(targets, c.Target)
+				}
+				byTarget[c.Target] = append(byTarget[c.Target], c)
+			}
+
+			var ok, mismatched, broken, fixed, failed int
+			for _, name := range targets {
+				fmt.Printf("\nTarget: %s\n", name)
+				fmt.Println(statusSeparator)
+				targetOK := 0
+				for _, c := range byTarget[name] {
+					switch c.State {
+					case usecase.LinkOK:
+						targetOK++
+						continue
+					case usecase.LinkBroken:
+						broken++
+					default:
+						mismatched++
+					}
+					switch {
+					case c.Fixed:
+						fmt.Printf("  ✓ %s (fixed, now %s)\n", c.SkillName, c.Got)
+						fixed++
+					case c.Error != nil:
+						fmt.Printf("  ! %s (error: %v)\n", c.SkillName, c.Error)
+						failed++
+					case c.State == usecase.LinkBroken:
+						fmt.Printf("  ! %s (link does not point at the store, expected %s)\n", c.SkillName, c.Want)
+					default:
+						fmt.Printf("  ~ %s (%s, expected %s)\n", c.SkillName, c.Got, c.Want)
+					}
+				}
+				if targetOK == len(byTarget[name]) {
+					fmt.Printf("  All %d install(s) match their strategy\n", targetOK)
+				}
+				ok += targetOK
+			}
+
+			if len(checks) == 0 {
+				fmt.Println("No installed skills found.")
+				return nil
+			}
+			fmt.Printf("\nSummary: %d checked, %d ok, %d mismatched, %d broken", len(checks), ok, mismatched, broken)
+			if fix {
+				fmt.Printf(", %d fixed", fixed)
+			}
+			fmt.Println()
+
+			switch {
+			case failed > 0:
+				return fmt.Errorf("%d install(s) could not be fixed", failed)
+			case !fix && mismatched+broken > 0:
+				return fmt.Errorf("%d install(s) do not match their strategy (run with --fix)", mismatched+broken)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Reinstall mismatched and broken installs with the configured strategy")
+	cmd.Flags().StringVar(&target, "target", "", "Only verify this target")
+
+	return cmd
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// newWhichCmd creates the which command.
+func newWhichCmd(a *app) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "which <skill>",
+		Short: "Show where a skill resolves from and where it is installed",
+		Long: `Show every location of a skill: the store copy that wins, the lower-priority
+copies it shadows, and its install path in each target with the mechanism used.
+
+Use --json to print the result as a JSON object for tooling.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSkillNames(a, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, _ := a.findProjectRoot()
+			result, err := usecase.NewLocateService(a.fs, a.config, root).Which(args[0])
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(result); err != nil {
+					return fmt.Errorf("failed to encode result: %w", err)
+				}
+				return nil
+			}
+
+			fmt.Printf("%s\n", result.Name)
+			fmt.Printf("  store:    %s (%s%s)\n", result.Winner.Path, result.Winner.Scope, vendoredSuffix(result.Winner))
+			for _, loc := range result.Shadowed {
+				fmt.Printf("  shadowed: %s (%s%s)\n", loc.Path, loc.Scope, vendoredSuffix(loc))
+			}
+			for _, loc := range result.Targets {
+				switch {
+				case !loc.Installed:
+					fmt.Printf("  %s: not installed\n", loc.Target)
+				case loc.LinkTo != "":
+					fmt.Printf("  %s: %s -> %s\n", loc.Target, loc.Path, loc.LinkTo)
+				default:
+					fmt.Printf("  %s: %s (%s)\n", loc.Target, loc.Path, loc.Strategy)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the result as JSON")
+
+	return cmd
+}
+
+// vendoredSuffix marks vendored store copies in which output.
+func vendoredSuffix(loc usecase.StoreLocation) string {
+	if loc.Vendored {
+		return ", vendored"
+	}
+	return ""
+}
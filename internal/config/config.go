@@ -2,6 +2,10 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
 )
@@ -9,16 +13,25 @@ import (
 const (
 	// ConfigDir is the directory name for skillet configuration.
 	ConfigDir = ".config/skillet"
+	// CacheDir is the directory name for data skillet can download again,
+	// used when $XDG_CACHE_HOME is not set.
+	CacheDir = ".cache/skillet"
 	// ConfigFileName is the name of the config file.
 	ConfigFileName = "config.yaml"
 	// AgentsDirName is the directory name for agents configuration.
 	AgentsDirName = ".agents"
-	// DefaultGlobalPath is the default path for global skills.
-	DefaultGlobalPath = "~/.agents"
+	// DefaultGlobalPath is the default path for global skills, following the
+	// XDG base directory spec.
+	DefaultGlobalPath = "${XDG_DATA_HOME:-~/.local/share}/skillet"
+	// LegacyGlobalPath is the global path used by older versions. It is still
+	// used when no global path is configured and a store exists there.
+	LegacyGlobalPath = "~/.agents"
 	// SkillsDirName is the directory name for skills.
 	SkillsDirName = "skills"
 	// OptionalDirName is the directory name for optional (selectable) skills.
 	OptionalDirName = "optional"
+	// VendorDirName is the project directory holding vendored copies of non-project skills.
+	VendorDirName = "vendor"
 )
 
 // Strategy represents the synchronization strategy.
@@ -29,33 +42,274 @@ const (
 	StrategySymlink Strategy = "symlink"
 	// StrategyCopy uses file copies for synchronization.
 	StrategyCopy Strategy = "copy"
+	// StrategyHardlink installs skill directories whose files are hard links
+	// to the store, falling back to copies across file systems.
+	StrategyHardlink Strategy = "hardlink"
 )
 
+// Valid reports whether s is a strategy skillet knows.
+func (s Strategy) Valid() bool {
+	switch s {
+	case StrategySymlink, StrategyCopy, StrategyHardlink:
+		return true
+	}
+	return false
+}
+
+// TransformKind selects how skills are laid out in copies installed into a target.
+type TransformKind string
+
+const (
+	// TransformPassthrough copies skill directories as they are (the default).
+	TransformPassthrough TransformKind = "passthrough"
+	// TransformFlatten merges a skill's markdown files into a single file.
+	TransformFlatten TransformKind = "flatten"
+	// TransformRename installs SKILL.md under another file name.
+	TransformRename TransformKind = "rename"
+	// TransformTemplate replaces SKILL.md with the output of a Go text/template.
+	TransformTemplate TransformKind = "template"
+)
+
+// MigrateSourceKind selects how the entries of a migrate source become skills.
+type MigrateSourceKind string
+
+const (
+	// MigrateSourceCommands converts each .md file, a slash command, into a
+	// skill, as convert-commands does.
+	MigrateSourceCommands MigrateSourceKind = "commands"
+	// MigrateSourceAgents converts each .md file, a subagent definition, into
+	// a skill whose allowed-tools are the agent's tools.
+	MigrateSourceAgents MigrateSourceKind = "agents"
+	// MigrateSourceSkills moves each skill directory as it is.
+	MigrateSourceSkills MigrateSourceKind = "skills"
+)
+
+// MigrateSource is a directory of a target, besides its skills directory,
+// whose entries migrate --all-sources moves into the store as skills.
+type MigrateSource struct {
+	// Dir is the directory relative to the target root (e.g. "commands").
+	Dir  string            `yaml:"dir"`
+	Kind MigrateSourceKind `yaml:"kind"`
+	// Collection is the collection the skills are stored in. Defaults to the
+	// last element of Dir.
+	Collection string `yaml:"collection,omitempty"`
+}
+
+// CollectionLayout selects how skills in collections (subdirectories of
+// skills/ that hold skills) are named when installed into a target, whose
+// skills directory is flat.
+type CollectionLayout string
+
+const (
+	// CollectionsNamespace installs backend/api as backend-api (the default).
+	CollectionsNamespace CollectionLayout = "namespace"
+	// CollectionsFlatten installs backend/api as api.
+	CollectionsFlatten CollectionLayout = "flatten"
+)
+
+// SymlinkMode selects how symlinks into the store name the skill directory.
+type SymlinkMode string
+
+const (
+	// SymlinkAbsolute links to the absolute path of the skill (the default).
+	SymlinkAbsolute SymlinkMode = "absolute"
+	// SymlinkRelative links to the skill's path relative to the link, so
+	// links keep working when the home directory moves, e.g. with dotfiles.
+	SymlinkRelative SymlinkMode = "relative"
+)
+
+// TransformConfig declares the transform applied to skills installed into a target.
+type TransformConfig struct {
+	Type TransformKind `yaml:"type,omitempty"`
+	// File is the name SKILL.md is written as by flatten, rename, and template;
+	// "{name}" is replaced by the installed skill name. Defaults to SKILL.md.
+	File string `yaml:"file,omitempty"`
+	// Template is the path of the template rendered by the template transform.
+	Template string `yaml:"template,omitempty"`
+}
+
 // TargetConfig represents configuration for a specific target.
+// Any name can be configured; the paths of targets skillet does not know
+// default to ~/.<name>, .<name>, and skills.
 type TargetConfig struct {
-	Enabled    bool   `yaml:"enabled"`
+	Enabled bool `yaml:"enabled"`
+	// GlobalPath is the user-level target root. It overrides GlobalPaths and
+	// the built-in candidates.
 	GlobalPath string `yaml:"globalPath,omitempty"`
+	// GlobalPaths are candidate user-level roots for tools installed in
+	// different places (e.g. an app folder under ~/Library/Application Support).
+	// The first that exists is used, or the first one when none do.
+	GlobalPaths []string `yaml:"globalPaths,omitempty"`
+	// ProjectPath is the target root relative to a project root (e.g. ".cursor").
+	ProjectPath string `yaml:"projectPath,omitempty"`
+	// SkillsDir is the skills directory relative to the target root.
+	SkillsDir string `yaml:"skillsDir,omitempty"`
+	// ProjectSkillsDir overrides SkillsDir under the project target root.
+	ProjectSkillsDir string `yaml:"projectSkillsDir,omitempty"`
+	// Manifest is a file, relative to the target root, that lists installed skills
+	// after each sync (e.g. "config.toml" for codex).
+	Manifest string `yaml:"manifest,omitempty"`
+	// Prefix is prepended to skill names installed in this target only
+	// (e.g. "team-" installs "review" as "team-review").
+	Prefix string `yaml:"prefix,omitempty"`
+	// StripFrontmatterKeys are removed from SKILL.md frontmatter in copies
+	// installed into this target; the store file is not changed.
+	StripFrontmatterKeys []string `yaml:"stripFrontmatterKeys,omitempty"`
+	// Banner adds a comment after the frontmatter of SKILL.md in copies
+	// installed into this target, naming the store copy to edit instead.
+	Banner bool `yaml:"banner,omitempty"`
+	// Transform changes the layout of skills installed into this target. Targets
+	// with a transform other than passthrough always receive copies.
+	Transform TransformConfig `yaml:"transform,omitempty"`
+	// Optional lists the optional skills (under skills/optional/) installed into
+	// this target. Other optional skills stay in the store only.
+	Optional []string `yaml:"optional,omitempty"`
+	// Aliases installs skills under another name in this target only, by
+	// skill name (e.g. "review: code-review"). The prefix still applies.
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+	// MigrateSources replaces the target's built-in migrate sources (commands
+	// and agents for claude).
+	MigrateSources []MigrateSource `yaml:"migrateSources,omitempty"`
+}
+
+// SkillConfig limits the targets one skill is installed into, on top of the
+// targets its frontmatter lists.
+type SkillConfig struct {
+	// Targets are the only targets the skill is installed into (empty for all).
+	Targets []string `yaml:"targets,omitempty"`
+	// ExcludeTargets are targets the skill is never installed into.
+	ExcludeTargets []string `yaml:"excludeTargets,omitempty"`
+}
+
+// Excludes reports whether the settings keep the skill out of the named target.
+func (c SkillConfig) Excludes(target string) bool {
+	return (len(c.Targets) > 0 && !slices.Contains(c.Targets, target)) || slices.Contains(c.ExcludeTargets, target)
+}
+
+// NotificationConfig controls desktop notifications for background syncs.
+type NotificationConfig struct {
+	// OnChange notifies when a sync applies changes.
+	OnChange bool `yaml:"onChange"`
+	// OnError notifies when a sync fails.
+	OnError bool `yaml:"onError"`
+}
+
+// ProjectDiscovery controls how the project root is searched for upward from the cwd.
+type ProjectDiscovery struct {
+	// CeilingDirs stops the search before reaching these directories; they are never examined.
+	CeilingDirs []string `yaml:"ceilingDirs,omitempty"`
+	// MaxDepth limits how many parent directories are examined (0 for no limit).
+	MaxDepth int `yaml:"maxDepth,omitempty"`
+	// AllowHome lets the home directory be a project root; by default it is a ceiling
+	// so a global store at ~/.agents is not mistaken for a project.
+	AllowHome bool `yaml:"allowHome,omitempty"`
+}
+
+// FrontmatterConfig controls validation of SKILL.md frontmatter.
+type FrontmatterConfig struct {
+	// Strict rejects skills whose frontmatter does not match the published schema.
+	Strict bool `yaml:"strict,omitempty"`
+}
+
+// ReportFormat represents the file format of per-run reports.
+type ReportFormat string
+
+const (
+	// ReportFormatMarkdown writes reports as markdown documents.
+	ReportFormatMarkdown ReportFormat = "markdown"
+	// ReportFormatJSON writes reports as JSON.
+	ReportFormatJSON ReportFormat = "json"
+)
+
+// ReportConfig controls per-run report files written after sync and migrate.
+type ReportConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Format is "markdown" (default) or "json".
+	Format ReportFormat `yaml:"format,omitempty"`
+}
+
+// DefaultStaleCopyDays is how far, in days, a copy may fall behind the store
+// before status warns about it.
+const DefaultStaleCopyDays = 30
+
+// StatusConfig controls checks made by status.
+type StatusConfig struct {
+	// StaleCopyDays warns when a copied install was synced this many days before
+	// the store last changed (0 for DefaultStaleCopyDays, negative to disable).
+	StaleCopyDays int `yaml:"staleCopyDays,omitempty"`
+}
+
+// DefaultRegistryCacheHours is how long, in hours, a downloaded registry
+// index is used before it is downloaded again.
+const DefaultRegistryCacheHours = 24
+
+// RegistryConfig configures the skill registry used by search and install.
+type RegistryConfig struct {
+	// Index is the URL of the registry's JSON index (empty for no registry).
+	Index string `yaml:"index,omitempty"`
+	// CacheHours is how long a downloaded index is reused (0 for
+	// DefaultRegistryCacheHours, negative to download it every time).
+	CacheHours int `yaml:"cacheHours,omitempty"`
+}
+
+// HooksConfig lists shell commands run around syncs. Each command runs with
+// sh -c in the project root (or the current directory outside a project),
+// with SKILLET_* environment variables describing the event.
+type HooksConfig struct {
+	// PreSync runs before a sync changes anything; a failure aborts the sync.
+	PreSync []string `yaml:"preSync,omitempty"`
+	// PostSync runs after a sync.
+	PostSync []string `yaml:"postSync,omitempty"`
+	// PostInstall runs after a skill is installed into or updated in a target.
+	PostInstall []string `yaml:"postInstall,omitempty"`
+	// PostRemove runs after a skill is uninstalled from a target.
+	PostRemove []string `yaml:"postRemove,omitempty"`
 }
 
 // Config represents the global configuration.
 type Config struct {
-	Version         int                     `yaml:"version"`
-	GlobalPath      string                  `yaml:"globalPath,omitempty"`
-	DefaultStrategy Strategy                `yaml:"defaultStrategy"`
+	Version         int      `yaml:"version"`
+	GlobalPath      string   `yaml:"globalPath,omitempty"`
+	OrgPath         string   `yaml:"orgPath,omitempty"`
+	SystemPath      string   `yaml:"systemPath,omitempty"`
+	DefaultStrategy Strategy `yaml:"defaultStrategy"`
+	// StrategyByScope overrides DefaultStrategy for skills of one scope
+	// ("global", "org", "system", or "project").
+	StrategyByScope map[string]Strategy     `yaml:"strategyByScope,omitempty"`
 	Targets         map[string]TargetConfig `yaml:"targets"`
+	// Skills limits the targets skills are installed into, by skill name
+	// (collection/name for skills in collections).
+	Skills        map[string]SkillConfig `yaml:"skills,omitempty"`
+	Notifications NotificationConfig     `yaml:"notifications,omitempty"`
+	Discovery     ProjectDiscovery       `yaml:"projectDiscovery,omitempty"`
+	Frontmatter   FrontmatterConfig      `yaml:"frontmatter,omitempty"`
+	// Resolution decides which copy wins when several scopes define a skill:
+	// "project-wins" (default), "global-wins", "error-on-conflict", or "newest-wins".
+	Resolution string `yaml:"resolution,omitempty"`
+	// Collections is how skills in collections are named in targets:
+	// "namespace" (default) or "flatten".
+	Collections CollectionLayout `yaml:"collections,omitempty"`
+	// SymlinkMode is how symlinks into the store are written: "absolute"
+	// (default) or "relative". Links of either form are valid.
+	SymlinkMode SymlinkMode    `yaml:"symlinkMode,omitempty"`
+	Reports     ReportConfig   `yaml:"reports,omitempty"`
+	Status      StatusConfig   `yaml:"status,omitempty"`
+	Registry    RegistryConfig `yaml:"registry,omitempty"`
+	Hooks       HooksConfig    `yaml:"hooks,omitempty"`
 }
 
 // PathFS is the minimum filesystem contract needed for path resolution helpers.
 type PathFS interface {
 	Join(elem ...string) string
 	UserHomeDir() (string, error)
+	LookupEnv(key string) (string, bool)
+	IsDir(path string) bool
 }
 
 // DefaultConfig returns the default configuration.
 func DefaultConfig() *Config {
 	return &Config{
-		Version:         1,
-		GlobalPath:      DefaultGlobalPath,
+		Version:         CurrentVersion,
 		DefaultStrategy: StrategySymlink,
 		Targets: map[string]TargetConfig{
 			"claude": {
@@ -66,19 +320,38 @@ func DefaultConfig() *Config {
 				Enabled:    true,
 				GlobalPath: "~/.codex",
 			},
+			"cursor": {
+				Enabled: false,
+			},
 		},
 	}
 }
 
-// AgentsDir returns the expanded global agents directory path.
+// AgentsDir returns the expanded global agents directory path. When no global
+// path is configured, it is detected with DetectGlobalPath.
 func (c *Config) AgentsDir(fsys PathFS) (string, error) {
 	path := c.GlobalPath
 	if path == "" {
-		path = DefaultGlobalPath
+		path = DetectGlobalPath(fsys)
 	}
 	return ExpandPath(fsys, path)
 }
 
+// DetectGlobalPath returns the global path used when none is configured:
+// DefaultGlobalPath, unless only LegacyGlobalPath holds a skill store.
+func DetectGlobalPath(fsys PathFS) string {
+	if hasStore(fsys, DefaultGlobalPath) || !hasStore(fsys, LegacyGlobalPath) {
+		return DefaultGlobalPath
+	}
+	return LegacyGlobalPath
+}
+
+// hasStore reports whether path holds a skills directory.
+func hasStore(fsys PathFS, path string) bool {
+	dir, err := ExpandPath(fsys, path)
+	return err == nil && fsys.IsDir(fsys.Join(dir, SkillsDirName))
+}
+
 // SkillsDir returns the expanded global skills directory path.
 func (c *Config) SkillsDir(fsys PathFS, category string) (string, error) {
 	agentsDir, err := c.AgentsDir(fsys)
@@ -88,16 +361,113 @@ func (c *Config) SkillsDir(fsys PathFS, category string) (string, error) {
 	return fsys.Join(agentsDir, SkillsDirName, category), nil
 }
 
+// StrategyFor returns the sync strategy for skills of the named scope.
+// Org and system skills install next to global skills, so they fall back to
+// the global override before DefaultStrategy.
+func (c *Config) StrategyFor(scope string) Strategy {
+	if s, ok := c.StrategyByScope[scope]; ok && s != "" {
+		return s
+	}
+	if scope == "org" || scope == "system" {
+		if s, ok := c.StrategyByScope["global"]; ok && s != "" {
+			return s
+		}
+	}
+	if c.DefaultStrategy == "" {
+		return StrategySymlink
+	}
+	return c.DefaultStrategy
+}
+
+// StaleCopyAge returns how far a copy may fall behind the store before status
+// warns about it, or zero when the warning is disabled.
+func (c *Config) StaleCopyAge() time.Duration {
+	days := c.Status.StaleCopyDays
+	switch {
+	case days < 0:
+		return 0
+	case days == 0:
+		days = DefaultStaleCopyDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// RegistryCacheAge returns how long a downloaded registry index is reused,
+// or zero when it is downloaded every time.
+func (c *Config) RegistryCacheAge() time.Duration {
+	hours := c.Registry.CacheHours
+	switch {
+	case hours < 0:
+		return 0
+	case hours == 0:
+		hours = DefaultRegistryCacheHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// StrictFrontmatter reports whether skills must match the frontmatter schema.
+func (c *Config) StrictFrontmatter() bool {
+	return c.Frontmatter.Strict
+}
+
+// ConflictResolution returns the configured resolution policy name.
+func (c *Config) ConflictResolution() string {
+	return c.Resolution
+}
+
 // GlobalSkillsDir resolves the global skills root directory.
 func (c *Config) GlobalSkillsDir(fsys platformfs.FileSystem) (string, error) {
 	return c.SkillsDir(fsys, "")
 }
 
+// SystemAgentsDir returns the expanded machine-wide agents directory path.
+// Returns an empty string when no system path is configured.
+func (c *Config) SystemAgentsDir(fsys PathFS) (string, error) {
+	if c.SystemPath == "" {
+		return "", nil
+	}
+	return ExpandPath(fsys, c.SystemPath)
+}
+
+// SystemSkillsDir resolves the machine-wide skills root directory.
+// Returns an empty string when no system path is configured.
+func (c *Config) SystemSkillsDir(fsys platformfs.FileSystem) (string, error) {
+	systemDir, err := c.SystemAgentsDir(fsys)
+	if err != nil || systemDir == "" {
+		return "", err
+	}
+	return fsys.Join(systemDir, SkillsDirName), nil
+}
+
+// OrgAgentsDir returns the expanded organization agents directory path.
+// Returns an empty string when no org path is configured.
+func (c *Config) OrgAgentsDir(fsys PathFS) (string, error) {
+	if c.OrgPath == "" {
+		return "", nil
+	}
+	return ExpandPath(fsys, c.OrgPath)
+}
+
+// OrgSkillsDir resolves the organization skills root directory.
+// Returns an empty string when no org path is configured.
+func (c *Config) OrgSkillsDir(fsys platformfs.FileSystem) (string, error) {
+	orgDir, err := c.OrgAgentsDir(fsys)
+	if err != nil || orgDir == "" {
+		return "", err
+	}
+	return fsys.Join(orgDir, SkillsDirName), nil
+}
+
 // ProjectSkillsDir resolves the project skills root directory.
 func (c *Config) ProjectSkillsDir(fsys platformfs.FileSystem, projectRoot string) string {
 	return ProjectSkillsDir(projectRoot, fsys, "")
 }
 
+// ProjectVendorDir resolves the project directory holding vendored skills.
+func (c *Config) ProjectVendorDir(fsys platformfs.FileSystem, projectRoot string) string {
+	return fsys.Join(ProjectAgentsDir(projectRoot, fsys), VendorDirName)
+}
+
 // GetAgentsDir returns the agents directory for the given scope.
 // If projectRoot is non-empty, returns the project agents directory.
 // Otherwise, returns the global agents directory.
@@ -118,21 +488,145 @@ func ProjectSkillsDir(projectRoot string, fsys PathFS, category string) string {
 	return fsys.Join(projectRoot, AgentsDirName, SkillsDirName, category)
 }
 
-// ExpandPath expands ~ in a path to the home directory.
+// userProfileVar is the Windows spelling of the home directory, which
+// ExpandPath accepts in place of ~ so configs shared across machines resolve.
+const userProfileVar = "%USERPROFILE%"
+
+// ExpandPath expands environment variables in a path, then a leading ~ or
+// %USERPROFILE% (in any case) to the home directory.
+//
+// $NAME and ${NAME} are replaced by the variable, which must be set and not
+// empty; ${NAME:-default} uses default instead. $$ is a literal $. HOME falls
+// back to the home directory where it is not set, as on Windows. Since
+// variables come first, a value or default may itself start with ~.
+// ~ and %USERPROFILE% may be followed by / or \; ~user is left as is.
 func ExpandPath(fsys PathFS, path string) (string, error) {
-	if len(path) == 0 {
+	path, err := expandEnv(fsys, path)
+	if err != nil {
+		return "", err
+	}
+
+	var rest string
+	switch {
+	case strings.HasPrefix(path, "~"):
+		rest = path[1:]
+	case len(path) >= len(userProfileVar) && strings.EqualFold(path[:len(userProfileVar)], userProfileVar):
+		rest = path[len(userProfileVar):]
+	default:
+		return path, nil
+	}
+	if rest != "" && rest[0] != '/' && rest[0] != '\\' {
+		return path, nil
+	}
+
+	home, err := fsys.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if rest == "" {
+		return home, nil
+	}
+	return fsys.Join(home, rest), nil
+}
+
+// expandEnv replaces the environment variables in path as described for
+// ExpandPath.
+func expandEnv(fsys PathFS, path string) (string, error) {
+	if !strings.Contains(path, "$") {
 		return path, nil
 	}
+	lookup := func(name string) string {
+		value, _ := fsys.LookupEnv(name)
+		if value == "" && name == "HOME" {
+			value, _ = fsys.UserHomeDir()
+		}
+		return value
+	}
 
-	if path[0] == '~' {
-		home, err := fsys.UserHomeDir()
-		if err != nil {
-			return "", err
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] != '$' || i+1 == len(path) {
+			b.WriteByte(path[i])
+			continue
+		}
+		switch next := path[i+1]; {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+		case next == '{':
+			end := strings.IndexByte(path[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated ${ in %q", path)
+			}
+			name, fallback, hasFallback := strings.Cut(path[i+2:i+2+end], ":-")
+			if !isEnvName(name) {
+				return "", fmt.Errorf("invalid variable name %q in %q", name, path)
+			}
+			value := lookup(name)
+			if value == "" && !hasFallback {
+				return "", fmt.Errorf("environment variable %s in %q is not set", name, path)
+			}
+			if value == "" {
+				value = fallback
+			}
+			b.WriteString(value)
+			i += 2 + end
+		case isEnvNameByte(next, true):
+			j := i + 1
+			for j < len(path) && isEnvNameByte(path[j], j == i+1) {
+				j++
+			}
+			name := path[i+1 : j]
+			value := lookup(name)
+			if value == "" {
+				return "", fmt.Errorf("environment variable %s in %q is not set", name, path)
+			}
+			b.WriteString(value)
+			i = j - 1
+		default:
+			b.WriteByte('$')
 		}
-		return home + path[1:], nil
 	}
+	return b.String(), nil
+}
 
-	return path, nil
+// isEnvName reports whether name is a valid environment variable name.
+func isEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := range len(name) {
+		if !isEnvNameByte(name[i], i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEnvNameByte reports whether c may appear in a variable name, at its
+// start when first is set.
+func isEnvNameByte(c byte, first bool) bool {
+	switch {
+	case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		return true
+	default:
+		return !first && '0' <= c && c <= '9'
+	}
+}
+
+// ContractPath abbreviates a path under the home directory to ~/..., with
+// forward slashes so it reads the same on every platform. Other paths are
+// returned unchanged.
+func ContractPath(fsys PathFS, path string) string {
+	home, err := fsys.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	rest, ok := strings.CutPrefix(path, home)
+	if !ok || rest == "" || rest[0] != '/' && rest[0] != filepath.Separator {
+		return path
+	}
+	return "~/" + filepath.ToSlash(rest[1:])
 }
 
 // GlobalConfigPath returns the path to the global config file (~/.config/skillet/config.yaml).
@@ -143,3 +637,16 @@ func GlobalConfigPath(fsys PathFS) (string, error) {
 	}
 	return fsys.Join(home, ConfigDir, ConfigFileName), nil
 }
+
+// CachePath returns the expanded path of skillet's cache directory:
+// $XDG_CACHE_HOME/skillet, or ~/.cache/skillet when that is not set.
+func CachePath(fsys PathFS) (string, error) {
+	if dir, ok := fsys.LookupEnv("XDG_CACHE_HOME"); ok && filepath.IsAbs(dir) {
+		return fsys.Join(dir, "skillet"), nil
+	}
+	home, err := fsys.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return fsys.Join(home, CacheDir), nil
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"testing"
+
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+func TestExpandPath(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	mock.Env = map[string]string{"XDG_DATA_HOME": "/data", "SKILLS": "~/skills", "EMPTY": ""}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", ""},
+		{"~", "/home/test"},
+		{"~/.claude", "/home/test/.claude"},
+		{"%USERPROFILE%", "/home/test"},
+		{"%USERPROFILE%/.agents/skills", "/home/test/.agents/skills"},
+		{"%userprofile%/.codex", "/home/test/.codex"},
+		{"~other/.claude", "~other/.claude"},
+		{"%USERPROFILE%x", "%USERPROFILE%x"},
+		{"/opt/skills", "/opt/skills"},
+		{"relative/~", "relative/~"},
+		{"$HOME/.claude", "/home/test/.claude"},
+		{"${XDG_DATA_HOME}/skillet", "/data/skillet"},
+		{"$XDG_DATA_HOME/skillet", "/data/skillet"},
+		{"${XDG_CONFIG_HOME:-~/.config}/x", "/home/test/.config/x"},
+		{"${EMPTY:-/fallback}", "/fallback"},
+		{"$SKILLS/team", "/home/test/skills/team"},
+		{"/opt/$$HOME/a$", "/opt/$HOME/a$"},
+		{"/opt/$1", "/opt/$1"},
+	}
+	for _, tt := range tests {
+		got, err := ExpandPath(mock, tt.path)
+		if err != nil {
+			t.Fatalf("ExpandPath(%q) error = %v", tt.path, err)
+		}
+		if got != tt.want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	for _, path := range []string{"$UNSET/x", "${UNSET}", "$EMPTY", "${XDG_DATA_HOME", "${1X}"} {
+		if got, err := ExpandPath(mock, path); err == nil {
+			t.Errorf("ExpandPath(%q) = %q, want error", path, got)
+		}
+	}
+}
+
+func TestContractPath(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/home/test/.agents/skills/pdf", "~/.agents/skills/pdf"},
+		{"/home/test", "/home/test"},
+		{"/home/tester/skills", "/home/tester/skills"},
+		{"/opt/skills", "/opt/skills"},
+	}
+	for _, tt := range tests {
+		if got := ContractPath(mock, tt.path); got != tt.want {
+			t.Errorf("ContractPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAgentsDirDetectsStore(t *testing.T) {
+	tests := []struct {
+		name string
+		dirs []string
+		env  map[string]string
+		want string
+	}{
+		{"no store", nil, nil, "/home/test/.local/share/skillet"},
+		{"legacy store", []string{"/home/test/.agents/skills"}, nil, "/home/test/.agents"},
+		{"both stores", []string{"/home/test/.agents/skills", "/home/test/.local/share/skillet/skills"}, nil, "/home/test/.local/share/skillet"},
+		{"XDG_DATA_HOME", []string{"/home/test/.agents"}, map[string]string{"XDG_DATA_HOME": "/data"}, "/data/skillet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := platformfs.NewMockFileSystem()
+			mock.HomeDir = "/home/test"
+			mock.Env = tt.env
+			for _, dir := range tt.dirs {
+				mock.Dirs[dir] = true
+			}
+
+			got, err := DefaultConfig().AgentsDir(mock)
+			if err != nil {
+				t.Fatalf("AgentsDir() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AgentsDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	mock.Dirs["/home/test/.agents/skills"] = true
+	cfg := &Config{GlobalPath: "~/skills"}
+	if got, _ := cfg.AgentsDir(mock); got != "/home/test/skills" {
+		t.Errorf("AgentsDir() with globalPath = %q, want the configured path", got)
+	}
+}
+
+func TestCachePath(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	if got, _ := CachePath(mock); got != "/home/test/.cache/skillet" {
+		t.Errorf("CachePath() = %q, want ~/.cache/skillet", got)
+	}
+
+	mock.Env = map[string]string{"XDG_CACHE_HOME": "/cache"}
+	if got, _ := CachePath(mock); got != "/cache/skillet" {
+		t.Errorf("CachePath() = %q, want $XDG_CACHE_HOME/skillet", got)
+	}
+
+	// Relative values are ignored, as the spec requires.
+	mock.Env = map[string]string{"XDG_CACHE_HOME": "cache"}
+	if got, _ := CachePath(mock); got != "/home/test/.cache/skillet" {
+		t.Errorf("CachePath() = %q, want ~/.cache/skillet", got)
+	}
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Setting is one scalar or list value of a config, addressed by its key.
+type Setting struct {
+	// Key is the dot-separated path of the value (e.g. targets.claude.enabled)
+	Key   string
+	Value string
+}
+
+// Validate parses config data, migrating older versions first, and checks
+// that it holds only known keys and usable settings.
+func Validate(data []byte) (*Config, error) {
+	data, _, _, err := migrateData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate reports settings that hold values skillet does not know. Paths are
+// not checked, since they may not exist yet.
+func (c *Config) Validate() error {
+	var errs []error
+	validStrategy := func(key string, st Strategy) {
+		if st != "" && !st.Valid() {
+			errs = append(errs, fmt.Errorf("%s %q is not a strategy (symlink, copy, or hardlink)", key, st))
+		}
+	}
+
+	validStrategy("defaultStrategy", c.DefaultStrategy)
+	for scope, st := range c.StrategyByScope {
+		switch scope {
+		case "global", "org", "system", "project":
+			validStrategy("strategyByScope."+scope, st)
+		default:
+			errs = append(errs, fmt.Errorf("strategyByScope has unknown scope %q (global, org, system, or project)", scope))
+		}
+	}
+	switch c.Collections {
+	case "", CollectionsNamespace, CollectionsFlatten:
+	default:
+		errs = append(errs, fmt.Errorf("collections %q is not a collection layout (namespace or flatten)", c.Collections))
+	}
+	switch c.SymlinkMode {
+	case "", SymlinkAbsolute, SymlinkRelative:
+	default:
+		errs = append(errs, fmt.Errorf("symlinkMode %q is not a symlink mode (absolute or relative)", c.SymlinkMode))
+	}
+	switch c.Reports.Format {
+	case "", ReportFormatMarkdown, ReportFormatJSON:
+	default:
+		errs = append(errs, fmt.Errorf("reports.format %q is not a report format (markdown or json)", c.Reports.Format))
+	}
+	for name, t := range c.Targets {
+		switch t.Transform.Type {
+		case "", TransformPassthrough, TransformFlatten, TransformRename, TransformTemplate:
+		default:
+			errs = append(errs, fmt.Errorf("targets.%s.transform.type %q is not a transform", name, t.Transform.Type))
+		}
+		for skillName, alias := range t.Aliases {
+			if alias == "" || alias == "." || alias == ".." || strings.ContainsAny(alias, `/\`) {
+				errs = append(errs, fmt.Errorf("targets.%s.aliases.%s %q is not a directory name", name, skillName, alias))
+			}
+		}
+		for i, src := range t.MigrateSources {
+			if src.Dir == "" {
+				errs = append(errs, fmt.Errorf("targets.%s.migrateSources[%d] has no dir", name, i))
+			}
+			switch src.Kind {
+			case MigrateSourceCommands, MigrateSourceAgents, MigrateSourceSkills:
+			default:
+				errs = append(errs, fmt.Errorf("targets.%s.migrateSources[%d].kind %q is not a source kind (commands, agents, or skills)", name, i, src.Kind))
+			}
+		}
+	}
+	for name, sk := range c.Skills {
+		if slices.Contains(sk.Targets, "") {
+			errs = append(errs, fmt.Errorf("skills.%s.targets has an empty target name", name))
+		}
+		if slices.Contains(sk.ExcludeTargets, "") {
+			errs = append(errs, fmt.Errorf("skills.%s.excludeTargets has an empty target name", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Get returns the value of key in cfg: a scalar as is, a list in flow style,
+// and a mapping as a YAML block.
+func Get(cfg *Config, key string) (string, error) {
+	root, err := configNode(cfg)
+	if err != nil {
+		return "", err
+	}
+	node := root
+	for _, part := range strings.Split(key, ".") {
+		if node.Kind != yaml.MappingNode {
+			node = nil
+			break
+		}
+		if _, node = mappingEntry(node, part); node == nil {
+			break
+		}
+	}
+	if node == nil {
+		return "", fmt.Errorf("%s is not set", key)
+	}
+	if node.Kind == yaml.MappingNode {
+		out, err := encodeDocument(node)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(string(out), "\n"), nil
+	}
+	return formatValue(node)
+}
+
+// Settings lists the scalar and list values set in cfg, in key order.
+func Settings(cfg *Config) ([]Setting, error) {
+	root, err := configNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var settings []Setting
+	var walk func(prefix string, node *yaml.Node) error
+	walk = func(prefix string, node *yaml.Node) error {
+		if node.Kind != yaml.MappingNode {
+			value, err := formatValue(node)
+			if err != nil {
+				return err
+			}
+			settings = append(settings, Setting{Key: prefix, Value: value})
+			return nil
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if err := walk(key, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk("", root); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Set returns config data with key set to value, which is parsed as YAML
+// (so "false" is a boolean and "[a, b]" a list). Missing parent mappings are
+// created; comments and the order of other keys are kept. The result is not
+// validated.
+func Set(data []byte, key, value string) ([]byte, error) {
+	data, _, _, err := migrateData(data)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	var parsed yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", value, err)
+	}
+	valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+	if len(parsed.Content) > 0 {
+		valueNode = parsed.Content[0]
+	}
+
+	parts := strings.Split(key, ".")
+	node := doc.Content[0]
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid key %q", key)
+		}
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s is not a mapping", strings.Join(parts[:i], "."))
+		}
+		k, v := mappingEntry(node, part)
+		last := i == len(parts)-1
+		switch {
+		case k == nil && last:
+			node.Content = append(node.Content, scalarNode(part), valueNode)
+		case k == nil:
+			v = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, scalarNode(part), v)
+		case last:
+			valueNode.LineComment = v.LineComment
+			*v = *valueNode
+		}
+		node = v
+	}
+	return encodeDocument(&doc)
+}
+
+// configNode returns cfg as a YAML mapping.
+func configNode(cfg *Config) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return &node, nil
+}
+
+// formatValue returns a scalar as is and other values as flow-style YAML.
+func formatValue(node *yaml.Node) (string, error) {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value, nil
+	}
+	flow := *node
+	flow.Style = yaml.FlowStyle
+	out, err := yaml.Marshal(&flow)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "valid", data: "version: 1\ndefaultStrategy: copy\ntargets:\n  claude:\n    enabled: true\n"},
+		{name: "empty", data: ""},
+		{name: "hardlink strategy", data: "version: 1\nstrategyByScope:\n  project: hardlink\n"},
+		{name: "unknown key", data: "version: 1\ndefaultStrategie: copy\n", wantErr: "defaultStrategie not found"},
+		{name: "bad strategy", data: "version: 1\ndefaultStrategy: junction\n", wantErr: "not a strategy"},
+		{name: "bad symlink mode", data: "version: 1\nsymlinkMode: canonical\n", wantErr: "symlinkMode"},
+		{name: "bad scope", data: "version: 1\nstrategyByScope:\n  team: copy\n", wantErr: "unknown scope"},
+		{name: "bad transform", data: "version: 1\ntargets:\n  x:\n    transform:\n      type: zip\n", wantErr: "targets.x.transform.type"},
+		{name: "bad migrate source", data: "version: 1\ntargets:\n  x:\n    migrateSources:\n      - dir: prompts\n        kind: prompt\n", wantErr: "targets.x.migrateSources[0].kind"},
+		{name: "empty skill target", data: "version: 1\nskills:\n  review:\n    excludeTargets: [\"\"]\n", wantErr: "skills.review.excludeTargets"},
+		{name: "bad alias", data: "version: 1\ntargets:\n  x:\n    aliases:\n      review: ../review\n", wantErr: "targets.x.aliases.review"},
+		{name: "wrong type", data: "version: 1\ntargets:\n  x:\n    enabled: maybe\n", wantErr: "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Validate([]byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	cfg := &Config{
+		Version:         1,
+		DefaultStrategy: StrategyCopy,
+		Targets: map[string]TargetConfig{
+			"claude": {Enabled: true, StripFrontmatterKeys: []string{"a", "b"}},
+		},
+	}
+
+	tests := []struct {
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{key: "defaultStrategy", want: "copy"},
+		{key: "targets.claude.enabled", want: "true"},
+		{key: "targets.claude.stripFrontmatterKeys", want: "[a, b]"},
+		{key: "targets.claude", want: "enabled: true\nstripFrontmatterKeys:\n  - a\n  - b"},
+		{key: "targets.codex.enabled", wantErr: true},
+		{key: "defaultStrategy.x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := Get(cfg, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Get() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettings(t *testing.T) {
+	cfg := &Config{
+		Version:         1,
+		DefaultStrategy: StrategySymlink,
+		Targets:         map[string]TargetConfig{"claude": {Enabled: true, GlobalPath: "~/.claude"}},
+	}
+	settings, err := Settings(cfg)
+	if err != nil {
+		t.Fatalf("Settings() error = %v", err)
+	}
+
+	var lines []string
+	for _, s := range settings {
+		lines = append(lines, s.Key+"="+s.Value)
+	}
+	want := "version=1 defaultStrategy=symlink targets.claude.enabled=true targets.claude.globalPath=~/.claude"
+	if got := strings.Join(lines, " "); got != want {
+		t.Errorf("Settings() = %s, want %s", got, want)
+	}
+}
+
+func TestSet(t *testing.T) {
+	const data = "version: 1\n# strategy\ndefaultStrategy: symlink # default\ntargets:\n  claude:\n    enabled: true\n"
+
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "replace keeps comments",
+			key:   "defaultStrategy",
+			value: "copy",
+			want:  "version: 1\n# strategy\ndefaultStrategy: copy # default\ntargets:\n  claude:\n    enabled: true\n",
+		},
+		{
+			name:  "creates parents",
+			key:   "targets.codex.enabled",
+			value: "false",
+			want:  "version: 1\n# strategy\ndefaultStrategy: symlink # default\ntargets:\n  claude:\n    enabled: true\n  codex:\n    enabled: false\n",
+		},
+		{
+			name:  "list value",
+			key:   "targets.claude.optional",
+			value: "[a, b]",
+			want:  "version: 1\n# strategy\ndefaultStrategy: symlink # default\ntargets:\n  claude:\n    enabled: true\n    optional: [a, b]\n",
+		},
+		{name: "through a scalar", key: "defaultStrategy.x", value: "1", wantErr: true},
+		{name: "empty key part", key: "targets..enabled", value: "1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Set([]byte(data), tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Set() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// LegacyConfigFileName is the global config file name used by older versions,
+// stored in the legacy global agents directory (~/.agents/skillet.yaml).
+const LegacyConfigFileName = "skillet.yaml"
+
+// LegacyConfigPath returns the path of the legacy global config file.
+func LegacyConfigPath(fsys PathFS) (string, error) {
+	agentsDir, err := ExpandPath(fsys, LegacyGlobalPath)
+	if err != nil {
+		return "", err
+	}
+	return fsys.Join(agentsDir, LegacyConfigFileName), nil
+}
+
+// FindLegacyConfig returns the legacy config path when that file exists,
+// or an empty string otherwise.
+func (s *Store) FindLegacyConfig() (string, error) {
+	path, err := LegacyConfigPath(s.fs)
+	if err != nil {
+		return "", err
+	}
+	if !s.fs.Exists(path) || s.fs.IsDir(path) {
+		return "", nil
+	}
+	return path, nil
+}
+
+// MigrateLegacy moves the legacy config to the global config path and returns
+// the new path. When a global config already exists, it keeps its own settings
+// and only takes the ones it leaves unset from the legacy file.
+func (s *Store) MigrateLegacy() (string, error) {
+	legacyPath, err := s.FindLegacyConfig()
+	if err != nil {
+		return "", err
+	}
+	if legacyPath == "" {
+		return "", fmt.Errorf("no legacy config found")
+	}
+	legacy, err := s.Load(legacyPath)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := s.GlobalConfigPath()
+	if err != nil {
+		return "", err
+	}
+	cfg := legacy
+	if s.fs.Exists(path) {
+		if cfg, err = s.Load(path); err != nil {
+			return "", err
+		}
+		mergeConfig(cfg, legacy)
+	}
+
+	if err := s.Save(cfg, path); err != nil {
+		return "", err
+	}
+	if err := s.fs.Remove(legacyPath); err != nil {
+		return "", fmt.Errorf("failed to remove legacy config: %w", err)
+	}
+	return path, nil
+}
+
+// mergeConfig fills fields left unset in dst from src. Map fields are merged
+// per key, with entries already in dst taking precedence.
+func mergeConfig(dst, src *Config) {
+	d := reflect.ValueOf(dst).Elem()
+	sv := reflect.ValueOf(src).Elem()
+	for i := range d.NumField() {
+		df, sf := d.Field(i), sv.Field(i)
+		switch {
+		case sf.IsZero():
+		case df.IsZero():
+			df.Set(sf)
+		case df.Kind() == reflect.Map:
+			for _, key := range sf.MapKeys() {
+				if !df.MapIndex(key).IsValid() {
+					df.SetMapIndex(key, sf.MapIndex(key))
+				}
+			}
+		}
+	}
+}
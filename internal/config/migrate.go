@@ -0,0 +1,216 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the config schema version this skillet reads and writes.
+const CurrentVersion = 1
+
+// migration upgrades a config document from version from to from+1.
+type migration struct {
+	from int
+	// description says what the step changes, for skillet config migrate
+	description string
+	// apply edits the top-level mapping of the document in place
+	apply func(root *yaml.Node) error
+}
+
+// migrations upgrade older configs one version at a time, in order. Each step
+// edits the YAML document rather than Config so comments and key order survive
+// the rewrite.
+var migrations = []migration{
+	{
+		from:        0,
+		description: "record the config version and default to the symlink strategy",
+		apply: func(root *yaml.Node) error {
+			setDefault(root, "defaultStrategy", string(StrategySymlink))
+			return nil
+		},
+	},
+}
+
+// MigrationResult describes the upgrade of a config file.
+type MigrationResult struct {
+	Path string
+	From int
+	To   int
+	// Steps describes each migration applied, oldest first (empty when the
+	// file is already current)
+	Steps []string
+	// Backup is the copy of the original file (empty when nothing was written)
+	Backup string
+}
+
+// NeedsMigration reports whether the config at path is older than
+// CurrentVersion.
+func (s *Store) NeedsMigration(path string) (bool, error) {
+	path, data, err := s.readConfig(path)
+	if err != nil {
+		return false, err
+	}
+	_, from, _, err := migrateData(data)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+	return from < CurrentVersion, nil
+}
+
+// MigrateFile upgrades the config at path (the global config when empty) to
+// CurrentVersion. The original file is kept next to it as
+// <path>.v<version>.bak. With dryRun set, nothing is written.
+func (s *Store) MigrateFile(path string, dryRun bool) (*MigrationResult, error) {
+	path, data, err := s.readConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	upgraded, from, steps, err := migrateData(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	result := &MigrationResult{Path: path, From: from, To: CurrentVersion, Steps: steps}
+	if len(steps) == 0 || dryRun {
+		return result, nil
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, from)
+	if err := s.fs.WriteFile(backup, data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to back up config file: %w", err)
+	}
+	if err := s.fs.WriteFile(path, upgraded, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+	result.Backup = backup
+	return result, nil
+}
+
+// migrateData upgrades config data to CurrentVersion. It returns the upgraded
+// data, the version the data had, and the descriptions of the steps applied.
+// Current data is returned unchanged.
+func migrateData(data []byte) ([]byte, int, []string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, 0, nil, fmt.Errorf("config file is not a YAML mapping")
+	}
+
+	from, err := documentVersion(root)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if from > CurrentVersion {
+		return nil, 0, nil, fmt.Errorf("config version %d is newer than this skillet supports (%d); upgrade skillet", from, CurrentVersion)
+	}
+	if from == CurrentVersion {
+		return data, from, nil, nil
+	}
+
+	var steps []string
+	for _, m := range migrations {
+		if m.from < from {
+			continue
+		}
+		if err := m.apply(root); err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to migrate config from version %d: %w", m.from, err)
+		}
+		setVersion(root, m.from+1)
+		steps = append(steps, m.description)
+	}
+
+	out, err := encodeDocument(&doc)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return out, from, steps, nil
+}
+
+// encodeDocument encodes a config document or node with the two-space
+// indentation configs are usually written with by hand.
+func encodeDocument(doc *yaml.Node) ([]byte, error) {
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// documentVersion returns the version key of a config mapping, or 0 for
+// configs written before versions were recorded.
+func documentVersion(root *yaml.Node) (int, error) {
+	_, value := mappingEntry(root, "version")
+	if value == nil {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(value.Value)
+	if err != nil || value.Kind != yaml.ScalarNode || version < 0 {
+		return 0, fmt.Errorf("invalid config version %q", value.Value)
+	}
+	return version, nil
+}
+
+// setVersion sets the version key of a config mapping, adding it as the first
+// key when missing. A comment heading the file stays at the top.
+func setVersion(root *yaml.Node, version int) {
+	if _, value := mappingEntry(root, "version"); value != nil {
+		value.Value = strconv.Itoa(version)
+		return
+	}
+	key := scalarNode("version")
+	if len(root.Content) > 0 {
+		key.HeadComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+	}
+	root.Content = append([]*yaml.Node{key, {Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(version)}}, root.Content...)
+}
+
+// setDefault adds key with value to a mapping unless it is already set.
+func setDefault(root *yaml.Node, key, value string) {
+	if k, _ := mappingEntry(root, key); k != nil {
+		return
+	}
+	root.Content = append(root.Content, scalarNode(key), scalarNode(value))
+}
+
+// renameKey renames oldKey of a mapping to newKey, keeping its value, comments,
+// and position. A value already set under newKey wins and oldKey is dropped.
+func renameKey(root *yaml.Node, oldKey, newKey string) {
+	k, _ := mappingEntry(root, oldKey)
+	if k == nil {
+		return
+	}
+	if existing, _ := mappingEntry(root, newKey); existing != nil {
+		for i := 0; i < len(root.Content); i += 2 {
+			if root.Content[i] == k {
+				root.Content = append(root.Content[:i], root.Content[i+2:]...)
+				return
+			}
+		}
+	}
+	k.Value = newKey
+}
+
+// mappingEntry returns the key and value nodes of key in a mapping, or nils.
+func mappingEntry(root *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			return root.Content[i], root.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+func TestMigrationsAreStepwise(t *testing.T) {
+	if len(migrations) != CurrentVersion {
+		t.Fatalf("%d migrations for CurrentVersion %d", len(migrations), CurrentVersion)
+	}
+	for i, m := range migrations {
+		if m.from != i {
+			t.Errorf("migrations[%d].from = %d, want %d", i, m.from, i)
+		}
+	}
+}
+
+func TestMigrateData(t *testing.T) {
+	t.Run("unversioned config", func(t *testing.T) {
+		data := []byte("# my settings\nglobalPath: ~/skills\ntargets:\n  claude:\n    enabled: true\n")
+		out, from, steps, err := migrateData(data)
+		if err != nil {
+			t.Fatalf("migrateData() error = %v", err)
+		}
+		if from != 0 || len(steps) != 1 {
+			t.Errorf("migrateData() from = %d, steps = %v", from, steps)
+		}
+		want := "# my settings\nversion: 1\nglobalPath: ~/skills\ntargets:\n  claude:\n    enabled: true\ndefaultStrategy: symlink\n"
+		if string(out) != want {
+			t.Errorf("migrateData() =\n%s\nwant\n%s", out, want)
+		}
+	})
+
+	t.Run("current config is unchanged", func(t *testing.T) {
+		data := []byte("version: 1\ndefaultStrategy:   copy\n")
+		out, from, steps, err := migrateData(data)
+		if err != nil {
+			t.Fatalf("migrateData() error = %v", err)
+		}
+		if from != CurrentVersion || len(steps) != 0 || string(out) != string(data) {
+			t.Errorf("migrateData() = %q, %d, %v", out, from, steps)
+		}
+	})
+
+	t.Run("empty config", func(t *testing.T) {
+		out, _, _, err := migrateData(nil)
+		if err != nil {
+			t.Fatalf("migrateData() error = %v", err)
+		}
+		if string(out) != "version: 1\ndefaultStrategy: symlink\n" {
+			t.Errorf("migrateData() = %q", out)
+		}
+	})
+
+	t.Run("newer config", func(t *testing.T) {
+		_, _, _, err := migrateData([]byte("version: 99\n"))
+		if err == nil || !strings.Contains(err.Error(), "newer") {
+			t.Errorf("migrateData() error = %v, want newer version error", err)
+		}
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		if _, _, _, err := migrateData([]byte("version: one\n")); err == nil {
+			t.Error("migrateData() expected error for invalid version")
+		}
+	})
+
+	t.Run("steps run in order", func(t *testing.T) {
+		saved := migrations
+		t.Cleanup(func() { migrations = saved })
+		migrations = append(saved[:len(saved):len(saved)], migration{
+			from:        CurrentVersion,
+			description: "rename globalPath",
+			apply: func(root *yaml.Node) error {
+				renameKey(root, "globalPath", "storePath")
+				return nil
+			},
+		})
+
+		// With the extra step in place, configs are one version behind.
+		out, from, steps, err := migrateData([]byte("globalPath: ~/skills # store\n"))
+		if err != nil {
+			t.Fatalf("migrateData() error = %v", err)
+		}
+		if from != 0 || len(steps) != 2 || steps[1] != "rename globalPath" {
+			t.Errorf("migrateData() from = %d, steps = %v", from, steps)
+		}
+		want := "version: 2\nstorePath: ~/skills # store\ndefaultStrategy: symlink\n"
+		if string(out) != want {
+			t.Errorf("migrateData() =\n%s\nwant\n%s", out, want)
+		}
+	})
+}
+
+func TestRenameKey(t *testing.T) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte("a: 1\nb: 2\n"), &doc); err != nil {
+		t.Fatal(err)
+	}
+	root := doc.Content[0]
+
+	renameKey(root, "a", "b")
+	if k, v := mappingEntry(root, "b"); k == nil || v.Value != "2" || len(root.Content) != 2 {
+		t.Errorf("renameKey() onto an existing key should keep its value, got %d nodes", len(root.Content))
+	}
+	renameKey(root, "b", "c")
+	if k, v := mappingEntry(root, "c"); k == nil || v.Value != "2" {
+		t.Error("renameKey() did not rename b to c")
+	}
+}
+
+func TestStoreMigrateFile(t *testing.T) {
+	const path = "/home/test/.config/skillet/config.yaml"
+	original := []byte("defaultStrategy: copy\n")
+
+	t.Run("writes backup and upgraded config", func(t *testing.T) {
+		mock := platformfs.NewMockFileSystem()
+		mock.Dirs["/home/test/.config/skillet"] = true
+		mock.Files[path] = original
+
+		cs := NewStore(mock)
+		result, err := cs.MigrateFile("", false)
+		if err != nil {
+			t.Fatalf("MigrateFile() error = %v", err)
+		}
+		if result.From != 0 || result.To != CurrentVersion || result.Backup != path+".v0.bak" {
+			t.Errorf("MigrateFile() = %+v", result)
+		}
+		if string(mock.Files[path+".v0.bak"]) != string(original) {
+			t.Errorf("backup = %q", mock.Files[path+".v0.bak"])
+		}
+		if string(mock.Files[path]) != "version: 1\ndefaultStrategy: copy\n" {
+			t.Errorf("config = %q", mock.Files[path])
+		}
+
+		result, err = cs.MigrateFile(path, false)
+		if err != nil || len(result.Steps) != 0 || result.Backup != "" {
+			t.Errorf("second MigrateFile() = %+v, %v", result, err)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		mock := platformfs.NewMockFileSystem()
+		mock.Dirs["/home/test/.config/skillet"] = true
+		mock.Files[path] = original
+
+		result, err := NewStore(mock).MigrateFile(path, true)
+		if err != nil {
+			t.Fatalf("MigrateFile() error = %v", err)
+		}
+		if len(result.Steps) != 1 || result.Backup != "" {
+			t.Errorf("MigrateFile() = %+v", result)
+		}
+		if string(mock.Files[path]) != string(original) || mock.Exists(path+".v0.bak") {
+			t.Error("dry run should not write files")
+		}
+	})
+
+	t.Run("load migrates in memory", func(t *testing.T) {
+		mock := platformfs.NewMockFileSystem()
+		mock.Dirs["/home/test/.config/skillet"] = true
+		mock.Files[path] = []byte("globalPath: ~/skills\n")
+
+		cfg, err := NewStore(mock).Load(path)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.Version != CurrentVersion || cfg.DefaultStrategy != StrategySymlink {
+			t.Errorf("Load() = %+v", cfg)
+		}
+		if string(mock.Files[path]) != "globalPath: ~/skills\n" {
+			t.Error("Load() should not rewrite the file")
+		}
+	})
+}
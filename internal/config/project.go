@@ -0,0 +1,148 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFileName is the name of the project config file in a
+// project's .agents directory.
+const ProjectConfigFileName = "skillet.yaml"
+
+// projectConfigVersion is the project config schema version this skillet reads.
+const projectConfigVersion = 1
+
+// ProjectConfig holds the settings a project overrides for commands run
+// inside it. It is merged over the global config with WithProject.
+type ProjectConfig struct {
+	Version int `yaml:"version"`
+	// Strategy is the strategy for the project's skills. It takes precedence
+	// over strategyByScope.project in the global config.
+	Strategy Strategy `yaml:"strategy,omitempty"`
+	// Targets overrides targets by name. Targets the global config does not
+	// list can be enabled here.
+	Targets map[string]ProjectTargetConfig `yaml:"targets,omitempty"`
+}
+
+// ProjectTargetConfig overrides the settings of one target in a project.
+type ProjectTargetConfig struct {
+	// Enabled turns the target on or off in the project (unset keeps the
+	// global setting)
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// ProjectPath is the target root relative to the project root.
+	ProjectPath string `yaml:"projectPath,omitempty"`
+	// SkillsDir is the skills directory relative to the project's target root.
+	SkillsDir string `yaml:"skillsDir,omitempty"`
+}
+
+// ProjectConfigPath returns the path of the project config file of a project root.
+func ProjectConfigPath(projectRoot string, fsys PathFS) string {
+	return fsys.Join(ProjectAgentsDir(projectRoot, fsys), ProjectConfigFileName)
+}
+
+// LoadProject reads the project config of projectRoot. It returns nil when
+// the project has none.
+func (s *Store) LoadProject(projectRoot string) (*ProjectConfig, error) {
+	path := ProjectConfigPath(projectRoot, s.fs)
+	if !s.fs.Exists(path) {
+		return nil, nil
+	}
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project config: %w", err)
+	}
+
+	var cfg ProjectConfig
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse project config %s: %w", path, err)
+	}
+	if cfg.Version > projectConfigVersion {
+		return nil, fmt.Errorf("%s: project config version %d is newer than this skillet supports (%d); upgrade skillet", path, cfg.Version, projectConfigVersion)
+	}
+	if cfg.Strategy != "" && !cfg.Strategy.Valid() {
+		return nil, fmt.Errorf("%s: strategy %q is not a strategy (symlink, copy, or hardlink)", path, cfg.Strategy)
+	}
+	if err := s.checkProjectPaths(projectRoot, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// checkProjectPaths rejects target paths that leave the project. A project
+// config is checked in with the project, so a cloned repository must not be
+// able to make sync write to, or prune from, directories elsewhere.
+func (s *Store) checkProjectPaths(projectRoot string, cfg *ProjectConfig) error {
+	for _, name := range slices.Sorted(maps.Keys(cfg.Targets)) {
+		pt := cfg.Targets[name]
+		if !insideProject(pt.ProjectPath) {
+			return fmt.Errorf("targets.%s.projectPath %q is not a relative path inside the project", name, pt.ProjectPath)
+		}
+		if !insideProject(pt.SkillsDir) {
+			return fmt.Errorf("targets.%s.skillsDir %q is not a relative path inside the project", name, pt.SkillsDir)
+		}
+		if pt.ProjectPath == "" && pt.SkillsDir == "" {
+			continue
+		}
+		rel, err := s.fs.Rel(projectRoot, s.fs.Join(projectRoot, pt.ProjectPath, pt.SkillsDir))
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("targets.%s: skills directory %q is not inside the project", name, s.fs.Join(pt.ProjectPath, pt.SkillsDir))
+		}
+	}
+	return nil
+}
+
+// insideProject reports whether p, a path from a project config, is empty or
+// relative without .. elements, in either separator style.
+func insideProject(p string) bool {
+	if p == "" {
+		return true
+	}
+	if filepath.IsAbs(p) || filepath.VolumeName(p) != "" || strings.HasPrefix(p, "/") || strings.HasPrefix(p, `\`) {
+		return false
+	}
+	return !slices.Contains(strings.FieldsFunc(p, func(r rune) bool { return r == '/' || r == '\\' }), "..")
+}
+
+// WithProject returns a copy of c with the overrides of a project config
+// applied. The strategy applies to project skills only, and a target's
+// skills directory to its project root only, so global installs do not change
+// between runs inside and outside the project. c is not modified.
+func (c *Config) WithProject(p *ProjectConfig) *Config {
+	merged := *c
+	merged.Targets = maps.Clone(c.Targets)
+	if merged.Targets == nil {
+		merged.Targets = make(map[string]TargetConfig, len(p.Targets))
+	}
+	if p.Strategy != "" {
+		merged.StrategyByScope = maps.Clone(c.StrategyByScope)
+		if merged.StrategyByScope == nil {
+			merged.StrategyByScope = make(map[string]Strategy, 1)
+		}
+		merged.StrategyByScope["project"] = p.Strategy
+	}
+
+	for name, pt := range p.Targets {
+		tc := merged.Targets[name]
+		if pt.Enabled != nil {
+			tc.Enabled = *pt.Enabled
+		}
+		if pt.ProjectPath != "" {
+			tc.ProjectPath = pt.ProjectPath
+		}
+		if pt.SkillsDir != "" {
+			tc.ProjectSkillsDir = pt.SkillsDir
+		}
+		merged.Targets[name] = tc
+	}
+	return &merged
+}
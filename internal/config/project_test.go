@@ -0,0 +1,82 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+func TestStoreLoadProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "valid", data: "version: 1\nstrategy: copy\ntargets:\n  codex:\n    enabled: false\n"},
+		{name: "global key", data: "version: 1\ndefaultStrategy: copy\n", wantErr: "defaultStrategy not found"},
+		{name: "bad strategy", data: "strategy: junction\n", wantErr: "not a strategy"},
+		{name: "newer version", data: "version: 2\n", wantErr: "newer"},
+		{name: "project path outside", data: "targets:\n  claude:\n    projectPath: ../victim\n", wantErr: "targets.claude.projectPath"},
+		{name: "absolute project path", data: "targets:\n  claude:\n    projectPath: /etc\n", wantErr: "targets.claude.projectPath"},
+		{name: "skills dir outside", data: "targets:\n  claude:\n    skillsDir: skills/../../../victim\n", wantErr: "targets.claude.skillsDir"},
+		{name: "backslash skills dir", data: "targets:\n  codex:\n    skillsDir: '..\\victim'\n", wantErr: "targets.codex.skillsDir"},
+		{name: "skills dir at the root", data: "targets:\n  claude:\n    projectPath: .\n    skillsDir: .\n", wantErr: "not inside the project"},
+		{name: "nested paths", data: "targets:\n  claude:\n    projectPath: tools/claude\n    skillsDir: commands\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := platformfs.NewMockFileSystem()
+			mock.Dirs["/project/.agents"] = true
+			mock.Files["/project/.agents/skillet.yaml"] = []byte(tt.data)
+
+			cfg, err := NewStore(mock).LoadProject("/project")
+			if tt.wantErr == "" {
+				if err != nil || cfg == nil {
+					t.Fatalf("LoadProject() = %v, %v", cfg, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadProject() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("no project config", func(t *testing.T) {
+		cfg, err := NewStore(platformfs.NewMockFileSystem()).LoadProject("/project")
+		if cfg != nil || err != nil {
+			t.Errorf("LoadProject() = %v, %v; want nil, nil", cfg, err)
+		}
+	})
+}
+
+func TestConfigWithProject(t *testing.T) {
+	global := DefaultConfig()
+	global.StrategyByScope = map[string]Strategy{"global": StrategySymlink}
+	enabled := true
+	project := &ProjectConfig{
+		Strategy: StrategyCopy,
+		Targets: map[string]ProjectTargetConfig{
+			"claude": {SkillsDir: "commands"},
+			"cursor": {Enabled: &enabled},
+		},
+	}
+
+	merged := global.WithProject(project)
+	if merged.StrategyFor("project") != StrategyCopy || merged.StrategyFor("global") != StrategySymlink {
+		t.Errorf("merged strategies = %v", merged.StrategyByScope)
+	}
+	claude := merged.Targets["claude"]
+	if !claude.Enabled || claude.GlobalPath != "~/.claude" || claude.ProjectSkillsDir != "commands" || claude.SkillsDir != "" {
+		t.Errorf("merged claude = %+v", claude)
+	}
+	if !merged.Targets["cursor"].Enabled {
+		t.Error("cursor should be enabled by the project config")
+	}
+
+	if global.Targets["cursor"].Enabled || global.StrategyByScope["project"] != "" || global.Targets["claude"].ProjectSkillsDir != "" {
+		t.Error("WithProject() modified the global config")
+	}
+}
@@ -12,7 +12,8 @@ import (
 
 // Store manages config file persistence.
 type Store struct {
-	fs platformfs.FileSystem
+	fs        platformfs.FileSystem
+	discovery ProjectDiscovery
 }
 
 // NewStore creates a new Store.
@@ -20,8 +21,30 @@ func NewStore(fsys platformfs.FileSystem) *Store {
 	return &Store{fs: fsys}
 }
 
-// Load loads the configuration from a file.
+// Load loads the configuration from a file. Configs older than
+// CurrentVersion are migrated in memory; the file is left as is.
 func (s *Store) Load(path string) (*Config, error) {
+	path, data, err := s.readConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, _, err = migrateData(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// readConfig expands path (the global config path when empty) and reads the
+// config file there.
+func (s *Store) readConfig(path string) (string, []byte, error) {
 	var err error
 	if path == "" {
 		path, err = s.GlobalConfigPath()
@@ -29,24 +52,18 @@ func (s *Store) Load(path string) (*Config, error) {
 		path, err = ExpandPath(s.fs, path)
 	}
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 
 	if !s.fs.Exists(path) {
-		return nil, fmt.Errorf("config file not found: %s", path)
+		return "", nil, fmt.Errorf("config file not found: %s", path)
 	}
 
 	data, err := s.fs.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
-
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+		return "", nil, fmt.Errorf("failed to read config file: %w", err)
 	}
-
-	return &cfg, nil
+	return path, data, nil
 }
 
 // Save saves the configuration to a specific path.
@@ -73,6 +90,11 @@ func (s *Store) GlobalConfigPath() (string, error) {
 	return GlobalConfigPath(s.fs)
 }
 
+// SetProjectDiscovery sets the options used when searching for the project root.
+func (s *Store) SetProjectDiscovery(d ProjectDiscovery) {
+	s.discovery = d
+}
+
 // FindProjectRoot searches for the project root by looking for .agents directory.
 func (s *Store) FindProjectRoot() (string, error) {
 	cwd, err := os.Getwd()
@@ -83,9 +105,23 @@ func (s *Store) FindProjectRoot() (string, error) {
 }
 
 // FindProjectRootFrom searches for the project root starting from the given directory.
+// The search stops at ceiling directories (including the home directory unless
+// AllowHome is set) and after MaxDepth parents when a limit is configured.
 func (s *Store) FindProjectRootFrom(startDir string) (string, error) {
-	dir := startDir
-	for {
+	ceilings, err := s.ceilingDirs()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Clean(startDir)
+	for depth := 0; ; depth++ {
+		if ceilings[dir] {
+			return "", fmt.Errorf("project root not found (no %s directory below %s)", AgentsDirName, dir)
+		}
+		if s.discovery.MaxDepth > 0 && depth > s.discovery.MaxDepth {
+			return "", fmt.Errorf("project root not found (no %s directory within %d parent directories)", AgentsDirName, s.discovery.MaxDepth)
+		}
+
 		agentsPath := s.fs.Join(dir, AgentsDirName)
 		if s.fs.Exists(agentsPath) && s.fs.IsDir(agentsPath) {
 			return dir, nil
@@ -98,3 +134,26 @@ func (s *Store) FindProjectRootFrom(startDir string) (string, error) {
 		dir = parent
 	}
 }
+
+// ceilingDirs returns the set of cleaned directories the search must not enter.
+func (s *Store) ceilingDirs() (map[string]bool, error) {
+	ceilings := make(map[string]bool, len(s.discovery.CeilingDirs)+1)
+
+	if !s.discovery.AllowHome {
+		if home, err := s.fs.UserHomeDir(); err == nil && home != "" {
+			ceilings[filepath.Clean(home)] = true
+		}
+	}
+
+	for _, dir := range s.discovery.CeilingDirs {
+		expanded, err := ExpandPath(s.fs, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand ceiling directory %s: %w", dir, err)
+		}
+		if expanded != "" {
+			ceilings[filepath.Clean(expanded)] = true
+		}
+	}
+
+	return ceilings, nil
+}
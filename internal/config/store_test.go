@@ -104,4 +104,143 @@ func TestStoreFindProjectRootFrom(t *testing.T) {
 			t.Error("FindProjectRootFrom() expected error when no project root, got nil")
 		}
 	})
+	t.Run("home directory is a ceiling by default", func(t *testing.T) {
+		mock := platformfs.NewMockFileSystem()
+		mock.HomeDir = "/home/test"
+		mock.Dirs["/home/test/.agents"] = true
+		mock.Dirs["/home/test/work/repo"] = true
+
+		cs := NewStore(mock)
+		if root, err := cs.FindProjectRootFrom("/home/test/work/repo"); err == nil {
+			t.Errorf("FindProjectRootFrom() = %v, want error at home ceiling", root)
+		}
+
+		cs.SetProjectDiscovery(ProjectDiscovery{AllowHome: true})
+		root, err := cs.FindProjectRootFrom("/home/test/work/repo")
+		if err != nil {
+			t.Fatalf("FindProjectRootFrom() with AllowHome error = %v", err)
+		}
+		if root != "/home/test" {
+			t.Errorf("FindProjectRootFrom() = %v, want /home/test", root)
+		}
+	})
+
+	t.Run("configured ceiling and max depth", func(t *testing.T) {
+		mock := platformfs.NewMockFileSystem()
+		mock.Dirs["/srv/.agents"] = true
+		mock.Dirs["/srv/repos/app/src"] = true
+
+		cs := NewStore(mock)
+		cs.SetProjectDiscovery(ProjectDiscovery{CeilingDirs: []string{"/srv/repos"}})
+		if _, err := cs.FindProjectRootFrom("/srv/repos/app/src"); err == nil {
+			t.Error("FindProjectRootFrom() expected error at configured ceiling, got nil")
+		}
+
+		cs.SetProjectDiscovery(ProjectDiscovery{MaxDepth: 2})
+		if _, err := cs.FindProjectRootFrom("/srv/repos/app/src"); err == nil {
+			t.Error("FindProjectRootFrom() expected error beyond max depth, got nil")
+		}
+
+		cs.SetProjectDiscovery(ProjectDiscovery{MaxDepth: 3})
+		root, err := cs.FindProjectRootFrom("/srv/repos/app/src")
+		if err != nil {
+			t.Fatalf("FindProjectRootFrom() within max depth error = %v", err)
+		}
+		if root != "/srv" {
+			t.Errorf("FindProjectRootFrom() = %v, want /srv", root)
+		}
+	})
+}
+
+func TestStrategyFor(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.StrategyByScope = map[string]Strategy{"global": StrategyCopy}
+
+	tests := map[string]Strategy{
+		"global":  StrategyCopy,
+		"org":     StrategyCopy,
+		"system":  StrategyCopy,
+		"project": StrategySymlink,
+	}
+	for scope, want := range tests {
+		if got := cfg.StrategyFor(scope); got != want {
+			t.Errorf("StrategyFor(%q) = %v, want %v", scope, got, want)
+		}
+	}
+
+	cfg.StrategyByScope = nil
+	cfg.DefaultStrategy = ""
+	if got := cfg.StrategyFor("project"); got != StrategySymlink {
+		t.Errorf("StrategyFor() without config = %v, want symlink", got)
+	}
+}
+
+func TestStoreMigrateLegacy(t *testing.T) {
+	t.Run("move", func(t *testing.T) {
+		mock := platformfs.NewMockFileSystem()
+		mock.Dirs["/home/test/.agents"] = true
+		mock.Files["/home/test/.agents/skillet.yaml"] = []byte("version: 1\ndefaultStrategy: copy\n")
+
+		cs := NewStore(mock)
+		path, err := cs.MigrateLegacy()
+		if err != nil {
+			t.Fatalf("MigrateLegacy() error = %v", err)
+		}
+		if path != "/home/test/.config/skillet/config.yaml" {
+			t.Errorf("MigrateLegacy() = %s", path)
+		}
+		if mock.Exists("/home/test/.agents/skillet.yaml") {
+			t.Error("legacy config should be removed")
+		}
+		cfg, err := cs.Load("")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.DefaultStrategy != StrategyCopy {
+			t.Errorf("DefaultStrategy = %v, want copy", cfg.DefaultStrategy)
+		}
+	})
+
+	t.Run("merge keeps existing settings", func(t *testing.T) {
+		mock := platformfs.NewMockFileSystem()
+		mock.Dirs["/home/test/.agents"] = true
+		mock.Files["/home/test/.agents/skillet.yaml"] = []byte(`version: 1
+defaultStrategy: copy
+orgPath: ~/org
+targets:
+  claude:
+    enabled: false
+  codex:
+    enabled: true
+`)
+		mock.Dirs["/home/test/.config/skillet"] = true
+		mock.Files["/home/test/.config/skillet/config.yaml"] = []byte(`version: 1
+defaultStrategy: symlink
+targets:
+  claude:
+    enabled: true
+`)
+
+		cs := NewStore(mock)
+		if _, err := cs.MigrateLegacy(); err != nil {
+			t.Fatalf("MigrateLegacy() error = %v", err)
+		}
+		cfg, err := cs.Load("")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.DefaultStrategy != StrategySymlink || cfg.OrgPath != "~/org" {
+			t.Errorf("merged config = %+v", cfg)
+		}
+		if !cfg.Targets["claude"].Enabled || !cfg.Targets["codex"].Enabled {
+			t.Errorf("merged targets = %+v", cfg.Targets)
+		}
+	})
+
+	t.Run("no legacy config", func(t *testing.T) {
+		cs := NewStore(platformfs.NewMockFileSystem())
+		if _, err := cs.MigrateLegacy(); err == nil {
+			t.Error("MigrateLegacy() expected error without legacy config")
+		}
+	})
 }
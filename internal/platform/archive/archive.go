@@ -0,0 +1,203 @@
+// Package archive reads and writes tar.gz and zip archives of regular files.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Format is an archive format.
+type Format string
+
+const (
+	TarGz Format = "tar.gz"
+	Zip   Format = "zip"
+)
+
+// File is a regular file in an archive.
+type File struct {
+	Data []byte
+	// Mode holds the file's permission bits. Write stores 0o644 for a zero
+	// mode, and Read reports 0o644 for entries that record none.
+	Mode fs.FileMode
+}
+
+// MaxSize is the largest total uncompressed size Read accepts.
+const MaxSize = 256 << 20
+
+// FormatFor returns the format of an archive file from its name.
+func FormatFor(name string) (Format, error) {
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return TarGz, nil
+	case strings.HasSuffix(name, ".zip"):
+		return Zip, nil
+	default:
+		return "", fmt.Errorf("unknown archive format for %s (use .tar.gz, .tgz, or .zip)", name)
+	}
+}
+
+// Write writes files, keyed by slash-separated relative path, to w in path
+// order with their permission bits. Entries get a fixed modification time, so
+// the same files always produce the same archive.
+func Write(w io.Writer, format Format, files map[string]File) error {
+	names := slices.Sorted(maps.Keys(files))
+	for _, name := range names {
+		if err := checkName(name); err != nil {
+			return err
+		}
+	}
+
+	switch format {
+	case TarGz:
+		gz := gzip.NewWriter(w)
+		tw := tar.NewWriter(gz)
+		for _, name := range names {
+			hdr := &tar.Header{
+				Name:     name,
+				Mode:     int64(perm(files[name].Mode)),
+				Size:     int64(len(files[name].Data)),
+				ModTime:  time.Unix(0, 0),
+				Typeflag: tar.TypeReg,
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := tw.Write(files[name].Data); err != nil {
+				return err
+			}
+		}
+		if err := tw.Close(); err != nil {
+			return err
+		}
+		return gz.Close()
+	case Zip:
+		zw := zip.NewWriter(w)
+		for _, name := range names {
+			hdr := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Unix(0, 0).UTC()}
+			hdr.SetMode(perm(files[name].Mode))
+			f, err := zw.CreateHeader(hdr)
+			if err != nil {
+				return err
+			}
+			if _, err := f.Write(files[name].Data); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	default:
+		return fmt.Errorf("unknown archive format %q", format)
+	}
+}
+
+// Read returns the files in an archive, keyed by slash-separated relative
+// path, with their permission bits. It rejects links and other special entries, paths that are absolute
+// or contain "..", duplicate paths, and archives larger than MaxSize once
+// uncompressed. Directory entries are skipped.
+func Read(data []byte, format Format) (map[string]File, error) {
+	files := make(map[string]File)
+	var total int64
+	add := func(name string, mode fs.FileMode, r io.Reader) error {
+		name = strings.TrimPrefix(name, "./")
+		if err := checkName(name); err != nil {
+			return err
+		}
+		if _, ok := files[name]; ok {
+			return fmt.Errorf("duplicate archive entry: %s", name)
+		}
+		content, err := io.ReadAll(io.LimitReader(r, MaxSize-total+1))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		total += int64(len(content))
+		if total > MaxSize {
+			return fmt.Errorf("archive is larger than %d MiB uncompressed", MaxSize>>20)
+		}
+		files[name] = File{Data: content, Mode: perm(mode)}
+		return nil
+	}
+
+	switch format {
+	case TarGz:
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("not a gzip archive: %w", err)
+		}
+		tr := tar.NewReader(gz)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read archive: %w", err)
+			}
+			switch hdr.Typeflag {
+			case tar.TypeDir:
+				continue
+			case tar.TypeReg:
+				if err := add(hdr.Name, hdr.FileInfo().Mode(), tr); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, fmt.Errorf("unsupported archive entry %s: only regular files are allowed", hdr.Name)
+			}
+		}
+	case Zip:
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("not a zip archive: %w", err)
+		}
+		for _, f := range zr.File {
+			mode := f.Mode()
+			if mode.IsDir() {
+				continue
+			}
+			if !mode.IsRegular() {
+				return nil, fmt.Errorf("unsupported archive entry %s: only regular files are allowed", f.Name)
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+			}
+			err = add(f.Name, mode, rc)
+			_ = rc.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown archive format %q", format)
+	}
+	return files, nil
+}
+
+// perm returns the permission bits of mode, or 0o644 when it has none.
+func perm(mode fs.FileMode) fs.FileMode {
+	if mode.Perm() == 0 {
+		return 0o644
+	}
+	return mode.Perm()
+}
+
+// checkName rejects entry paths that could escape the extraction directory.
+func checkName(name string) error {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") || path.Clean(name) != name {
+		return fmt.Errorf("invalid archive entry path: %q", name)
+	}
+	if slices.Contains(strings.Split(name, "/"), "..") {
+		return fmt.Errorf("archive entry escapes its directory: %s", name)
+	}
+	return nil
+}
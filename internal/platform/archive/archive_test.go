@@ -0,0 +1,82 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	files := map[string]File{
+		"skillet-pack.yaml":          {Data: []byte("version: 1\n"), Mode: 0o644},
+		"skills/demo/SKILL.md":       {Data: []byte("---\nname: demo\n---\n"), Mode: 0o644},
+		"skills/demo/docs/ref.md":    {Data: []byte("notes\n"), Mode: 0o600},
+		"skills/demo/scripts/run.sh": {Data: []byte{}, Mode: 0o755},
+	}
+	for _, format := range []Format{TarGz, Zip} {
+		var first, second bytes.Buffer
+		if err := Write(&first, format, files); err != nil {
+			t.Fatalf("Write(%s) error = %v", format, err)
+		}
+		if err := Write(&second, format, files); err != nil {
+			t.Fatalf("Write(%s) error = %v", format, err)
+		}
+		if !bytes.Equal(first.Bytes(), second.Bytes()) {
+			t.Errorf("Write(%s) is not reproducible", format)
+		}
+
+		got, err := Read(first.Bytes(), format)
+		if err != nil {
+			t.Fatalf("Read(%s) error = %v", format, err)
+		}
+		if !maps.EqualFunc(got, files, func(a, b File) bool { return bytes.Equal(a.Data, b.Data) && a.Mode == b.Mode }) {
+			t.Errorf("Read(%s) = %v, want %v", format, got, files)
+		}
+		if mode := got["skills/demo/scripts/run.sh"].Mode; mode != 0o755 {
+			t.Errorf("Read(%s) scripts/run.sh mode = %v, want it to stay executable", format, mode)
+		}
+	}
+}
+
+func TestReadRejectsUnsafeEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		hdr  tar.Header
+		want string
+	}{
+		{"parent", tar.Header{Name: "skills/../../etc/passwd", Typeflag: tar.TypeReg}, "invalid archive entry"},
+		{"absolute", tar.Header{Name: "/etc/passwd", Typeflag: tar.TypeReg}, "invalid archive entry"},
+		{"symlink", tar.Header{Name: "skills/demo/link", Typeflag: tar.TypeSymlink, Linkname: "/etc"}, "only regular files"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			if err := tw.WriteHeader(&tt.hdr); err != nil {
+				t.Fatal(err)
+			}
+			_ = tw.Close()
+			_ = gz.Close()
+
+			_, err := Read(buf.Bytes(), TarGz)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Read() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFor(t *testing.T) {
+	for name, want := range map[string]Format{"a.tar.gz": TarGz, "a.tgz": TarGz, "dir/a.zip": Zip} {
+		if got, err := FormatFor(name); err != nil || got != want {
+			t.Errorf("FormatFor(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := FormatFor("a.rar"); err == nil {
+		t.Error("FormatFor(a.rar) should fail")
+	}
+}
@@ -0,0 +1,176 @@
+// Package diff compares texts line by line and formats the differences as
+// unified diffs.
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Context is the number of unchanged lines shown around each change.
+const Context = 3
+
+type op int
+
+const (
+	opEqual op = iota
+	opDelete
+	opInsert
+)
+
+// edit is one line of a diff: kept, deleted from the old text, or inserted
+// from the new one. text keeps its line ending.
+type edit struct {
+	op   op
+	text string
+}
+
+// IsBinary reports whether data looks like binary content, which Unified
+// does not diff line by line.
+func IsBinary(data []byte) bool {
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// Unified returns the unified diff turning before into after, with oldName
+// and newName in the --- and +++ headers, or an empty string when they are
+// equal.
+func Unified(oldName, newName string, before, after []byte) string {
+	if bytes.Equal(before, after) {
+		return ""
+	}
+	edits := lineEdits(splitLines(before), splitLines(after))
+
+	// oldPos and newPos count the lines of each text before edits[i].
+	oldPos := make([]int, len(edits)+1)
+	newPos := make([]int, len(edits)+1)
+	for i, e := range edits {
+		oldPos[i+1], newPos[i+1] = oldPos[i], newPos[i]
+		if e.op != opInsert {
+			oldPos[i+1]++
+		}
+		if e.op != opDelete {
+			newPos[i+1]++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for i := 0; i < len(edits); {
+		for i < len(edits) && edits[i].op == opEqual {
+			i++
+		}
+		if i == len(edits) {
+			break
+		}
+		// A hunk runs until a stretch of unchanged lines too long to share
+		// context with the next change.
+		start := max(i-Context, 0)
+		last := i
+		for j := i; j < len(edits) && j-last <= 2*Context+1; j++ {
+			if edits[j].op != opEqual {
+				last = j
+			}
+		}
+		end := min(last+Context+1, len(edits))
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			hunkRange(oldPos[start], oldPos[end]-oldPos[start]),
+			hunkRange(newPos[start], newPos[end]-newPos[start]))
+		for _, e := range edits[start:end] {
+			b.WriteString([]string{" ", "-", "+"}[e.op])
+			b.WriteString(e.text)
+			if !strings.HasSuffix(e.text, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// hunkRange formats the lines of one side of a hunk: count lines after the
+// first pos lines.
+func hunkRange(pos, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", pos)
+	case 1:
+		return fmt.Sprintf("%d", pos+1)
+	default:
+		return fmt.Sprintf("%d,%d", pos+1, count)
+	}
+}
+
+// splitLines splits data after each newline. The last line has no newline
+// when data does not end with one.
+func splitLines(data []byte) []string {
+	lines := strings.SplitAfter(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// lineEdits returns a shortest edit script from a to b, using Myers'
+// algorithm.
+func lineEdits(a, b []string) []edit {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, slices.Clone(v))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk back from the end through the furthest points of each round.
+	var edits []edit
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			edits = append(edits, edit{opEqual, a[x]})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, edit{opInsert, b[y]})
+		} else {
+			x--
+			edits = append(edits, edit{opDelete, a[x]})
+		}
+	}
+	for x > 0 {
+		x--
+		edits = append(edits, edit{opEqual, a[x]})
+	}
+	slices.Reverse(edits)
+	return edits
+}
@@ -0,0 +1,79 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// maxDownloadSize bounds the size of a downloaded document.
+const maxDownloadSize = 32 << 20
+
+// Downloader retrieves the content of a URL. Canceling ctx stops the download.
+type Downloader interface {
+	Download(ctx context.Context, url string) ([]byte, error)
+}
+
+// HTTPDownloader downloads http(s) URLs and reads file:// URLs from disk.
+type HTTPDownloader struct {
+	// Client is the HTTP client to use (nil for one with a 30 second timeout)
+	Client *http.Client
+}
+
+func (d HTTPDownloader) Download(ctx context.Context, url string) ([]byte, error) {
+	if path, ok := strings.CutPrefix(url, "file://"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", url, err)
+		}
+		return data, nil
+	}
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return nil, fmt.Errorf("unsupported URL %q: use https://, http://, or file://", url)
+	}
+
+	client := d.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("failed to download %s: larger than %d MiB", url, maxDownloadSize>>20)
+	}
+	return data, nil
+}
+
+// OfflineDownloader refuses every download without touching the network.
+type OfflineDownloader struct{}
+
+func (OfflineDownloader) Download(_ context.Context, url string) ([]byte, error) {
+	return nil, fmt.Errorf("cannot download %s: %w", url, ErrOffline)
+}
+
+// GuardDownloader returns d, or an OfflineDownloader when offline is true.
+// Every network-capable downloader should be obtained through GuardDownloader.
+func GuardDownloader(d Downloader, offline bool) Downloader {
+	if offline {
+		return OfflineDownloader{}
+	}
+	return d
+}
@@ -0,0 +1,32 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrOffline is returned by fetchers when network access is disabled.
+var ErrOffline = errors.New("network access is disabled in offline mode")
+
+// Fetcher retrieves a remote skill source into a local directory.
+// Canceling ctx stops the fetch.
+type Fetcher interface {
+	Fetch(ctx context.Context, source, dest string) error
+}
+
+// OfflineFetcher refuses every fetch without touching the network.
+type OfflineFetcher struct{}
+
+func (OfflineFetcher) Fetch(_ context.Context, source, _ string) error {
+	return fmt.Errorf("cannot fetch %s: %w", source, ErrOffline)
+}
+
+// Guard returns f, or an OfflineFetcher when offline is true.
+// Every network-capable fetcher should be obtained through Guard.
+func Guard(f Fetcher, offline bool) Fetcher {
+	if offline {
+		return OfflineFetcher{}
+	}
+	return f
+}
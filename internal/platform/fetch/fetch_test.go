@@ -0,0 +1,64 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recordingFetcher struct {
+	called bool
+}
+
+func (r *recordingFetcher) Fetch(context.Context, string, string) error {
+	r.called = true
+	return nil
+}
+
+func TestGuardOffline(t *testing.T) {
+	online := &recordingFetcher{}
+
+	err := Guard(online, true).Fetch(t.Context(), "https://example.com/skills.git", "/tmp/dest")
+	if !errors.Is(err, ErrOffline) {
+		t.Fatalf("Fetch() error = %v, want ErrOffline", err)
+	}
+	if online.called {
+		t.Fatal("offline guard must not call the underlying fetcher")
+	}
+
+	if err := Guard(online, false).Fetch(t.Context(), "https://example.com/skills.git", "/tmp/dest"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if !online.called {
+		t.Fatal("expected underlying fetcher to be called when online")
+	}
+}
+
+func TestHTTPDownloader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"version":1}`))
+	}))
+	defer srv.Close()
+
+	d := HTTPDownloader{Client: srv.Client()}
+	data, err := d.Download(t.Context(), srv.URL+"/index.json")
+	if err != nil || string(data) != `{"version":1}` {
+		t.Fatalf("Download() = %q, %v", data, err)
+	}
+	if _, err := d.Download(t.Context(), srv.URL+"/missing.json"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("Download() error = %v, want a 404 error", err)
+	}
+	if _, err := d.Download(t.Context(), "ftp://example.com/index.json"); err == nil {
+		t.Fatal("Download() should reject unsupported schemes")
+	}
+	if _, err := GuardDownloader(d, true).Download(t.Context(), srv.URL+"/index.json"); !errors.Is(err, ErrOffline) {
+		t.Fatalf("Download() error = %v, want ErrOffline", err)
+	}
+}
@@ -0,0 +1,112 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GitSource is a skill directory inside a git repository.
+type GitSource struct {
+	// Repo is the URL passed to git clone
+	Repo string
+	// Path is the skill directory within the repository ("" for the root)
+	Path string
+	// Ref is the branch or tag to fetch ("" for the default branch)
+	Ref string
+}
+
+// Name returns the default skill name for the source: the last path
+// element, or the repository name when the skill is the repository root.
+func (s GitSource) Name() string {
+	if s.Path != "" {
+		return filepath.Base(s.Path)
+	}
+	return strings.TrimSuffix(filepath.Base(s.Repo), ".git")
+}
+
+// ParseGitSource parses a skill source such as:
+//
+//	github.com/org/skills/my-skill
+//	github.com/org/skills/my-skill@v1.2.0
+//	https://git.example.com/team/skills.git//tools/my-skill
+//	file:///srv/skills//my-skill
+//
+// A "//" separates the repository from the skill path explicitly. Without it,
+// the first three elements (host, owner, repository) name the repository.
+// Sources without a scheme are fetched over HTTPS.
+func ParseGitSource(source string) (GitSource, error) {
+	var src GitSource
+	rest := source
+	if i := strings.LastIndex(rest, "@"); i > strings.LastIndex(rest, "/") {
+		rest, src.Ref = rest[:i], rest[i+1:]
+	}
+
+	scheme := "https://"
+	if i := strings.Index(rest, "://"); i >= 0 {
+		scheme, rest = rest[:i+3], rest[i+3:]
+	}
+
+	if repo, path, ok := strings.Cut(rest, "//"); ok {
+		src.Repo, src.Path = scheme+repo, strings.Trim(path, "/")
+	} else {
+		parts := strings.Split(strings.Trim(rest, "/"), "/")
+		if len(parts) < 3 {
+			return GitSource{}, fmt.Errorf("invalid source %q: expected host/owner/repo[/path][@ref]", source)
+		}
+		src.Repo = scheme + strings.Join(parts[:3], "/")
+		src.Path = strings.Join(parts[3:], "/")
+	}
+
+	if src.Repo == scheme || strings.Contains(src.Path, "..") {
+		return GitSource{}, fmt.Errorf("invalid source %q", source)
+	}
+	return src, nil
+}
+
+// GitFetcher fetches skill directories with the git command-line client.
+type GitFetcher struct{}
+
+// Fetch shallow-clones the repository of source and copies its skill
+// directory to dest, which must not exist yet. Canceling ctx kills git.
+func (GitFetcher) Fetch(ctx context.Context, source, dest string) error {
+	src, err := ParseGitSource(source)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp("", "skillet-fetch-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if src.Ref != "" {
+		args = append(args, "--branch", src.Ref)
+	}
+	args = append(args, "--", src.Repo, tmp)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("git clone %s stopped: %w", src.Repo, ctx.Err())
+		}
+		return fmt.Errorf("git clone %s failed: %w: %s", src.Repo, err, strings.TrimSpace(stderr.String()))
+	}
+
+	dir := filepath.Join(tmp, filepath.FromSlash(src.Path))
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("path %q not found in %s", src.Path, src.Repo)
+	}
+	if err := os.CopyFS(dest, os.DirFS(dir)); err != nil {
+		return fmt.Errorf("failed to copy skill: %w", err)
+	}
+	return os.RemoveAll(filepath.Join(dest, ".git"))
+}
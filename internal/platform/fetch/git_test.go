@@ -0,0 +1,75 @@
+package fetch
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGitSource(t *testing.T) {
+	tests := []struct {
+		source string
+		want   GitSource
+		name   string
+	}{
+		{"github.com/org/skills/my-skill", GitSource{Repo: "https://github.com/org/skills", Path: "my-skill"}, "my-skill"},
+		{"github.com/org/skills/tools/lint@v1.2.0", GitSource{Repo: "https://github.com/org/skills", Path: "tools/lint", Ref: "v1.2.0"}, "lint"},
+		{"github.com/org/review-skill", GitSource{Repo: "https://github.com/org/review-skill"}, "review-skill"},
+		{"https://git.example.com/team/skills.git//a/b", GitSource{Repo: "https://git.example.com/team/skills.git", Path: "a/b"}, "b"},
+		{"file:///srv/skills//my-skill", GitSource{Repo: "file:///srv/skills", Path: "my-skill"}, "my-skill"},
+	}
+	for _, tt := range tests {
+		got, err := ParseGitSource(tt.source)
+		if err != nil {
+			t.Errorf("ParseGitSource(%q) error = %v", tt.source, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseGitSource(%q) = %+v, want %+v", tt.source, got, tt.want)
+		}
+		if got.Name() != tt.name {
+			t.Errorf("ParseGitSource(%q).Name() = %q, want %q", tt.source, got.Name(), tt.name)
+		}
+	}
+
+	for _, bad := range []string{"github.com/org", "github.com/org/repo/../x"} {
+		if _, err := ParseGitSource(bad); err == nil {
+			t.Errorf("ParseGitSource(%q) expected error", bad)
+		}
+	}
+}
+
+func TestGitFetcher(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	skillDir := filepath.Join(repo, "my-skill")
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("---\nname: my-skill\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	dest := filepath.Join(t.TempDir(), "my-skill")
+	if err := (GitFetcher{}).Fetch(t.Context(), "file://"+repo+"//my-skill", dest); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "SKILL.md")); err != nil {
+		t.Fatalf("expected SKILL.md in %s: %v", dest, err)
+	}
+}
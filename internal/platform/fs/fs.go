@@ -10,17 +10,23 @@ import (
 type FileSystem interface {
 	ReadFile(path string) ([]byte, error)
 	WriteFile(path string, data []byte, perm os.FileMode) error
+	// WriteNewFile writes data to a file that must not exist yet, failing
+	// with an error matching fs.ErrExist when it does.
+	WriteNewFile(path string, data []byte, perm os.FileMode) error
 	Stat(path string) (os.FileInfo, error)
 	Lstat(path string) (os.FileInfo, error)
 	Remove(path string) error
 	RemoveAll(path string) error
 	Rename(oldpath, newpath string) error
 	MkdirAll(path string, perm os.FileMode) error
+	MkdirTemp(dir, pattern string) (string, error)
 	ReadDir(path string) ([]os.DirEntry, error)
 	Exists(path string) bool
 	IsDir(path string) bool
 	IsSymlink(path string) bool
 	Symlink(oldname, newname string) error
+	// Link creates newname as a hard link to the file oldname.
+	Link(oldname, newname string) error
 	Readlink(path string) (string, error)
 	CopyFile(src, dst string) error
 	CopyDir(src, dst string) error
@@ -30,6 +36,9 @@ type FileSystem interface {
 	Dir(path string) string
 	Base(path string) string
 	UserHomeDir() (string, error)
+	// LookupEnv returns an environment variable of the system the file
+	// system belongs to.
+	LookupEnv(key string) (string, bool)
 }
 
 // RealFileSystem implements FileSystem using the real file system.
@@ -48,6 +57,18 @@ func (r *RealFileSystem) WriteFile(path string, data []byte, perm os.FileMode) e
 	return os.WriteFile(path, data, perm)
 }
 
+func (r *RealFileSystem) WriteNewFile(path string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
 func (r *RealFileSystem) Stat(path string) (os.FileInfo, error) {
 	return os.Stat(path)
 }
@@ -72,6 +93,10 @@ func (r *RealFileSystem) MkdirAll(path string, perm os.FileMode) error {
 	return os.MkdirAll(path, perm)
 }
 
+func (r *RealFileSystem) MkdirTemp(dir, pattern string) (string, error) {
+	return os.MkdirTemp(dir, pattern)
+}
+
 func (r *RealFileSystem) ReadDir(path string) ([]os.DirEntry, error) {
 	return os.ReadDir(path)
 }
@@ -89,16 +114,19 @@ func (r *RealFileSystem) IsDir(path string) bool {
 	return info.IsDir()
 }
 
+// IsSymlink reports whether path is a symbolic link, or a junction on Windows.
 func (r *RealFileSystem) IsSymlink(path string) bool {
-	info, err := os.Lstat(path)
-	if err != nil {
-		return false
-	}
-	return info.Mode()&os.ModeSymlink != 0
+	return isSymlink(path)
 }
 
+// Symlink creates newname as a symbolic link to oldname. On Windows, a link
+// to a directory falls back to a junction when symbolic links are not allowed.
 func (r *RealFileSystem) Symlink(oldname, newname string) error {
-	return os.Symlink(oldname, newname)
+	return symlink(oldname, newname)
+}
+
+func (r *RealFileSystem) Link(oldname, newname string) error {
+	return os.Link(oldname, newname)
 }
 
 func (r *RealFileSystem) Readlink(path string) (string, error) {
@@ -182,3 +210,7 @@ func (r *RealFileSystem) Base(path string) string {
 func (r *RealFileSystem) UserHomeDir() (string, error) {
 	return os.UserHomeDir()
 }
+
+func (r *RealFileSystem) LookupEnv(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
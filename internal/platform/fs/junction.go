@@ -0,0 +1,40 @@
+package fs
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+)
+
+// ioReparseTagMountPoint is the reparse tag of NTFS junctions (mount points).
+const ioReparseTagMountPoint = 0xA0000003
+
+// junctionReparseData returns the REPARSE_DATA_BUFFER that turns an empty
+// directory into a junction to the absolute Windows path target. The buffer
+// holds the target twice, NUL-terminated: as the NT path the file system
+// follows (\??\C:\...) and as the path shown to users.
+func junctionReparseData(target string) []byte {
+	substitute := utf16.Encode([]rune(`\??\` + target))
+	printName := utf16.Encode([]rune(target))
+
+	// MountPointReparseBuffer: four uint16 offsets and lengths in bytes,
+	// then the path buffer.
+	pathBuffer := make([]uint16, 0, len(substitute)+len(printName)+2)
+	pathBuffer = append(pathBuffer, substitute...)
+	pathBuffer = append(pathBuffer, 0)
+	pathBuffer = append(pathBuffer, printName...)
+	pathBuffer = append(pathBuffer, 0)
+	dataLength := 8 + 2*len(pathBuffer)
+
+	buf := make([]byte, 8+dataLength)
+	binary.LittleEndian.PutUint32(buf[0:], ioReparseTagMountPoint)
+	binary.LittleEndian.PutUint16(buf[4:], uint16(dataLength))
+	// buf[6:8] is reserved.
+	binary.LittleEndian.PutUint16(buf[8:], 0)
+	binary.LittleEndian.PutUint16(buf[10:], uint16(2*len(substitute)))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(2*len(substitute)+2))
+	binary.LittleEndian.PutUint16(buf[14:], uint16(2*len(printName)))
+	for i, c := range pathBuffer {
+		binary.LittleEndian.PutUint16(buf[16+2*i:], c)
+	}
+	return buf
+}
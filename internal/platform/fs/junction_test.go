@@ -0,0 +1,38 @@
+package fs
+
+import (
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestJunctionReparseData(t *testing.T) {
+	target := `C:\Users\me\.agents\skills\pdf`
+	buf := junctionReparseData(target)
+
+	u16 := func(at int) int { return int(binary.LittleEndian.Uint16(buf[at:])) }
+	str := func(offset, length int) string {
+		chars := make([]uint16, length/2)
+		for i := range chars {
+			chars[i] = binary.LittleEndian.Uint16(buf[16+offset+2*i:])
+		}
+		return string(utf16.Decode(chars))
+	}
+
+	if tag := binary.LittleEndian.Uint32(buf); tag != ioReparseTagMountPoint {
+		t.Errorf("reparse tag = %#x, want %#x", tag, ioReparseTagMountPoint)
+	}
+	if got := u16(4); got != len(buf)-8 {
+		t.Errorf("data length = %d, want %d", got, len(buf)-8)
+	}
+	if got := str(u16(8), u16(10)); got != `\??\`+target {
+		t.Errorf("substitute name = %q", got)
+	}
+	if got := str(u16(12), u16(14)); got != target {
+		t.Errorf("print name = %q", got)
+	}
+	// Both names are NUL-terminated.
+	if want := 16 + u16(12) + u16(14) + 2; len(buf) != want {
+		t.Errorf("buffer length = %d, want %d", len(buf), want)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package fs
+
+import "os"
+
+// symlink creates newname as a symbolic link to oldname.
+func symlink(oldname, newname string) error {
+	return os.Symlink(oldname, newname)
+}
+
+// isSymlink reports whether path is a symbolic link.
+func isSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	return err == nil && info.Mode()&os.ModeSymlink != 0
+}
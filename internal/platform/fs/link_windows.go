@@ -0,0 +1,82 @@
+//go:build windows
+
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows"
+)
+
+// symlink creates newname as a symbolic link to oldname. Symbolic links on
+// Windows need Developer Mode or an elevated process, so a link to a
+// directory falls back to a junction, which needs neither. When that fails
+// too, the symlink error is returned and callers copy instead.
+func symlink(oldname, newname string) error {
+	err := os.Symlink(oldname, newname)
+	if err == nil {
+		return nil
+	}
+	target := oldname
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(newname), target)
+	}
+	if info, statErr := os.Stat(target); statErr != nil || !info.IsDir() {
+		return err
+	}
+	if junctionErr := createJunction(target, newname); junctionErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to create junction: %w", junctionErr))
+	}
+	return nil
+}
+
+// createJunction creates link as a junction to the directory target.
+func createJunction(target, link string) error {
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+	linkPtr, err := windows.UTF16PtrFromString(link)
+	if err != nil {
+		return err
+	}
+	if err := os.Mkdir(link, 0o755); err != nil {
+		return err
+	}
+	h, err := windows.CreateFile(linkPtr, windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING,
+		windows.FILE_FLAG_OPEN_REPARSE_POINT|windows.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		_ = os.Remove(link)
+		return err
+	}
+	data := junctionReparseData(target)
+	var returned uint32
+	err = windows.DeviceIoControl(h, windows.FSCTL_SET_REPARSE_POINT, &data[0], uint32(len(data)), nil, 0, &returned, nil)
+	_ = windows.CloseHandle(h)
+	if err != nil {
+		_ = os.Remove(link)
+		return err
+	}
+	return nil
+}
+
+// isSymlink reports whether path is a symbolic link or a junction. Lstat
+// reports junctions as irregular files rather than symbolic links, but
+// Readlink resolves both.
+func isSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return false
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return true
+	}
+	if info.Mode()&os.ModeIrregular == 0 {
+		return false
+	}
+	_, err = os.Readlink(path)
+	return err == nil
+}
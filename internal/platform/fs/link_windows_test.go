@@ -0,0 +1,43 @@
+//go:build windows
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJunction(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "store", "pdf")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "SKILL.md"), []byte("---\nname: pdf\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	link := filepath.Join(dir, "pdf")
+	if err := createJunction(target, link); err != nil {
+		t.Fatalf("createJunction() error = %v", err)
+	}
+	fsys := NewFileSystem()
+	if !fsys.IsSymlink(link) || !fsys.IsDir(link) {
+		t.Fatal("expected the junction to be reported as a link to a directory")
+	}
+	if got, err := fsys.Readlink(link); err != nil || got != target {
+		t.Errorf("Readlink() = %q, %v, want %q", got, err, target)
+	}
+	if _, err := fsys.ReadFile(filepath.Join(link, "SKILL.md")); err != nil {
+		t.Errorf("reading through the junction: %v", err)
+	}
+
+	// Removing the junction leaves its target alone.
+	if err := fsys.RemoveAll(link); err != nil {
+		t.Fatalf("RemoveAll() error = %v", err)
+	}
+	if fsys.Exists(link) || !fsys.Exists(filepath.Join(target, "SKILL.md")) {
+		t.Error("expected only the junction to be removed")
+	}
+}
@@ -13,7 +13,17 @@ type MockFileSystem struct {
 	Files    map[string][]byte
 	Dirs     map[string]bool
 	Symlinks map[string]string
-	HomeDir  string
+	// Links maps each file created by Link to the file it links to. The
+	// mock copies the content, so the two do not share later writes.
+	Links map[string]string
+	// ModTimes optionally sets the modification time reported for files.
+	ModTimes map[string]time.Time
+	// Modes optionally sets the permission bits Stat reports.
+	Modes   map[string]os.FileMode
+	HomeDir string
+	// Env holds environment variables for LookupEnv. HOME defaults to HomeDir.
+	Env     map[string]string
+	tempSeq int
 }
 
 // NewMockFileSystem returns a new MockFileSystem.
@@ -22,6 +32,9 @@ func NewMockFileSystem() *MockFileSystem {
 		Files:    make(map[string][]byte),
 		Dirs:     make(map[string]bool),
 		Symlinks: make(map[string]string),
+		Links:    make(map[string]string),
+		ModTimes: make(map[string]time.Time),
+		Modes:    make(map[string]os.FileMode),
 		HomeDir:  "/home/test",
 	}
 }
@@ -34,25 +47,33 @@ func (m *MockFileSystem) ReadFile(path string) ([]byte, error) {
 	return nil, os.ErrNotExist
 }
 
-func (m *MockFileSystem) WriteFile(path string, data []byte, _ os.FileMode) error {
+func (m *MockFileSystem) WriteFile(path string, data []byte, perm os.FileMode) error {
 	path = m.normalizePath(path)
 	m.Files[path] = data
+	m.Modes[path] = perm.Perm()
 	return nil
 }
 
+func (m *MockFileSystem) WriteNewFile(path string, data []byte, perm os.FileMode) error {
+	if _, err := m.Lstat(path); err == nil {
+		return &os.PathError{Op: "open", Path: path, Err: os.ErrExist}
+	}
+	return m.WriteFile(path, data, perm)
+}
+
 func (m *MockFileSystem) Stat(path string) (os.FileInfo, error) {
 	path = m.normalizePath(path)
 
 	// Follow symlinks
 	if target, ok := m.Symlinks[path]; ok {
-		return m.Stat(target)
+		return m.Stat(linkDest(path, target))
 	}
 
-	if _, ok := m.Files[path]; ok {
-		return &mockFileInfo{name: filepath.Base(path), isDir: false}, nil
+	if data, ok := m.Files[path]; ok {
+		return &mockFileInfo{name: filepath.Base(path), isDir: false, size: int64(len(data)), modTime: m.ModTimes[path], mode: m.Modes[path]}, nil
 	}
 	if m.Dirs[path] {
-		return &mockFileInfo{name: filepath.Base(path), isDir: true}, nil
+		return &mockFileInfo{name: filepath.Base(path), isDir: true, mode: m.Modes[path]}, nil
 	}
 	return nil, os.ErrNotExist
 }
@@ -112,6 +133,19 @@ func (m *MockFileSystem) Rename(oldpath, newpath string) error {
 	oldpath = m.normalizePath(oldpath)
 	newpath = m.normalizePath(newpath)
 
+	// Like os.Rename, the destination directory has to exist.
+	if parent := filepath.Dir(newpath); parent != filepath.Dir(parent) && !m.Dirs[parent] {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: os.ErrNotExist}
+	}
+	if target, ok := m.Symlinks[oldpath]; ok {
+		m.Symlinks[newpath] = target
+		delete(m.Symlinks, oldpath)
+		return nil
+	}
+	if mode, ok := m.Modes[oldpath]; ok {
+		m.Modes[newpath] = mode
+		delete(m.Modes, oldpath)
+	}
 	if data, ok := m.Files[oldpath]; ok {
 		m.Files[newpath] = data
 		delete(m.Files, oldpath)
@@ -120,6 +154,33 @@ func (m *MockFileSystem) Rename(oldpath, newpath string) error {
 	if m.Dirs[oldpath] {
 		m.Dirs[newpath] = true
 		delete(m.Dirs, oldpath)
+
+		// Move all children
+		prefix := oldpath + "/"
+		for k, v := range m.Files {
+			if rest, ok := strings.CutPrefix(k, prefix); ok {
+				m.Files[newpath+"/"+rest] = v
+				delete(m.Files, k)
+			}
+		}
+		for k := range m.Dirs {
+			if rest, ok := strings.CutPrefix(k, prefix); ok {
+				m.Dirs[newpath+"/"+rest] = true
+				delete(m.Dirs, k)
+			}
+		}
+		for k, v := range m.Symlinks {
+			if rest, ok := strings.CutPrefix(k, prefix); ok {
+				m.Symlinks[newpath+"/"+rest] = v
+				delete(m.Symlinks, k)
+			}
+		}
+		for k, v := range m.Modes {
+			if rest, ok := strings.CutPrefix(k, prefix); ok {
+				m.Modes[newpath+"/"+rest] = v
+				delete(m.Modes, k)
+			}
+		}
 		return nil
 	}
 	return os.ErrNotExist
@@ -140,6 +201,22 @@ func (m *MockFileSystem) MkdirAll(path string, _ os.FileMode) error {
 	return nil
 }
 
+func (m *MockFileSystem) MkdirTemp(dir, pattern string) (string, error) {
+	if dir == "" {
+		dir = "/tmp"
+	}
+	m.tempSeq++
+	name := strings.Replace(pattern, "*", fmt.Sprint(m.tempSeq), 1)
+	if !strings.Contains(pattern, "*") {
+		name = pattern + fmt.Sprint(m.tempSeq)
+	}
+	path := m.normalizePath(filepath.Join(dir, name))
+	if err := m.MkdirAll(path, 0o700); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
 func (m *MockFileSystem) ReadDir(path string) ([]os.DirEntry, error) {
 	path = m.normalizePath(path)
 
@@ -211,7 +288,7 @@ func (m *MockFileSystem) IsDir(path string) bool {
 
 	// Follow symlinks
 	if target, ok := m.Symlinks[path]; ok {
-		return m.IsDir(target)
+		return m.IsDir(linkDest(path, target))
 	}
 
 	return m.Dirs[path]
@@ -229,6 +306,21 @@ func (m *MockFileSystem) Symlink(oldname, newname string) error {
 	return nil
 }
 
+func (m *MockFileSystem) Link(oldname, newname string) error {
+	oldname = m.normalizePath(oldname)
+	newname = m.normalizePath(newname)
+	data, ok := m.Files[oldname]
+	if !ok {
+		return &os.LinkError{Op: "link", Old: oldname, New: newname, Err: os.ErrNotExist}
+	}
+	if _, err := m.Lstat(newname); err == nil {
+		return &os.LinkError{Op: "link", Old: oldname, New: newname, Err: os.ErrExist}
+	}
+	m.Files[newname] = data
+	m.Links[newname] = oldname
+	return nil
+}
+
 func (m *MockFileSystem) Readlink(path string) (string, error) {
 	path = m.normalizePath(path)
 	if target, ok := m.Symlinks[path]; ok {
@@ -307,6 +399,16 @@ func (m *MockFileSystem) UserHomeDir() (string, error) {
 	return m.HomeDir, nil
 }
 
+func (m *MockFileSystem) LookupEnv(key string) (string, bool) {
+	if value, ok := m.Env[key]; ok {
+		return value, true
+	}
+	if key == "HOME" {
+		return m.HomeDir, true
+	}
+	return "", false
+}
+
 func (m *MockFileSystem) normalizePath(path string) string {
 	// Replace ~ with home directory
 	if strings.HasPrefix(path, "~") {
@@ -315,17 +417,27 @@ func (m *MockFileSystem) normalizePath(path string) string {
 	return filepath.Clean(path)
 }
 
+// linkDest returns the path a symlink at path holding target points to.
+func linkDest(path, target string) string {
+	if filepath.IsAbs(target) {
+		return target
+	}
+	return filepath.Join(filepath.Dir(path), target)
+}
+
 // mockFileInfo implements os.FileInfo for testing
 type mockFileInfo struct {
-	name  string
-	isDir bool
-	mode  os.FileMode
+	name    string
+	isDir   bool
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
 }
 
 func (m *mockFileInfo) Name() string       { return m.name }
-func (m *mockFileInfo) Size() int64        { return 0 }
+func (m *mockFileInfo) Size() int64        { return m.size }
 func (m *mockFileInfo) Mode() os.FileMode  { return m.mode }
-func (m *mockFileInfo) ModTime() time.Time { return time.Time{} }
+func (m *mockFileInfo) ModTime() time.Time { return m.modTime }
 func (m *mockFileInfo) IsDir() bool        { return m.isDir }
 func (m *mockFileInfo) Sys() any           { return nil }
 
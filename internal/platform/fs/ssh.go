@@ -0,0 +1,314 @@
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// errRemoteNotExist is the exit status remote scripts use for a missing path.
+	errRemoteNotExist = 3
+	// errRemoteExist is the exit status remote scripts use for a path that
+	// must not exist.
+	errRemoteExist = 4
+)
+
+// SSHFileSystem implements FileSystem on a remote host by running POSIX shell
+// commands through the ssh client. All paths are remote, slash-separated paths;
+// relative paths are resolved against the remote home directory.
+type SSHFileSystem struct {
+	host string
+	// run executes a shell command on the remote host with the given stdin.
+	run  func(command string, stdin []byte) ([]byte, error)
+	home string
+	env  map[string]*string // remote variables looked up so far; nil when unset
+}
+
+// NewSSHFileSystem returns a FileSystem for host, which may be any destination
+// the ssh client accepts (e.g. "devbox" from ~/.ssh/config or "user@host").
+// sshArgs are passed to ssh before the destination (e.g. "-p", "2222").
+func NewSSHFileSystem(host string, sshArgs ...string) *SSHFileSystem {
+	return &SSHFileSystem{
+		host: host,
+		run: func(command string, stdin []byte) ([]byte, error) {
+			args := append(append([]string{}, sshArgs...), host, "--", command)
+			cmd := exec.Command("ssh", args...)
+			cmd.Stdin = bytes.NewReader(stdin)
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+			err := cmd.Run()
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() == errRemoteNotExist {
+				return nil, os.ErrNotExist
+			}
+			if errors.As(err, &exitErr) && exitErr.ExitCode() == errRemoteExist {
+				return nil, os.ErrExist
+			}
+			if err != nil {
+				return nil, fmt.Errorf("ssh %s: %w: %s", host, err, strings.TrimSpace(stderr.String()))
+			}
+			return stdout.Bytes(), nil
+		},
+	}
+}
+
+// exec runs script with sh on the remote host, passing args as $1, $2, ...
+func (s *SSHFileSystem) exec(stdin []byte, script string, args ...string) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("sh -c ")
+	b.WriteString(shellQuote(script))
+	b.WriteString(" sh")
+	for _, arg := range args {
+		b.WriteByte(' ')
+		b.WriteString(shellQuote(arg))
+	}
+	return s.run(b.String(), stdin)
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func (s *SSHFileSystem) ReadFile(path string) ([]byte, error) {
+	return s.exec(nil, `[ -f "$1" ] || exit 3; cat -- "$1"`, path)
+}
+
+func (s *SSHFileSystem) WriteFile(path string, data []byte, perm os.FileMode) error {
+	_, err := s.exec(data, `cat > "$1" && chmod "$2" "$1"`, path, fmt.Sprintf("%o", perm.Perm()))
+	return err
+}
+
+// WriteNewFile sets noclobber, so the redirection also fails when another
+// process creates the file after the check.
+func (s *SSHFileSystem) WriteNewFile(path string, data []byte, perm os.FileMode) error {
+	_, err := s.exec(data, `if [ -e "$1" ] || [ -L "$1" ]; then exit 4; fi; set -C; cat > "$1" && chmod "$2" "$1"`, path, fmt.Sprintf("%o", perm.Perm()))
+	return err
+}
+
+// statScript prints the type of $1 (d, f, or l for a symlink when $2 is "l").
+const statScript = `if [ "$2" = l ] && [ -L "$1" ]; then echo l
+elif [ -d "$1" ]; then echo d
+elif [ -e "$1" ]; then echo f
+else exit 3; fi`
+
+func (s *SSHFileSystem) stat(p, mode string) (os.FileInfo, error) {
+	out, err := s.exec(nil, statScript, p, mode)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteFileInfo{name: path.Base(p), kind: strings.TrimSpace(string(out))}, nil
+}
+
+func (s *SSHFileSystem) Stat(path string) (os.FileInfo, error) {
+	return s.stat(path, "")
+}
+
+func (s *SSHFileSystem) Lstat(path string) (os.FileInfo, error) {
+	return s.stat(path, "l")
+}
+
+func (s *SSHFileSystem) Remove(path string) error {
+	_, err := s.exec(nil, `if [ -d "$1" ] && [ ! -L "$1" ]; then rmdir -- "$1"; else rm -- "$1"; fi`, path)
+	return err
+}
+
+func (s *SSHFileSystem) RemoveAll(path string) error {
+	_, err := s.exec(nil, `rm -rf -- "$1"`, path)
+	return err
+}
+
+func (s *SSHFileSystem) Rename(oldpath, newpath string) error {
+	_, err := s.exec(nil, `mv -- "$1" "$2"`, oldpath, newpath)
+	return err
+}
+
+func (s *SSHFileSystem) MkdirAll(path string, perm os.FileMode) error {
+	_, err := s.exec(nil, `mkdir -p -m "$2" -- "$1"`, path, fmt.Sprintf("%o", perm.Perm()))
+	return err
+}
+
+func (s *SSHFileSystem) MkdirTemp(dir, pattern string) (string, error) {
+	if dir == "" {
+		dir = "/tmp"
+	}
+	out, err := s.exec(nil, `mktemp -d "$1/$2XXXXXX"`, dir, strings.ReplaceAll(pattern, "*", ""))
+	return strings.TrimSpace(string(out)), err
+}
+
+// readDirScript lists $1 as "<type> <name>" lines, including dotfiles.
+const readDirScript = `[ -d "$1" ] || exit 3
+cd -- "$1" || exit 1
+for f in * .*; do
+	case $f in .|..) continue;; esac
+	if [ -L "$f" ]; then echo "l $f"
+	elif [ -d "$f" ]; then echo "d $f"
+	elif [ -e "$f" ]; then echo "f $f"
+	fi
+done`
+
+func (s *SSHFileSystem) ReadDir(path string) ([]os.DirEntry, error) {
+	out, err := s.exec(nil, readDirScript, path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []os.DirEntry
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		kind, name, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		entries = append(entries, &remoteFileInfo{name: name, kind: kind})
+	}
+	return entries, nil
+}
+
+func (s *SSHFileSystem) Exists(path string) bool {
+	_, err := s.Stat(path)
+	return err == nil
+}
+
+func (s *SSHFileSystem) IsDir(path string) bool {
+	info, err := s.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func (s *SSHFileSystem) IsSymlink(path string) bool {
+	info, err := s.Lstat(path)
+	return err == nil && info.Mode()&os.ModeSymlink != 0
+}
+
+func (s *SSHFileSystem) Symlink(oldname, newname string) error {
+	_, err := s.exec(nil, `ln -s -- "$1" "$2"`, oldname, newname)
+	return err
+}
+
+func (s *SSHFileSystem) Link(oldname, newname string) error {
+	_, err := s.exec(nil, `ln -- "$1" "$2"`, oldname, newname)
+	return err
+}
+
+func (s *SSHFileSystem) Readlink(path string) (string, error) {
+	out, err := s.exec(nil, `[ -L "$1" ] || exit 3; readlink -- "$1"`, path)
+	return strings.TrimRight(string(out), "\n"), err
+}
+
+func (s *SSHFileSystem) CopyFile(src, dst string) error {
+	_, err := s.exec(nil, `mkdir -p -- "$(dirname -- "$2")" && cp -p -- "$1" "$2"`, src, dst)
+	return err
+}
+
+func (s *SSHFileSystem) CopyDir(src, dst string) error {
+	_, err := s.exec(nil, `mkdir -p -- "$2" && cp -pR -- "$1"/. "$2"`, src, dst)
+	return err
+}
+
+func (s *SSHFileSystem) Abs(p string) (string, error) {
+	if path.IsAbs(p) {
+		return path.Clean(p), nil
+	}
+	home, err := s.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(home, p), nil
+}
+
+func (s *SSHFileSystem) Rel(basepath, targpath string) (string, error) {
+	base, targ := path.Clean(basepath), path.Clean(targpath)
+	if targ == base {
+		return ".", nil
+	}
+	if base == "/" {
+		base = ""
+	}
+	if rel, ok := strings.CutPrefix(targ, base+"/"); ok {
+		return rel, nil
+	}
+	return "", fmt.Errorf("Rel: %s is not under %s", targpath, basepath)
+}
+
+func (s *SSHFileSystem) Join(elem ...string) string {
+	return path.Join(elem...)
+}
+
+func (s *SSHFileSystem) Dir(p string) string {
+	return path.Dir(p)
+}
+
+func (s *SSHFileSystem) Base(p string) string {
+	return path.Base(p)
+}
+
+// UserHomeDir returns the remote $HOME, queried once per file system.
+func (s *SSHFileSystem) UserHomeDir() (string, error) {
+	if s.home != "" {
+		return s.home, nil
+	}
+	out, err := s.exec(nil, `printf '%s' "$HOME"`)
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", fmt.Errorf("remote home directory is not set on %s", s.host)
+	}
+	s.home = string(out)
+	return s.home, nil
+}
+
+// LookupEnv returns a remote environment variable, queried once per name.
+func (s *SSHFileSystem) LookupEnv(key string) (string, bool) {
+	if value, ok := s.env[key]; ok {
+		if value == nil {
+			return "", false
+		}
+		return *value, true
+	}
+	if s.env == nil {
+		s.env = make(map[string]*string)
+	}
+	out, err := s.exec(nil, `printenv "$1"`, key)
+	if err != nil {
+		s.env[key] = nil
+		return "", false
+	}
+	value := strings.TrimSuffix(string(out), "\n")
+	s.env[key] = &value
+	return value, true
+}
+
+// remoteFileInfo describes a remote path; it serves as both FileInfo and DirEntry.
+// Sizes and modification times are not transferred.
+type remoteFileInfo struct {
+	name string
+	kind string // "d", "f", or "l"
+}
+
+func (r *remoteFileInfo) Name() string       { return r.name }
+func (r *remoteFileInfo) Size() int64        { return 0 }
+func (r *remoteFileInfo) ModTime() time.Time { return time.Time{} }
+func (r *remoteFileInfo) IsDir() bool        { return r.kind == "d" }
+func (r *remoteFileInfo) Sys() any           { return nil }
+func (r *remoteFileInfo) Type() os.FileMode  { return r.Mode().Type() }
+
+func (r *remoteFileInfo) Mode() os.FileMode {
+	switch r.kind {
+	case "d":
+		return os.ModeDir | 0o755
+	case "l":
+		return os.ModeSymlink | 0o777
+	default:
+		return 0o644
+	}
+}
+
+func (r *remoteFileInfo) Info() (os.FileInfo, error) {
+	return r, nil
+}
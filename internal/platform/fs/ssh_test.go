@@ -0,0 +1,100 @@
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// newLocalSSHFileSystem runs the remote scripts with the local shell.
+func newLocalSSHFileSystem(t *testing.T) *SSHFileSystem {
+	t.Helper()
+	return &SSHFileSystem{
+		host: "local",
+		home: t.TempDir(),
+		run: func(command string, stdin []byte) ([]byte, error) {
+			cmd := exec.Command("sh", "-c", command)
+			cmd.Stdin = bytes.NewReader(stdin)
+			out, err := cmd.Output()
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() == errRemoteNotExist {
+				return nil, os.ErrNotExist
+			}
+			if errors.As(err, &exitErr) && exitErr.ExitCode() == errRemoteExist {
+				return nil, os.ErrExist
+			}
+			return out, err
+		},
+	}
+}
+
+func TestSSHFileSystem(t *testing.T) {
+	s := newLocalSSHFileSystem(t)
+	dir := filepath.Join(s.home, "it's skills")
+
+	if err := s.MkdirAll(filepath.Join(dir, "review"), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	file := filepath.Join(dir, "review", "SKILL.md")
+	if err := s.WriteFile(file, []byte("---\nname: review\n---\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	data, err := s.ReadFile(file)
+	if err != nil || string(data) != "---\nname: review\n---\n" {
+		t.Fatalf("ReadFile() = %q, %v", data, err)
+	}
+	if _, err := s.ReadFile(filepath.Join(dir, "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("ReadFile(missing) error = %v, want ErrNotExist", err)
+	}
+	if err := s.WriteNewFile(file, []byte("new"), 0o644); !errors.Is(err, os.ErrExist) {
+		t.Fatalf("WriteNewFile(existing) error = %v, want ErrExist", err)
+	}
+	if err := s.WriteNewFile(filepath.Join(dir, "review", "notes.md"), []byte("new"), 0o644); err != nil || !s.Exists(filepath.Join(dir, "review", "notes.md")) {
+		t.Fatalf("WriteNewFile() error = %v", err)
+	}
+
+	if err := s.Symlink(filepath.Join(dir, "review"), filepath.Join(dir, ".link")); err != nil {
+		t.Fatalf("Symlink() error = %v", err)
+	}
+	entries, err := s.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{".link", "review"}) {
+		t.Fatalf("ReadDir() = %v", names)
+	}
+	if !s.IsSymlink(filepath.Join(dir, ".link")) || !s.IsDir(filepath.Join(dir, ".link")) {
+		t.Error("symlink to a directory should be a symlink and a directory")
+	}
+
+	if err := s.CopyDir(filepath.Join(dir, "review"), filepath.Join(dir, "copy")); err != nil {
+		t.Fatalf("CopyDir() error = %v", err)
+	}
+	if !s.Exists(filepath.Join(dir, "copy", "SKILL.md")) {
+		t.Error("CopyDir() did not copy SKILL.md")
+	}
+	if err := s.RemoveAll(dir); err != nil || s.Exists(dir) {
+		t.Fatalf("RemoveAll() error = %v", err)
+	}
+}
+
+func TestSSHFileSystemLookupEnv(t *testing.T) {
+	t.Setenv("SKILLET_TEST_VAR", "a b\n")
+	s := newLocalSSHFileSystem(t)
+
+	if value, ok := s.LookupEnv("SKILLET_TEST_VAR"); !ok || value != "a b\n" {
+		t.Errorf("LookupEnv() = %q, %v", value, ok)
+	}
+	if _, ok := s.LookupEnv("SKILLET_TEST_UNSET"); ok {
+		t.Error("LookupEnv() should not find an unset variable")
+	}
+}
@@ -0,0 +1,42 @@
+package hook
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// Runner runs hook commands.
+type Runner interface {
+	// Run runs command in dir ("" for the current directory) with env added
+	// to the environment. Canceling ctx kills the command.
+	Run(ctx context.Context, command, dir string, env []string) error
+}
+
+// ShellRunner runs hook commands with sh -c.
+type ShellRunner struct {
+	// Output receives the command's stdout and stderr (nil for os.Stderr, so
+	// hooks do not mix with skillet's own output)
+	Output io.Writer
+}
+
+func (r ShellRunner) Run(ctx context.Context, command, dir string, env []string) error {
+	out := r.Output
+	if out == nil {
+		out = os.Stderr
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%q stopped: %w", command, ctx.Err())
+		}
+		return fmt.Errorf("%q: %w", command, err)
+	}
+	return nil
+}
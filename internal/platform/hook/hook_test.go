@@ -0,0 +1,36 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShellRunner(t *testing.T) {
+	var out bytes.Buffer
+	r := ShellRunner{Output: &out}
+	dir := t.TempDir()
+
+	if err := r.Run(t.Context(), `echo "$SKILLET_SKILL in $(pwd)"`, dir, []string{"SKILLET_SKILL=pdf"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "pdf in ") || !strings.Contains(got, filepath.Base(dir)) {
+		t.Errorf("output = %q, want the skill name and the directory", got)
+	}
+
+	err := r.Run(t.Context(), "exit 3", "", nil)
+	if err == nil || !strings.Contains(err.Error(), "exit status 3") {
+		t.Errorf("Run() error = %v, want the exit status", err)
+	}
+}
+
+func TestShellRunnerCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if err := (ShellRunner{Output: &bytes.Buffer{}}).Run(ctx, "sleep 5", "", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Notifier delivers desktop notifications.
+type Notifier interface {
+	Notify(title, message string) error
+}
+
+// NewNotifier returns a Notifier for the current platform.
+// Platforms without a supported notification command get a no-op notifier.
+func NewNotifier() Notifier {
+	switch runtime.GOOS {
+	case "darwin":
+		return &commandNotifier{name: "osascript", args: osascriptArgs}
+	case "linux":
+		return &commandNotifier{name: "notify-send", args: notifySendArgs}
+	default:
+		return NopNotifier{}
+	}
+}
+
+// NopNotifier discards all notifications.
+type NopNotifier struct{}
+
+func (NopNotifier) Notify(string, string) error {
+	return nil
+}
+
+// commandNotifier sends notifications by running an external command.
+type commandNotifier struct {
+	name string
+	args func(title, message string) []string
+}
+
+func (c *commandNotifier) Notify(title, message string) error {
+	path, err := exec.LookPath(c.name)
+	if err != nil {
+		return fmt.Errorf("notification command not found: %s", c.name)
+	}
+	if err := exec.Command(path, c.args(title, message)...).Run(); err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	return nil
+}
+
+// osascriptArgs returns the osascript arguments that display a notification.
+func osascriptArgs(title, message string) []string {
+	script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+	return []string{"-e", script}
+}
+
+// appleScriptString quotes s as an AppleScript string literal, in which only
+// backslashes and double quotes are escaped.
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// notifySendArgs returns the notify-send arguments that display a
+// notification. A title or message starting with "-" is not read as an option.
+func notifySendArgs(title, message string) []string {
+	return []string{"--", title, message}
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOsascriptArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		message string
+		want    string
+	}{
+		{"plain", "skillet synced", "claude/alpha", `display notification "claude/alpha" with title "skillet synced"`},
+		{"quotes", `say "hi"`, `a "b"`, `display notification "a \"b\"" with title "say \"hi\""`},
+		{"backslash", "title", `C:\skills`, `display notification "C:\\skills" with title "title"`},
+		{"newline", "title", "one\ntwo", "display notification \"one\ntwo\" with title \"title\""},
+		{"unicode", "título", "✓ done", `display notification "✓ done" with title "título"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := osascriptArgs(tt.title, tt.message)
+			if want := []string{"-e", tt.want}; !slices.Equal(got, want) {
+				t.Errorf("osascriptArgs(%q, %q) = %q, want %q", tt.title, tt.message, got, want)
+			}
+		})
+	}
+}
+
+func TestNotifySendArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		message string
+		want    []string
+	}{
+		{"plain", "skillet synced", "claude/alpha", []string{"--", "skillet synced", "claude/alpha"}},
+		{"dash", "-title", "--urgency=critical", []string{"--", "-title", "--urgency=critical"}},
+		{"quotes", `say "hi"`, `C:\skills`, []string{"--", `say "hi"`, `C:\skills`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notifySendArgs(tt.title, tt.message); !slices.Equal(got, tt.want) {
+				t.Errorf("notifySendArgs(%q, %q) = %q, want %q", tt.title, tt.message, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package proc
+
+import (
+	"errors"
+	"syscall"
+)
+
+// alive sends signal 0, which only checks that the process can be signaled.
+func alive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
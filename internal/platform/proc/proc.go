@@ -0,0 +1,8 @@
+// Package proc inspects processes of the operating system.
+package proc
+
+// Alive reports whether a process with the given id is running. A process
+// that exists but cannot be inspected counts as running.
+func Alive(pid int) bool {
+	return pid > 0 && alive(pid)
+}
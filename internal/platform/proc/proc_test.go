@@ -0,0 +1,15 @@
+package proc
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAlive(t *testing.T) {
+	if !Alive(os.Getpid()) {
+		t.Error("Alive() = false for the current process")
+	}
+	if Alive(0) || Alive(-1) {
+		t.Error("Alive() = true for an invalid process id")
+	}
+}
@@ -0,0 +1,70 @@
+package prompt
+
+import (
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Prompter asks the user for input.
+type Prompter interface {
+	Confirm(message string, defaultValue bool) (bool, error)
+	Select(message string, options []string, defaultValue string) (string, error)
+	MultiSelect(message string, options, defaults []string) ([]string, error)
+	Input(message, defaultValue string) (string, error)
+}
+
+// SurveyPrompter asks questions interactively on the terminal.
+type SurveyPrompter struct{}
+
+// NewSurveyPrompter returns an interactive Prompter.
+func NewSurveyPrompter() *SurveyPrompter {
+	return &SurveyPrompter{}
+}
+
+func (p *SurveyPrompter) Confirm(message string, defaultValue bool) (bool, error) {
+	var answer bool
+	err := survey.AskOne(&survey.Confirm{Message: message, Default: defaultValue}, &answer)
+	return answer, err
+}
+
+func (p *SurveyPrompter) Select(message string, options []string, defaultValue string) (string, error) {
+	var answer string
+	err := survey.AskOne(&survey.Select{Message: message, Options: options, Default: defaultValue}, &answer)
+	return answer, err
+}
+
+func (p *SurveyPrompter) MultiSelect(message string, options, defaults []string) ([]string, error) {
+	var answer []string
+	err := survey.AskOne(&survey.MultiSelect{Message: message, Options: options, Default: defaults}, &answer)
+	return answer, err
+}
+
+func (p *SurveyPrompter) Input(message, defaultValue string) (string, error) {
+	var answer string
+	err := survey.AskOne(&survey.Input{Message: message, Default: defaultValue}, &answer)
+	return answer, err
+}
+
+// AutoPrompter answers every question without user interaction.
+// Confirmations are accepted and all other prompts return their defaults.
+type AutoPrompter struct{}
+
+// NewAutoPrompter returns a non-interactive Prompter.
+func NewAutoPrompter() *AutoPrompter {
+	return &AutoPrompter{}
+}
+
+func (p *AutoPrompter) Confirm(string, bool) (bool, error) {
+	return true, nil
+}
+
+func (p *AutoPrompter) Select(_ string, _ []string, defaultValue string) (string, error) {
+	return defaultValue, nil
+}
+
+func (p *AutoPrompter) MultiSelect(_ string, _, defaults []string) ([]string, error) {
+	return defaults, nil
+}
+
+func (p *AutoPrompter) Input(_, defaultValue string) (string, error) {
+	return defaultValue, nil
+}
@@ -0,0 +1,93 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher reports changes to files under a set of directories in batches.
+// Directories created after the watcher starts are watched as well.
+type Watcher struct {
+	w        *fsnotify.Watcher
+	debounce time.Duration
+}
+
+// New watches dirs and their subdirectories. Changes are reported once no
+// further change has happened for the debounce interval.
+func New(dirs []string, debounce time.Duration) (*Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start watcher: %w", err)
+	}
+	watcher := &Watcher{w: w, debounce: debounce}
+	for _, dir := range dirs {
+		if err := watcher.addTree(dir); err != nil {
+			_ = w.Close()
+			return nil, err
+		}
+	}
+	return watcher, nil
+}
+
+// addTree watches dir and every directory below it. Symlinked directories are not followed.
+func (w *Watcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := w.w.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// Run calls onChange with the sorted paths changed in each batch until ctx is
+// done or the watcher fails. It returns nil when ctx is done.
+func (w *Watcher) Run(ctx context.Context, onChange func(paths []string)) error {
+	pending := make(map[string]bool)
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.w.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				// A new directory may already contain files; they are reported with it.
+				_ = w.addTree(event.Name)
+			}
+			pending[event.Name] = true
+			timer.Reset(w.debounce)
+		case err, ok := <-w.w.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("watch failed: %w", err)
+		case <-timer.C:
+			paths := slices.Sorted(maps.Keys(pending))
+			clear(pending)
+			onChange(paths)
+		}
+	}
+}
+
+// Close stops watching.
+func (w *Watcher) Close() error {
+	return w.w.Close()
+}
@@ -0,0 +1,54 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatcherReportsBatchedChanges(t *testing.T) {
+	root := t.TempDir()
+	w, err := New([]string{root}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer w.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	batches := make(chan []string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(ctx, func(paths []string) { batches <- paths })
+	}()
+
+	// A file written into a new directory is reported once the directory is watched.
+	skillDir := filepath.Join(root, "review")
+	if err := os.Mkdir(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	skillFile := filepath.Join(skillDir, "SKILL.md")
+	var seen []string
+	for !slices.Contains(seen, skillFile) {
+		if err := os.WriteFile(skillFile, []byte("# review\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case paths := <-batches:
+			seen = append(seen, paths...)
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for %s, saw %v", skillFile, seen)
+		}
+	}
+	if !slices.Contains(seen, skillDir) {
+		t.Errorf("changes = %v, want %s", seen, skillDir)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package skill
+
+import "strings"
+
+// byteOrderMark is the UTF-8 byte order mark some editors write at the start
+// of a file.
+const byteOrderMark = "\ufeff"
+
+// frontmatterSpan locates the YAML frontmatter of SKILL.md content as byte
+// offsets.
+type frontmatterSpan struct {
+	// start and end delimit the YAML between the delimiter lines, without
+	// the line break before the closing delimiter.
+	start, end int
+	// close is the offset just past the closing delimiter line, before its
+	// line break.
+	close int
+	// next is the offset of the line after the closing delimiter.
+	next int
+}
+
+// findFrontmatter locates the frontmatter at the start of content: a line
+// holding only ---, optionally after a byte order mark, then the YAML, then
+// the next line holding only ---. Delimiter lines may have trailing spaces
+// and CRLF line endings, and a line such as ---- or --- text inside the YAML
+// does not close it. Content is read a line at a time up to the closing
+// delimiter, so the body is never scanned.
+func findFrontmatter(content string) (frontmatterSpan, bool) {
+	pos := 0
+	if strings.HasPrefix(content, byteOrderMark) {
+		pos = len(byteOrderMark)
+	}
+	line, next := nextLine(content, pos)
+	if !isFrontmatterDelimiter(line) {
+		return frontmatterSpan{}, false
+	}
+
+	start := next
+	for pos = next; pos < len(content); pos = next {
+		line, next = nextLine(content, pos)
+		if !isFrontmatterDelimiter(line) {
+			continue
+		}
+		end := pos
+		if end > start {
+			end--
+			if end > start && content[end-1] == '\r' {
+				end--
+			}
+		}
+		return frontmatterSpan{start: start, end: end, close: pos + len(strings.TrimSuffix(line, "\r")), next: next}, true
+	}
+	return frontmatterSpan{}, false
+}
+
+// nextLine returns the line starting at pos, without its newline, and the
+// offset of the line after it.
+func nextLine(content string, pos int) (line string, next int) {
+	i := strings.IndexByte(content[pos:], '\n')
+	if i < 0 {
+		return content[pos:], len(content)
+	}
+	return content[pos : pos+i], pos + i + 1
+}
+
+// isFrontmatterDelimiter reports whether line opens or closes frontmatter.
+func isFrontmatterDelimiter(line string) bool {
+	return strings.TrimRight(line, " \t\r") == "---"
+}
+
+// FrontmatterEnd returns the offset of the line after the one closing the
+// YAML frontmatter of content, or 0 when content has no frontmatter.
+func FrontmatterEnd(content []byte) int {
+	span, ok := findFrontmatter(string(content))
+	if !ok {
+		return 0
+	}
+	return span.next
+}
@@ -0,0 +1,184 @@
+package skill
+
+import "testing"
+
+func TestSplitFrontmatter(t *testing.T) {
+	tests := []struct {
+		name            string
+		content         string
+		wantFrontmatter string
+		wantBody        string
+	}{
+		{
+			name:            "lf",
+			content:         "---\nname: demo\ndescription: Demo\n---\n# Demo\n",
+			wantFrontmatter: "name: demo\ndescription: Demo",
+			wantBody:        "# Demo\n",
+		},
+		{
+			name:            "crlf",
+			content:         "---\r\nname: demo\r\ndescription: Demo\r\n---\r\n# Demo\r\n",
+			wantFrontmatter: "name: demo\r\ndescription: Demo",
+			wantBody:        "# Demo\r\n",
+		},
+		{
+			name:            "byte order mark",
+			content:         "\ufeff---\nname: demo\n---\n# Demo\n",
+			wantFrontmatter: "name: demo",
+			wantBody:        "# Demo\n",
+		},
+		{
+			name:            "trailing spaces on delimiters",
+			content:         "--- \nname: demo\n---\t \n# Demo\n",
+			wantFrontmatter: "name: demo",
+			wantBody:        "# Demo\n",
+		},
+		{
+			name:            "blank lines before body",
+			content:         "---\nname: demo\n---\n\n\n# Demo\n",
+			wantFrontmatter: "name: demo",
+			wantBody:        "# Demo\n",
+		},
+		{
+			name:            "empty",
+			content:         "---\n---\n# Demo\n",
+			wantFrontmatter: "",
+			wantBody:        "# Demo\n",
+		},
+		{
+			name:            "closing delimiter at end of file",
+			content:         "---\nname: demo\n---",
+			wantFrontmatter: "name: demo",
+			wantBody:        "",
+		},
+		{
+			name:            "dashes inside values",
+			content:         "---\nname: demo\ndescription: \"a --- b\"\nnotes: |\n  ---\n  text\n---\n# Demo\n",
+			wantFrontmatter: "name: demo\ndescription: \"a --- b\"\nnotes: |\n  ---\n  text",
+			wantBody:        "# Demo\n",
+		},
+		{
+			name:            "lines starting with dashes do not close",
+			content:         "---\nname: demo\n----\n--- x\n---\n# Demo\n",
+			wantFrontmatter: "name: demo\n----\n--- x",
+			wantBody:        "# Demo\n",
+		},
+		{
+			name:            "horizontal rule in body",
+			content:         "---\nname: demo\n---\n# Demo\n---\nmore\n",
+			wantFrontmatter: "name: demo",
+			wantBody:        "# Demo\n---\nmore\n",
+		},
+		{
+			name:     "no frontmatter",
+			content:  "# Demo\n",
+			wantBody: "# Demo\n",
+		},
+		{
+			name:     "unclosed",
+			content:  "---\nname: demo\n# Demo\n",
+			wantBody: "---\nname: demo\n# Demo\n",
+		},
+		{
+			name:     "not at the start",
+			content:  "\n---\nname: demo\n---\n# Demo\n",
+			wantBody: "\n---\nname: demo\n---\n# Demo\n",
+		},
+		{
+			name:     "opening delimiter with text",
+			content:  "--- name: demo\n---\n# Demo\n",
+			wantBody: "--- name: demo\n---\n# Demo\n",
+		},
+		{
+			name:     "delimiter only",
+			content:  "---",
+			wantBody: "---",
+		},
+		{
+			name:     "empty content",
+			content:  "",
+			wantBody: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter, body := SplitFrontmatter(tt.content)
+			if frontmatter != tt.wantFrontmatter || body != tt.wantBody {
+				t.Errorf("SplitFrontmatter() = %q, %q, want %q, %q", frontmatter, body, tt.wantFrontmatter, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestParseFrontmatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantDesc string
+		wantErr  bool
+	}{
+		{name: "lf", content: "---\nname: demo\ndescription: Demo\n---\n", wantDesc: "Demo"},
+		{name: "crlf", content: "---\r\nname: demo\r\ndescription: Demo\r\n---\r\n", wantDesc: "Demo"},
+		{name: "byte order mark", content: "\ufeff---\nname: demo\ndescription: Demo\n---\n", wantDesc: "Demo"},
+		{name: "quoted dashes", content: "---\nname: demo\ndescription: \"--- Demo ---\"\n---\n", wantDesc: "--- Demo ---"},
+		{name: "block scalar with dashes", content: "---\nname: demo\ndescription: |\n  Demo\n  ---\n---\n", wantDesc: "Demo\n---"},
+		{name: "crlf block scalar", content: "---\r\ndescription: |\r\n  Demo\r\n  more\r\n---\r\n", wantDesc: "Demo\nmore"},
+		{name: "empty", content: "---\n---\n# Demo\n"},
+		{name: "missing", content: "# Demo\n", wantErr: true},
+		{name: "invalid yaml", content: "---\ndescription: [unterminated\n---\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := parseFrontmatter(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseFrontmatter() = %+v, want an error", meta)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFrontmatter() error = %v", err)
+			}
+			if meta.Description != tt.wantDesc {
+				t.Errorf("Description = %q, want %q", meta.Description, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestFrontmatterEnd(t *testing.T) {
+	tests := []struct {
+		content string
+		want    int
+	}{
+		{content: "---\nname: demo\n---\nbody\n", want: len("---\nname: demo\n---\n")},
+		{content: "---\r\nname: demo\r\n---\r\nbody\r\n", want: len("---\r\nname: demo\r\n---\r\n")},
+		{content: "\ufeff---\nname: demo\n---\n", want: len("\ufeff---\nname: demo\n---\n")},
+		{content: "---\nname: demo\n---", want: len("---\nname: demo\n---")},
+		{content: "body\n", want: 0},
+		{content: "---\nname: demo\n", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := FrontmatterEnd([]byte(tt.content)); got != tt.want {
+			t.Errorf("FrontmatterEnd(%q) = %d, want %d", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestSetFrontmatterKeyKeepsBody(t *testing.T) {
+	content := []byte("\ufeff---\r\nname: demo\r\n---\r\n# Demo\r\n---\r\n")
+	got, err := SetFrontmatterKey(content, "version", "1.0.0")
+	if err != nil {
+		t.Fatalf("SetFrontmatterKey() error = %v", err)
+	}
+	want := "---\nname: demo\nversion: 1.0.0\n---\r\n# Demo\r\n---\r\n"
+	if string(got) != want {
+		t.Errorf("SetFrontmatterKey() = %q, want %q", got, want)
+	}
+	if v := VersionOf(got); v != "1.0.0" {
+		t.Errorf("VersionOf() = %q, want 1.0.0", v)
+	}
+}
@@ -0,0 +1,133 @@
+package skill
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+// LintIssue represents a problem found in a skill's SKILL.md body.
+type LintIssue struct {
+	Line    int
+	Link    string
+	Message string
+}
+
+var (
+	// inlineLinkRegex matches [text](dest) and ![alt](dest) links.
+	inlineLinkRegex = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	// referenceLinkRegex matches reference definitions like [id]: dest.
+	referenceLinkRegex = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?(\S+?)>?(?:\s+.*)?$`)
+	// inlineCodeRegex matches inline code spans, which are ignored.
+	inlineCodeRegex = regexp.MustCompile("`[^`]*`")
+	// schemeRegex matches links with a URL scheme (https:, mailto:, ...).
+	schemeRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+)
+
+// LintLinks checks that relative links in SKILL.md point to files inside the skill directory.
+func (s *Store) LintLinks(sk *Skill) ([]LintIssue, error) {
+	skillFile := s.findSkillFile(sk.Path)
+	if skillFile == "" {
+		return nil, fmt.Errorf("SKILL.md not found in %s", sk.Path)
+	}
+
+	content, err := s.fs.ReadFile(skillFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SKILL.md: %w", err)
+	}
+
+	return lintLinks(s.fs, sk.Path, skillFile, content), nil
+}
+
+// lintLinks checks the relative links in content, the markdown file file in
+// the skill directory dir.
+func lintLinks(fsys platformfs.FileSystem, dir, file string, content []byte) []LintIssue {
+	baseDir := fsys.Dir(file)
+	var issues []LintIssue
+	for _, ref := range extractLinks(string(content)) {
+		target, ok := localLinkPath(ref.dest)
+		if !ok {
+			continue
+		}
+
+		resolved := filepath.Clean(fsys.Join(baseDir, target))
+		rel, err := fsys.Rel(dir, resolved)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			issues = append(issues, LintIssue{Line: ref.line, Link: ref.dest, Message: "link points outside the skill directory"})
+			continue
+		}
+
+		if !fsys.Exists(resolved) {
+			issues = append(issues, LintIssue{Line: ref.line, Link: ref.dest, Message: "referenced file does not exist"})
+		}
+	}
+
+	return issues
+}
+
+// linkRef is a link destination found in markdown.
+type linkRef struct {
+	line int
+	dest string
+}
+
+// extractLinks returns link destinations in markdown content,
+// skipping frontmatter, fenced code blocks, and inline code.
+func extractLinks(content string) []linkRef {
+	lines := strings.Split(content, "\n")
+
+	start := 0
+	if span, ok := findFrontmatter(content); ok {
+		start = strings.Count(content[:span.close], "\n") + 1
+	}
+
+	var refs []linkRef
+	inFence := false
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if m := referenceLinkRegex.FindStringSubmatch(line); m != nil {
+			refs = append(refs, linkRef{line: i + 1, dest: m[1]})
+			continue
+		}
+
+		line = inlineCodeRegex.ReplaceAllString(line, "")
+		for _, m := range inlineLinkRegex.FindAllStringSubmatch(line, -1) {
+			refs = append(refs, linkRef{line: i + 1, dest: m[1]})
+		}
+	}
+
+	return refs
+}
+
+// localLinkPath returns the relative file path for a link destination,
+// or false for URLs, absolute paths, and in-page anchors.
+func localLinkPath(dest string) (string, bool) {
+	if dest == "" || strings.HasPrefix(dest, "#") || strings.HasPrefix(dest, "/") || schemeRegex.MatchString(dest) {
+		return "", false
+	}
+
+	if i := strings.IndexAny(dest, "#?"); i >= 0 {
+		dest = dest[:i]
+	}
+	if unescaped, err := url.PathUnescape(dest); err == nil {
+		dest = unescaped
+	}
+	if dest == "" {
+		return "", false
+	}
+
+	return dest, true
+}
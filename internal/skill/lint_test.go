@@ -0,0 +1,59 @@
+package skill
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+func TestStoreLintLinks(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	setupGlobalSkillsDir(mock)
+
+	skillDir := "/home/test/.agents/skills/linked"
+	mock.Dirs[skillDir] = true
+	mock.Dirs[skillDir+"/scripts"] = true
+	mock.Files[skillDir+"/scripts/run.sh"] = []byte("#!/bin/sh\n")
+	mock.Files[skillDir+"/SKILL.md"] = []byte(`---
+name: linked
+description: links [here](missing.md)
+---
+
+Run [the script](scripts/run.sh) or see [docs](https://example.com) and [top](#usage).
+![diagram](images/diagram%20v2.png "Diagram")
+Do not follow [escape](../other/SKILL.md).
+Inline ` + "`[code](ignored.md)`" + ` is skipped.
+
+` + "```" + `
+[fenced](ignored.md)
+` + "```" + `
+
+[ref]: reference.md
+`)
+
+	store := NewStore(mock, config.DefaultConfig(), "")
+	sk, err := store.GetByName("linked")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+
+	issues, err := store.LintLinks(sk)
+	if err != nil {
+		t.Fatalf("LintLinks() error = %v", err)
+	}
+
+	want := []LintIssue{
+		{Line: 7, Link: "images/diagram%20v2.png", Message: "referenced file does not exist"},
+		{Line: 8, Link: "../other/SKILL.md", Message: "link points outside the skill directory"},
+		{Line: 15, Link: "reference.md", Message: "referenced file does not exist"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("LintLinks() = %+v, want %+v", issues, want)
+	}
+	for i := range want {
+		if issues[i] != want[i] {
+			t.Errorf("LintLinks()[%d] = %+v, want %+v", i, issues[i], want[i])
+		}
+	}
+}
@@ -3,17 +3,24 @@ package skill
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 )
 
 // Scope represents the scope level of a skill.
 type Scope int
 
 const (
-	// ScopeGlobal represents skills stored in ~/.agents/skills/
+	// ScopeGlobal represents skills stored in the global store
+	// (~/.local/share/skillet/skills/ or ~/.agents/skills/)
 	ScopeGlobal Scope = iota
 	// ScopeProject represents skills stored in <project>/.agents/skills/
 	ScopeProject
+	// ScopeOrg represents skills stored in a shared organization path (e.g. a cloned org repo).
+	ScopeOrg
+	// ScopeSystem represents read-only skills in a machine-wide store (e.g. /opt/agents/skills/).
+	ScopeSystem
 )
 
 func (s Scope) String() string {
@@ -22,6 +29,10 @@ func (s Scope) String() string {
 		return "global"
 	case ScopeProject:
 		return "project"
+	case ScopeOrg:
+		return "org"
+	case ScopeSystem:
+		return "system"
 	default:
 		return "unknown"
 	}
@@ -48,43 +59,218 @@ func (c Category) String() string {
 	}
 }
 
+// ParseCategory parses a category name as returned by Category.String.
+func ParseCategory(name string) (Category, error) {
+	switch name {
+	case CategoryDefault.String():
+		return CategoryDefault, nil
+	case CategoryOptional.String():
+		return CategoryOptional, nil
+	default:
+		return 0, fmt.Errorf("invalid category %q (use default or optional)", name)
+	}
+}
+
 // Skill represents an AI agent skill.
+//
+// A Skill loaded from a store is a handle: its name, path, scope, and
+// category come from the store's directories, while the frontmatter of its
+// SKILL.md is read only when Description or other metadata is first
+// accessed.
 type Skill struct {
-	Name        string
+	Name     string
+	Path     string   // absolute path to the skill directory
+	Scope    Scope    // where this skill is stored (system, global, org, project)
+	Category Category // whether the skill is always active or available on demand
+
+	// Vendored is true for project copies of skills from another scope
+	// (see skillet vendor). They are treated as project skills.
+	Vendored bool
+
+	// meta is the frontmatter of SKILL.md, shared by copies of the Skill.
+	meta *lazyMetadata
+}
+
+// Metadata is what a skill declares in the frontmatter of its SKILL.md.
+type Metadata struct {
 	Description string
-	Path        string   // absolute path to the skill directory
-	Scope       Scope    // where this skill is stored (global, project)
-	Category    Category // whether the skill is always active or available on demand
+
+	// RequiresCommands lists executables the skill expects on PATH.
+	RequiresCommands []string
+
+	// Requires lists skills that sync installs along with this one.
+	Requires []string
+
+	// Strategy is "copy", "symlink", or "hardlink" when the skill overrides the configured
+	// install strategy, and empty otherwise.
+	Strategy string
+
+	// Version is the skill's semantic version (version: in frontmatter), or
+	// empty when it declares none. Sync updates copies with an older version.
+	Version string
+
+	// Tags are free-form labels for finding the skill (tags: in frontmatter).
+	Tags []string
+
+	// Author and License describe who wrote the skill and how it may be used.
+	Author  string
+	License string
+
+	// Targets lists the only targets the skill works with (targets: in
+	// frontmatter), or is empty when it works with all of them. Sync does not
+	// install the skill into other targets.
+	Targets []string
+
+	// Draft is true for work-in-progress skills (draft: true in frontmatter).
+	// Drafts stay in the store but are never synced to targets.
+	Draft bool
+}
+
+// lazyMetadata reads the metadata of a skill once, on first use.
+type lazyMetadata struct {
+	once  sync.Once
+	load  func() (Metadata, error)
+	value Metadata
+	err   error
 }
 
 // NewSkill creates a new Skill. Use for all Skill creation.
-// Returns an error if the name is invalid.
+// Returns an error if the name is invalid. Skills in a collection are named
+// collection/skill-name.
 func NewSkill(name, description, path string, scope Scope, category Category) (*Skill, error) {
-	if err := ValidateName(name); err != nil {
+	if err := ValidateQualifiedName(name); err != nil {
 		return nil, err
 	}
-	return &Skill{
-		Name:        name,
-		Description: description,
-		Path:        path,
-		Scope:       scope,
-		Category:    category,
-	}, nil
+	sk := &Skill{
+		Name:     name,
+		Path:     path,
+		Scope:    scope,
+		Category: category,
+	}
+	sk.SetMetadata(Metadata{Description: description})
+	return sk, nil
+}
+
+// SetMetadata replaces the skill's metadata, so it is never read from SKILL.md.
+func (s *Skill) SetMetadata(m Metadata) {
+	s.meta = &lazyMetadata{load: func() (Metadata, error) { return m, nil }}
+}
+
+// Load reads the frontmatter of the skill's SKILL.md if it was not read yet,
+// and returns why it could not be read or parsed. Metadata of a skill that
+// failed to load is empty.
+func (s *Skill) Load() error {
+	_, err := s.metadata()
+	return err
 }
 
+// Metadata returns the frontmatter of the skill's SKILL.md, reading it on first use.
+func (s *Skill) Metadata() Metadata {
+	m, _ := s.metadata()
+	return m
+}
+
+func (s *Skill) metadata() (Metadata, error) {
+	if s.meta == nil {
+		return Metadata{}, nil
+	}
+	s.meta.once.Do(func() {
+		s.meta.value, s.meta.err = s.meta.load()
+	})
+	return s.meta.value, s.meta.err
+}
+
+// Description returns the skill's description, reading SKILL.md on first use.
+func (s *Skill) Description() string { return s.Metadata().Description }
+
+// Version returns the skill's semantic version, or empty when it declares none.
+func (s *Skill) Version() string { return s.Metadata().Version }
+
+// Draft reports whether the skill is a work in progress that is never synced.
+func (s *Skill) Draft() bool { return s.Metadata().Draft }
+
+// Targets returns the only targets the skill works with, or nil for all of them.
+func (s *Skill) Targets() []string { return s.Metadata().Targets }
+
+// Requires returns the skills sync installs along with this one.
+func (s *Skill) Requires() []string { return s.Metadata().Requires }
+
+// RequiresCommands returns the executables the skill expects on PATH.
+func (s *Skill) RequiresCommands() []string { return s.Metadata().RequiresCommands }
+
+// Strategy returns the install strategy the skill overrides, or empty.
+func (s *Skill) Strategy() string { return s.Metadata().Strategy }
+
+// Tags returns the skill's free-form labels.
+func (s *Skill) Tags() []string { return s.Metadata().Tags }
+
+// Author returns who wrote the skill.
+func (s *Skill) Author() string { return s.Metadata().Author }
+
+// License returns how the skill may be used.
+func (s *Skill) License() string { return s.Metadata().License }
+
 // Priority returns the priority of this skill for conflict resolution.
-// Higher priority wins. Project > Global.
+// Higher priority wins. Project > Org > Global > System.
 func (s *Skill) Priority() int {
 	switch s.Scope {
 	case ScopeProject:
-		return 2
+		return 4
+	case ScopeOrg:
+		return 3
 	case ScopeGlobal:
+		return 2
+	case ScopeSystem:
 		return 1
 	default:
 		return 0
 	}
 }
 
+// Collection returns the collection the skill belongs to (backend for
+// backend/api), or an empty string for a skill directly under skills/.
+func (s *Skill) Collection() string {
+	i := strings.LastIndex(s.Name, CollectionSeparator)
+	if i < 0 {
+		return ""
+	}
+	return s.Name[:i]
+}
+
+// ReadOnly reports whether the skill lives in a store skillet must not modify.
+func (s *Skill) ReadOnly() bool {
+	return s.Scope == ScopeSystem
+}
+
+// SupportsTarget reports whether the skill works with the named target: it
+// declares no targets, or lists this one.
+func (s *Skill) SupportsTarget(target string) bool {
+	targets := s.Targets()
+	return len(targets) == 0 || slices.Contains(targets, target)
+}
+
+// HasTags reports whether the skill has every one of tags.
+func (s *Skill) HasTags(tags []string) bool {
+	have := s.Tags()
+	for _, tag := range tags {
+		if !slices.Contains(have, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// MissingCommands returns the required commands that lookPath cannot find.
+func (s *Skill) MissingCommands(lookPath func(string) (string, error)) []string {
+	var missing []string
+	for _, name := range s.RequiresCommands() {
+		if _, err := lookPath(name); err != nil {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
 // validNamePattern matches valid skill names (alphanumeric, hyphen, underscore).
 var validNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
 
@@ -116,3 +302,21 @@ func ValidateName(name string) error {
 
 	return nil
 }
+
+// CollectionSeparator separates collections from the skill name in the name
+// of a skill in a collection (backend/api).
+const CollectionSeparator = "/"
+
+// ValidateQualifiedName checks the name of a skill that may be in a
+// collection: each part separated by CollectionSeparator must be a valid name.
+func ValidateQualifiedName(name string) error {
+	if name == "" {
+		return fmt.Errorf("skill name cannot be empty")
+	}
+	for _, part := range strings.Split(name, CollectionSeparator) {
+		if err := ValidateName(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,6 +1,10 @@
 package skill
 
-import "testing"
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
 
 func TestValidateName(t *testing.T) {
 	tests := []struct {
@@ -45,6 +49,8 @@ func TestScopeString(t *testing.T) {
 	}{
 		{ScopeGlobal, "global"},
 		{ScopeProject, "project"},
+		{ScopeOrg, "org"},
+		{ScopeSystem, "system"},
 		{Scope(99), "unknown"},
 	}
 
@@ -76,14 +82,28 @@ func TestCategoryString(t *testing.T) {
 	}
 }
 
+func TestParseCategory(t *testing.T) {
+	for _, want := range []Category{CategoryDefault, CategoryOptional} {
+		got, err := ParseCategory(want.String())
+		if err != nil || got != want {
+			t.Errorf("ParseCategory(%q) = %v, %v, want %v", want.String(), got, err, want)
+		}
+	}
+	if _, err := ParseCategory("unknown"); err == nil {
+		t.Error("ParseCategory(\"unknown\") should fail")
+	}
+}
+
 func TestSkillPriority(t *testing.T) {
 	tests := []struct {
 		name  string
 		scope Scope
 		want  int
 	}{
-		{"project priority", ScopeProject, 2},
-		{"global priority", ScopeGlobal, 1},
+		{"project priority", ScopeProject, 4},
+		{"org priority", ScopeOrg, 3},
+		{"global priority", ScopeGlobal, 2},
+		{"system priority", ScopeSystem, 1},
 		{"unknown priority", Scope(99), 0},
 	}
 
@@ -104,7 +124,52 @@ func TestSkillPriorityOrder(t *testing.T) {
 	projectSkill, _ := NewSkill("test", "", "", ScopeProject, 0)
 	globalSkill, _ := NewSkill("test", "", "", ScopeGlobal, 0)
 
+	orgSkill, _ := NewSkill("test", "", "", ScopeOrg, 0)
+
 	if projectSkill.Priority() <= globalSkill.Priority() {
 		t.Error("Project scope should have higher priority than Global scope")
 	}
+	if orgSkill.Priority() <= globalSkill.Priority() || orgSkill.Priority() >= projectSkill.Priority() {
+		t.Error("Org scope should have priority between Global and Project scope")
+	}
+
+	systemSkill, _ := NewSkill("test", "", "", ScopeSystem, 0)
+	if systemSkill.Priority() >= globalSkill.Priority() {
+		t.Error("System scope should have lower priority than Global scope")
+	}
+}
+
+func TestSkillMissingCommands(t *testing.T) {
+	s, err := NewSkill("test", "", "", ScopeGlobal, CategoryDefault)
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	s.SetMetadata(Metadata{RequiresCommands: []string{"git", "terraform", "kubectl"}})
+
+	lookPath := func(name string) (string, error) {
+		if name == "git" {
+			return "/usr/bin/git", nil
+		}
+		return "", fmt.Errorf("not found: %s", name)
+	}
+
+	got := s.MissingCommands(lookPath)
+	want := []string{"terraform", "kubectl"}
+	if !slices.Equal(got, want) {
+		t.Errorf("MissingCommands() = %v, want %v", got, want)
+	}
+}
+
+func TestSkillSupportsTarget(t *testing.T) {
+	s, err := NewSkill("test", "", "", ScopeGlobal, CategoryDefault)
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	if !s.SupportsTarget("codex") {
+		t.Error("a skill without targets should support every target")
+	}
+	s.SetMetadata(Metadata{Targets: []string{"claude"}})
+	if !s.SupportsTarget("claude") || s.SupportsTarget("codex") {
+		t.Errorf("SupportsTarget() with targets %v is wrong", s.Targets())
+	}
 }
@@ -0,0 +1,110 @@
+package skill
+
+import (
+	"sync"
+	"time"
+
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+// racyWindow is how long after a SKILL.md was modified its cached parse is
+// not trusted. Some file systems, network ones in particular, store
+// modification times with a granularity of a second or two, so a file
+// rewritten within that window may keep its mod time and size.
+const racyWindow = 2 * time.Second
+
+// parseKey identifies a SKILL.md on a file system.
+type parseKey struct {
+	fs   platformfs.FileSystem
+	path string
+}
+
+// parsedFile is the frontmatter of a SKILL.md as it was when it was read.
+type parsedFile struct {
+	modTime time.Time
+	size    int64
+	readAt  time.Time
+
+	meta     *skillMetadata
+	parseErr error
+
+	// schemaErr is the result of validating the frontmatter against
+	// FrontmatterSchema, set once validated is true.
+	validated   bool
+	frontmatter string
+	schemaErr   error
+}
+
+// parseCache holds the parsed frontmatter of every SKILL.md read during the
+// run, so the stores created by each service, and each GetAll call, read and
+// parse a file only once while it is unchanged. Entries of files that changed
+// are replaced and those of removed files are dropped when they are next
+// looked up; ResetParseCache drops the rest between runs.
+var parseCache = struct {
+	sync.Mutex
+	files map[parseKey]*parsedFile
+}{files: make(map[parseKey]*parsedFile)}
+
+// ResetParseCache drops every cached parse. A process that runs several
+// times, like sync --watch, calls it before each run, so the cache holds only
+// the files of one run.
+func ResetParseCache() {
+	parseCache.Lock()
+	defer parseCache.Unlock()
+	clear(parseCache.files)
+}
+
+// parseSkillFile returns the parsed frontmatter of the SKILL.md at path,
+// reading it only when it changed since it was last parsed.
+func parseSkillFile(fsys platformfs.FileSystem, path string) (*parsedFile, error) {
+	key := parseKey{fs: fsys, path: path}
+	info, statErr := fsys.Stat(path)
+	parseCache.Lock()
+	cached := parseCache.files[key]
+	if cached != nil && (statErr != nil || !cached.fresh(info.ModTime(), info.Size())) {
+		delete(parseCache.files, key)
+		cached = nil
+	}
+	parseCache.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	readAt := time.Now()
+	content, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed := &parsedFile{readAt: readAt}
+	parsed.meta, parsed.parseErr = parseFrontmatter(string(content))
+	parsed.frontmatter, _ = SplitFrontmatter(string(content))
+
+	// A file system that reports no modification time cannot tell when a
+	// file changes, so its files are read every time.
+	if statErr == nil && !info.ModTime().IsZero() {
+		parsed.modTime, parsed.size = info.ModTime(), info.Size()
+		parseCache.Lock()
+		parseCache.files[key] = parsed
+		parseCache.Unlock()
+	}
+	return parsed, nil
+}
+
+// fresh reports whether the file still has the mod time and size it had when
+// it was parsed, and was last modified long enough before it was read for
+// the mod time to be trusted.
+func (p *parsedFile) fresh(modTime time.Time, size int64) bool {
+	return modTime.Equal(p.modTime) && size == p.size && p.readAt.Sub(modTime) >= racyWindow
+}
+
+// validate checks the frontmatter against FrontmatterSchema, once per parse.
+func (p *parsedFile) validate() error {
+	parseCache.Lock()
+	defer parseCache.Unlock()
+	if !p.validated {
+		p.schemaErr = ValidateFrontmatter(p.frontmatter)
+		p.frontmatter = ""
+		p.validated = true
+	}
+	return p.schemaErr
+}
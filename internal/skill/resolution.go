@@ -0,0 +1,134 @@
+package skill
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ResolutionPolicy selects which copy wins when several scopes define a skill
+// with the same name.
+type ResolutionPolicy string
+
+const (
+	// ResolveProjectWins prefers Project > Org > Global > System (the default).
+	ResolveProjectWins ResolutionPolicy = "project-wins"
+	// ResolveGlobalWins prefers Global > Org > Project > System.
+	ResolveGlobalWins ResolutionPolicy = "global-wins"
+	// ResolveErrorOnConflict refuses to pick a copy and reports the conflict.
+	ResolveErrorOnConflict ResolutionPolicy = "error-on-conflict"
+	// ResolveNewestWins prefers the copy whose SKILL.md was modified last,
+	// falling back to ResolveProjectWins on ties.
+	ResolveNewestWins ResolutionPolicy = "newest-wins"
+)
+
+// ParseResolutionPolicy parses a policy name. An empty name is ResolveProjectWins.
+func ParseResolutionPolicy(name string) (ResolutionPolicy, error) {
+	switch p := ResolutionPolicy(name); p {
+	case "":
+		return ResolveProjectWins, nil
+	case ResolveProjectWins, ResolveGlobalWins, ResolveErrorOnConflict, ResolveNewestWins:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown resolution policy %q (use project-wins, global-wins, error-on-conflict, or newest-wins)", name)
+	}
+}
+
+// ResolutionPolicySource is implemented by resolvers that choose how
+// same-named skills from different scopes are resolved.
+type ResolutionPolicySource interface {
+	ConflictResolution() string
+}
+
+// Conflict is a skill name defined in more than one scope.
+type Conflict struct {
+	Name string
+	// Skills are the conflicting copies, highest priority first.
+	Skills []*Skill
+}
+
+// ConflictError reports skills that could not be resolved under
+// ResolveErrorOnConflict. Conflicts are sorted by name.
+type ConflictError struct {
+	Conflicts []Conflict
+}
+
+func (e *ConflictError) Error() string {
+	parts := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		parts = append(parts, c.String())
+	}
+	return "conflicting skills: " + strings.Join(parts, ", ")
+}
+
+// String describes the conflict as the name and its scopes, e.g. "review (project, global)".
+func (c Conflict) String() string {
+	scopes := make([]string, 0, len(c.Skills))
+	for _, sk := range c.Skills {
+		scopes = append(scopes, sk.Scope.String())
+	}
+	return fmt.Sprintf("%s (%s)", c.Name, strings.Join(scopes, ", "))
+}
+
+// resolve picks the winning copy among same-named candidates. Under
+// ResolveErrorOnConflict it returns nil and the conflict when there is more
+// than one copy; a vendored copy still wins over the skill it was copied from.
+func (s *Store) resolve(candidates []*Skill) (*Skill, *Conflict) {
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	ordered := slices.Clone(candidates)
+	slices.SortStableFunc(ordered, func(a, b *Skill) int {
+		return b.Priority() - a.Priority()
+	})
+
+	switch s.policy {
+	case ResolveGlobalWins:
+		slices.SortStableFunc(ordered, func(a, b *Skill) int {
+			return globalWinsRank(b) - globalWinsRank(a)
+		})
+	case ResolveNewestWins:
+		modTimes := make(map[*Skill]time.Time, len(ordered))
+		for _, sk := range ordered {
+			modTimes[sk] = s.modTime(sk)
+		}
+		slices.SortStableFunc(ordered, func(a, b *Skill) int {
+			return modTimes[b].Compare(modTimes[a])
+		})
+	case ResolveErrorOnConflict:
+		if i := slices.IndexFunc(ordered, func(sk *Skill) bool { return sk.Vendored }); i >= 0 {
+			return ordered[i], nil
+		}
+		return nil, &Conflict{Name: ordered[0].Name, Skills: ordered}
+	}
+	return ordered[0], nil
+}
+
+// globalWinsRank orders scopes for ResolveGlobalWins: Global > Org > Project > System.
+func globalWinsRank(sk *Skill) int {
+	switch sk.Scope {
+	case ScopeGlobal:
+		return 4
+	case ScopeOrg:
+		return 3
+	case ScopeProject:
+		return 2
+	default:
+		return sk.Priority()
+	}
+}
+
+// modTime returns when the skill's SKILL.md was last modified, or the zero
+// time when it cannot be read.
+func (s *Store) modTime(sk *Skill) time.Time {
+	skillFile := s.findSkillFile(sk.Path)
+	if skillFile == "" {
+		return time.Time{}
+	}
+	info, err := s.fs.Stat(skillFile)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
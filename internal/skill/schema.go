@@ -0,0 +1,270 @@
+package skill
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FrontmatterSchemaVersion is the version of the embedded frontmatter schema.
+const FrontmatterSchemaVersion = 1
+
+// FrontmatterSchema is the JSON Schema for SKILL.md frontmatter.
+//
+//go:embed schema/frontmatter.v1.json
+var FrontmatterSchema []byte
+
+const (
+	// MaxNameLength is the longest name the frontmatter schema allows.
+	MaxNameLength = 64
+	// MaxDescriptionLength is the longest description the frontmatter schema allows.
+	MaxDescriptionLength = 1024
+)
+
+// requiredFields are the frontmatter fields the schema requires.
+var requiredFields = []string{"name", "description"}
+
+// fieldChecks validates the value of each property in FrontmatterSchema.
+// Frontmatter keys without a check are unknown to the schema.
+var fieldChecks = map[string]func(value *yaml.Node) error{
+	"name": func(value *yaml.Node) error {
+		name, err := decodeString(value, "name")
+		if err != nil || name == "" {
+			return err
+		}
+		if len(name) > MaxNameLength {
+			return fmt.Errorf("name is longer than %d characters", MaxNameLength)
+		}
+		return ValidateName(name)
+	},
+	"description": func(value *yaml.Node) error {
+		description, err := decodeString(value, "description")
+		if err == nil && len(description) > MaxDescriptionLength {
+			return fmt.Errorf("description is %d characters, longer than %d", len(description), MaxDescriptionLength)
+		}
+		return err
+	},
+	"requiresCommands": func(value *yaml.Node) error {
+		commands, err := decodeStrings(value, "requiresCommands")
+		if err != nil {
+			return err
+		}
+		for _, c := range commands {
+			if strings.TrimSpace(c) == "" {
+				return fmt.Errorf("requiresCommands must not contain empty entries")
+			}
+		}
+		return nil
+	},
+	"requires": func(value *yaml.Node) error {
+		names, err := decodeStrings(value, "requires")
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if err := ValidateQualifiedName(name); err != nil {
+				return fmt.Errorf("requires: %w", err)
+			}
+		}
+		return nil
+	},
+	"strategy": func(value *yaml.Node) error {
+		strategy, err := decodeString(value, "strategy")
+		if err != nil {
+			return err
+		}
+		return validateStrategy(strategy)
+	},
+	"version": func(value *yaml.Node) error {
+		version, err := decodeString(value, "version")
+		if err != nil {
+			return err
+		}
+		return validateVersion(version)
+	},
+	"allowed-tools": func(value *yaml.Node) error {
+		if value.Kind == yaml.ScalarNode {
+			_, err := decodeString(value, "allowed-tools")
+			return err
+		}
+		if value.Kind != yaml.SequenceNode {
+			return fmt.Errorf("allowed-tools must be a string or a list of strings")
+		}
+		if _, err := decodeStrings(value, "allowed-tools"); err != nil {
+			return fmt.Errorf("allowed-tools entries must be strings")
+		}
+		return nil
+	},
+	"draft": func(value *yaml.Node) error {
+		var draft bool
+		if err := value.Decode(&draft); err != nil {
+			return fmt.Errorf("draft must be true or false")
+		}
+		return nil
+	},
+	"tags": func(value *yaml.Node) error {
+		tags, err := decodeStrings(value, "tags")
+		if err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			if strings.TrimSpace(tag) == "" {
+				return fmt.Errorf("tags must not contain empty entries")
+			}
+		}
+		return nil
+	},
+	"author": func(value *yaml.Node) error {
+		_, err := decodeString(value, "author")
+		return err
+	},
+	"targets": func(value *yaml.Node) error {
+		targets, err := decodeStrings(value, "targets")
+		if err != nil {
+			return err
+		}
+		for _, target := range targets {
+			if strings.TrimSpace(target) == "" {
+				return fmt.Errorf("targets must not contain empty entries")
+			}
+		}
+		return nil
+	},
+	"license": func(value *yaml.Node) error {
+		_, err := decodeString(value, "license")
+		return err
+	},
+	"metadata": func(value *yaml.Node) error {
+		if value.Kind != yaml.MappingNode {
+			return fmt.Errorf("metadata must be a mapping")
+		}
+		return nil
+	},
+}
+
+// CheckField checks the value of a frontmatter field against the schema. It
+// reports false for fields the schema does not know.
+func CheckField(key string, value *yaml.Node) (bool, error) {
+	check, ok := fieldChecks[key]
+	if !ok {
+		return false, nil
+	}
+	return true, check(value)
+}
+
+// decodeString decodes a scalar frontmatter value.
+func decodeString(value *yaml.Node, field string) (string, error) {
+	if value.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("%s must be a string", field)
+	}
+	return strings.TrimSpace(value.Value), nil
+}
+
+// decodeStrings decodes a frontmatter list of scalars.
+func decodeStrings(value *yaml.Node, field string) ([]string, error) {
+	if value.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("%s must be a list of strings", field)
+	}
+	values := make([]string, 0, len(value.Content))
+	for _, item := range value.Content {
+		if item.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%s must be a list of strings", field)
+		}
+		values = append(values, item.Value)
+	}
+	return values, nil
+}
+
+// validateStrategy checks the strategy a skill asks to be installed with.
+func validateStrategy(strategy string) error {
+	switch strategy {
+	case "", "copy", "symlink", "hardlink":
+		return nil
+	default:
+		return fmt.Errorf("invalid strategy %q (use copy, symlink, or hardlink)", strategy)
+	}
+}
+
+// SchemaProblem is a way frontmatter does not match FrontmatterSchema.
+type SchemaProblem struct {
+	// Line is the frontmatter line the problem is on, counting from 1, or 0
+	// when it concerns the frontmatter as a whole
+	Line    int
+	Message string
+}
+
+// yamlLineRegex matches the line number in YAML syntax errors.
+var yamlLineRegex = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// CheckFrontmatter checks raw YAML frontmatter against the schema and
+// returns every problem: those on a line in line order, then missing
+// required fields.
+func CheckFrontmatter(frontmatter string) []SchemaProblem {
+	problems, _ := checkFrontmatter(frontmatter)
+	return problems
+}
+
+// checkFrontmatter checks frontmatter like CheckFrontmatter and also returns
+// its fields' values by key.
+func checkFrontmatter(frontmatter string) ([]SchemaProblem, map[string]*yaml.Node) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(frontmatter), &doc); err != nil {
+		problem := SchemaProblem{Message: err.Error()}
+		if m := yamlLineRegex.FindStringSubmatch(problem.Message); m != nil {
+			problem.Line, _ = strconv.Atoi(m[1])
+			problem.Message = strings.TrimPrefix(problem.Message, m[0])
+		}
+		return []SchemaProblem{problem}, nil
+	}
+
+	var problems []SchemaProblem
+	fields := make(map[string]*yaml.Node)
+	if len(doc.Content) > 0 {
+		mapping := doc.Content[0]
+		if mapping.Kind != yaml.MappingNode {
+			return []SchemaProblem{{Line: mapping.Line, Message: "frontmatter must be a mapping"}}, nil
+		}
+		for i := 0; i+1 < len(mapping.Content); i += 2 {
+			key, value := mapping.Content[i], mapping.Content[i+1]
+			check, ok := fieldChecks[key.Value]
+			if !ok {
+				problems = append(problems, SchemaProblem{Line: key.Line, Message: fmt.Sprintf("field %s not found in schema v%d", key.Value, FrontmatterSchemaVersion)})
+				continue
+			}
+			fields[key.Value] = value
+			if err := check(value); err != nil {
+				problems = append(problems, SchemaProblem{Line: value.Line, Message: err.Error()})
+			}
+		}
+	}
+
+	for _, field := range requiredFields {
+		value, ok := fields[field]
+		switch {
+		case !ok:
+			problems = append(problems, SchemaProblem{Message: fmt.Sprintf("frontmatter is missing required field %q", field)})
+		case value.Kind == yaml.ScalarNode && strings.TrimSpace(value.Value) == "":
+			problems = append(problems, SchemaProblem{Line: value.Line, Message: fmt.Sprintf("required field %q is empty", field)})
+		}
+	}
+	return problems, fields
+}
+
+// ValidateFrontmatter checks raw YAML frontmatter against the schema:
+// unknown fields and missing required fields are errors. It reports the
+// first problem CheckFrontmatter finds.
+func ValidateFrontmatter(frontmatter string) error {
+	problems := CheckFrontmatter(frontmatter)
+	if len(problems) == 0 {
+		return nil
+	}
+	p := problems[0]
+	if p.Line > 0 {
+		return fmt.Errorf("frontmatter does not match schema v%d: line %d: %s", FrontmatterSchemaVersion, p.Line, p.Message)
+	}
+	return fmt.Errorf("frontmatter does not match schema v%d: %s", FrontmatterSchemaVersion, p.Message)
+}
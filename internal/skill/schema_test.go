@@ -0,0 +1,112 @@
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+func TestFrontmatterSchemaMatchesFieldChecks(t *testing.T) {
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Required   []string                   `json:"required"`
+	}
+	if err := json.Unmarshal(FrontmatterSchema, &schema); err != nil {
+		t.Fatalf("embedded schema is not valid JSON: %v", err)
+	}
+
+	fields := slices.Sorted(maps.Keys(fieldChecks))
+	props := slices.Sorted(maps.Keys(schema.Properties))
+	if !slices.Equal(fields, props) {
+		t.Fatalf("fieldChecks %v do not match schema properties %v", fields, props)
+	}
+	if !slices.Equal(requiredFields, schema.Required) {
+		t.Fatalf("requiredFields %v do not match schema required %v", requiredFields, schema.Required)
+	}
+}
+
+func TestValidateFrontmatter(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		wantErr     string
+	}{
+		{"minimal", "name: a\ndescription: b", ""},
+		{"all fields", "name: a\ndescription: b\nrequiresCommands: [git]\nrequires: [base]\nstrategy: copy\nversion: 1.2.0\nallowed-tools: [Read, Bash]\ntags: [pdf]\nauthor: Jane\ntargets: [claude]\nlicense: MIT\nmetadata:\n  owner: team", ""},
+		{"allowed-tools string", "name: a\ndescription: b\nallowed-tools: Read", ""},
+		{"unknown field", "name: a\ndescription: b\nhomepage: x", "field homepage not found"},
+		{"missing description", "name: a", `"description"`},
+		{"empty", "", `"name"`},
+		{"bad requires", "name: a\ndescription: b\nrequires: [../x]", "requires"},
+		{"bad strategy", "name: a\ndescription: b\nstrategy: junction", "strategy"},
+		{"bad version", "name: a\ndescription: b\nversion: latest", "version"},
+		{"bad allowed-tools", "name: a\ndescription: b\nallowed-tools: {x: 1}", "allowed-tools"},
+		{"collection requires", "name: a\ndescription: b\nrequires: [backend/api]", ""},
+		{"long description", "name: a\ndescription: " + strings.Repeat("x", MaxDescriptionLength+1), "longer than 1024"},
+		{"bad name", "name: my skill\ndescription: b", "line 1: skill name must start"},
+		{"bad tags", "name: a\ndescription: b\ntags: pdf", "tags"},
+		{"empty target", "name: a\ndescription: b\ntargets: [\"\"]", "targets"},
+		{"bad draft", "name: a\ndescription: b\ndraft: maybe", "draft"},
+		{"not yaml", "name: [a", "line 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFrontmatter(tt.frontmatter)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateFrontmatter() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateFrontmatter() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckFrontmatterReportsEveryProblem(t *testing.T) {
+	problems := CheckFrontmatter("name: a\nhomepage: x\nstrategy: junction")
+	var got []string
+	for _, p := range problems {
+		got = append(got, fmt.Sprintf("%d: %s", p.Line, p.Message))
+	}
+	want := []string{
+		"2: field homepage not found in schema v1",
+		`3: invalid strategy "junction" (use copy, symlink, or hardlink)`,
+		`0: frontmatter is missing required field "description"`,
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("CheckFrontmatter() = %q, want %q", got, want)
+	}
+}
+
+func TestStoreStrictFrontmatter(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	setupGlobalSkillsDir(mock)
+	addSkillToMock(mock, "/home/test/.agents/skills", "valid", "A valid skill")
+	mock.Dirs["/home/test/.agents/skills/extra"] = true
+	mock.Files["/home/test/.agents/skills/extra/SKILL.md"] = []byte("---\nname: extra\ndescription: x\nhomepage: x\n---\n")
+
+	cfg := config.DefaultConfig()
+	skills, err := NewStore(mock, cfg, "").GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(skills) != 2 {
+		t.Fatalf("lenient GetAll() returned %d skills, want 2", len(skills))
+	}
+
+	cfg.Frontmatter.Strict = true
+	if _, err := NewStore(mock, cfg, "").GetAll(); err == nil || !strings.Contains(err.Error(), "extra") {
+		t.Fatalf("strict GetAll() error = %v, want error naming the invalid skill", err)
+	}
+}
@@ -1,11 +1,11 @@
 package skill
 
 import (
-	"cmp"
+	"bytes"
 	"fmt"
+	"log/slog"
 	"maps"
 	"os"
-	"regexp"
 	"slices"
 	"strings"
 
@@ -16,8 +16,16 @@ import (
 
 // SkillsPathResolver resolves scope-specific skill root directories.
 type SkillsPathResolver interface {
+	SystemSkillsDir(fsys platformfs.FileSystem) (string, error)
 	GlobalSkillsDir(fsys platformfs.FileSystem) (string, error)
+	OrgSkillsDir(fsys platformfs.FileSystem) (string, error)
 	ProjectSkillsDir(fsys platformfs.FileSystem, projectRoot string) string
+	ProjectVendorDir(fsys platformfs.FileSystem, projectRoot string) string
+}
+
+// FrontmatterPolicy is implemented by resolvers that control frontmatter validation.
+type FrontmatterPolicy interface {
+	StrictFrontmatter() bool
 }
 
 // Store manages skill persistence and retrieval.
@@ -25,27 +33,55 @@ type Store struct {
 	fs          platformfs.FileSystem
 	paths       SkillsPathResolver
 	projectRoot string
+	strict      bool
+	policy      ResolutionPolicy
+	policyErr   error
 }
 
 // NewStore creates a new Store.
+// When paths implements FrontmatterPolicy and enables it, skills whose
+// frontmatter does not match FrontmatterSchema fail to load.
+// When paths implements ResolutionPolicySource, its policy decides which copy
+// of a skill defined in several scopes wins; otherwise project wins.
 func NewStore(fsys platformfs.FileSystem, paths SkillsPathResolver, projectRoot string) *Store {
-	return &Store{
+	s := &Store{
 		fs:          fsys,
 		paths:       paths,
 		projectRoot: projectRoot,
+		policy:      ResolveProjectWins,
+	}
+	if p, ok := paths.(FrontmatterPolicy); ok {
+		s.strict = p.StrictFrontmatter()
 	}
+	if p, ok := paths.(ResolutionPolicySource); ok {
+		s.policy, s.policyErr = ParseResolutionPolicy(p.ConflictResolution())
+	}
+	return s
 }
 
-// GetAll returns all skills from all scopes.
+// GetAll returns all skills from all scopes. Their SKILL.md files are read
+// only when their metadata is accessed, unless the store is strict.
 func (s *Store) GetAll() ([]*Skill, error) {
 	var allSkills []*Skill
 
+	systemSkills, err := s.getSystemSkills()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load system skills: %w", err)
+	}
+	allSkills = append(allSkills, systemSkills...)
+
 	globalSkills, err := s.getGlobalSkills()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load global skills: %w", err)
 	}
 	allSkills = append(allSkills, globalSkills...)
 
+	orgSkills, err := s.getOrgSkills()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load org skills: %w", err)
+	}
+	allSkills = append(allSkills, orgSkills...)
+
 	projectSkills, err := s.getProjectSkills()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load project skills: %w", err)
@@ -55,11 +91,45 @@ func (s *Store) GetAll() ([]*Skill, error) {
 	return allSkills, nil
 }
 
+// Dirs returns the existing skill root directories of every scope, including
+// the project's vendor directory.
+func (s *Store) Dirs() ([]string, error) {
+	var candidates []string
+	for _, resolve := range []func(platformfs.FileSystem) (string, error){
+		s.paths.SystemSkillsDir,
+		s.paths.GlobalSkillsDir,
+		s.paths.OrgSkillsDir,
+	} {
+		dir, err := resolve(s.fs)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, dir)
+	}
+	if s.projectRoot != "" {
+		candidates = append(candidates,
+			s.paths.ProjectSkillsDir(s.fs, s.projectRoot),
+			s.paths.ProjectVendorDir(s.fs, s.projectRoot))
+	}
+
+	var dirs []string
+	for _, dir := range candidates {
+		if dir != "" && s.fs.IsDir(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs, nil
+}
+
 // GetByScope returns skills from a specific scope.
 func (s *Store) GetByScope(scope Scope) ([]*Skill, error) {
 	switch scope {
+	case ScopeSystem:
+		return s.getSystemSkills()
 	case ScopeGlobal:
 		return s.getGlobalSkills()
+	case ScopeOrg:
+		return s.getOrgSkills()
 	case ScopeProject:
 		return s.getProjectSkills()
 	default:
@@ -67,32 +137,50 @@ func (s *Store) GetByScope(scope Scope) ([]*Skill, error) {
 	}
 }
 
-// GetByName returns a skill by name, respecting priority.
+// GetByName returns a skill by name, resolved by the store's resolution policy.
+// Under ResolveErrorOnConflict, a name defined in several scopes returns a *ConflictError.
 func (s *Store) GetByName(name string) (*Skill, error) {
-	if err := ValidateName(name); err != nil {
+	if err := ValidateQualifiedName(name); err != nil {
 		return nil, fmt.Errorf("invalid skill name %q: %w", name, err)
 	}
+	if s.policyErr != nil {
+		return nil, s.policyErr
+	}
 	allSkills, err := s.GetAll()
 	if err != nil {
 		return nil, err
 	}
 
-	var best *Skill
+	var candidates []*Skill
 	for _, sk := range allSkills {
-		if sk.Name == name && (best == nil || sk.Priority() > best.Priority()) {
-			best = sk
+		if sk.Name == name {
+			candidates = append(candidates, sk)
 		}
 	}
 
-	if best == nil {
+	if len(candidates) == 0 {
 		return nil, fmt.Errorf("skill not found: %s", name)
 	}
+	// A copy whose SKILL.md fails to load never shadows another one.
+	if len(candidates) > 1 {
+		if loaded := slices.DeleteFunc(slices.Clone(candidates), func(sk *Skill) bool { return sk.Load() != nil }); len(loaded) > 0 {
+			candidates = loaded
+		}
+	}
 
+	best, conflict := s.resolve(candidates)
+	if conflict != nil {
+		return nil, &ConflictError{Conflicts: []Conflict{*conflict}}
+	}
 	return best, nil
 }
 
 // Remove removes a skill from the store.
+// Read-only skills (system scope) cannot be removed.
 func (s *Store) Remove(sk *Skill) error {
+	if sk.ReadOnly() {
+		return fmt.Errorf("skill %s is in the read-only %s store", sk.Name, sk.Scope)
+	}
 	if err := s.fs.RemoveAll(sk.Path); err != nil {
 		return fmt.Errorf("failed to remove skill: %w", err)
 	}
@@ -105,23 +193,52 @@ func (s *Store) Exists(name string) bool {
 	return err == nil
 }
 
-// GetResolved returns all skills after resolving conflicts.
+// GetResolved returns all skills to deploy after resolving conflicts with the
+// store's resolution policy. Drafts are left out, so a draft never shadows a
+// published skill. Under ResolveErrorOnConflict, names defined in several
+// scopes are left out and reported in a *ConflictError returned along with
+// the remaining skills.
 func (s *Store) GetResolved() ([]*Skill, error) {
+	if s.policyErr != nil {
+		return nil, s.policyErr
+	}
 	allSkills, err := s.GetAll()
 	if err != nil {
 		return nil, err
 	}
 
-	best := make(map[string]*Skill)
+	byName := make(map[string][]*Skill)
 	for _, sk := range allSkills {
-		if cur, ok := best[sk.Name]; !ok || sk.Priority() > cur.Priority() {
-			best[sk.Name] = sk
+		// Deploying needs the metadata of every skill, so skills whose
+		// SKILL.md fails to load are left out here, as are drafts.
+		if sk.Load() != nil {
+			continue
+		}
+		if sk.Draft() {
+			slog.Debug("skipping draft skill", "skill", sk.Name, "path", sk.Path)
+			continue
 		}
+		byName[sk.Name] = append(byName[sk.Name], sk)
 	}
 
-	return slices.SortedFunc(maps.Values(best), func(a, b *Skill) int {
-		return cmp.Compare(a.Name, b.Name)
-	}), nil
+	var resolved []*Skill
+	var conflicts []Conflict
+	for _, name := range slices.Sorted(maps.Keys(byName)) {
+		best, conflict := s.resolve(byName[name])
+		if conflict != nil {
+			conflicts = append(conflicts, *conflict)
+			continue
+		}
+		if len(byName[name]) > 1 {
+			slog.Debug("resolved skill defined in several scopes", "skill", name, "scope", best.Scope.String(), "path", best.Path)
+		}
+		resolved = append(resolved, best)
+	}
+
+	if len(conflicts) > 0 {
+		return resolved, &ConflictError{Conflicts: conflicts}
+	}
+	return resolved, nil
 }
 
 // FindInScope finds a skill by name in a specific scope.
@@ -155,6 +272,44 @@ func (s *Store) getGlobalSkills() ([]*Skill, error) {
 	return append(defaultSkills, optionalSkills...), nil
 }
 
+// getSystemSkills loads skills from the machine-wide system directory.
+// Returns no skills when no system path is configured.
+func (s *Store) getSystemSkills() ([]*Skill, error) {
+	skillsDir, err := s.paths.SystemSkillsDir(s.fs)
+	if err != nil {
+		return nil, err
+	}
+	if skillsDir == "" {
+		return nil, nil
+	}
+
+	defaultSkills, optionalSkills, err := s.loadAllInDir(skillsDir, ScopeSystem)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(defaultSkills, optionalSkills...), nil
+}
+
+// getOrgSkills loads skills from the shared organization directory.
+// Returns no skills when no org path is configured.
+func (s *Store) getOrgSkills() ([]*Skill, error) {
+	skillsDir, err := s.paths.OrgSkillsDir(s.fs)
+	if err != nil {
+		return nil, err
+	}
+	if skillsDir == "" {
+		return nil, nil
+	}
+
+	defaultSkills, optionalSkills, err := s.loadAllInDir(skillsDir, ScopeOrg)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(defaultSkills, optionalSkills...), nil
+}
+
 // getProjectSkills loads skills from project directories.
 func (s *Store) getProjectSkills() ([]*Skill, error) {
 	if s.projectRoot == "" {
@@ -166,8 +321,40 @@ func (s *Store) getProjectSkills() ([]*Skill, error) {
 	if err != nil {
 		return nil, err
 	}
+	skills := append(defaultSkills, optionalSkills...)
 
-	return append(defaultSkills, optionalSkills...), nil
+	vendored, err := s.getVendoredSkills(skills)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(skills, vendored...), nil
+}
+
+// getVendoredSkills loads vendored copies of non-project skills as project skills.
+// Project skills with the same name take precedence and hide the vendored copy.
+func (s *Store) getVendoredSkills(projectSkills []*Skill) ([]*Skill, error) {
+	vendorDir := s.paths.ProjectVendorDir(s.fs, s.projectRoot)
+	loaded, err := s.loadCollection(vendorDir, "", ScopeProject, CategoryDefault, "vendored skill", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	defined := make(map[string]bool, len(projectSkills))
+	for _, sk := range projectSkills {
+		defined[sk.Name] = true
+	}
+
+	var skills []*Skill
+	for _, sk := range loaded {
+		if defined[sk.Name] {
+			continue
+		}
+		sk.Vendored = true
+		skills = append(skills, sk)
+	}
+
+	return skills, nil
 }
 
 const (
@@ -177,28 +364,81 @@ const (
 
 // skillMetadata represents the YAML frontmatter in SKILL.md.
 type skillMetadata struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
+	Name             string   `yaml:"name"`
+	Description      string   `yaml:"description"`
+	RequiresCommands []string `yaml:"requiresCommands,omitempty"`
+	Requires         []string `yaml:"requires,omitempty"`
+	Strategy         string   `yaml:"strategy,omitempty"`
+	Version          string   `yaml:"version,omitempty"`
+	Tags             []string `yaml:"tags,omitempty"`
+	Author           string   `yaml:"author,omitempty"`
+	License          string   `yaml:"license,omitempty"`
+	Targets          []string `yaml:"targets,omitempty"`
+	Draft            bool     `yaml:"draft,omitempty"`
 }
 
-// loadSkill loads a skill from a directory.
+// loadSkill returns a handle to the skill in a directory. Its SKILL.md is
+// read on first access to its metadata, except for a strict store, which
+// validates it right away. In a lenient store, a skill whose SKILL.md cannot
+// be loaded warns when it is accessed and has empty metadata.
 func (s *Store) loadSkill(dir string, scope Scope, category Category) (*Skill, error) {
 	skillFile := s.findSkillFile(dir)
 	if skillFile == "" {
 		return nil, fmt.Errorf("SKILL.md not found in %s", dir)
 	}
 
-	content, err := s.fs.ReadFile(skillFile)
+	sk, err := NewSkill(s.fs.Base(dir), "", dir, scope, category)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read SKILL.md: %w", err)
+		return nil, err
+	}
+	sk.meta = &lazyMetadata{load: func() (Metadata, error) {
+		meta, err := s.readMetadata(skillFile)
+		if err != nil && !s.strict {
+			fmt.Fprintf(os.Stderr, "warning: failed to load skill %q: %v\n", sk.Name, err)
+		}
+		return meta, err
+	}}
+	if s.strict {
+		if err := sk.Load(); err != nil {
+			return nil, err
+		}
 	}
+	return sk, nil
+}
 
-	meta, err := parseFrontmatter(string(content))
+// readMetadata reads and checks the frontmatter of a SKILL.md.
+func (s *Store) readMetadata(skillFile string) (Metadata, error) {
+	parsed, err := parseSkillFile(s.fs, skillFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse SKILL.md frontmatter: %w", err)
+		return Metadata{}, fmt.Errorf("failed to read SKILL.md: %w", err)
 	}
-
-	return NewSkill(s.fs.Base(dir), strings.TrimSpace(meta.Description), dir, scope, category)
+	if parsed.parseErr != nil {
+		return Metadata{}, fmt.Errorf("failed to parse SKILL.md frontmatter: %w", parsed.parseErr)
+	}
+	if s.strict {
+		if err := parsed.validate(); err != nil {
+			return Metadata{}, err
+		}
+	}
+	meta := parsed.meta
+	if err := validateStrategy(meta.Strategy); err != nil {
+		return Metadata{}, err
+	}
+	if err := validateVersion(meta.Version); err != nil {
+		return Metadata{}, err
+	}
+	return Metadata{
+		Description:      strings.TrimSpace(meta.Description),
+		RequiresCommands: slices.Clone(meta.RequiresCommands),
+		Requires:         slices.Clone(meta.Requires),
+		Strategy:         meta.Strategy,
+		Version:          meta.Version,
+		Tags:             slices.Clone(meta.Tags),
+		Author:           strings.TrimSpace(meta.Author),
+		License:          strings.TrimSpace(meta.License),
+		Targets:          slices.Clone(meta.Targets),
+		Draft:            meta.Draft,
+	}, nil
 }
 
 // findSkillFile finds SKILL.md in a directory or its subdirectories.
@@ -232,25 +472,22 @@ func (s *Store) findSkillFileWithDepth(dir string, depth int) string {
 	return ""
 }
 
-var frontmatterRegex = regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---`)
-
 // parseFrontmatter extracts and parses YAML frontmatter from content.
 func parseFrontmatter(content string) (*skillMetadata, error) {
-	matches := frontmatterRegex.FindStringSubmatch(content)
-
-	if len(matches) < 2 {
+	span, ok := findFrontmatter(content)
+	if !ok {
 		return nil, fmt.Errorf("no frontmatter found")
 	}
 
 	var meta skillMetadata
-	if err := yaml.Unmarshal([]byte(matches[1]), &meta); err != nil {
+	if err := yaml.Unmarshal([]byte(content[span.start:span.end]), &meta); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML frontmatter: %w", err)
 	}
 
 	return &meta, nil
 }
 
-// listSkillsInDir returns all skill names in a directory.
+// listSkillsInDir returns all skill names in a directory, sorted by name.
 func (s *Store) listSkillsInDir(dir string) ([]string, error) {
 	if !s.fs.Exists(dir) {
 		return nil, nil
@@ -263,50 +500,176 @@ func (s *Store) listSkillsInDir(dir string) ([]string, error) {
 
 	var skills []string
 	for _, entry := range entries {
-		if entry.IsDir() || entry.Type()&os.ModeSymlink != 0 {
-			skillDir := s.fs.Join(dir, entry.Name())
-			if isValidSkillDir(s.fs, skillDir) {
-				skills = append(skills, entry.Name())
-			}
+		skillDir := s.fs.Join(dir, entry.Name())
+		if (entry.IsDir() || s.fs.IsSymlink(skillDir)) && isValidSkillDir(s.fs, skillDir) {
+			skills = append(skills, entry.Name())
 		}
 	}
+	slices.Sort(skills)
 
 	return skills, nil
 }
 
 // loadAllInDir loads skills from a directory.
 func (s *Store) loadAllInDir(dir string, scope Scope) (defaultSkills, optionalSkills []*Skill, err error) {
-	names, err := s.listSkillsInDir(dir)
+	defaultSkills, err = s.loadCollection(dir, "", scope, CategoryDefault, "skill", 0)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	optDir := s.fs.Join(dir, optionalDir)
+	if s.fs.IsDir(optDir) {
+		optionalSkills, err = s.loadCollection(optDir, "", scope, CategoryOptional, "optional skill", 0)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	slog.Debug("loaded store", "dir", dir, "scope", scope.String(), "skills", len(defaultSkills), "optional", len(optionalSkills))
+
+	return defaultSkills, optionalSkills, nil
+}
+
+// loadCollection loads the skills in dir, naming each prefix followed by its
+// directory name. A directory without its own SKILL.md is a collection whose
+// skills are loaded recursively and named collection/skill-name. kind names
+// the skills in warnings and errors.
+func (s *Store) loadCollection(dir, prefix string, scope Scope, category Category, kind string, depth int) ([]*Skill, error) {
+	names, err := s.listSkillsInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var skills []*Skill
 	for _, name := range names {
-		if name == optionalDir {
+		if depth == 0 && category == CategoryDefault && name == optionalDir {
 			continue
 		}
-		sk, loadErr := s.loadSkill(s.fs.Join(dir, name), scope, CategoryDefault)
+		path := s.fs.Join(dir, name)
+		qualified := prefix + name
+		if !s.fs.Exists(s.fs.Join(path, "SKILL.md")) && depth < maxSearchDepth {
+			nested, err := s.loadCollection(path, qualified+CollectionSeparator, scope, category, kind, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			skills = append(skills, nested...)
+			continue
+		}
+
+		sk, loadErr := s.loadSkill(path, scope, category)
+		if loadErr != nil && s.strict {
+			return nil, fmt.Errorf("%s %q: %w", kind, qualified, loadErr)
+		}
 		if loadErr != nil {
-			fmt.Fprintf(os.Stderr, "warning: failed to load skill %q: %v\n", name, loadErr)
+			fmt.Fprintf(os.Stderr, "warning: failed to load %s %q: %v\n", kind, qualified, loadErr)
 			continue
 		}
-		defaultSkills = append(defaultSkills, sk)
+		sk.Name = qualified
+		skills = append(skills, sk)
 	}
 
-	optDir := s.fs.Join(dir, optionalDir)
-	optNames, err := s.listSkillsInDir(optDir)
-	if err != nil {
-		return defaultSkills, nil, nil
+	return skills, nil
+}
+
+// SplitFrontmatter separates the YAML frontmatter from the markdown body.
+// Returns an empty frontmatter and the full content when none is present.
+func SplitFrontmatter(content string) (frontmatter, body string) {
+	span, ok := findFrontmatter(content)
+	if !ok {
+		return "", content
 	}
+	return content[span.start:span.end], strings.TrimLeft(content[span.close:], "\r\n")
+}
 
-	for _, name := range optNames {
-		sk, loadErr := s.loadSkill(s.fs.Join(optDir, name), scope, CategoryOptional)
-		if loadErr != nil {
-			fmt.Fprintf(os.Stderr, "warning: failed to load optional skill %q: %v\n", name, loadErr)
-			continue
+// StripFrontmatterKeys removes top-level keys from the YAML frontmatter of
+// SKILL.md content, keeping the remaining keys in order. Content without
+// frontmatter or without any of the keys is returned unchanged.
+func StripFrontmatterKeys(content []byte, keys []string) ([]byte, error) {
+	span, ok := findFrontmatter(string(content))
+	if !ok || len(keys) == 0 {
+		return content, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content[span.start:span.end], &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML frontmatter: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return content, nil
+	}
+
+	mapping := doc.Content[0]
+	kept := make([]*yaml.Node, 0, len(mapping.Content))
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if !slices.Contains(keys, mapping.Content[i].Value) {
+			kept = append(kept, mapping.Content[i], mapping.Content[i+1])
 		}
-		optionalSkills = append(optionalSkills, sk)
 	}
+	if len(kept) == len(mapping.Content) {
+		return content, nil
+	}
+	mapping.Content = kept
+	return replaceFrontmatter(content, span, mapping)
+}
 
-	return defaultSkills, optionalSkills, nil
+// SetFrontmatterKey sets a top-level string key in the YAML frontmatter of
+// SKILL.md content. A new key is added after name, or last when there is none.
+func SetFrontmatterKey(content []byte, key, value string) ([]byte, error) {
+	span, ok := findFrontmatter(string(content))
+	if !ok {
+		return nil, fmt.Errorf("no frontmatter found")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content[span.start:span.end], &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML frontmatter: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("frontmatter is not a mapping")
+	}
+
+	mapping := doc.Content[0]
+	valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	insertAt := len(mapping.Content)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		switch mapping.Content[i].Value {
+		case key:
+			mapping.Content[i+1] = valueNode
+			return replaceFrontmatter(content, span, mapping)
+		case "name":
+			insertAt = i + 2
+		}
+	}
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	mapping.Content = slices.Insert(mapping.Content, insertAt, keyNode, valueNode)
+	return replaceFrontmatter(content, span, mapping)
+}
+
+// replaceFrontmatter re-renders the frontmatter at span from mapping.
+func replaceFrontmatter(content []byte, span frontmatterSpan, mapping *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if len(mapping.Content) > 0 {
+		if err := enc.Encode(mapping); err != nil {
+			return nil, fmt.Errorf("failed to marshal frontmatter: %w", err)
+		}
+	}
+	out := append([]byte("---\n"), buf.Bytes()...)
+	out = append(out, "---"...)
+	return append(out, content[span.close:]...), nil
+}
+
+// FormatSkillFile renders SKILL.md content with name and description
+// frontmatter, followed by fields, the key and value nodes of further fields.
+func FormatSkillFile(name, description, body string, fields ...*yaml.Node) ([]byte, error) {
+	var mapping yaml.Node
+	if err := mapping.Encode(skillMetadata{Name: name, Description: description}); err != nil {
+		return nil, fmt.Errorf("failed to marshal frontmatter: %w", err)
+	}
+	mapping.Content = append(mapping.Content, fields...)
+	meta, err := yaml.Marshal(&mapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal frontmatter: %w", err)
+	}
+	return []byte("---\n" + string(meta) + "---\n\n" + body), nil
 }
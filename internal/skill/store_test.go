@@ -1,7 +1,10 @@
 package skill
 
 import (
+	"errors"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/wwwyo/skillet/internal/config"
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
@@ -95,6 +98,29 @@ func TestStoreGetAllWithoutProject(t *testing.T) {
 	}
 }
 
+func TestStoreDirs(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	setupGlobalSkillsDir(mock)
+	setupProjectSkillsDir(mock, "/project")
+
+	dirs, err := NewStore(mock, config.DefaultConfig(), "/project").Dirs()
+	if err != nil {
+		t.Fatalf("Dirs() error = %v", err)
+	}
+	want := []string{"/home/test/.agents/skills", "/project/.agents/skills"}
+	if !slices.Equal(dirs, want) {
+		t.Errorf("Dirs() = %v, want %v", dirs, want)
+	}
+
+	dirs, err = NewStore(mock, config.DefaultConfig(), "").Dirs()
+	if err != nil {
+		t.Fatalf("Dirs() error = %v", err)
+	}
+	if !slices.Equal(dirs, want[:1]) {
+		t.Errorf("Dirs() without project = %v, want %v", dirs, want[:1])
+	}
+}
+
 func TestStoreGetByScope(t *testing.T) {
 	mock := platformfs.NewMockFileSystem()
 	setupGlobalSkillsDir(mock)
@@ -158,8 +184,8 @@ func TestStoreGetByName(t *testing.T) {
 		if sk.Scope != ScopeProject {
 			t.Errorf("GetByName() returned scope = %v, want project", sk.Scope)
 		}
-		if sk.Description != "Project version" {
-			t.Errorf("GetByName() returned description = %v, want 'Project version'", sk.Description)
+		if sk.Description() != "Project version" {
+			t.Errorf("GetByName() returned description = %v, want 'Project version'", sk.Description())
 		}
 	})
 
@@ -257,8 +283,86 @@ func TestStoreGetResolved(t *testing.T) {
 		t.Errorf("GetResolved() shared-skill scope = %v, want project", sharedSkill.Scope)
 	}
 
-	if sharedSkill.Description != "Project version" {
-		t.Errorf("GetResolved() shared-skill description = %v, want 'Project version'", sharedSkill.Description)
+	if sharedSkill.Description() != "Project version" {
+		t.Errorf("GetResolved() shared-skill description = %v, want 'Project version'", sharedSkill.Description())
+	}
+}
+
+func TestStoreGetResolvedOrgScope(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	setupGlobalSkillsDir(mock)
+	setupProjectSkillsDir(mock, "/project")
+	mock.Dirs["/org/.agents/skills"] = true
+
+	addSkillToMock(mock, "/home/test/.agents/skills", "org-over-global", "Global version")
+	addSkillToMock(mock, "/org/.agents/skills", "org-over-global", "Org version")
+	addSkillToMock(mock, "/org/.agents/skills", "project-over-org", "Org version")
+	addSkillToMock(mock, "/project/.agents/skills", "project-over-org", "Project version")
+
+	cfg := config.DefaultConfig()
+	cfg.OrgPath = "/org/.agents"
+	store := NewStore(mock, cfg, "/project")
+
+	resolved, err := store.GetResolved()
+	if err != nil {
+		t.Fatalf("GetResolved() error = %v", err)
+	}
+
+	want := map[string]Scope{
+		"org-over-global":  ScopeOrg,
+		"project-over-org": ScopeProject,
+	}
+	if len(resolved) != len(want) {
+		t.Fatalf("GetResolved() returned %d skills, want %d", len(resolved), len(want))
+	}
+	for _, sk := range resolved {
+		if sk.Scope != want[sk.Name] {
+			t.Errorf("GetResolved() %s scope = %v, want %v", sk.Name, sk.Scope, want[sk.Name])
+		}
+	}
+
+	orgSkills, err := store.GetByScope(ScopeOrg)
+	if err != nil {
+		t.Fatalf("GetByScope(ScopeOrg) error = %v", err)
+	}
+	if len(orgSkills) != 2 {
+		t.Errorf("GetByScope(ScopeOrg) returned %d skills, want 2", len(orgSkills))
+	}
+}
+
+func TestStoreSystemScopeIsReadOnly(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	setupGlobalSkillsDir(mock)
+	mock.Dirs["/opt/agents/skills"] = true
+
+	addSkillToMock(mock, "/opt/agents/skills", "shared", "System version")
+	addSkillToMock(mock, "/home/test/.agents/skills", "shared", "User version")
+	addSkillToMock(mock, "/opt/agents/skills", "system-only", "System only")
+
+	cfg := config.DefaultConfig()
+	cfg.SystemPath = "/opt/agents"
+	store := NewStore(mock, cfg, "")
+
+	shared, err := store.GetByName("shared")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if shared.Scope != ScopeGlobal {
+		t.Errorf("GetByName() scope = %v, want global over system", shared.Scope)
+	}
+
+	systemOnly, err := store.GetByName("system-only")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if !systemOnly.ReadOnly() {
+		t.Error("system skill should be read-only")
+	}
+	if err := store.Remove(systemOnly); err == nil {
+		t.Error("Remove() should refuse to remove a system skill")
+	}
+	if !mock.Exists("/opt/agents/skills/system-only") {
+		t.Error("system skill should not be removed")
 	}
 }
 
@@ -321,6 +425,24 @@ func TestStoreLoadSkill(t *testing.T) {
 			dir:     "/skills/invalid",
 			wantErr: true,
 		},
+		{
+			name: "invalid strategy",
+			setup: func(m *platformfs.MockFileSystem) {
+				m.Dirs["/skills/linked"] = true
+				m.Files["/skills/linked/SKILL.md"] = []byte("---\nname: linked\nstrategy: junction\n---\n")
+			},
+			dir:     "/skills/linked",
+			wantErr: true,
+		},
+		{
+			name: "invalid version",
+			setup: func(m *platformfs.MockFileSystem) {
+				m.Dirs["/skills/versioned"] = true
+				m.Files["/skills/versioned/SKILL.md"] = []byte("---\nname: versioned\nversion: one\n---\n")
+			},
+			dir:     "/skills/versioned",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -330,6 +452,10 @@ func TestStoreLoadSkill(t *testing.T) {
 			store := NewStore(mock, config.DefaultConfig(), "")
 
 			sk, err := store.loadSkill(tt.dir, ScopeGlobal, CategoryDefault)
+			if err == nil {
+				// SKILL.md is read when the skill is loaded.
+				err = sk.Load()
+			}
 			if tt.wantErr {
 				if err == nil {
 					t.Error("loadSkill() expected error, got nil")
@@ -342,8 +468,8 @@ func TestStoreLoadSkill(t *testing.T) {
 			if sk.Name != tt.wantName {
 				t.Errorf("loadSkill() Name = %v, want %v", sk.Name, tt.wantName)
 			}
-			if sk.Description != tt.wantDesc {
-				t.Errorf("loadSkill() Description = %v, want %v", sk.Description, tt.wantDesc)
+			if sk.Description() != tt.wantDesc {
+				t.Errorf("loadSkill() Description = %v, want %v", sk.Description(), tt.wantDesc)
 			}
 		})
 	}
@@ -376,3 +502,297 @@ func TestStoreLoadAllInDir(t *testing.T) {
 		}
 	})
 }
+
+func TestStoreLoadsCollections(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	setupGlobalSkillsDir(mock)
+	skillsDir := "/home/test/.agents/skills"
+	addSkillToMock(mock, skillsDir, "review", "Top level")
+	mock.Dirs[skillsDir+"/backend"] = true
+	addSkillToMock(mock, skillsDir+"/backend", "api", "API conventions")
+	mock.Dirs[skillsDir+"/backend/db"] = true
+	addSkillToMock(mock, skillsDir+"/backend/db", "migrations", "Nested collection")
+	mock.Dirs[skillsDir+"/optional/frontend"] = true
+	addSkillToMock(mock, skillsDir+"/optional/frontend", "forms", "Optional in a collection")
+
+	store := NewStore(mock, config.DefaultConfig(), "")
+	skills, err := store.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	var names []string
+	for _, sk := range skills {
+		names = append(names, sk.Name)
+	}
+	want := []string{"backend/api", "backend/db/migrations", "review", "frontend/forms"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("GetAll() names = %v, want %v", names, want)
+	}
+	if skills[3].Category != CategoryOptional || skills[1].Collection() != "backend/db" || skills[2].Collection() != "" {
+		t.Errorf("GetAll() = %+v, want collections and categories kept", skills)
+	}
+
+	sk, err := store.GetByName("backend/api")
+	if err != nil || sk.Path != skillsDir+"/backend/api" {
+		t.Errorf("GetByName(backend/api) = %+v, %v", sk, err)
+	}
+	if _, err := store.GetByName("backend/../api"); err == nil {
+		t.Error("GetByName() should reject path traversal in a collection name")
+	}
+}
+
+func TestStripFrontmatterKeys(t *testing.T) {
+	content := []byte("---\nname: demo\ntags:\n  - a\n# kept comment\ndescription: Demo\n---\nBody\n")
+
+	got, err := StripFrontmatterKeys(content, []string{"tags"})
+	if err != nil {
+		t.Fatalf("StripFrontmatterKeys() error = %v", err)
+	}
+	want := "---\nname: demo\n# kept comment\ndescription: Demo\n---\nBody\n"
+	if string(got) != want {
+		t.Errorf("StripFrontmatterKeys() = %q, want %q", got, want)
+	}
+
+	got, err = StripFrontmatterKeys(content, []string{"missing"})
+	if err != nil || string(got) != string(content) {
+		t.Errorf("StripFrontmatterKeys() without matching keys = %q, %v", got, err)
+	}
+}
+
+func TestStoreGetResolvedSkipsDrafts(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	setupGlobalSkillsDir(mock)
+	setupProjectSkillsDir(mock, "/project")
+	addSkillToMock(mock, "/home/test/.agents/skills", "shared-skill", "Global version")
+	mock.Dirs["/project/.agents/skills/shared-skill"] = true
+	mock.Files["/project/.agents/skills/shared-skill/SKILL.md"] = []byte("---\nname: shared-skill\ndescription: WIP\ndraft: true\n---\n")
+
+	store := NewStore(mock, config.DefaultConfig(), "/project")
+	all, err := store.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	drafts := 0
+	for _, sk := range all {
+		if sk.Draft() {
+			drafts++
+		}
+	}
+	if drafts != 1 {
+		t.Fatalf("GetAll() returned %d drafts, want 1", drafts)
+	}
+
+	resolved, err := store.GetResolved()
+	if err != nil {
+		t.Fatalf("GetResolved() error = %v", err)
+	}
+	if len(resolved) != 1 || resolved[0].Scope != ScopeGlobal {
+		t.Fatalf("GetResolved() = %+v, want only the published global skill", resolved)
+	}
+}
+
+func TestStoreResolutionPolicy(t *testing.T) {
+	newMock := func() *platformfs.MockFileSystem {
+		mock := platformfs.NewMockFileSystem()
+		setupGlobalSkillsDir(mock)
+		setupProjectSkillsDir(mock, "/project")
+		addSkillToMock(mock, "/home/test/.agents/skills", "shared-skill", "Global version")
+		addSkillToMock(mock, "/project/.agents/skills", "shared-skill", "Project version")
+		addSkillToMock(mock, "/home/test/.agents/skills", "global-only", "Global only")
+		return mock
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		policy    string
+		modTimes  map[string]time.Time
+		wantScope Scope
+	}{
+		{name: "default", policy: "", wantScope: ScopeProject},
+		{name: "project-wins", policy: "project-wins", wantScope: ScopeProject},
+		{name: "global-wins", policy: "global-wins", wantScope: ScopeGlobal},
+		{
+			name:   "newest-wins",
+			policy: "newest-wins",
+			modTimes: map[string]time.Time{
+				"/home/test/.agents/skills/shared-skill/SKILL.md": now,
+				"/project/.agents/skills/shared-skill/SKILL.md":   now.Add(-time.Hour),
+			},
+			wantScope: ScopeGlobal,
+		},
+		{name: "newest-wins tie", policy: "newest-wins", wantScope: ScopeProject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMock()
+			for path, mt := range tt.modTimes {
+				mock.ModTimes[path] = mt
+			}
+			cfg := config.DefaultConfig()
+			cfg.Resolution = tt.policy
+			store := NewStore(mock, cfg, "/project")
+
+			resolved, err := store.GetResolved()
+			if err != nil {
+				t.Fatalf("GetResolved() error = %v", err)
+			}
+			if len(resolved) != 2 || resolved[1].Name != "shared-skill" || resolved[1].Scope != tt.wantScope {
+				t.Fatalf("GetResolved() = %+v, want shared-skill from %s", resolved, tt.wantScope)
+			}
+
+			sk, err := store.GetByName("shared-skill")
+			if err != nil {
+				t.Fatalf("GetByName() error = %v", err)
+			}
+			if sk.Scope != tt.wantScope {
+				t.Errorf("GetByName() scope = %s, want %s", sk.Scope, tt.wantScope)
+			}
+		})
+	}
+
+	t.Run("error-on-conflict", func(t *testing.T) {
+		cfg := config.DefaultConfig()
+		cfg.Resolution = "error-on-conflict"
+		store := NewStore(newMock(), cfg, "/project")
+
+		resolved, err := store.GetResolved()
+		var conflictErr *ConflictError
+		if !errors.As(err, &conflictErr) {
+			t.Fatalf("GetResolved() error = %v, want *ConflictError", err)
+		}
+		if len(conflictErr.Conflicts) != 1 || conflictErr.Conflicts[0].String() != "shared-skill (project, global)" {
+			t.Fatalf("conflicts = %v", conflictErr.Conflicts)
+		}
+		if len(resolved) != 1 || resolved[0].Name != "global-only" {
+			t.Fatalf("GetResolved() = %+v, want only the unconflicted skill", resolved)
+		}
+
+		if _, err := store.GetByName("shared-skill"); !errors.As(err, &conflictErr) {
+			t.Errorf("GetByName() error = %v, want *ConflictError", err)
+		}
+		if _, err := store.GetByName("global-only"); err != nil {
+			t.Errorf("GetByName() error = %v", err)
+		}
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		cfg := config.DefaultConfig()
+		cfg.Resolution = "oldest-wins"
+		if _, err := NewStore(newMock(), cfg, "/project").GetResolved(); err == nil {
+			t.Fatal("GetResolved() expected error for an unknown policy")
+		}
+	})
+}
+
+func TestStoreCachesParsedSkills(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	setupGlobalSkillsDir(mock)
+	addSkillToMock(mock, "/home/test/.agents/skills", "cached", "First")
+	skillFile := "/home/test/.agents/skills/cached/SKILL.md"
+	mock.ModTimes[skillFile] = time.Now().Add(-time.Hour)
+
+	description := func() string {
+		t.Helper()
+		sk, err := NewStore(mock, config.DefaultConfig(), "").GetByName("cached")
+		if err != nil {
+			t.Fatalf("GetByName() error = %v", err)
+		}
+		return sk.Description()
+	}
+	if got := description(); got != "First" {
+		t.Fatalf("Description = %q, want First", got)
+	}
+
+	// Content rewritten without changing the mod time or size is not read
+	// again, even by another store.
+	addSkillToMock(mock, "/home/test/.agents/skills", "cached", "Other")
+	if got := description(); got != "First" {
+		t.Errorf("Description = %q, want the cached First", got)
+	}
+
+	// A file modified too recently for its mod time to be trusted is read
+	// again.
+	mock.ModTimes[skillFile] = time.Now()
+	if got := description(); got != "Other" {
+		t.Errorf("Description = %q, want Other after the file changed", got)
+	}
+	addSkillToMock(mock, "/home/test/.agents/skills", "cached", "Third")
+	if got := description(); got != "Third" {
+		t.Errorf("Description = %q, want Third while the mod time is recent", got)
+	}
+}
+
+func TestStoreLoadsSkillsLazily(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	setupGlobalSkillsDir(mock)
+	addSkillToMock(mock, "/home/test/.agents/skills", "lazy", "Before")
+	mock.Dirs["/home/test/.agents/skills/broken"] = true
+	mock.Files["/home/test/.agents/skills/broken/SKILL.md"] = []byte("No frontmatter here\n")
+	store := NewStore(mock, config.DefaultConfig(), "")
+
+	skills, err := store.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(skills) != 2 {
+		t.Fatalf("GetAll() returned %d skills, want the broken one listed too", len(skills))
+	}
+
+	// SKILL.md is read when the description is first accessed, not by GetAll.
+	addSkillToMock(mock, "/home/test/.agents/skills", "lazy", "After")
+	lazy := skills[1]
+	if lazy.Name != "lazy" || lazy.Description() != "After" {
+		t.Fatalf("%s Description() = %q, want After", lazy.Name, lazy.Description())
+	}
+	addSkillToMock(mock, "/home/test/.agents/skills", "lazy", "Later")
+	if lazy.Description() != "After" {
+		t.Error("a loaded skill should not read SKILL.md again")
+	}
+
+	if err := skills[0].Load(); err == nil {
+		t.Error("Load() of the broken skill should fail")
+	}
+	resolved, err := store.GetResolved()
+	if err != nil {
+		t.Fatalf("GetResolved() error = %v", err)
+	}
+	if len(resolved) != 1 || resolved[0].Name != "lazy" {
+		t.Errorf("GetResolved() = %v, want only lazy", resolved)
+	}
+}
+
+func TestParseCacheEviction(t *testing.T) {
+	ResetParseCache()
+	mock := platformfs.NewMockFileSystem()
+	path := "/skills/gone/SKILL.md"
+	mock.Files[path] = []byte("---\nname: gone\n---\n")
+	mock.ModTimes[path] = time.Now().Add(-time.Hour)
+	key := parseKey{fs: mock, path: path}
+
+	if _, err := parseSkillFile(mock, path); err != nil {
+		t.Fatalf("parseSkillFile() error = %v", err)
+	}
+	if parseCache.files[key] == nil {
+		t.Fatal("expected the parse to be cached")
+	}
+
+	// A removed file is dropped when it is looked up again.
+	delete(mock.Files, path)
+	if _, err := parseSkillFile(mock, path); err == nil {
+		t.Fatal("parseSkillFile() of a removed file should fail")
+	}
+	if parseCache.files[key] != nil {
+		t.Error("the parse of a removed file should be evicted")
+	}
+
+	mock.Files[path] = []byte("---\nname: gone\n---\n")
+	if _, err := parseSkillFile(mock, path); err != nil {
+		t.Fatalf("parseSkillFile() error = %v", err)
+	}
+	ResetParseCache()
+	if len(parseCache.files) != 0 {
+		t.Errorf("ResetParseCache() left %d entries", len(parseCache.files))
+	}
+}
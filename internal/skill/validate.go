@@ -0,0 +1,156 @@
+package skill
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+// DefaultMaxAssetSize is the largest file Validate accepts in a skill
+// directory unless told otherwise.
+const DefaultMaxAssetSize = 1 << 20
+
+// Problem is something Validate found wrong with a skill directory.
+type Problem struct {
+	// File is the path of the file, relative to the skill directory
+	File string
+	// Line is the line in File, counting from 1 (0 when the problem is not on a line)
+	Line    int
+	Message string
+}
+
+// Validate checks the skill directory dir: that SKILL.md has frontmatter
+// matching FrontmatterSchema and naming the directory, that relative links in
+// its markdown files resolve to files inside dir, and that no file is larger
+// than maxAssetSize bytes (0 for DefaultMaxAssetSize). Problems are ordered
+// by file, then by line.
+func Validate(fsys platformfs.FileSystem, dir string, maxAssetSize int64) ([]Problem, error) {
+	if !fsys.IsDir(dir) {
+		return nil, fmt.Errorf("not a directory: %s", dir)
+	}
+	if maxAssetSize <= 0 {
+		maxAssetSize = DefaultMaxAssetSize
+	}
+
+	files, err := listFiles(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []Problem
+	if !slices.Contains(files, "SKILL.md") {
+		problems = append(problems, Problem{File: "SKILL.md", Message: "SKILL.md not found"})
+	}
+	for _, rel := range files {
+		file := fsys.Join(dir, rel)
+		info, err := fsys.Stat(file)
+		if err != nil {
+			problems = append(problems, Problem{File: rel, Message: fmt.Sprintf("cannot read file: %v", err)})
+			continue
+		}
+		if info.Size() > maxAssetSize {
+			problems = append(problems, Problem{File: rel, Message: fmt.Sprintf("file is %s, larger than the %s limit", formatSize(info.Size()), formatSize(maxAssetSize))})
+			continue
+		}
+		if !strings.EqualFold(path.Ext(rel), ".md") {
+			continue
+		}
+
+		content, err := fsys.ReadFile(file)
+		if err != nil {
+			problems = append(problems, Problem{File: rel, Message: fmt.Sprintf("cannot read file: %v", err)})
+			continue
+		}
+		if rel == "SKILL.md" {
+			problems = append(problems, checkSkillFile(string(content), fsys.Base(dir))...)
+		}
+		for _, issue := range lintLinks(fsys, dir, file, content) {
+			problems = append(problems, Problem{File: rel, Line: issue.Line, Message: fmt.Sprintf("%s: %s", issue.Link, issue.Message)})
+		}
+	}
+
+	slices.SortStableFunc(problems, func(a, b Problem) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		return a.Line - b.Line
+	})
+	return problems, nil
+}
+
+// checkSkillFile checks the frontmatter of SKILL.md content in the skill
+// directory named dirName.
+func checkSkillFile(content, dirName string) []Problem {
+	span, ok := findFrontmatter(content)
+	if !ok {
+		return []Problem{{File: "SKILL.md", Line: 1, Message: "SKILL.md must start with YAML frontmatter between --- lines"}}
+	}
+	// Frontmatter lines are numbered from the line after the opening ---.
+	offset := strings.Count(content[:span.start], "\n")
+
+	schemaProblems, fields := checkFrontmatter(content[span.start:span.end])
+	problems := make([]Problem, 0, len(schemaProblems)+1)
+	for _, p := range schemaProblems {
+		line := p.Line
+		if line > 0 {
+			line += offset
+		} else {
+			line = 1
+		}
+		problems = append(problems, Problem{File: "SKILL.md", Line: line, Message: p.Message})
+	}
+	if name, ok := fields["name"]; ok && name.Value != "" && name.Value != dirName {
+		problems = append(problems, Problem{
+			File:    "SKILL.md",
+			Line:    name.Line + offset,
+			Message: fmt.Sprintf("name %q does not match the directory name %q", name.Value, dirName),
+		})
+	}
+	return problems
+}
+
+// listFiles returns the paths of the files under dir, relative to dir and
+// sorted. Version control directories are skipped.
+func listFiles(fsys platformfs.FileSystem, dir string) ([]string, error) {
+	var files []string
+	var walk func(rel string) error
+	walk = func(rel string) error {
+		entries, err := fsys.ReadDir(fsys.Join(dir, rel))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", fsys.Join(dir, rel), err)
+		}
+		for _, entry := range entries {
+			name := path.Join(rel, entry.Name())
+			switch {
+			case entry.Name() == ".git":
+			case fsys.IsDir(fsys.Join(dir, name)):
+				if err := walk(name); err != nil {
+					return err
+				}
+			default:
+				files = append(files, name)
+			}
+		}
+		return nil
+	}
+	if err := walk(""); err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// formatSize formats a file size in bytes for display.
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
@@ -0,0 +1,62 @@
+package skill
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+func TestValidate(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	dir := "/work/pdf-tools"
+	mock.Dirs[dir] = true
+	mock.Dirs[dir+"/docs"] = true
+	mock.Dirs[dir+"/.git"] = true
+	mock.Files[dir+"/SKILL.md"] = []byte(`---
+name: pdf
+description: Fill PDF forms
+homepage: x
+---
+
+See [the guide](docs/guide.md) and [forms](docs/forms.md).
+`)
+	mock.Files[dir+"/docs/guide.md"] = []byte("Back to [the skill](../SKILL.md), not [outside](../../x.md).\n")
+	mock.Files[dir+"/docs/sample.pdf"] = []byte(strings.Repeat("x", 2048))
+	mock.Files[dir+"/.git/objects"] = []byte(strings.Repeat("x", 4096))
+
+	problems, err := Validate(mock, dir, 1024)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message))
+	}
+	want := []string{
+		`SKILL.md:2: name "pdf" does not match the directory name "pdf-tools"`,
+		"SKILL.md:4: field homepage not found in schema v1",
+		"SKILL.md:7: docs/forms.md: referenced file does not exist",
+		"docs/guide.md:1: ../../x.md: link points outside the skill directory",
+		"docs/sample.pdf:0: file is 2.0 KiB, larger than the 1.0 KiB limit",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Validate() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	delete(mock.Files, dir+"/docs/guide.md")
+	delete(mock.Files, dir+"/docs/sample.pdf")
+	mock.Files[dir+"/SKILL.md"] = []byte("# No frontmatter\n")
+	problems, err = Validate(mock, dir, 0)
+	if err != nil || len(problems) != 1 || problems[0].Line != 1 || !strings.Contains(problems[0].Message, "frontmatter") {
+		t.Fatalf("Validate() = %+v, %v, want a missing frontmatter problem", problems, err)
+	}
+
+	delete(mock.Files, dir+"/SKILL.md")
+	problems, err = Validate(mock, dir, 0)
+	if err != nil || len(problems) != 1 || problems[0].Message != "SKILL.md not found" {
+		t.Fatalf("Validate() = %+v, %v, want SKILL.md not found", problems, err)
+	}
+}
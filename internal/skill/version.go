@@ -0,0 +1,48 @@
+package skill
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// canonicalVersion returns v with the leading "v" semver expects.
+func canonicalVersion(v string) string {
+	if v == "" || strings.HasPrefix(v, "v") {
+		return v
+	}
+	return "v" + v
+}
+
+// validateVersion checks the version a skill declares: a semantic version,
+// with or without a leading "v" (e.g. 1.2.0 or v1.2.0-rc.1).
+func validateVersion(v string) error {
+	if v != "" && !semver.IsValid(canonicalVersion(v)) {
+		return fmt.Errorf("invalid version %q (use a semantic version such as 1.2.0)", v)
+	}
+	return nil
+}
+
+// CompareVersions compares two skill versions, returning -1, 0, or +1 like
+// semver.Compare. An empty or invalid version is less than any valid one.
+func CompareVersions(a, b string) int {
+	return semver.Compare(canonicalVersion(a), canonicalVersion(b))
+}
+
+// VersionOf returns the version declared in a SKILL.md's frontmatter, or an
+// empty string when there is none.
+func VersionOf(content []byte) string {
+	span, ok := findFrontmatter(string(content))
+	if !ok {
+		return ""
+	}
+	var meta struct {
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(content[span.start:span.end], &meta); err != nil {
+		return ""
+	}
+	return meta.Version
+}
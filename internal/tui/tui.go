@@ -0,0 +1,350 @@
+// Package tui implements skillet's interactive terminal dashboard.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// Skill is a row in the dashboard's skill list.
+type Skill struct {
+	usecase.SkillInfo
+	// Enabled lists the targets an optional skill is enabled for, sorted
+	Enabled []string
+}
+
+// Optional reports whether the skill is optional.
+func (s Skill) Optional() bool {
+	return s.Category == skill.CategoryOptional.String()
+}
+
+// Backend is what the dashboard reads and changes.
+type Backend interface {
+	// Skills returns every skill in the store, in store order.
+	Skills() ([]Skill, error)
+	// Status returns the synchronization status of each target.
+	Status() ([]*usecase.StatusResult, error)
+	// SetOptional enables or disables an optional skill for every target.
+	SetOptional(name string, enable bool) error
+	// Sync syncs the store to the targets.
+	Sync() ([]usecase.SyncResult, error)
+	// Content returns the skill's SKILL.md.
+	Content(sk Skill) ([]byte, error)
+}
+
+type view int
+
+const (
+	viewList view = iota
+	viewContent
+)
+
+// Model is the dashboard's bubbletea model.
+type Model struct {
+	backend Backend
+	skills  []Skill
+	status  []*usecase.StatusResult
+	cursor  int
+	view    view
+	content []string
+	offset  int
+	height  int
+	message string
+	busy    bool
+}
+
+// New creates a dashboard model over backend.
+func New(backend Backend) *Model {
+	return &Model{backend: backend, height: 24}
+}
+
+// Run starts the dashboard and blocks until the user quits.
+func Run(backend Backend) error {
+	_, err := tea.NewProgram(New(backend), tea.WithAltScreen()).Run()
+	return err
+}
+
+type loadedMsg struct {
+	skills []Skill
+	status []*usecase.StatusResult
+	err    error
+}
+
+type toggledMsg struct {
+	name   string
+	enable bool
+	err    error
+}
+
+type syncedMsg struct {
+	results []usecase.SyncResult
+	err     error
+}
+
+type contentMsg struct {
+	content []byte
+	err     error
+}
+
+// Init loads the skills and target status.
+func (m *Model) Init() tea.Cmd {
+	return m.load
+}
+
+func (m *Model) load() tea.Msg {
+	skills, err := m.backend.Skills()
+	if err != nil {
+		return loadedMsg{err: err}
+	}
+	status, err := m.backend.Status()
+	return loadedMsg{skills: skills, status: status, err: err}
+}
+
+// Update handles a message.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.view == viewContent {
+			return m, m.updateContent(msg)
+		}
+		return m, m.updateList(msg)
+	case loadedMsg:
+		m.busy = false
+		if msg.err != nil {
+			m.message = "Error: " + msg.err.Error()
+			return m, nil
+		}
+		m.skills, m.status = msg.skills, msg.status
+		m.cursor = min(m.cursor, max(len(m.skills)-1, 0))
+	case toggledMsg:
+		if msg.err != nil {
+			m.busy = false
+			m.message = "Error: " + msg.err.Error()
+			return m, nil
+		}
+		state := "enabled"
+		if !msg.enable {
+			state = "disabled"
+		}
+		m.message = fmt.Sprintf("%s %s; press s to sync", msg.name, state)
+		return m, m.load
+	case syncedMsg:
+		if msg.err != nil {
+			m.busy = false
+			m.message = "Sync failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.message = syncSummary(msg.results)
+		return m, m.load
+	case contentMsg:
+		m.busy = false
+		if msg.err != nil {
+			m.message = "Error: " + msg.err.Error()
+			return m, nil
+		}
+		m.content = strings.Split(strings.TrimRight(string(msg.content), "\n"), "\n")
+		m.offset = 0
+		m.view = viewContent
+	}
+	return m, nil
+}
+
+func (m *Model) updateList(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "q", "esc":
+		return tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.skills)-1 {
+			m.cursor++
+		}
+	}
+	if m.busy {
+		return nil
+	}
+
+	switch msg.String() {
+	case "r":
+		m.busy = true
+		m.message = ""
+		return m.load
+	case "s":
+		m.busy = true
+		m.message = "Syncing..."
+		return func() tea.Msg {
+			results, err := m.backend.Sync()
+			return syncedMsg{results: results, err: err}
+		}
+	}
+
+	if len(m.skills) == 0 {
+		return nil
+	}
+	sk := m.skills[m.cursor]
+	switch msg.String() {
+	case " ", "t":
+		if !sk.Optional() {
+			m.message = sk.Name + " is not optional; default skills are installed into every target"
+			return nil
+		}
+		enable := len(sk.Enabled) == 0
+		m.busy = true
+		return func() tea.Msg {
+			return toggledMsg{name: sk.Name, enable: enable, err: m.backend.SetOptional(sk.Name, enable)}
+		}
+	case "enter":
+		m.busy = true
+		return func() tea.Msg {
+			content, err := m.backend.Content(sk)
+			return contentMsg{content: content, err: err}
+		}
+	}
+	return nil
+}
+
+func (m *Model) updateContent(msg tea.KeyMsg) tea.Cmd {
+	page := m.pageHeight()
+	last := max(len(m.content)-page, 0)
+	switch msg.String() {
+	case "q", "esc", "enter":
+		m.view = viewList
+	case "up", "k":
+		m.offset = max(m.offset-1, 0)
+	case "down", "j":
+		m.offset = min(m.offset+1, last)
+	case "pgup", "b":
+		m.offset = max(m.offset-page, 0)
+	case "pgdown", " ", "f":
+		m.offset = min(m.offset+page, last)
+	}
+	return nil
+}
+
+// pageHeight is the number of content lines shown, leaving room for the
+// header and footer.
+func (m *Model) pageHeight() int {
+	return max(m.height-4, 1)
+}
+
+// View renders the dashboard.
+func (m *Model) View() string {
+	var b strings.Builder
+	if m.view == viewContent {
+		sk := m.skills[m.cursor]
+		fmt.Fprintf(&b, "%s (%s) - %s\n\n", sk.Name, sk.Scope, sk.Path)
+		end := min(m.offset+m.pageHeight(), len(m.content))
+		for _, line := range m.content[m.offset:end] {
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n↑/↓ scroll  pgup/pgdn page  esc back\n")
+		return b.String()
+	}
+
+	b.WriteString("Skills\n")
+	if len(m.skills) == 0 {
+		b.WriteString("  (no skills in the store)\n")
+	}
+	for i, sk := range m.skills {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		fmt.Fprintf(&b, "%s%s %s [%s]", cursor, skillMark(sk), sk.Name, sk.Scope)
+		if sk.Draft {
+			b.WriteString(" (draft)")
+		}
+		if len(sk.Targets) > 0 {
+			fmt.Fprintf(&b, " → %s", strings.Join(sk.Targets, ", "))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\nTargets\n")
+	for _, st := range m.status {
+		fmt.Fprintf(&b, "  %s: %s\n", st.Target, targetSummary(st))
+	}
+
+	b.WriteString("\n")
+	if m.message != "" {
+		b.WriteString(m.message + "\n")
+	}
+	b.WriteString("↑/↓ move  space toggle optional  enter view SKILL.md  s sync  r refresh  q quit\n")
+	return b.String()
+}
+
+// skillMark shows whether a skill is a default skill (•), or an optional
+// skill that is enabled ([x]) or disabled ([ ]).
+func skillMark(sk Skill) string {
+	switch {
+	case !sk.Optional():
+		return " • "
+	case len(sk.Enabled) > 0:
+		return "[x]"
+	default:
+		return "[ ]"
+	}
+}
+
+func targetSummary(st *usecase.StatusResult) string {
+	if st.Error != nil {
+		return "error - " + st.Error.Error()
+	}
+	if st.InSync {
+		return fmt.Sprintf("in sync (%d installed)", len(st.Installed))
+	}
+	var parts []string
+	for _, c := range []struct {
+		n    int
+		what string
+	}{
+		{len(st.Missing), "missing"},
+		{len(st.Extra), "extra"},
+		{len(st.Stale), "stale"},
+		{len(st.Mismatched), "mismatched"},
+	} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.what))
+		}
+	}
+	return "out of sync (" + strings.Join(parts, ", ") + ")"
+}
+
+// syncSummary counts sync results by action, e.g. "Synced: 2 install, 1 error".
+func syncSummary(results []usecase.SyncResult) string {
+	counts := make(map[usecase.SyncAction]int)
+	for _, r := range results {
+		counts[r.Action]++
+	}
+	var parts []string
+	for _, action := range []usecase.SyncAction{
+		usecase.SyncActionInstall,
+		usecase.SyncActionUpdate,
+		usecase.SyncActionUninstall,
+		usecase.SyncActionExtra,
+		usecase.SyncActionError,
+	} {
+		if counts[action] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[action], action))
+		}
+	}
+	if len(parts) == 0 {
+		return "Synced: nothing to do"
+	}
+	if counts[usecase.SyncActionError] > 0 {
+		parts[len(parts)-1] += " (run skillet sync for details)"
+	}
+	return "Synced: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+type fakeBackend struct {
+	skills  []Skill
+	toggled []string
+	synced  int
+}
+
+func (f *fakeBackend) Skills() ([]Skill, error) {
+	return f.skills, nil
+}
+
+func (f *fakeBackend) Status() ([]*usecase.StatusResult, error) {
+	return []*usecase.StatusResult{
+		{Target: "claude", Installed: []string{"always"}, InSync: true},
+		{Target: "codex", Missing: []string{"always"}},
+	}, nil
+}
+
+func (f *fakeBackend) SetOptional(name string, enable bool) error {
+	for i := range f.skills {
+		if f.skills[i].Name != name {
+			continue
+		}
+		if !f.skills[i].Optional() {
+			return errors.New("not optional")
+		}
+		f.skills[i].Enabled = nil
+		if enable {
+			f.skills[i].Enabled = []string{"claude", "codex"}
+		}
+	}
+	f.toggled = append(f.toggled, name)
+	return nil
+}
+
+func (f *fakeBackend) Sync() ([]usecase.SyncResult, error) {
+	f.synced++
+	return []usecase.SyncResult{
+		{Target: "codex", SkillName: "always", Action: usecase.SyncActionInstall},
+		{Target: "claude", SkillName: "always", Action: usecase.SyncActionSkip},
+	}, nil
+}
+
+func (f *fakeBackend) Content(sk Skill) ([]byte, error) {
+	return []byte("---\nname: " + sk.Name + "\n---\n# " + sk.Name + "\n"), nil
+}
+
+// send runs msg through the model, then every message its commands produce.
+func send(t *testing.T, m *Model, msg tea.Msg) {
+	t.Helper()
+	for msg != nil {
+		_, cmd := m.Update(msg)
+		if cmd == nil {
+			return
+		}
+		msg = cmd()
+	}
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestModel(t *testing.T) {
+	backend := &fakeBackend{skills: []Skill{
+		{SkillInfo: usecase.SkillInfo{Name: "always", Scope: "global", Category: "default", Targets: []string{"claude"}}},
+		{SkillInfo: usecase.SkillInfo{Name: "extra", Scope: "global", Category: "optional"}},
+	}}
+	m := New(backend)
+	send(t, m, m.Init()())
+
+	view := m.View()
+	for _, want := range []string{">  •  always [global] → claude", "[ ] extra [global]", "claude: in sync (1 installed)", "codex: out of sync (1 missing)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() = %q, want it to contain %q", view, want)
+		}
+	}
+
+	send(t, m, key(" "))
+	if len(backend.toggled) != 0 {
+		t.Errorf("toggled = %v, want default skill left alone", backend.toggled)
+	}
+	if !strings.Contains(m.View(), "always is not optional") {
+		t.Errorf("View() = %q, want a not-optional message", m.View())
+	}
+
+	send(t, m, key("j"))
+	send(t, m, key(" "))
+	if !slices.Equal(backend.toggled, []string{"extra"}) {
+		t.Errorf("toggled = %v, want [extra]", backend.toggled)
+	}
+	if view := m.View(); !strings.Contains(view, "[x] extra") || !strings.Contains(view, "extra enabled") {
+		t.Errorf("View() = %q, want extra enabled", view)
+	}
+	send(t, m, key(" "))
+	if !strings.Contains(m.View(), "[ ] extra") {
+		t.Errorf("View() = %q, want extra disabled", m.View())
+	}
+
+	send(t, m, key("s"))
+	if backend.synced != 1 {
+		t.Errorf("synced = %d, want 1", backend.synced)
+	}
+	if !strings.Contains(m.View(), "Synced: 1 install") {
+		t.Errorf("View() = %q, want a sync summary", m.View())
+	}
+
+	send(t, m, key("enter"))
+	if view := m.View(); !strings.Contains(view, "# extra") || !strings.Contains(view, "esc back") {
+		t.Errorf("View() = %q, want extra's SKILL.md", view)
+	}
+	send(t, m, key("esc"))
+	if !strings.Contains(m.View(), "Skills") {
+		t.Errorf("View() = %q, want the skill list after esc", m.View())
+	}
+
+	_, cmd := m.Update(key("q"))
+	if cmd == nil {
+		t.Fatal("q should quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q should quit")
+	}
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// AddAction represents the outcome of adding a skill.
+type AddAction string
+
+const (
+	AddActionAdded     AddAction = "added"
+	AddActionReplaced  AddAction = "replaced"
+	AddActionUnchanged AddAction = "unchanged"
+)
+
+// AddOptions contains options for adding a skill from a remote source.
+type AddOptions struct {
+	// Source is the skill location, e.g. github.com/org/skills/my-skill@v1
+	Source string
+	// Name overrides the skill name derived from the source
+	Name string
+	// Scope is the store to add the skill to (global or project)
+	Scope skill.Scope
+	// Force fetches again and replaces an existing skill of the same name
+	Force bool
+	// Checksum, when set, is the checksum the fetched skill must have; a
+	// skill that does not match is not installed
+	Checksum string
+}
+
+// AddResult represents the result of adding a skill.
+type AddResult struct {
+	SkillName string
+	Scope     skill.Scope
+	Path      string
+	Checksum  string
+	Action    AddAction
+}
+
+// AddService imports skills from remote sources into a store.
+type AddService struct {
+	fs      platformfs.FileSystem
+	cfg     *config.Config
+	root    string
+	fetcher fetch.Fetcher
+}
+
+// NewAddService creates a new add service that retrieves sources with fetcher.
+func NewAddService(fsys platformfs.FileSystem, cfg *config.Config, root string, fetcher fetch.Fetcher) *AddService {
+	return &AddService{
+		fs:      fsys,
+		cfg:     cfg,
+		root:    root,
+		fetcher: fetcher,
+	}
+}
+
+// Add fetches the source into the scope's skills directory and records it
+// in the store's lock file. The fetch is staged inside the agents directory
+// so a failed or invalid download never leaves a partial skill behind.
+//
+// Adding a source again is a no-op while the installed skill still matches
+// its lock entry. A skill of the same name from elsewhere, or one edited
+// since it was added, is only replaced with Force.
+func (s *AddService) Add(ctx context.Context, opts AddOptions) (*AddResult, error) {
+	src, err := fetch.ParseGitSource(opts.Source)
+	if err != nil {
+		return nil, err
+	}
+	name := opts.Name
+	if name == "" {
+		name = src.Name()
+	}
+	if err := skill.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	agentsDir, skillsDir, err := s.storeDirs(opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+	dest := s.fs.Join(skillsDir, name)
+
+	lock, err := loadLockfile(s.fs, agentsDir)
+	if err != nil {
+		return nil, err
+	}
+	result := &AddResult{SkillName: name, Scope: opts.Scope, Path: dest, Action: AddActionAdded}
+	if s.fs.Exists(dest) {
+		result.Action = AddActionReplaced
+		if !opts.Force {
+			entry, ok := lock.source(name)
+			if !ok || entry.Source != opts.Source {
+				return nil, fmt.Errorf("skill already exists in %s scope: %s (use --force to replace it)", opts.Scope, name)
+			}
+			if sum, err := dirChecksum(s.fs, dest); err != nil || sum != entry.Checksum {
+				return nil, fmt.Errorf("skill %s was modified since it was added (use --force to replace it)", name)
+			}
+			result.Action = AddActionUnchanged
+			result.Checksum = entry.Checksum
+			return result, nil
+		}
+	}
+
+	if err := s.fs.MkdirAll(skillsDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create skills directory: %w", err)
+	}
+	staged, cleanup, err := s.fetchStaged(ctx, opts.Source, name, agentsDir)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	sum, err := dirChecksum(s.fs, staged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to checksum skill: %w", err)
+	}
+	if opts.Checksum != "" && sum != opts.Checksum {
+		return nil, fmt.Errorf("checksum mismatch for %s: got %s, want %s", opts.Source, sum, opts.Checksum)
+	}
+	result.Checksum = sum
+	if result.Action == AddActionReplaced {
+		if existing, err := dirChecksum(s.fs, dest); err == nil && existing == sum {
+			result.Action = AddActionUnchanged
+		}
+		if err := s.fs.RemoveAll(dest); err != nil {
+			return nil, fmt.Errorf("failed to remove existing skill: %w", err)
+		}
+	}
+	if err := s.fs.Rename(staged, dest); err != nil {
+		return nil, fmt.Errorf("failed to install skill: %w", err)
+	}
+
+	lock.setSource(SourcedSkill{Name: name, Source: opts.Source, Checksum: sum})
+	if err := saveLockfile(s.fs, agentsDir, lock); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// fetchStaged fetches source into a staging directory inside agentsDir and
+// checks that it holds a skill. cleanup removes the staging directory.
+func (s *AddService) fetchStaged(ctx context.Context, source, name, agentsDir string) (staged string, cleanup func(), err error) {
+	tmp, err := s.fs.MkdirTemp(agentsDir, ".add-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	cleanup = func() { _ = s.fs.RemoveAll(tmp) }
+
+	staged = s.fs.Join(tmp, name)
+	if err := s.fetcher.Fetch(ctx, source, staged); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	if !s.fs.Exists(s.fs.Join(staged, "SKILL.md")) {
+		cleanup()
+		return "", nil, fmt.Errorf("no SKILL.md found in %s", source)
+	}
+	return staged, cleanup, nil
+}
+
+// storeDirs returns the agents and skills directories for a writable scope.
+func (s *AddService) storeDirs(scope skill.Scope) (agentsDir, skillsDir string, err error) {
+	return writableStoreDirs(s.fs, s.cfg, s.root, scope)
+}
+
+// writableStoreDirs returns the agents and skills directories of a scope
+// skills can be written to: global, or project inside a project.
+func writableStoreDirs(fsys platformfs.FileSystem, cfg *config.Config, root string, scope skill.Scope) (agentsDir, skillsDir string, err error) {
+	switch scope {
+	case skill.ScopeGlobal:
+		agentsDir, err = cfg.AgentsDir(fsys)
+		if err != nil {
+			return "", "", err
+		}
+		return agentsDir, fsys.Join(agentsDir, config.SkillsDirName), nil
+	case skill.ScopeProject:
+		if root == "" {
+			return "", "", fmt.Errorf("not in a project directory")
+		}
+		return config.ProjectAgentsDir(root, fsys), cfg.ProjectSkillsDir(fsys, root), nil
+	default:
+		return "", "", fmt.Errorf("cannot add skills to %s scope", scope)
+	}
+}
@@ -0,0 +1,143 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// mockFetcher writes files into the mock filesystem instead of fetching.
+type mockFetcher struct {
+	fs      *platformfs.MockFileSystem
+	files   map[string]string
+	sources []string
+}
+
+func (f *mockFetcher) Fetch(_ context.Context, source, dest string) error {
+	f.sources = append(f.sources, source)
+	f.fs.Dirs[dest] = true
+	for name, content := range f.files {
+		f.fs.Files[dest+"/"+name] = []byte(content)
+	}
+	return nil
+}
+
+func TestAddFetchesIntoGlobalStore(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	fetcher := &mockFetcher{fs: mock, files: map[string]string{
+		"SKILL.md":     "---\nname: my-skill\n---\nbody\n",
+		"reference.md": "notes\n",
+	}}
+
+	svc := usecase.NewAddService(mock, config.DefaultConfig(), "", fetcher)
+	result, err := svc.Add(t.Context(), usecase.AddOptions{Source: "github.com/org/skills/my-skill@v1", Scope: skill.ScopeGlobal})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if result.SkillName != "my-skill" || result.Path != "/home/test/.agents/skills/my-skill" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if !mock.Exists("/home/test/.agents/skills/my-skill/reference.md") {
+		t.Fatal("expected fetched files in the skills directory")
+	}
+	entries, _ := mock.ReadDir("/home/test/.agents")
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".add-") {
+			t.Fatalf("expected staging directory to be removed, found %s", e.Name())
+		}
+	}
+
+	lock := string(mock.Files["/home/test/.agents/skillet.lock"])
+	if !strings.Contains(lock, "sources:") || !strings.Contains(lock, "source: github.com/org/skills/my-skill@v1") {
+		t.Fatalf("unexpected lock file:\n%s", lock)
+	}
+
+	if _, err := svc.Add(t.Context(), usecase.AddOptions{Source: "github.com/org/skills/my-skill", Scope: skill.ScopeGlobal}); err == nil {
+		t.Fatal("Add() expected error for an existing skill from another source")
+	}
+	if len(fetcher.sources) != 1 {
+		t.Fatalf("existing skill should not be fetched again, fetched %v", fetcher.sources)
+	}
+}
+
+func TestAddIsIdempotent(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	fetcher := &mockFetcher{fs: mock, files: map[string]string{"SKILL.md": "---\nname: my-skill\n---\nbody\n"}}
+	svc := usecase.NewAddService(mock, config.DefaultConfig(), "", fetcher)
+	opts := usecase.AddOptions{Source: "github.com/org/skills/my-skill", Scope: skill.ScopeGlobal}
+
+	if result, err := svc.Add(t.Context(), opts); err != nil || result.Action != usecase.AddActionAdded {
+		t.Fatalf("Add() = %+v, %v; want added", result, err)
+	}
+	result, err := svc.Add(t.Context(), opts)
+	if err != nil || result.Action != usecase.AddActionUnchanged {
+		t.Fatalf("second Add() = %+v, %v; want unchanged", result, err)
+	}
+	if len(fetcher.sources) != 1 {
+		t.Fatalf("unchanged skill should not be fetched again, fetched %v", fetcher.sources)
+	}
+
+	// Local edits are not overwritten without Force.
+	mock.Files["/home/test/.agents/skills/my-skill/SKILL.md"] = []byte("---\nname: my-skill\n---\nedited\n")
+	if _, err := svc.Add(t.Context(), opts); err == nil {
+		t.Fatal("Add() expected error for a modified skill")
+	}
+
+	opts.Force = true
+	result, err = svc.Add(t.Context(), opts)
+	if err != nil || result.Action != usecase.AddActionReplaced {
+		t.Fatalf("forced Add() = %+v, %v; want replaced", result, err)
+	}
+	if got := string(mock.Files["/home/test/.agents/skills/my-skill/SKILL.md"]); !strings.Contains(got, "body") {
+		t.Fatalf("forced Add() should restore fetched content, got %q", got)
+	}
+}
+
+func TestAddProjectScopeWithName(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	mock.Dirs["/project"] = true
+	mock.Dirs["/project/.agents"] = true
+	fetcher := &mockFetcher{fs: mock, files: map[string]string{"SKILL.md": "---\nname: renamed\n---\n"}}
+
+	svc := usecase.NewAddService(mock, config.DefaultConfig(), "/project", fetcher)
+	result, err := svc.Add(t.Context(), usecase.AddOptions{Source: "github.com/org/skills", Name: "renamed", Scope: skill.ScopeProject})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if result.Path != "/project/.agents/skills/renamed" || !mock.Exists("/project/.agents/skills/renamed/SKILL.md") {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if !mock.Exists("/project/.agents/skillet.lock") {
+		t.Fatal("expected project lock file")
+	}
+}
+
+func TestAddRejectsInvalidSources(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	cfg := config.DefaultConfig()
+
+	noSkill := &mockFetcher{fs: mock, files: map[string]string{"README.md": "not a skill\n"}}
+	if _, err := usecase.NewAddService(mock, cfg, "", noSkill).Add(t.Context(), usecase.AddOptions{Source: "github.com/org/skills/docs", Scope: skill.ScopeGlobal}); err == nil {
+		t.Fatal("Add() expected error without SKILL.md")
+	}
+	if mock.Exists("/home/test/.agents/skills/docs") {
+		t.Fatal("invalid source should not be installed")
+	}
+
+	offline := fetch.Guard(noSkill, true)
+	_, err := usecase.NewAddService(mock, cfg, "", offline).Add(t.Context(), usecase.AddOptions{Source: "github.com/org/skills/x", Scope: skill.ScopeGlobal})
+	if !errors.Is(err, fetch.ErrOffline) {
+		t.Fatalf("Add() offline error = %v, want ErrOffline", err)
+	}
+
+	if _, err := usecase.NewAddService(mock, cfg, "", noSkill).Add(t.Context(), usecase.AddOptions{Source: "github.com/org/skills/x", Scope: skill.ScopeProject}); err == nil {
+		t.Fatal("Add() expected error for project scope outside a project")
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// AssertResult represents the outcome of a single assertion.
+type AssertResult struct {
+	Assertion string `json:"assertion"`
+	Passed    bool   `json:"passed"`
+	Message   string `json:"message"`
+}
+
+// AssertService evaluates assertions about the store and targets.
+type AssertService struct {
+	store   *skill.Store
+	targets *TargetRegistry
+	status  *StatusService
+}
+
+// NewAssertService creates a new assert service.
+func NewAssertService(fsys platformfs.FileSystem, cfg *config.Config, root string) *AssertService {
+	return &AssertService{
+		store:   skill.NewStore(fsys, cfg, root),
+		targets: NewTargetRegistry(fsys, root, cfg),
+		status:  NewStatusService(fsys, cfg, root),
+	}
+}
+
+// InSync asserts that every target is in sync with the store.
+func (s *AssertService) InSync(opts StatusOptions) (*AssertResult, error) {
+	statuses, err := s.status.GetStatus(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var outOfSync []string
+	for _, st := range statuses {
+		if st.Error != nil || !st.InSync {
+			outOfSync = append(outOfSync, st.Target)
+		}
+	}
+
+	result := &AssertResult{Assertion: "in-sync", Passed: len(outOfSync) == 0}
+	if result.Passed {
+		result.Message = "all targets are in sync"
+	} else {
+		result.Message = "out of sync: " + strings.Join(outOfSync, ", ")
+	}
+	return result, nil
+}
+
+// Installed asserts that a skill is installed in the given target,
+// or in every enabled target when targetName is empty.
+func (s *AssertService) Installed(name, targetName string) (*AssertResult, error) {
+	if err := skill.ValidateQualifiedName(name); err != nil {
+		return nil, fmt.Errorf("invalid skill name: %w", err)
+	}
+
+	targets := s.targets.GetAll()
+	if targetName != "" {
+		t, err := s.targets.Lookup(targetName)
+		if err != nil {
+			return nil, err
+		}
+		targets = []*Target{t}
+	}
+
+	var missing []string
+	for _, t := range targets {
+		if !t.IsInstalled(name) {
+			missing = append(missing, t.Name())
+		}
+	}
+
+	result := &AssertResult{Assertion: "installed", Passed: len(missing) == 0}
+	if result.Passed {
+		result.Message = fmt.Sprintf("%s is installed", name)
+	} else {
+		result.Message = fmt.Sprintf("%s is not installed in: %s", name, strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// Exists asserts that a skill exists in the store in any scope.
+func (s *AssertService) Exists(name string) (*AssertResult, error) {
+	if err := skill.ValidateQualifiedName(name); err != nil {
+		return nil, fmt.Errorf("invalid skill name: %w", err)
+	}
+
+	result := &AssertResult{Assertion: "exists", Passed: s.store.Exists(name)}
+	if result.Passed {
+		result.Message = fmt.Sprintf("%s exists", name)
+	} else {
+		result.Message = fmt.Sprintf("%s does not exist", name)
+	}
+	return result, nil
+}
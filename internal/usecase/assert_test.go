@@ -0,0 +1,54 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestAssertInstalledAndExists(t *testing.T) {
+	mock, syncSvc := setupSyncEnv()
+	addGlobalSkill(mock, "asserted")
+	svc := usecase.NewAssertService(mock, config.DefaultConfig(), "")
+
+	exists, err := svc.Exists("asserted")
+	if err != nil {
+		t.Fatalf("Exists() error = %v", err)
+	}
+	if !exists.Passed {
+		t.Fatal("Exists() should pass for store skill")
+	}
+
+	installed, err := svc.Installed("asserted", "claude")
+	if err != nil {
+		t.Fatalf("Installed() error = %v", err)
+	}
+	if installed.Passed {
+		t.Fatal("Installed() should fail before sync")
+	}
+
+	if _, err := syncSvc.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	installed, err = svc.Installed("asserted", "")
+	if err != nil {
+		t.Fatalf("Installed() error = %v", err)
+	}
+	if !installed.Passed {
+		t.Fatalf("Installed() should pass after sync: %s", installed.Message)
+	}
+
+	inSync, err := svc.InSync(usecase.StatusOptions{})
+	if err != nil {
+		t.Fatalf("InSync() error = %v", err)
+	}
+	if !inSync.Passed {
+		t.Fatalf("InSync() should pass after sync: %s", inSync.Message)
+	}
+
+	if _, err := svc.Installed("asserted", "unknown"); err == nil {
+		t.Fatal("Installed() should fail for unknown target")
+	}
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// maxBackfillLength caps derived descriptions, in runes.
+const maxBackfillLength = 200
+
+// DescriptionSource is where a derived description came from.
+type DescriptionSource string
+
+const (
+	DescriptionFromParagraph DescriptionSource = "paragraph"
+	DescriptionFromHeading   DescriptionSource = "heading"
+	DescriptionFromName      DescriptionSource = "name"
+)
+
+// BackfillProposal is a derived description for a skill that has none.
+type BackfillProposal struct {
+	Skill       *skill.Skill
+	Description string
+	Source      DescriptionSource
+}
+
+// BackfillService derives and writes descriptions for skills missing one.
+type BackfillService struct {
+	fs    platformfs.FileSystem
+	store *skill.Store
+}
+
+// NewBackfillService creates a new backfill service.
+func NewBackfillService(fsys platformfs.FileSystem, cfg *config.Config, root string) *BackfillService {
+	return &BackfillService{
+		fs:    fsys,
+		store: skill.NewStore(fsys, cfg, root),
+	}
+}
+
+// Propose returns a description for every writable skill with an empty one,
+// ordered by scope and name. Read-only and vendored skills are skipped.
+func (s *BackfillService) Propose() ([]BackfillProposal, error) {
+	skills, err := s.store.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	var proposals []BackfillProposal
+	for _, sk := range skills {
+		if sk.Description() != "" || sk.ReadOnly() || sk.Vendored {
+			continue
+		}
+		data, err := s.fs.ReadFile(s.fs.Join(sk.Path, "SKILL.md"))
+		if err != nil {
+			continue
+		}
+		_, body := skill.SplitFrontmatter(string(data))
+		desc, source := deriveDescription(sk.Name, body)
+		proposals = append(proposals, BackfillProposal{Skill: sk, Description: desc, Source: source})
+	}
+
+	slices.SortStableFunc(proposals, func(a, b BackfillProposal) int {
+		if c := b.Skill.Priority() - a.Skill.Priority(); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Skill.Name, b.Skill.Name)
+	})
+	return proposals, nil
+}
+
+// Apply writes description into a skill's SKILL.md frontmatter.
+func (s *BackfillService) Apply(sk *skill.Skill, description string) error {
+	if sk.ReadOnly() {
+		return fmt.Errorf("skill %s is in the read-only %s store", sk.Name, sk.Scope)
+	}
+	path := s.fs.Join(sk.Path, "SKILL.md")
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read SKILL.md: %w", err)
+	}
+	updated, err := skill.SetFrontmatterKey(data, "description", description)
+	if err != nil {
+		return err
+	}
+	if err := s.fs.WriteFile(path, updated, 0o644); err != nil {
+		return fmt.Errorf("failed to write SKILL.md: %w", err)
+	}
+	return nil
+}
+
+// deriveDescription picks the first sentence of the first prose paragraph,
+// then the first heading, then a description based on the skill name.
+func deriveDescription(name, body string) (string, DescriptionSource) {
+	var heading string
+	var paragraph []string
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		switch {
+		case trimmed == "":
+			if len(paragraph) > 0 {
+				return truncateDescription(firstSentence(strings.Join(paragraph, " "))), DescriptionFromParagraph
+			}
+		case strings.HasPrefix(trimmed, "#"):
+			if len(paragraph) > 0 {
+				return truncateDescription(firstSentence(strings.Join(paragraph, " "))), DescriptionFromParagraph
+			}
+			if heading == "" {
+				heading = strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			}
+		case isProse(trimmed):
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	if len(paragraph) > 0 {
+		return truncateDescription(firstSentence(strings.Join(paragraph, " "))), DescriptionFromParagraph
+	}
+	if heading != "" {
+		return truncateDescription(heading), DescriptionFromHeading
+	}
+
+	words := strings.Fields(strings.NewReplacer("-", " ", "_", " ").Replace(name))
+	if len(words) == 0 {
+		return name, DescriptionFromName
+	}
+	desc := strings.Join(words, " ")
+	r := []rune(desc)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r), DescriptionFromName
+}
+
+// isProse reports whether a markdown line is paragraph text rather than a
+// list item, quote, table row, or other block.
+func isProse(line string) bool {
+	for _, prefix := range []string{"- ", "* ", "+ ", ">", "|", "<", "---", "***"} {
+		if strings.HasPrefix(line, prefix) {
+			return false
+		}
+	}
+	if i := strings.Index(line, ". "); i > 0 && strings.Trim(line[:i], "0123456789") == "" {
+		return false
+	}
+	return true
+}
+
+// firstSentence returns text up to and including its first sentence end.
+func firstSentence(text string) string {
+	for i, r := range text {
+		if (r == '.' || r == '!' || r == '?') && (i+1 == len(text) || text[i+1] == ' ') {
+			return text[:i+1]
+		}
+	}
+	return text
+}
+
+// truncateDescription shortens text to maxBackfillLength runes at a word boundary.
+func truncateDescription(text string) string {
+	r := []rune(text)
+	if len(r) <= maxBackfillLength {
+		return text
+	}
+	cut := string(r[:maxBackfillLength])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:") + "…"
+}
@@ -0,0 +1,56 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestBackfillDescriptions(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	skills := map[string]string{
+		"paragraph":   "---\nname: paragraph\n---\n# Title\n\n```sh\nrun me\n```\n\nReviews pull requests for style.\nThen leaves comments.\n",
+		"heading":     "---\nname: heading\ndescription: \"\"\nlicense: MIT\n---\n# Deploy Helper\n\n- step one\n",
+		"code-review": "---\nname: code-review\n---\n",
+		"described":   "---\nname: described\ndescription: Already set\n---\nBody.\n",
+	}
+	for name, content := range skills {
+		dir := "/home/test/.agents/skills/" + name
+		mock.Dirs[dir] = true
+		mock.Files[dir+"/SKILL.md"] = []byte(content)
+	}
+
+	svc := usecase.NewBackfillService(mock, config.DefaultConfig(), "")
+	proposals, err := svc.Propose()
+	if err != nil {
+		t.Fatalf("Propose() error = %v", err)
+	}
+
+	want := map[string]string{
+		"code-review": "Code review",
+		"heading":     "Deploy Helper",
+		"paragraph":   "Reviews pull requests for style.",
+	}
+	if len(proposals) != len(want) {
+		t.Fatalf("Propose() = %d proposals, want %d", len(proposals), len(want))
+	}
+	for _, p := range proposals {
+		if p.Description != want[p.Skill.Name] {
+			t.Errorf("%s description = %q, want %q", p.Skill.Name, p.Description, want[p.Skill.Name])
+		}
+	}
+
+	for _, p := range proposals {
+		if p.Skill.Name == "heading" {
+			if err := svc.Apply(p.Skill, p.Description); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+		}
+	}
+	got := string(mock.Files["/home/test/.agents/skills/heading/SKILL.md"])
+	wantFile := "---\nname: heading\ndescription: Deploy Helper\nlicense: MIT\n---\n# Deploy Helper\n\n- step one\n"
+	if got != wantFile {
+		t.Errorf("SKILL.md = %q, want %q", got, wantFile)
+	}
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// bannerPrefix starts the comment added to SKILL.md in copies of targets with a banner.
+const bannerPrefix = "<!-- managed by skillet"
+
+// addBanner inserts a comment naming the store copy after the frontmatter of
+// SKILL.md, so the installed copy is not edited by mistake.
+func addBanner(t *Target, sk *skill.Skill, relPath string, data []byte) ([]byte, error) {
+	if relPath != "SKILL.md" {
+		return data, nil
+	}
+	banner := fmt.Sprintf("%s - edit %s instead; changes here are overwritten by sync -->\n", bannerPrefix, config.ContractPath(t.fs, sk.Path))
+
+	at := skill.FrontmatterEnd(data)
+	if at > 0 && data[at-1] != '\n' {
+		banner = "\n" + banner
+	}
+	out := make([]byte, 0, len(data)+len(banner))
+	out = append(out, data[:at]...)
+	out = append(out, banner...)
+	return append(out, data[at:]...), nil
+}
+
+// stripBanner removes the comment added by addBanner from SKILL.md content.
+// Content without a banner is returned unchanged.
+func stripBanner(data []byte) []byte {
+	at := skill.FrontmatterEnd(data)
+	if !bytes.HasPrefix(data[at:], []byte(bannerPrefix)) {
+		return data
+	}
+	end := bytes.IndexByte(data[at:], '\n')
+	if end < 0 {
+		return data[:at]
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:at]...)
+	return append(out, data[at+end+1:]...)
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+// dirChecksum returns a SHA-256 digest over the relative paths and contents
+// of all files under dir, independent of directory listing order.
+func dirChecksum(fsys platformfs.FileSystem, dir string) (string, error) {
+	files, err := readSkillFiles(fsys, dir)
+	if err != nil {
+		return "", err
+	}
+	return filesChecksum(files), nil
+}
+
+// filesChecksum returns the digest dirChecksum computes for a directory
+// holding exactly files.
+func filesChecksum(files skillFiles) string {
+	h := sha256.New()
+	for _, rel := range sortedPaths(files) {
+		_, _ = h.Write([]byte(rel))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write(files[rel])
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sortedPaths returns the paths in files ordered by path component, so a
+// directory's files come right after its name.
+func sortedPaths(files skillFiles) []string {
+	return slices.SortedFunc(maps.Keys(files), func(a, b string) int {
+		return slices.Compare(strings.Split(a, "/"), strings.Split(b, "/"))
+	})
+}
+
+// latestModTime returns the most recent modification time of files under dir.
+func latestModTime(fsys platformfs.FileSystem, dir string) time.Time {
+	var latest time.Time
+	entries, err := fsys.ReadDir(dir)
+	if err != nil {
+		return latest
+	}
+	for _, entry := range entries {
+		path := fsys.Join(dir, entry.Name())
+		var t time.Time
+		if fsys.IsDir(path) {
+			t = latestModTime(fsys, path)
+		} else if info, err := fsys.Stat(path); err == nil {
+			t = info.ModTime()
+		}
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// treeStamp summarizes the files under dir without reading them: their
+// number, total size, and latest modification time. Directories count toward
+// the modification time, so removing a file changes the stamp too. The stamp
+// is empty when dir cannot be read.
+func treeStamp(fsys platformfs.FileSystem, dir string) string {
+	var count, size, latest int64
+	var walk func(dir string) bool
+	walk = func(dir string) bool {
+		info, err := fsys.Stat(dir)
+		if err != nil {
+			return false
+		}
+		latest = max(latest, info.ModTime().UnixNano())
+		entries, err := fsys.ReadDir(dir)
+		if err != nil {
+			return false
+		}
+		for _, entry := range entries {
+			path := fsys.Join(dir, entry.Name())
+			if fsys.IsDir(path) {
+				if !walk(path) {
+					return false
+				}
+				continue
+			}
+			info, err := fsys.Stat(path)
+			if err != nil {
+				return false
+			}
+			count++
+			size += info.Size()
+			latest = max(latest, info.ModTime().UnixNano())
+		}
+		return true
+	}
+	if !walk(dir) {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d:%d", count, size, latest)
+}
+
+// fileDigests returns the SHA-256 digest of every file under dir, keyed by
+// path relative to dir.
+func fileDigests(fsys platformfs.FileSystem, dir string) (map[string]string, error) {
+	files, err := readSkillFiles(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	digests := make(map[string]string, len(files))
+	for rel, data := range files {
+		sum := sha256.Sum256(data)
+		digests[rel] = hex.EncodeToString(sum[:])
+	}
+	return digests, nil
+}
+
+// readSkillFiles reads every file under dir, keyed by path relative to dir.
+func readSkillFiles(fsys platformfs.FileSystem, dir string) (skillFiles, error) {
+	files := make(skillFiles)
+	var walk func(rel string) error
+	walk = func(rel string) error {
+		entries, err := fsys.ReadDir(fsys.Join(dir, rel))
+		if err != nil {
+			return fmt.Errorf("failed to read directory: %w", err)
+		}
+		for _, entry := range entries {
+			relPath := path.Join(rel, entry.Name())
+			fullPath := fsys.Join(dir, relPath)
+			if fsys.IsDir(fullPath) {
+				if err := walk(relPath); err != nil {
+					return err
+				}
+				continue
+			}
+			data, err := fsys.ReadFile(fullPath)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", relPath, err)
+			}
+			files[relPath] = data
+		}
+		return nil
+	}
+	return files, walk("")
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// NameConflict is a name claimed by more than one skill: a skill defined in
+// several scopes of the store, or skills that a target would install under
+// the same directory name.
+type NameConflict struct {
+	// Name is the skill name, or the installed name for a target clash
+	Name string `json:"name"`
+	// Target is the target whose installed names clash (empty for a skill
+	// defined in several scopes)
+	Target string `json:"target,omitempty"`
+	// Winner is the copy that takes effect, or nil when the resolution
+	// policy refuses to pick one
+	Winner *ConflictCopy `json:"winner"`
+	// Losers are the copies the winner overrides, highest priority first
+	Losers []ConflictCopy `json:"losers"`
+}
+
+// ConflictCopy is one of the skills claiming a conflicting name.
+type ConflictCopy struct {
+	Skill    string `json:"skill"`
+	Scope    string `json:"scope"`
+	Path     string `json:"path"`
+	Version  string `json:"version,omitempty"`
+	Vendored bool   `json:"vendored,omitempty"`
+}
+
+// Conflicts lists the names defined in more than one scope, ordered by name,
+// followed by the installed names that clash in each target, ordered by
+// target, then by name. Drafts never shadow a published skill and are left
+// out.
+func (s *LocateService) Conflicts() ([]NameConflict, error) {
+	resolved, err := s.store.GetResolved()
+	var conflictErr *skill.ConflictError
+	if errors.As(err, &conflictErr) {
+		err = nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	all, err := s.store.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	winners := make(map[string]*skill.Skill, len(resolved))
+	for _, sk := range resolved {
+		winners[sk.Name] = sk
+	}
+	byName := make(map[string][]*skill.Skill)
+	for _, sk := range all {
+		if !sk.Draft() {
+			byName[sk.Name] = append(byName[sk.Name], sk)
+		}
+	}
+
+	var conflicts []NameConflict
+	for _, name := range slices.Sorted(maps.Keys(byName)) {
+		if len(byName[name]) > 1 {
+			conflicts = append(conflicts, newNameConflict(name, "", winners[name], byName[name]))
+		}
+	}
+
+	for _, t := range s.targets.GetAll() {
+		collisions := entryCollisions(t, resolved, wantedSkills(t, resolved))
+		losers := make(map[string][]*skill.Skill)
+		for _, sk := range resolved {
+			if first, ok := collisions[sk.Name]; ok {
+				losers[first] = append(losers[first], sk)
+			}
+		}
+		for _, first := range slices.Sorted(maps.Keys(losers)) {
+			winner := winners[first]
+			conflicts = append(conflicts, newNameConflict(t.installedName(first), t.Name(), winner, append([]*skill.Skill{winner}, losers[first]...)))
+		}
+	}
+	return conflicts, nil
+}
+
+// newNameConflict describes the skills claiming name, of which winner (nil
+// for none) takes effect.
+func newNameConflict(name, target string, winner *skill.Skill, skills []*skill.Skill) NameConflict {
+	c := NameConflict{Name: name, Target: target, Losers: []ConflictCopy{}}
+	if winner != nil {
+		copied := conflictCopy(winner)
+		c.Winner = &copied
+	}
+	ordered := slices.Clone(skills)
+	slices.SortStableFunc(ordered, func(a, b *skill.Skill) int {
+		return cmp.Compare(b.Priority(), a.Priority())
+	})
+	for _, sk := range ordered {
+		if winner == nil || sk.Path != winner.Path {
+			c.Losers = append(c.Losers, conflictCopy(sk))
+		}
+	}
+	return c
+}
+
+func conflictCopy(sk *skill.Skill) ConflictCopy {
+	return ConflictCopy{Skill: sk.Name, Scope: sk.Scope.String(), Path: sk.Path, Version: sk.Version(), Vendored: sk.Vendored}
+}
@@ -0,0 +1,53 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestConflicts(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	addGlobalSkill(mock, "review")
+	mock.Dirs["/project/.agents/skills"] = true
+	mock.Dirs["/project/.agents/skills/alpha"] = true
+	mock.Files["/project/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\nversion: 2.0.0\n---\n")
+	mock.Dirs["/home/test/.agents/skills/docs"] = true
+	mock.Dirs["/home/test/.agents/skills/docs/review"] = true
+	mock.Files["/home/test/.agents/skills/docs/review/SKILL.md"] = []byte("---\nname: review\n---\n")
+
+	cfg := config.DefaultConfig()
+	cfg.Collections = config.CollectionsFlatten
+	conflicts, err := usecase.NewLocateService(mock, cfg, "/project").Conflicts()
+	if err != nil {
+		t.Fatalf("Conflicts() error = %v", err)
+	}
+	// alpha is defined in two scopes, and docs/review installs as review in
+	// both targets.
+	if len(conflicts) != 3 {
+		t.Fatalf("Conflicts() = %+v, want 3 conflicts", conflicts)
+	}
+	alpha := conflicts[0]
+	if alpha.Name != "alpha" || alpha.Target != "" || alpha.Winner == nil || alpha.Winner.Scope != "project" || alpha.Winner.Version != "2.0.0" {
+		t.Errorf("conflicts[0] = %+v, want alpha won by the project copy", alpha)
+	}
+	if len(alpha.Losers) != 1 || alpha.Losers[0].Path != "/home/test/.agents/skills/alpha" {
+		t.Errorf("alpha losers = %+v, want the global copy", alpha.Losers)
+	}
+	clash := conflicts[1]
+	if clash.Name != "review" || clash.Target != "claude" || clash.Winner.Skill != "docs/review" || len(clash.Losers) != 1 || clash.Losers[0].Skill != "review" {
+		t.Errorf("conflicts[1] = %+v, want docs/review winning over review in claude", clash)
+	}
+
+	cfg.Resolution = "error-on-conflict"
+	cfg.Collections = config.CollectionsNamespace
+	conflicts, err = usecase.NewLocateService(mock, cfg, "/project").Conflicts()
+	if err != nil {
+		t.Fatalf("Conflicts() error = %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Winner != nil || len(conflicts[0].Losers) != 2 {
+		t.Errorf("Conflicts() = %+v, want alpha without a winner", conflicts)
+	}
+}
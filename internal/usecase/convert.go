@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// commandsDirName is the directory holding slash-command prompts in a target.
+const commandsDirName = "commands"
+
+// ConvertAction represents the outcome of converting a single command.
+type ConvertAction string
+
+const (
+	ConvertActionConverted ConvertAction = "converted"
+	ConvertActionSkipped   ConvertAction = "skipped"
+	ConvertActionError     ConvertAction = "error"
+)
+
+// ConvertOptions contains options for converting commands to skills.
+type ConvertOptions struct {
+	// Target is the target whose commands directory is converted
+	Target string
+	// Scope selects the global or project commands directory and store
+	Scope skill.Scope
+	// KeepShim replaces each command with a stub pointing at the new skill
+	KeepShim bool
+	// DryRun only shows what would be done without making changes
+	DryRun bool
+}
+
+// ConvertResult represents the result of converting a single command.
+type ConvertResult struct {
+	Command   string
+	SkillName string
+	Action    ConvertAction
+	Message   string
+	Error     error
+}
+
+// ConvertService converts slash-command prompts into skills.
+type ConvertService struct {
+	fs      platformfs.FileSystem
+	cfg     *config.Config
+	root    string
+	targets *TargetRegistry
+}
+
+// NewConvertService creates a new convert service.
+func NewConvertService(fsys platformfs.FileSystem, cfg *config.Config, root string) *ConvertService {
+	return &ConvertService{
+		fs:      fsys,
+		cfg:     cfg,
+		root:    root,
+		targets: NewTargetRegistry(fsys, root, cfg),
+	}
+}
+
+// Convert turns each <target>/commands/**/*.md file into a skill in the store.
+// Commands in subdirectories are named <dir>-<file>.
+func (s *ConvertService) Convert(opts ConvertOptions) ([]ConvertResult, error) {
+	t, err := s.targets.Lookup(opts.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	targetRoot, err := t.GetRootPath(opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+	commandsDir := s.fs.Join(targetRoot, commandsDirName)
+
+	skillsDir, err := s.skillsDir(opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := listCommands(s.fs, commandsDir, "")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ConvertResult, 0, len(commands))
+	for _, rel := range commands {
+		results = append(results, s.convertCommand(commandsDir, rel, skillsDir, opts))
+	}
+
+	return results, nil
+}
+
+// skillsDir returns the store skills directory for a scope.
+func (s *ConvertService) skillsDir(scope skill.Scope) (string, error) {
+	switch scope {
+	case skill.ScopeGlobal:
+		return s.cfg.GlobalSkillsDir(s.fs)
+	case skill.ScopeProject:
+		if s.root == "" {
+			return "", fmt.Errorf("project root not set")
+		}
+		return config.ProjectSkillsDir(s.root, s.fs, ""), nil
+	default:
+		return "", fmt.Errorf("convert does not support %s scope", scope)
+	}
+}
+
+// listCommands returns command file paths relative to dir, sorted.
+func listCommands(fsys platformfs.FileSystem, dir, rel string) ([]string, error) {
+	full := fsys.Join(dir, rel)
+	if !fsys.IsDir(full) {
+		return nil, nil
+	}
+
+	entries, err := fsys.ReadDir(full)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commands directory: %w", err)
+	}
+
+	var commands []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		if entry.IsDir() && rel == "" {
+			nested, err := listCommands(fsys, dir, name)
+			if err != nil {
+				return nil, err
+			}
+			commands = append(commands, nested...)
+			continue
+		}
+		if entry.Type().IsRegular() && strings.HasSuffix(name, ".md") {
+			commands = append(commands, fsys.Join(rel, name))
+		}
+	}
+	slices.Sort(commands)
+
+	return commands, nil
+}
+
+// convertCommand converts a single command file into a skill.
+func (s *ConvertService) convertCommand(commandsDir, rel, skillsDir string, opts ConvertOptions) ConvertResult {
+	name := commandSkillName(rel)
+	src := s.fs.Join(commandsDir, rel)
+	result := ConvertResult{Command: src, SkillName: name}
+
+	if err := skill.ValidateName(name); err != nil {
+		result.Action = ConvertActionError
+		result.Error = fmt.Errorf("invalid skill name: %w", err)
+		return result
+	}
+
+	dst := s.fs.Join(skillsDir, name)
+	if s.fs.Exists(dst) {
+		result.Action = ConvertActionSkipped
+		result.Message = "skill already exists in store"
+		return result
+	}
+
+	data, err := s.fs.ReadFile(src)
+	if err != nil {
+		result.Action = ConvertActionError
+		result.Error = fmt.Errorf("failed to read command: %w", err)
+		return result
+	}
+
+	description, body := commandDescription(string(data))
+	fields, moved := commandFields(string(data))
+	content, err := skill.FormatSkillFile(name, description, body, fields...)
+	if err != nil {
+		result.Action = ConvertActionError
+		result.Error = err
+		return result
+	}
+	if len(moved) > 0 {
+		result.Message = "kept " + strings.Join(moved, ", ") + " under metadata"
+	}
+
+	result.Action = ConvertActionConverted
+	if opts.DryRun {
+		return result
+	}
+
+	if err := s.fs.MkdirAll(dst, 0o755); err != nil {
+		result.Action = ConvertActionError
+		result.Error = fmt.Errorf("failed to create skill directory: %w", err)
+		return result
+	}
+	if err := s.fs.WriteFile(s.fs.Join(dst, "SKILL.md"), content, 0o644); err != nil {
+		result.Action = ConvertActionError
+		result.Error = fmt.Errorf("failed to write SKILL.md: %w", err)
+		return result
+	}
+
+	if opts.KeepShim {
+		shim := fmt.Sprintf("---\ndescription: %s\n---\n\nUse the `%s` skill.\n", yamlScalar(description), name)
+		err = s.fs.WriteFile(src, []byte(shim), 0o644)
+	} else {
+		err = s.fs.Remove(src)
+	}
+	if err != nil {
+		result.Action = ConvertActionError
+		result.Error = fmt.Errorf("skill created but failed to update command: %w", err)
+	}
+
+	return result
+}
+
+// commandSkillName returns the skill name of the command file at rel, relative
+// to the commands directory: commands in subdirectories are named <dir>-<file>.
+func commandSkillName(rel string) string {
+	return strings.ReplaceAll(strings.TrimSuffix(rel, ".md"), string(os.PathSeparator), "-")
+}
+
+// commandDescription extracts a description and body from a command file.
+// It prefers a frontmatter description and falls back to the first line of the body.
+func commandDescription(content string) (string, string) {
+	frontmatter, body := skill.SplitFrontmatter(content)
+
+	var meta struct {
+		Description string `yaml:"description"`
+	}
+	if frontmatter != "" && yaml.Unmarshal([]byte(frontmatter), &meta) == nil && strings.TrimSpace(meta.Description) != "" {
+		return strings.TrimSpace(meta.Description), body
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if line != "" {
+			return line, body
+		}
+	}
+	return "", body
+}
+
+// commandFields returns the key and value nodes of the frontmatter fields of
+// a command other than its name and description, to carry into the skill
+// converted from it. Fields the schema knows, such as allowed-tools, are kept
+// as they are; the others, such as argument-hint and model, are moved under
+// metadata so the skill still validates. It also returns the moved fields.
+func commandFields(content string) ([]*yaml.Node, []string) {
+	frontmatter, _ := skill.SplitFrontmatter(content)
+	var doc yaml.Node
+	if frontmatter == "" || yaml.Unmarshal([]byte(frontmatter), &doc) != nil ||
+		len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var fields, metadata []*yaml.Node
+	var moved []string
+	mapping := doc.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		switch key.Value {
+		case "name", "description":
+			continue
+		case "metadata":
+			if value.Kind == yaml.MappingNode {
+				metadata = append(metadata, value.Content...)
+				continue
+			}
+		}
+		if known, err := skill.CheckField(key.Value, value); known && err == nil {
+			fields = append(fields, key, value)
+			continue
+		}
+		metadata = append(metadata, key, value)
+		moved = append(moved, key.Value)
+	}
+	if len(metadata) > 0 {
+		fields = append(fields,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "metadata"},
+			&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: metadata})
+	}
+	return fields, moved
+}
+
+// yamlScalar renders s as a single-line YAML scalar.
+func yamlScalar(s string) string {
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		return `""`
+	}
+	return strings.TrimSpace(string(out))
+}
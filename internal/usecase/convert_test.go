@@ -0,0 +1,137 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestConvertCommands(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "existing")
+	mock.Dirs["/home/test/.claude/commands"] = true
+	mock.Dirs["/home/test/.claude/commands/git"] = true
+	mock.Files["/home/test/.claude/commands/review.md"] = []byte("# Review the diff\n\nLook at $ARGUMENTS carefully.\n")
+	mock.Files["/home/test/.claude/commands/git/commit.md"] = []byte("---\ndescription: Write a commit message\n---\n\nCommit it.\n")
+	mock.Files["/home/test/.claude/commands/existing.md"] = []byte("Existing\n")
+
+	svc := usecase.NewConvertService(mock, config.DefaultConfig(), "")
+	results, err := svc.Convert(usecase.ConvertOptions{Target: "claude", Scope: skill.ScopeGlobal, KeepShim: true})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	actions := make(map[string]usecase.ConvertAction)
+	for _, r := range results {
+		if r.Error != nil {
+			t.Errorf("unexpected error for %s: %v", r.Command, r.Error)
+		}
+		actions[r.SkillName] = r.Action
+	}
+	if actions["review"] != usecase.ConvertActionConverted || actions["git-commit"] != usecase.ConvertActionConverted {
+		t.Errorf("expected review and git-commit to be converted, got %v", actions)
+	}
+	if actions["existing"] != usecase.ConvertActionSkipped {
+		t.Errorf("expected existing to be skipped, got %v", actions["existing"])
+	}
+
+	review := string(mock.Files["/home/test/.agents/skills/review/SKILL.md"])
+	if !strings.Contains(review, "name: review") || !strings.Contains(review, "description: Review the diff") {
+		t.Errorf("unexpected review SKILL.md:\n%s", review)
+	}
+	if !strings.Contains(review, "Look at $ARGUMENTS carefully.") {
+		t.Errorf("expected command body to be preserved:\n%s", review)
+	}
+
+	commit := string(mock.Files["/home/test/.agents/skills/git-commit/SKILL.md"])
+	if !strings.Contains(commit, "description: Write a commit message") || strings.Count(commit, "---") != 2 {
+		t.Errorf("unexpected git-commit SKILL.md:\n%s", commit)
+	}
+
+	shim := string(mock.Files["/home/test/.claude/commands/review.md"])
+	if !strings.Contains(shim, "`review` skill") {
+		t.Errorf("expected command to be replaced by a shim, got:\n%s", shim)
+	}
+}
+
+func TestConvertCommandsKeepsFrontmatter(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	mock.Dirs["/home/test/.claude/commands"] = true
+	mock.Files["/home/test/.claude/commands/review.md"] = []byte("---\n" +
+		"description: Review the diff\n" +
+		"allowed-tools: Bash(git diff:*), Read\n" +
+		"argument-hint: [pr-number]\n" +
+		"model: claude-sonnet\n" +
+		"---\n\nReview $ARGUMENTS.\n")
+
+	svc := usecase.NewConvertService(mock, config.DefaultConfig(), "")
+	results, err := svc.Convert(usecase.ConvertOptions{Target: "claude", Scope: skill.ScopeGlobal})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Action != usecase.ConvertActionConverted {
+		t.Fatalf("Convert() = %+v, want review converted", results)
+	}
+	if want := "kept argument-hint, model under metadata"; results[0].Message != want {
+		t.Errorf("Message = %q, want %q", results[0].Message, want)
+	}
+
+	content := string(mock.Files["/home/test/.agents/skills/review/SKILL.md"])
+	want := "---\n" +
+		"name: review\n" +
+		"description: Review the diff\n" +
+		"allowed-tools: Bash(git diff:*), Read\n" +
+		"metadata:\n" +
+		"    argument-hint: [pr-number]\n" +
+		"    model: claude-sonnet\n" +
+		"---\n\nReview $ARGUMENTS.\n"
+	if content != want {
+		t.Errorf("SKILL.md =\n%s\nwant\n%s", content, want)
+	}
+	frontmatter, _ := skill.SplitFrontmatter(content)
+	if err := skill.ValidateFrontmatter(frontmatter); err != nil {
+		t.Errorf("converted frontmatter does not validate: %v", err)
+	}
+}
+
+func TestConvertCommandsRemovesOriginal(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	mock.Dirs["/home/test/.claude/commands"] = true
+	mock.Files["/home/test/.claude/commands/deploy.md"] = []byte("Deploy the app\n")
+
+	svc := usecase.NewConvertService(mock, config.DefaultConfig(), "")
+	if _, err := svc.Convert(usecase.ConvertOptions{Target: "claude", Scope: skill.ScopeGlobal}); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if _, ok := mock.Files["/home/test/.claude/commands/deploy.md"]; ok {
+		t.Error("expected original command to be removed")
+	}
+	if _, ok := mock.Files["/home/test/.agents/skills/deploy/SKILL.md"]; !ok {
+		t.Error("expected deploy skill to be created")
+	}
+}
+
+func TestConvertCommandsDryRun(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	mock.Dirs["/home/test/.claude/commands"] = true
+	mock.Files["/home/test/.claude/commands/deploy.md"] = []byte("Deploy the app\n")
+
+	svc := usecase.NewConvertService(mock, config.DefaultConfig(), "")
+	results, err := svc.Convert(usecase.ConvertOptions{Target: "claude", Scope: skill.ScopeGlobal, DryRun: true})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Action != usecase.ConvertActionConverted {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if _, ok := mock.Files["/home/test/.agents/skills/deploy/SKILL.md"]; ok {
+		t.Error("dry run should not create skills")
+	}
+	if _, ok := mock.Files["/home/test/.claude/commands/deploy.md"]; !ok {
+		t.Error("dry run should not remove commands")
+	}
+}
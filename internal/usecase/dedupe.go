@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+const (
+	// DefaultDedupeThreshold is the default similarity for two skills to be clustered.
+	DefaultDedupeThreshold = 0.8
+	// archiveDirName is the directory under .agents holding archived skills.
+	archiveDirName = ".archive"
+	// shingleSize is the number of words per shingle when comparing bodies.
+	shingleSize = 3
+)
+
+// DedupeOptions contains options for finding duplicate skills.
+type DedupeOptions struct {
+	// Threshold is the minimum similarity (0-1) to cluster two skills
+	Threshold float64
+}
+
+// DedupeCluster is a group of skills with identical or similar content.
+type DedupeCluster struct {
+	// Skills are ordered with the suggested skill to keep first
+	Skills []*skill.Skill
+	// Similarity is the lowest similarity between linked skills in the cluster
+	Similarity float64
+	// Exact is true when every skill has the same normalized body
+	Exact bool
+}
+
+// Keep returns the skill suggested to keep.
+func (c *DedupeCluster) Keep() *skill.Skill {
+	return c.Skills[0]
+}
+
+// DedupeService finds and archives duplicate skills.
+type DedupeService struct {
+	fs    platformfs.FileSystem
+	cfg   *config.Config
+	store *skill.Store
+	root  string
+}
+
+// NewDedupeService creates a new dedupe service.
+func NewDedupeService(fsys platformfs.FileSystem, cfg *config.Config, root string) *DedupeService {
+	return &DedupeService{
+		fs:    fsys,
+		cfg:   cfg,
+		store: skill.NewStore(fsys, cfg, root),
+		root:  root,
+	}
+}
+
+// dedupeEntry holds the comparable form of a skill's SKILL.md body.
+type dedupeEntry struct {
+	skill    *skill.Skill
+	hash     [sha256.Size]byte
+	shingles map[string]bool
+}
+
+// Find clusters skills whose normalized SKILL.md bodies are identical or similar.
+// Skills sharing a name (scope overrides) are never compared with each other.
+func (s *DedupeService) Find(opts DedupeOptions) ([]DedupeCluster, error) {
+	threshold := opts.Threshold
+	if threshold <= 0 {
+		threshold = DefaultDedupeThreshold
+	}
+	if threshold > 1 {
+		return nil, fmt.Errorf("threshold must be between 0 and 1: %v", threshold)
+	}
+
+	skills, err := s.store.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	entries := make([]dedupeEntry, 0, len(skills))
+	for _, sk := range skills {
+		data, err := s.fs.ReadFile(s.fs.Join(sk.Path, "SKILL.md"))
+		if err != nil {
+			continue
+		}
+		_, body := skill.SplitFrontmatter(string(data))
+		words := strings.Fields(strings.ToLower(body))
+		if len(words) == 0 {
+			continue
+		}
+		entries = append(entries, dedupeEntry{
+			skill:    sk,
+			hash:     sha256.Sum256([]byte(strings.Join(words, " "))),
+			shingles: shingles(words),
+		})
+	}
+
+	// Union-find over every pair above the threshold.
+	parent := make([]int, len(entries))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	minSim := make(map[int]float64)
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			if entries[i].skill.Name == entries[j].skill.Name {
+				continue
+			}
+			sim := 1.0
+			if entries[i].hash != entries[j].hash {
+				sim = jaccard(entries[i].shingles, entries[j].shingles)
+			}
+			if sim < threshold {
+				continue
+			}
+			ri, rj := find(i), find(j)
+			low := sim
+			for _, r := range []int{ri, rj} {
+				if v, ok := minSim[r]; ok && v < low {
+					low = v
+				}
+			}
+			parent[rj] = ri
+			minSim[ri] = low
+		}
+	}
+
+	groups := make(map[int][]dedupeEntry)
+	for i := range entries {
+		r := find(i)
+		groups[r] = append(groups[r], entries[i])
+	}
+
+	var clusters []DedupeCluster
+	for r, group := range groups {
+		if len(group) < 2 {
+			continue
+		}
+		cluster := DedupeCluster{Similarity: minSim[r], Exact: true}
+		for _, e := range group {
+			cluster.Skills = append(cluster.Skills, e.skill)
+			if e.hash != group[0].hash {
+				cluster.Exact = false
+			}
+		}
+		// Suggest keeping the highest-priority skill, then the shortest name.
+		slices.SortFunc(cluster.Skills, func(a, b *skill.Skill) int {
+			if c := cmp.Compare(b.Priority(), a.Priority()); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(len(a.Name), len(b.Name)); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Name, b.Name)
+		})
+		clusters = append(clusters, cluster)
+	}
+	slices.SortFunc(clusters, func(a, b DedupeCluster) int {
+		return cmp.Compare(a.Keep().Name, b.Keep().Name)
+	})
+
+	return clusters, nil
+}
+
+// Archive moves a skill out of its store into <agents>/.archive/<name>.
+// Read-only skills cannot be archived.
+func (s *DedupeService) Archive(sk *skill.Skill) (string, error) {
+	if sk.ReadOnly() {
+		return "", fmt.Errorf("skill %s is in the read-only %s store", sk.Name, sk.Scope)
+	}
+
+	var agentsDir string
+	var err error
+	switch sk.Scope {
+	case skill.ScopeProject:
+		agentsDir = config.ProjectAgentsDir(s.root, s.fs)
+	case skill.ScopeOrg:
+		agentsDir, err = s.cfg.OrgAgentsDir(s.fs)
+	default:
+		agentsDir, err = s.cfg.AgentsDir(s.fs)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	archiveDir := s.fs.Join(agentsDir, archiveDirName)
+	dst := s.fs.Join(archiveDir, sk.Name)
+	for i := 2; s.fs.Exists(dst); i++ {
+		dst = s.fs.Join(archiveDir, fmt.Sprintf("%s-%d", sk.Name, i))
+	}
+
+	if err := s.fs.MkdirAll(archiveDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	if err := s.fs.Rename(sk.Path, dst); err != nil {
+		return "", fmt.Errorf("failed to archive skill: %w", err)
+	}
+	return dst, nil
+}
+
+// shingles returns the set of consecutive word groups in words.
+func shingles(words []string) map[string]bool {
+	set := make(map[string]bool)
+	if len(words) < shingleSize {
+		set[strings.Join(words, " ")] = true
+		return set
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleSize], " ")] = true
+	}
+	return set
+}
+
+// jaccard returns the Jaccard similarity of two sets.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	inter := 0
+	for k := range a {
+		if b[k] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
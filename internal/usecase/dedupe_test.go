@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestDedupeFindAndArchive(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	body := "Review the pull request for correctness, style, and missing tests before approving it."
+	skills := map[string]string{
+		"review":      "---\nname: review\n---\n" + body + "\n",
+		"code-review": "---\nname: code-review\ndescription: copy\n---\n\n" + "REVIEW the pull request   for correctness, style, and missing tests before approving it.\n",
+		"deploy":      "---\nname: deploy\n---\nDeploy the service to production after checks pass.\n",
+	}
+	for name, content := range skills {
+		dir := "/home/test/.agents/skills/" + name
+		mock.Dirs[dir] = true
+		mock.Files[dir+"/SKILL.md"] = []byte(content)
+	}
+
+	svc := usecase.NewDedupeService(mock, config.DefaultConfig(), "")
+	clusters, err := svc.Find(usecase.DedupeOptions{})
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(clusters) != 1 {
+		t.Fatalf("Find() returned %d clusters, want 1", len(clusters))
+	}
+	c := clusters[0]
+	if !c.Exact || len(c.Skills) != 2 {
+		t.Fatalf("cluster = %+v, want exact pair", c)
+	}
+	if c.Keep().Name != "review" {
+		t.Fatalf("Keep() = %s, want review", c.Keep().Name)
+	}
+
+	dst, err := svc.Archive(c.Skills[1])
+	if err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if dst != "/home/test/.agents/.archive/code-review" {
+		t.Fatalf("Archive() = %s", dst)
+	}
+	if mock.IsDir("/home/test/.agents/skills/code-review") || !mock.IsDir(dst) {
+		t.Fatal("Archive() did not move the skill")
+	}
+}
+
+func TestDedupeThreshold(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	base := "one two three four five six seven eight nine ten"
+	for name, body := range map[string]string{
+		"a": base,
+		"b": base + " eleven",
+	} {
+		dir := "/home/test/.agents/skills/" + name
+		mock.Dirs[dir] = true
+		mock.Files[dir+"/SKILL.md"] = []byte("---\nname: " + name + "\n---\n" + body + "\n")
+	}
+
+	svc := usecase.NewDedupeService(mock, config.DefaultConfig(), "")
+	clusters, err := svc.Find(usecase.DedupeOptions{Threshold: 0.8})
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(clusters) != 1 || clusters[0].Exact {
+		t.Fatalf("Find() = %+v, want one similar cluster", clusters)
+	}
+
+	clusters, err = svc.Find(usecase.DedupeOptions{Threshold: 0.95})
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(clusters) != 0 {
+		t.Fatalf("Find() at 0.95 = %+v, want none", clusters)
+	}
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/diff"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// FileDiff is a file that differs between an installed copy and the store.
+// Added files are in the store only, removed files in the installed copy only.
+type FileDiff struct {
+	FileChange
+	// Diff is the unified diff from the installed file to the store's
+	// (empty for binary files)
+	Diff   string
+	Binary bool
+}
+
+// DiffOptions contains options for comparing a skill with its installs.
+type DiffOptions struct {
+	// Name is the skill to compare
+	Name string
+	// Scope limits the lookup to a specific scope (nil to resolve by priority)
+	Scope *skill.Scope
+	// Target limits the comparison to a single target (empty for all)
+	Target string
+}
+
+// DiffResult compares the install of a skill in one target with the store.
+type DiffResult struct {
+	SkillName string
+	Target    string
+	// Path is the install path in the target
+	Path string
+	// Installed is false when the target has no install of the skill
+	Installed bool
+	Strategy  config.Strategy
+	// Files lists the differing files in path order. A symlink to the store
+	// has none.
+	Files []FileDiff
+	Error error
+}
+
+// DiffService compares installed copies of skills with the store.
+type DiffService struct {
+	fs      platformfs.FileSystem
+	store   *skill.Store
+	targets *TargetRegistry
+}
+
+// NewDiffService creates a new diff service.
+func NewDiffService(fsys platformfs.FileSystem, cfg *config.Config, root string) *DiffService {
+	return &DiffService{
+		fs:      fsys,
+		store:   skill.NewStore(fsys, cfg, root),
+		targets: NewTargetRegistry(fsys, root, cfg),
+	}
+}
+
+// Diff compares the install of a skill in each target, or in opts.Target,
+// with the files the store would install there: the changes a forced update
+// would make. Results are ordered by target name.
+func (s *DiffService) Diff(opts DiffOptions) ([]DiffResult, error) {
+	if err := skill.ValidateQualifiedName(opts.Name); err != nil {
+		return nil, fmt.Errorf("invalid skill name: %w", err)
+	}
+	var sk *skill.Skill
+	var err error
+	if opts.Scope != nil {
+		sk, err = s.store.FindInScope(opts.Name, *opts.Scope)
+	} else {
+		sk, err = s.store.GetByName(opts.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	targets := s.targets.GetAll()
+	if opts.Target != "" {
+		t, err := s.targets.Lookup(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		targets = []*Target{t}
+	}
+
+	results := make([]DiffResult, 0, len(targets))
+	for _, t := range targets {
+		results = append(results, s.diffTarget(t, sk))
+	}
+	return results, nil
+}
+
+// diffTarget compares the install of sk in t with the store.
+func (s *DiffService) diffTarget(t *Target, sk *skill.Skill) DiffResult {
+	result := DiffResult{SkillName: sk.Name, Target: t.Name()}
+	path, err := t.GetInstallPath(sk.Name, sk.Scope)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	result.Path = path
+	result.Strategy, result.Installed = t.InstalledStrategy(sk.Name, sk.Scope)
+	if !result.Installed || t.linksTo(sk) {
+		return result
+	}
+
+	want, err := t.deployedFiles(sk)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	got, err := readSkillFiles(s.fs, path)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	result.Files = diffFiles(s.fs, path, sk.Path, got, want)
+	return result
+}
+
+// diffFiles compares the files of an installed copy at installedDir with
+// the files the store at storeDir would install.
+func diffFiles(fsys platformfs.FileSystem, installedDir, storeDir string, got, want skillFiles) []FileDiff {
+	all := maps.Clone(want)
+	maps.Copy(all, got)
+
+	var diffs []FileDiff
+	for _, rel := range sortedPaths(all) {
+		before, inCopy := got[rel]
+		after, inStore := want[rel]
+		if inCopy && inStore && bytes.Equal(before, after) {
+			continue
+		}
+
+		d := FileDiff{FileChange: FileChange{Path: rel, Kind: FileModified}}
+		oldName, newName := fsys.Join(installedDir, rel), fsys.Join(storeDir, rel)
+		switch {
+		case !inCopy:
+			d.Kind, oldName = FileAdded, "/dev/null"
+		case !inStore:
+			d.Kind, newName = FileRemoved, "/dev/null"
+		}
+		if diff.IsBinary(before) || diff.IsBinary(after) {
+			d.Binary = true
+		} else {
+			d.Diff = diff.Unified(oldName, newName, before, after)
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
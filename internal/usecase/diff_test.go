@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestDiffInstalledCopies(t *testing.T) {
+	mock, syncSvc := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	mock.Files["/home/test/.agents/skills/alpha/notes.md"] = []byte("one\ntwo\n")
+	mock.Files["/home/test/.agents/skills/alpha/logo.png"] = []byte("\x89PNG\x00")
+	if _, err := syncSvc.Sync(t.Context(), usecase.SyncOptions{Strategy: config.StrategyCopy}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	copyDir := "/home/test/.claude/skills/alpha"
+	mock.Files[copyDir+"/notes.md"] = []byte("one\nTWO\n")
+	mock.Files[copyDir+"/logo.png"] = []byte("\x89PNG\x01")
+	mock.Files[copyDir+"/local.md"] = []byte("mine\n")
+	delete(mock.Files, copyDir+"/SKILL.md")
+
+	svc := usecase.NewDiffService(mock, config.DefaultConfig(), "")
+	results, err := svc.Diff(usecase.DiffOptions{Name: "alpha"})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Target != "claude" || results[1].Target != "codex" {
+		t.Fatalf("Diff() = %+v, want a result per target", results)
+	}
+	if !results[1].Installed || len(results[1].Files) != 0 {
+		t.Errorf("codex = %+v, want an unchanged copy", results[1])
+	}
+
+	var changes []string
+	for _, f := range results[0].Files {
+		changes = append(changes, string(f.Kind)+" "+f.Path)
+	}
+	if got := strings.Join(changes, ", "); got != "added SKILL.md, removed local.md, modified logo.png, modified notes.md" {
+		t.Fatalf("claude changes = %s", got)
+	}
+	files := results[0].Files
+	if !strings.HasPrefix(files[0].Diff, "--- /dev/null\n+++ /home/test/.agents/skills/alpha/SKILL.md\n") {
+		t.Errorf("added file diff = %q", files[0].Diff)
+	}
+	if !files[2].Binary || files[2].Diff != "" {
+		t.Errorf("binary file = %+v, want no line diff", files[2])
+	}
+	if !strings.Contains(files[3].Diff, "-TWO\n+two\n") {
+		t.Errorf("notes.md diff = %q, want the copy's line replaced by the store's", files[3].Diff)
+	}
+
+	results, err = svc.Diff(usecase.DiffOptions{Name: "alpha", Target: "codex"})
+	if err != nil || len(results) != 1 || results[0].Target != "codex" {
+		t.Fatalf("Diff(codex) = %+v, %v", results, err)
+	}
+	if _, err := svc.Diff(usecase.DiffOptions{Name: "alpha", Target: "nope"}); err == nil {
+		t.Error("Diff() should fail for an unknown target")
+	}
+
+	// A symlink to the store never differs.
+	if _, err := syncSvc.Sync(t.Context(), usecase.SyncOptions{Strategy: config.StrategySymlink, Force: true}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	results, err = svc.Diff(usecase.DiffOptions{Name: "alpha", Target: "claude"})
+	if err != nil || results[0].Strategy != config.StrategySymlink || len(results[0].Files) != 0 {
+		t.Errorf("Diff(symlink) = %+v, %v, want no files", results, err)
+	}
+}
@@ -0,0 +1,434 @@
+package usecase
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// DoctorCheck names the check that produced a finding.
+type DoctorCheck string
+
+const (
+	DoctorCheckConfig         DoctorCheck = "config"
+	DoctorCheckAgentsDir      DoctorCheck = "agents-dir"
+	DoctorCheckBrokenSymlink  DoctorCheck = "broken-symlink"
+	DoctorCheckForeignSymlink DoctorCheck = "foreign-symlink"
+	DoctorCheckFrontmatter    DoctorCheck = "frontmatter"
+	DoctorCheckDuplicate      DoctorCheck = "duplicate"
+	DoctorCheckPermissions    DoctorCheck = "permissions"
+)
+
+// DoctorSeverity tells whether a finding breaks skillet or only deserves attention.
+type DoctorSeverity string
+
+const (
+	DoctorError   DoctorSeverity = "error"
+	DoctorWarning DoctorSeverity = "warning"
+)
+
+// DoctorFinding is a single problem found by doctor.
+type DoctorFinding struct {
+	Check    DoctorCheck
+	Severity DoctorSeverity
+	Path     string
+	Message  string
+	// Fix suggests how to resolve the problem
+	Fix string
+}
+
+// DoctorOptions contains options for diagnosing the environment.
+type DoctorOptions struct {
+	// ConfigPath is the config file in use
+	ConfigPath string
+	// ConfigErr is the error loading ConfigPath failed with (nil when it loaded)
+	ConfigErr error
+}
+
+// DoctorService diagnoses the config, stores, and targets.
+type DoctorService struct {
+	fs      platformfs.FileSystem
+	cfg     *config.Config
+	root    string
+	targets *TargetRegistry
+}
+
+// NewDoctorService creates a new doctor service.
+func NewDoctorService(fsys platformfs.FileSystem, cfg *config.Config, root string) *DoctorService {
+	return &DoctorService{
+		fs:      fsys,
+		cfg:     cfg,
+		root:    root,
+		targets: NewTargetRegistry(fsys, root, cfg),
+	}
+}
+
+// storeDir is a skill root directory of one scope.
+type storeDir struct {
+	scope  skill.Scope
+	path   string
+	vendor bool
+}
+
+// Diagnose runs every check and returns the findings ordered by check, then by path.
+func (s *DoctorService) Diagnose(opts DoctorOptions) ([]DoctorFinding, error) {
+	dirs, err := s.storeDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []DoctorFinding
+	findings = append(findings, s.checkConfig(opts)...)
+	findings = append(findings, s.checkAgentsDirs()...)
+	findings = append(findings, s.checkTargets(dirs)...)
+	findings = append(findings, s.checkSkills(dirs)...)
+	findings = append(findings, s.checkPermissions(dirs)...)
+
+	order := []DoctorCheck{
+		DoctorCheckConfig, DoctorCheckAgentsDir, DoctorCheckBrokenSymlink, DoctorCheckForeignSymlink,
+		DoctorCheckFrontmatter, DoctorCheckDuplicate, DoctorCheckPermissions,
+	}
+	slices.SortStableFunc(findings, func(a, b DoctorFinding) int {
+		return cmp.Or(
+			cmp.Compare(slices.Index(order, a.Check), slices.Index(order, b.Check)),
+			cmp.Compare(a.Path, b.Path),
+		)
+	})
+	return findings, nil
+}
+
+// storeDirs returns the configured skill root of each scope, whether or not it exists.
+func (s *DoctorService) storeDirs() ([]storeDir, error) {
+	var dirs []storeDir
+	for _, d := range []struct {
+		scope   skill.Scope
+		resolve func(platformfs.FileSystem) (string, error)
+	}{
+		{skill.ScopeSystem, s.cfg.SystemSkillsDir},
+		{skill.ScopeGlobal, s.cfg.GlobalSkillsDir},
+		{skill.ScopeOrg, s.cfg.OrgSkillsDir},
+	} {
+		path, err := d.resolve(s.fs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s skills directory: %w", d.scope, err)
+		}
+		if path != "" {
+			dirs = append(dirs, storeDir{scope: d.scope, path: path})
+		}
+	}
+	if s.root != "" {
+		dirs = append(dirs,
+			storeDir{scope: skill.ScopeProject, path: s.cfg.ProjectSkillsDir(s.fs, s.root)},
+			storeDir{scope: skill.ScopeProject, path: s.cfg.ProjectVendorDir(s.fs, s.root), vendor: true})
+	}
+	return dirs, nil
+}
+
+// checkConfig reports a config file that failed to load and settings skillet cannot use.
+func (s *DoctorService) checkConfig(opts DoctorOptions) []DoctorFinding {
+	if opts.ConfigErr != nil {
+		fix := "fix the file, or move it aside and run `skillet init`"
+		if !s.fs.Exists(opts.ConfigPath) {
+			fix = "run `skillet init` to create it"
+		}
+		return []DoctorFinding{{
+			Check: DoctorCheckConfig, Severity: DoctorError, Path: opts.ConfigPath,
+			Message: opts.ConfigErr.Error(), Fix: fix,
+		}}
+	}
+
+	var findings []DoctorFinding
+	add := func(severity DoctorSeverity, message, fix string) {
+		findings = append(findings, DoctorFinding{
+			Check: DoctorCheckConfig, Severity: severity, Path: opts.ConfigPath, Message: message, Fix: fix,
+		})
+	}
+	if !s.cfg.DefaultStrategy.Valid() {
+		add(DoctorError, fmt.Sprintf("defaultStrategy %q is not a strategy", s.cfg.DefaultStrategy), "set it to symlink, copy, or hardlink")
+	}
+	for _, scope := range slices.Sorted(maps.Keys(s.cfg.StrategyByScope)) {
+		switch scope {
+		case "global", "org", "system", "project":
+		default:
+			add(DoctorError, fmt.Sprintf("strategyByScope has unknown scope %q", scope), "use global, org, system, or project")
+			continue
+		}
+		if st := s.cfg.StrategyByScope[scope]; !st.Valid() {
+			add(DoctorError, fmt.Sprintf("strategyByScope.%s %q is not a strategy", scope, st), "set it to symlink, copy, or hardlink")
+		}
+	}
+	if _, err := skill.ParseResolutionPolicy(s.cfg.Resolution); err != nil {
+		add(DoctorError, "resolution: "+err.Error(), "remove the setting to use project-wins")
+	}
+	switch s.cfg.Collections {
+	case "", config.CollectionsNamespace, config.CollectionsFlatten:
+	default:
+		add(DoctorError, fmt.Sprintf("collections %q is not a collection layout", s.cfg.Collections), "set it to namespace or flatten")
+	}
+	switch s.cfg.SymlinkMode {
+	case "", config.SymlinkAbsolute, config.SymlinkRelative:
+	default:
+		add(DoctorError, fmt.Sprintf("symlinkMode %q is not a symlink mode", s.cfg.SymlinkMode), "set it to absolute or relative")
+	}
+	if len(s.targets.Names()) == 0 {
+		add(DoctorWarning, "no targets are enabled, so sync installs nothing", "set enabled: true on a target")
+	}
+	return findings
+}
+
+// checkAgentsDirs reports missing global store directories, a global store
+// left at the legacy location, and configured org and system stores that do
+// not exist.
+func (s *DoctorService) checkAgentsDirs() []DoctorFinding {
+	var findings []DoctorFinding
+	agentsDir, err := s.cfg.AgentsDir(s.fs)
+	if err != nil {
+		return []DoctorFinding{{Check: DoctorCheckAgentsDir, Severity: DoctorError, Message: err.Error(), Fix: "check globalPath in the config"}}
+	}
+	skillsDir := s.fs.Join(agentsDir, config.SkillsDirName)
+	switch {
+	case !s.fs.IsDir(agentsDir):
+		findings = append(findings, DoctorFinding{
+			Check: DoctorCheckAgentsDir, Severity: DoctorError, Path: agentsDir,
+			Message: "global agents directory does not exist", Fix: "run `skillet init --global`",
+		})
+	case !s.fs.IsDir(skillsDir):
+		findings = append(findings, DoctorFinding{
+			Check: DoctorCheckAgentsDir, Severity: DoctorError, Path: skillsDir,
+			Message: "skills directory does not exist", Fix: "run `skillet fsck --fix`",
+		})
+	case s.cfg.GlobalPath == "" && config.DetectGlobalPath(s.fs) == config.LegacyGlobalPath:
+		findings = append(findings, DoctorFinding{
+			Check: DoctorCheckAgentsDir, Severity: DoctorWarning, Path: agentsDir,
+			Message: "global store is at the legacy location", Fix: "run `skillet migrate-store`",
+		})
+	}
+
+	for _, d := range []struct {
+		name    string
+		resolve func(config.PathFS) (string, error)
+		key     string
+	}{
+		{"org", s.cfg.OrgAgentsDir, "orgPath"},
+		{"system", s.cfg.SystemAgentsDir, "systemPath"},
+	} {
+		dir, err := d.resolve(s.fs)
+		if err != nil || dir == "" || s.fs.IsDir(dir) {
+			continue
+		}
+		findings = append(findings, DoctorFinding{
+			Check: DoctorCheckAgentsDir, Severity: DoctorWarning, Path: dir,
+			Message: d.name + " agents directory does not exist", Fix: "create it or remove " + d.key + " from the config",
+		})
+	}
+	return findings
+}
+
+// checkTargets reports symlinks in target skill directories that are dangling
+// or resolve outside every store.
+func (s *DoctorService) checkTargets(dirs []storeDir) []DoctorFinding {
+	paths := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		paths = append(paths, d.path)
+	}
+
+	var findings []DoctorFinding
+	for _, t := range s.targets.GetAll() {
+		for _, scope := range []skill.Scope{skill.ScopeGlobal, skill.ScopeProject} {
+			dir, err := t.GetSkillsPath(scope)
+			if err != nil || !s.fs.IsDir(dir) {
+				continue
+			}
+			entries, err := s.fs.ReadDir(dir)
+			if err != nil {
+				continue
+			}
+			for _, entry := range entries {
+				path := s.fs.Join(dir, entry.Name())
+				if !s.fs.IsSymlink(path) {
+					continue
+				}
+				dest, err := s.fs.Readlink(path)
+				if err != nil {
+					continue
+				}
+				if !filepath.IsAbs(dest) {
+					dest = s.fs.Join(dir, dest)
+				}
+				switch {
+				case !s.fs.Exists(dest):
+					findings = append(findings, DoctorFinding{
+						Check: DoctorCheckBrokenSymlink, Severity: DoctorError, Path: path,
+						Message: "symlink target does not exist: " + dest,
+						Fix:     "run `skillet sync` to reinstall it, or `skillet prune` if the skill was deleted",
+					})
+				case !withinDirs(s.fs, paths, dest):
+					findings = append(findings, DoctorFinding{
+						Check: DoctorCheckForeignSymlink, Severity: DoctorWarning, Path: path,
+						Message: "symlink points outside the skill store: " + dest,
+						Fix:     "move the skill into the store with `skillet migrate`, or remove the link with `skillet prune`",
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// checkSkills reports SKILL.md files with invalid frontmatter and skill names
+// defined in more than one scope. Vendored copies never count as duplicates.
+func (s *DoctorService) checkSkills(dirs []storeDir) []DoctorFinding {
+	var findings []DoctorFinding
+	scopesByName := make(map[string][]string)
+	for _, d := range dirs {
+		for _, skillDir := range s.skillDirs(d.path) {
+			findings = append(findings, s.checkFrontmatter(skillDir)...)
+			name := s.fs.Base(skillDir)
+			if !d.vendor && !slices.Contains(scopesByName[name], d.scope.String()) {
+				scopesByName[name] = append(scopesByName[name], d.scope.String())
+			}
+		}
+	}
+
+	severity := DoctorWarning
+	if s.cfg.ConflictResolution() == string(skill.ResolveErrorOnConflict) {
+		severity = DoctorError
+	}
+	for name, scopes := range scopesByName {
+		if len(scopes) < 2 {
+			continue
+		}
+		findings = append(findings, DoctorFinding{
+			Check: DoctorCheckDuplicate, Severity: severity, Path: name,
+			Message: "skill is defined in " + strings.Join(scopes, ", ") + " scopes",
+			Fix:     fmt.Sprintf("run `skillet which %s` to see which copy wins, then remove or rename the others", name),
+		})
+	}
+	return findings
+}
+
+// skillDirs returns the directories under dir and its optional directory that hold a SKILL.md.
+func (s *DoctorService) skillDirs(dir string) []string {
+	var out []string
+	for _, parent := range []string{dir, s.fs.Join(dir, config.OptionalDirName)} {
+		entries, err := s.fs.ReadDir(parent)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			path := s.fs.Join(parent, name)
+			if strings.HasPrefix(name, ".") || (parent == dir && name == config.OptionalDirName) || !s.fs.IsDir(path) {
+				continue
+			}
+			if s.fs.Exists(s.fs.Join(path, "SKILL.md")) {
+				out = append(out, path)
+			}
+		}
+	}
+	return out
+}
+
+// checkFrontmatter reports a SKILL.md that cannot be read or parsed, or does
+// not match the frontmatter schema. Schema mismatches are errors only when
+// the config makes the schema strict.
+func (s *DoctorService) checkFrontmatter(skillDir string) []DoctorFinding {
+	path := s.fs.Join(skillDir, "SKILL.md")
+	content, err := s.fs.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			return []DoctorFinding{{
+				Check: DoctorCheckPermissions, Severity: DoctorError, Path: path,
+				Message: "SKILL.md is not readable", Fix: "chmod u+r " + path,
+			}}
+		}
+		return []DoctorFinding{{Check: DoctorCheckFrontmatter, Severity: DoctorError, Path: path, Message: err.Error()}}
+	}
+
+	frontmatter, _ := skill.SplitFrontmatter(string(content))
+	if frontmatter == "" {
+		return []DoctorFinding{{
+			Check: DoctorCheckFrontmatter, Severity: DoctorError, Path: path,
+			Message: "SKILL.md has no frontmatter", Fix: "start the file with a --- block holding name and description",
+		}}
+	}
+	var raw map[string]any
+	if err := yaml.Unmarshal([]byte(frontmatter), &raw); err != nil {
+		return []DoctorFinding{{
+			Check: DoctorCheckFrontmatter, Severity: DoctorError, Path: path,
+			Message: "frontmatter is not valid YAML: " + err.Error(), Fix: "fix the YAML between the --- lines",
+		}}
+	}
+	if err := skill.ValidateFrontmatter(frontmatter); err != nil {
+		severity := DoctorWarning
+		if s.cfg.StrictFrontmatter() {
+			severity = DoctorError
+		}
+		return []DoctorFinding{{
+			Check: DoctorCheckFrontmatter, Severity: severity, Path: path,
+			Message: err.Error(), Fix: "see `skillet schema print` for the known fields",
+		}}
+	}
+	return nil
+}
+
+// checkPermissions reports store and target skill directories skillet cannot
+// write to. The system store is read-only for skillet and is not checked;
+// instead it and the org store are reported when others can write to them.
+func (s *DoctorService) checkPermissions(dirs []storeDir) []DoctorFinding {
+	var paths []string
+	for _, d := range dirs {
+		if d.scope != skill.ScopeSystem && !d.vendor {
+			paths = append(paths, d.path)
+		}
+	}
+	for _, t := range s.targets.GetAll() {
+		for _, scope := range []skill.Scope{skill.ScopeGlobal, skill.ScopeProject} {
+			if dir, err := t.GetSkillsPath(scope); err == nil && !slices.Contains(paths, dir) {
+				paths = append(paths, dir)
+			}
+		}
+	}
+
+	var findings []DoctorFinding
+	for _, resolve := range []func(config.PathFS) (string, error){s.cfg.SystemAgentsDir, s.cfg.OrgAgentsDir} {
+		agentsDir, err := resolve(s.fs)
+		if err != nil || agentsDir == "" {
+			continue
+		}
+		for _, p := range sharedStorePermissions(s.fs, agentsDir) {
+			findings = append(findings, DoctorFinding{
+				Check: DoctorCheckPermissions, Severity: DoctorWarning, Path: p.path,
+				Message: p.message, Fix: "chmod go-w " + p.path,
+			})
+		}
+	}
+	for _, dir := range paths {
+		if !s.fs.IsDir(dir) {
+			continue
+		}
+		probe, err := s.fs.MkdirTemp(dir, ".skillet-doctor-")
+		if err == nil {
+			_ = s.fs.RemoveAll(probe)
+			continue
+		}
+		if errors.Is(err, fs.ErrPermission) {
+			findings = append(findings, DoctorFinding{
+				Check: DoctorCheckPermissions, Severity: DoctorError, Path: dir,
+				Message: "directory is not writable", Fix: "chmod u+w " + dir,
+			})
+		}
+	}
+	return findings
+}
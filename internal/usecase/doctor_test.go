@@ -0,0 +1,131 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestDoctorHealthyEnvironment(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	mock.Dirs["/home/test/.agents/skills/review"] = true
+	mock.Files["/home/test/.agents/skills/review/SKILL.md"] = []byte("---\nname: review\ndescription: Review code\n---\n")
+	if _, err := svc.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	// A store configured at ~/.agents is not reported as a legacy one.
+	cfg := config.DefaultConfig()
+	cfg.GlobalPath = config.LegacyGlobalPath
+
+	findings, err := usecase.NewDoctorService(mock, cfg, "").Diagnose(usecase.DoctorOptions{})
+	if err != nil {
+		t.Fatalf("Diagnose() error = %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("Diagnose() = %+v, want no findings", findings)
+	}
+}
+
+func TestDoctorFindings(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	mock.Dirs["/home/test/.agents/skills/shared"] = true
+	mock.Files["/home/test/.agents/skills/shared/SKILL.md"] = []byte("---\nname: shared\ndescription: Global copy\n---\n")
+	mock.Dirs["/home/test/.agents/skills/plain"] = true
+	mock.Files["/home/test/.agents/skills/plain/SKILL.md"] = []byte("# No frontmatter\n")
+	mock.Dirs["/home/test/.agents/skills/optional/tagged"] = true
+	mock.Files["/home/test/.agents/skills/optional/tagged/SKILL.md"] = []byte("---\nname: tagged\ndescription: x\nhomepage: x\n---\n")
+	mock.Dirs["/project/.agents/skills"] = true
+	mock.Dirs["/project/.agents/skills/shared"] = true
+	mock.Files["/project/.agents/skills/shared/SKILL.md"] = []byte("---\nname: shared\ndescription: Project copy\n---\n")
+	mock.Symlinks["/home/test/.claude/skills/gone"] = "/home/test/.agents/skills/gone"
+	mock.Dirs["/home/test/elsewhere/ext"] = true
+	mock.Symlinks["/home/test/.claude/skills/ext"] = "/home/test/elsewhere/ext"
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = "junction"
+
+	findings, err := usecase.NewDoctorService(mock, cfg, "/project").Diagnose(usecase.DoctorOptions{ConfigPath: "/home/test/.config/skillet/config.yaml"})
+	if err != nil {
+		t.Fatalf("Diagnose() error = %v", err)
+	}
+
+	type key struct {
+		check    usecase.DoctorCheck
+		severity usecase.DoctorSeverity
+		path     string
+	}
+	want := []key{
+		{usecase.DoctorCheckConfig, usecase.DoctorError, "/home/test/.config/skillet/config.yaml"},
+		{usecase.DoctorCheckAgentsDir, usecase.DoctorWarning, "/home/test/.agents"},
+		{usecase.DoctorCheckBrokenSymlink, usecase.DoctorError, "/home/test/.claude/skills/gone"},
+		{usecase.DoctorCheckForeignSymlink, usecase.DoctorWarning, "/home/test/.claude/skills/ext"},
+		{usecase.DoctorCheckFrontmatter, usecase.DoctorWarning, "/home/test/.agents/skills/optional/tagged/SKILL.md"},
+		{usecase.DoctorCheckFrontmatter, usecase.DoctorError, "/home/test/.agents/skills/plain/SKILL.md"},
+		{usecase.DoctorCheckDuplicate, usecase.DoctorWarning, "shared"},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("Diagnose() = %+v, want %d findings", findings, len(want))
+	}
+	for i, f := range findings {
+		if got := (key{f.Check, f.Severity, f.Path}); got != want[i] {
+			t.Errorf("finding %d = %+v, want %+v", i, got, want[i])
+		}
+		if f.Fix == "" {
+			t.Errorf("finding %d has no fix: %+v", i, f)
+		}
+	}
+}
+
+func TestDoctorConfigError(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	path := "/home/test/.config/skillet/config.yaml"
+	findings, err := usecase.NewDoctorService(mock, config.DefaultConfig(), "").Diagnose(usecase.DoctorOptions{
+		ConfigPath: path,
+		ConfigErr:  errors.New("config file not found: " + path),
+	})
+	if err != nil {
+		t.Fatalf("Diagnose() error = %v", err)
+	}
+	if len(findings) != 2 || findings[0].Check != usecase.DoctorCheckConfig || findings[1].Check != usecase.DoctorCheckAgentsDir {
+		t.Fatalf("Diagnose() = %+v, want config and agents-dir errors", findings)
+	}
+	if findings[0].Fix != "run `skillet init` to create it" {
+		t.Errorf("config fix = %q", findings[0].Fix)
+	}
+}
+
+func TestDoctorSharedStorePermissions(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	for _, dir := range []string{"/opt/skills", "/opt/skills/skills", "/srv/org", "/srv/org/skills"} {
+		mock.Dirs[dir] = true
+		mock.Modes[dir] = 0o755
+	}
+	mock.Modes["/opt/skills/skills"] = 0o777
+	mock.Modes["/srv/org"] = 0o775
+
+	cfg := config.DefaultConfig()
+	cfg.GlobalPath = config.LegacyGlobalPath
+	cfg.SystemPath = "/opt/skills"
+	cfg.OrgPath = "/srv/org"
+	findings, err := usecase.NewDoctorService(mock, cfg, "").Diagnose(usecase.DoctorOptions{})
+	if err != nil {
+		t.Fatalf("Diagnose() error = %v", err)
+	}
+
+	want := []string{"/opt/skills/skills", "/srv/org"}
+	if len(findings) != len(want) {
+		t.Fatalf("Diagnose() = %+v, want permission warnings for %v", findings, want)
+	}
+	for i, f := range findings {
+		if f.Check != usecase.DoctorCheckPermissions || f.Severity != usecase.DoctorWarning || f.Path != want[i] {
+			t.Errorf("finding %d = %+v, want a permissions warning for %s", i, f, want[i])
+		}
+		if f.Fix != "chmod go-w "+want[i] {
+			t.Errorf("finding %d fix = %q", i, f.Fix)
+		}
+	}
+}
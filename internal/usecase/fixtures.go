@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+// FixtureOptions controls the shape of a generated fixture tree.
+type FixtureOptions struct {
+	// Dir is the output directory (a new temp dir when empty)
+	Dir string
+	// Skills is the number of global default skills to create
+	Skills int
+	// OptionalSkills is the number of global optional skills to create
+	OptionalSkills int
+	// ProjectSkills is the number of project skills to create
+	ProjectSkills int
+	// Nested adds a skill whose SKILL.md lives in a subdirectory
+	Nested bool
+	// BrokenFrontmatter adds a skill with unparsable frontmatter
+	BrokenFrontmatter bool
+	// DanglingLinks adds target symlinks pointing at missing paths
+	DanglingLinks bool
+	// Strategy is written to the generated config
+	Strategy config.Strategy
+}
+
+// FixtureResult describes a generated fixture tree.
+type FixtureResult struct {
+	Root        string
+	HomeDir     string
+	ConfigPath  string
+	AgentsDir   string
+	ProjectRoot string
+}
+
+// FixtureService generates store and target trees for manual and e2e testing.
+type FixtureService struct {
+	fs platformfs.FileSystem
+}
+
+// NewFixtureService creates a new fixture service.
+func NewFixtureService(fsys platformfs.FileSystem) *FixtureService {
+	return &FixtureService{fs: fsys}
+}
+
+// Generate writes a fixture tree and a config file that points at it.
+func (s *FixtureService) Generate(opts FixtureOptions) (*FixtureResult, error) {
+	root := opts.Dir
+	if root == "" {
+		var err error
+		root, err = s.fs.MkdirTemp("", "skillet-fixtures-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp directory: %w", err)
+		}
+	}
+
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = config.StrategySymlink
+	}
+
+	result := &FixtureResult{
+		Root:        root,
+		HomeDir:     s.fs.Join(root, "home"),
+		ConfigPath:  s.fs.Join(root, "config.yaml"),
+		AgentsDir:   s.fs.Join(root, "home", config.AgentsDirName),
+		ProjectRoot: s.fs.Join(root, "project"),
+	}
+
+	skillsDir := s.fs.Join(result.AgentsDir, config.SkillsDirName)
+	optionalDir := s.fs.Join(skillsDir, config.OptionalDirName)
+	projectSkillsDir := config.ProjectSkillsDir(result.ProjectRoot, s.fs, "")
+
+	for _, dir := range []string{
+		result.HomeDir,
+		optionalDir,
+		s.fs.Join(projectSkillsDir, config.OptionalDirName),
+		s.fs.Join(result.HomeDir, ".claude", "skills"),
+		s.fs.Join(result.HomeDir, ".codex", "skills"),
+	} {
+		if err := s.fs.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	for i := 1; i <= opts.Skills; i++ {
+		if err := s.writeSkill(skillsDir, fmt.Sprintf("skill-%03d", i), ""); err != nil {
+			return nil, err
+		}
+	}
+	for i := 1; i <= opts.OptionalSkills; i++ {
+		if err := s.writeSkill(optionalDir, fmt.Sprintf("optional-skill-%03d", i), ""); err != nil {
+			return nil, err
+		}
+	}
+	for i := 1; i <= opts.ProjectSkills; i++ {
+		if err := s.writeSkill(projectSkillsDir, fmt.Sprintf("project-skill-%03d", i), ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Nested {
+		if err := s.writeSkill(skillsDir, "nested-skill", "inner"); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.BrokenFrontmatter {
+		dir := s.fs.Join(skillsDir, "broken-frontmatter")
+		content := "---\nname: broken-frontmatter\ndescription: [unterminated\n---\n\n# Broken\n"
+		if err := s.writeFile(s.fs.Join(dir, "SKILL.md"), content); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.DanglingLinks {
+		for _, target := range []string{".claude", ".codex"} {
+			link := s.fs.Join(result.HomeDir, target, "skills", "dangling-link")
+			if err := s.fs.Symlink(s.fs.Join(root, "missing-skill"), link); err != nil {
+				return nil, fmt.Errorf("failed to create dangling link: %w", err)
+			}
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.GlobalPath = result.AgentsDir
+	cfg.DefaultStrategy = strategy
+	for name, target := range cfg.Targets {
+		target.GlobalPath = s.fs.Join(result.HomeDir, "."+name)
+		cfg.Targets[name] = target
+	}
+	if err := config.NewStore(s.fs).Save(cfg, result.ConfigPath); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// writeSkill creates a skill directory; subdir places SKILL.md one level deeper.
+func (s *FixtureService) writeSkill(parent, name, subdir string) error {
+	dir := s.fs.Join(parent, name)
+	if subdir != "" {
+		dir = s.fs.Join(dir, subdir)
+	}
+	content := fmt.Sprintf("---\nname: %s\ndescription: Fixture skill %s\n---\n\n# %s\n", name, name, name)
+	return s.writeFile(s.fs.Join(dir, "SKILL.md"), content)
+}
+
+func (s *FixtureService) writeFile(path, content string) error {
+	if err := s.fs.MkdirAll(s.fs.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := s.fs.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestFixtureGenerateLoadsAsStore(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+
+	result, err := usecase.NewFixtureService(mock).Generate(usecase.FixtureOptions{
+		Dir:           "/fixtures",
+		Skills:        2,
+		ProjectSkills: 1,
+		Nested:        true,
+		DanglingLinks: true,
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	cfg, err := config.NewStore(mock).Load(result.ConfigPath)
+	if err != nil {
+		t.Fatalf("Load() generated config error = %v", err)
+	}
+
+	skills, err := skill.NewStore(mock, cfg, result.ProjectRoot).GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(skills) != 4 {
+		t.Errorf("GetAll() returned %d skills, want 4", len(skills))
+	}
+
+	if !mock.IsSymlink("/fixtures/home/.claude/skills/dangling-link") {
+		t.Error("Generate() should create dangling link in claude target")
+	}
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// FsckIssueKind represents the type of problem found in a store.
+type FsckIssueKind string
+
+const (
+	FsckIssueMissingDir     FsckIssueKind = "missing-dir"
+	FsckIssueMisplacedSkill FsckIssueKind = "misplaced-skill"
+	FsckIssueStrayEntry     FsckIssueKind = "stray-entry"
+	FsckIssueOptionalMisuse FsckIssueKind = "optional-misuse"
+	FsckIssueBrokenSymlink  FsckIssueKind = "broken-symlink"
+	FsckIssueInvalidName    FsckIssueKind = "invalid-name"
+	FsckIssuePermissions    FsckIssueKind = "permissions"
+)
+
+// FsckIssue represents a single problem found in a store directory layout.
+type FsckIssue struct {
+	Scope   skill.Scope
+	Kind    FsckIssueKind
+	Path    string
+	Message string
+	Fixable bool
+	Fixed   bool
+	Error   error
+}
+
+// FsckOptions contains options for checking the store layout.
+type FsckOptions struct {
+	// Fix repairs issues that can be fixed safely
+	Fix bool
+	// Scope limits the check to a specific scope (nil for all)
+	Scope *skill.Scope
+}
+
+// FsckService verifies and repairs the store directory layout.
+type FsckService struct {
+	fs          platformfs.FileSystem
+	cfg         *config.Config
+	projectRoot string
+}
+
+// NewFsckService creates a new fsck service.
+func NewFsckService(fsys platformfs.FileSystem, cfg *config.Config, root string) *FsckService {
+	return &FsckService{
+		fs:          fsys,
+		cfg:         cfg,
+		projectRoot: root,
+	}
+}
+
+// Check inspects each store and returns the issues found.
+func (s *FsckService) Check(opts FsckOptions) ([]FsckIssue, error) {
+	stores, err := s.storeDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []FsckIssue
+	for _, scope := range []skill.Scope{skill.ScopeSystem, skill.ScopeGlobal, skill.ScopeOrg, skill.ScopeProject} {
+		agentsDir, ok := stores[scope]
+		if !ok || (opts.Scope != nil && *opts.Scope != scope) {
+			continue
+		}
+		storeIssues := s.checkStore(scope, agentsDir)
+		if scope == skill.ScopeSystem {
+			// The system store is read-only for skillet; report but never repair.
+			for i := range storeIssues {
+				storeIssues[i].Fixable = false
+			}
+		}
+		issues = append(issues, storeIssues...)
+	}
+
+	if opts.Fix {
+		for i := range issues {
+			if issues[i].Fixable {
+				s.fix(&issues[i])
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// storeDirs returns the agents directory for each configured scope.
+func (s *FsckService) storeDirs() (map[skill.Scope]string, error) {
+	stores := make(map[skill.Scope]string)
+
+	systemDir, err := s.cfg.SystemAgentsDir(s.fs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve system agents directory: %w", err)
+	}
+	if systemDir != "" {
+		stores[skill.ScopeSystem] = systemDir
+	}
+
+	globalDir, err := s.cfg.AgentsDir(s.fs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve global agents directory: %w", err)
+	}
+	stores[skill.ScopeGlobal] = globalDir
+
+	orgDir, err := s.cfg.OrgAgentsDir(s.fs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve org agents directory: %w", err)
+	}
+	if orgDir != "" {
+		stores[skill.ScopeOrg] = orgDir
+	}
+
+	if s.projectRoot != "" {
+		stores[skill.ScopeProject] = config.ProjectAgentsDir(s.projectRoot, s.fs)
+	}
+
+	return stores, nil
+}
+
+// permissionProblem is a directory of a shared store that others can write to.
+type permissionProblem struct {
+	path    string
+	message string
+}
+
+// sharedStorePermissions reports the directories of the shared store at
+// agentsDir that group or other users can write to, letting them change the
+// skills of everyone using the store.
+func sharedStorePermissions(fsys platformfs.FileSystem, agentsDir string) []permissionProblem {
+	var problems []permissionProblem
+	for _, dir := range []string{agentsDir, fsys.Join(agentsDir, config.SkillsDirName)} {
+		if info, err := fsys.Stat(dir); err == nil && info.Mode().Perm()&0o022 != 0 {
+			problems = append(problems, permissionProblem{dir, fmt.Sprintf("shared store is group- or world-writable (%s)", info.Mode().Perm())})
+		}
+	}
+	return problems
+}
+
+// checkStore inspects a single agents directory.
+func (s *FsckService) checkStore(scope skill.Scope, agentsDir string) []FsckIssue {
+	if !s.fs.IsDir(agentsDir) {
+		return nil
+	}
+
+	var issues []FsckIssue
+	add := func(kind FsckIssueKind, path, message string, fixable bool) {
+		issues = append(issues, FsckIssue{Scope: scope, Kind: kind, Path: path, Message: message, Fixable: fixable})
+	}
+
+	skillsDir := s.fs.Join(agentsDir, config.SkillsDirName)
+	optionalDir := s.fs.Join(skillsDir, config.OptionalDirName)
+
+	// Skills placed directly under the agents directory are never loaded.
+	if entries, err := s.fs.ReadDir(agentsDir); err == nil {
+		for _, entry := range entries {
+			name := entry.Name()
+			if name == config.SkillsDirName || strings.HasPrefix(name, ".") || !entry.IsDir() {
+				continue
+			}
+			path := s.fs.Join(agentsDir, name)
+			if s.fs.Exists(s.fs.Join(path, "SKILL.md")) {
+				fixable := skill.ValidateName(name) == nil && !s.fs.Exists(s.fs.Join(skillsDir, name))
+				add(FsckIssueMisplacedSkill, path, "skill is outside the skills directory", fixable)
+			}
+		}
+	}
+
+	if scope == skill.ScopeSystem || scope == skill.ScopeOrg {
+		for _, p := range sharedStorePermissions(s.fs, agentsDir) {
+			add(FsckIssuePermissions, p.path, p.message, false)
+		}
+	}
+
+	if !s.fs.IsDir(skillsDir) {
+		add(FsckIssueMissingDir, skillsDir, "skills directory is missing", true)
+		return sortFsckIssues(issues)
+	}
+
+	issues = append(issues, s.checkSkillsDir(scope, skillsDir, true)...)
+
+	switch {
+	case !s.fs.Exists(optionalDir):
+		add(FsckIssueMissingDir, optionalDir, "optional directory is missing", true)
+	case !s.fs.IsDir(optionalDir):
+		add(FsckIssueOptionalMisuse, optionalDir, "optional is not a directory", false)
+	default:
+		if s.fs.Exists(s.fs.Join(optionalDir, "SKILL.md")) {
+			add(FsckIssueOptionalMisuse, optionalDir, "optional directory contains SKILL.md and is not a skill", false)
+		}
+		if s.fs.Exists(s.fs.Join(optionalDir, config.OptionalDirName)) {
+			add(FsckIssueOptionalMisuse, s.fs.Join(optionalDir, config.OptionalDirName), "nested optional directory is not supported", false)
+		}
+		issues = append(issues, s.checkSkillsDir(scope, optionalDir, false)...)
+	}
+
+	return sortFsckIssues(issues)
+}
+
+// sortFsckIssues orders issues by path for stable output.
+func sortFsckIssues(issues []FsckIssue) []FsckIssue {
+	slices.SortStableFunc(issues, func(a, b FsckIssue) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+	return issues
+}
+
+// checkSkillsDir inspects the entries of a skills or optional directory.
+func (s *FsckService) checkSkillsDir(scope skill.Scope, dir string, isRoot bool) []FsckIssue {
+	entries, err := s.fs.ReadDir(dir)
+	if err != nil {
+		return []FsckIssue{{Scope: scope, Kind: FsckIssueStrayEntry, Path: dir, Message: "failed to read directory", Error: err}}
+	}
+
+	var issues []FsckIssue
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || (isRoot && name == config.OptionalDirName) {
+			continue
+		}
+		path := s.fs.Join(dir, name)
+
+		if s.fs.IsSymlink(path) {
+			if _, err := s.fs.Stat(path); err != nil {
+				issues = append(issues, FsckIssue{Scope: scope, Kind: FsckIssueBrokenSymlink, Path: path, Message: "symlink target does not exist", Fixable: true})
+				continue
+			}
+		} else if !entry.IsDir() {
+			issues = append(issues, FsckIssue{Scope: scope, Kind: FsckIssueStrayEntry, Path: path, Message: "file is not a skill directory"})
+			continue
+		}
+
+		if err := skill.ValidateName(name); err != nil {
+			issues = append(issues, FsckIssue{Scope: scope, Kind: FsckIssueInvalidName, Path: path, Message: err.Error()})
+			continue
+		}
+
+		if !isValidSkillDir(s.fs, path) {
+			issues = append(issues, FsckIssue{Scope: scope, Kind: FsckIssueStrayEntry, Path: path, Message: "directory does not contain SKILL.md"})
+		}
+	}
+
+	return issues
+}
+
+// fix repairs a single fixable issue in place.
+func (s *FsckService) fix(issue *FsckIssue) {
+	var err error
+	switch issue.Kind {
+	case FsckIssueMissingDir:
+		err = s.fs.MkdirAll(issue.Path, 0o755)
+	case FsckIssueBrokenSymlink:
+		err = s.fs.Remove(issue.Path)
+	case FsckIssueMisplacedSkill:
+		skillsDir := s.fs.Join(s.fs.Dir(issue.Path), config.SkillsDirName)
+		if err = s.fs.MkdirAll(skillsDir, 0o755); err == nil {
+			err = s.fs.Rename(issue.Path, s.fs.Join(skillsDir, s.fs.Base(issue.Path)))
+		}
+	default:
+		return
+	}
+
+	if err != nil {
+		issue.Error = fmt.Errorf("failed to fix %s: %w", issue.Kind, err)
+		return
+	}
+	issue.Fixed = true
+}
@@ -0,0 +1,78 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestFsckReportsLayoutIssues(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+
+	mock.Dirs["/home/test/.agents"] = true
+	mock.Dirs["/home/test/.agents/skills"] = true
+	mock.Dirs["/home/test/.agents/skills/optional"] = true
+	addGlobalSkill(mock, "good-skill")
+	mock.Dirs["/home/test/.agents/misplaced"] = true
+	mock.Files["/home/test/.agents/misplaced/SKILL.md"] = []byte("---\nname: misplaced\n---\n")
+	mock.Files["/home/test/.agents/skills/notes.txt"] = []byte("stray")
+	mock.Symlinks["/home/test/.agents/skills/dangling"] = "/nowhere"
+	mock.Dirs["/home/test/.agents/skills/bad name"] = true
+	mock.Files["/home/test/.agents/skills/bad name/SKILL.md"] = []byte("---\nname: bad\n---\n")
+
+	svc := usecase.NewFsckService(mock, config.DefaultConfig(), "")
+	issues, err := svc.Check(usecase.FsckOptions{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	got := make(map[usecase.FsckIssueKind]string)
+	for _, issue := range issues {
+		got[issue.Kind] = issue.Path
+	}
+
+	want := map[usecase.FsckIssueKind]string{
+		usecase.FsckIssueMisplacedSkill: "/home/test/.agents/misplaced",
+		usecase.FsckIssueStrayEntry:     "/home/test/.agents/skills/notes.txt",
+		usecase.FsckIssueBrokenSymlink:  "/home/test/.agents/skills/dangling",
+		usecase.FsckIssueInvalidName:    "/home/test/.agents/skills/bad name",
+	}
+	for kind, path := range want {
+		if got[kind] != path {
+			t.Errorf("Check() %s issue path = %q, want %q", kind, got[kind], path)
+		}
+	}
+	if len(issues) != len(want) {
+		t.Errorf("Check() returned %d issues, want %d: %+v", len(issues), len(want), issues)
+	}
+}
+
+func TestFsckFixRepairsSafeIssues(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+
+	mock.Dirs["/home/test/.agents"] = true
+	mock.Dirs["/home/test/.agents/skills"] = true
+	mock.Symlinks["/home/test/.agents/skills/dangling"] = "/nowhere"
+
+	svc := usecase.NewFsckService(mock, config.DefaultConfig(), "")
+	issues, err := svc.Check(usecase.FsckOptions{Fix: true})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	for _, issue := range issues {
+		if !issue.Fixed {
+			t.Errorf("Check() issue %s at %s was not fixed", issue.Kind, issue.Path)
+		}
+	}
+	if mock.IsSymlink("/home/test/.agents/skills/dangling") {
+		t.Error("broken symlink should be removed")
+	}
+	if !mock.IsDir("/home/test/.agents/skills/optional") {
+		t.Error("optional directory should be created")
+	}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// Hook events, as given to hook commands in SKILLET_HOOK.
+const (
+	hookPreSync     = "preSync"
+	hookPostSync    = "postSync"
+	hookPostInstall = "postInstall"
+	hookPostRemove  = "postRemove"
+)
+
+// runHooks runs the commands configured for event with env added to their
+// environment, stopping at the first that fails.
+func (s *SyncService) runHooks(ctx context.Context, event string, commands []string, env []string) error {
+	if len(commands) == 0 {
+		return nil
+	}
+	env = append([]string{"SKILLET_HOOK=" + event}, env...)
+	if s.root != "" {
+		env = append(env, "SKILLET_PROJECT_ROOT="+s.root)
+	}
+	for _, command := range commands {
+		slog.Debug("running hook", "event", event, "command", command, "dir", s.root)
+		if err := s.hooks.Run(ctx, command, s.root, env); err != nil {
+			return fmt.Errorf("%s hook failed: %w", event, err)
+		}
+	}
+	return nil
+}
+
+// syncHookEnv describes a sync of skills into targets to preSync and postSync hooks.
+func syncHookEnv(skills []*skill.Skill, targets []*Target) []string {
+	names := make([]string, 0, len(skills))
+	dirs := make([]string, 0, len(skills))
+	for _, sk := range skills {
+		names = append(names, sk.Name)
+		dirs = append(dirs, sk.Path)
+	}
+	return []string{
+		"SKILLET_ACTION=sync",
+		"SKILLET_SKILLS=" + strings.Join(names, " "),
+		"SKILLET_SKILL_DIRS=" + strings.Join(dirs, string(os.PathListSeparator)),
+		"SKILLET_TARGETS=" + strings.Join(targetNames(targets), " "),
+	}
+}
+
+// skillHookEnv describes an install or uninstall of a skill in t to
+// postInstall and postRemove hooks. dir is the skill's store directory, or
+// empty for an uninstall.
+func skillHookEnv(t *Target, action SyncAction, name string, scope skill.Scope, dir string) []string {
+	env := []string{
+		"SKILLET_ACTION=" + string(action),
+		"SKILLET_SKILL=" + name,
+		"SKILLET_SCOPE=" + scope.String(),
+		"SKILLET_TARGET=" + t.Name(),
+	}
+	if path, err := t.GetInstallPath(name, scope); err == nil {
+		env = append(env, "SKILLET_PATH="+path)
+	}
+	if dir != "" {
+		env = append(env, "SKILLET_SKILL_DIR="+dir)
+	}
+	return env
+}
+
+// changedCount counts the results that installed, updated, or uninstalled a skill.
+func changedCount(results []SyncResult) int {
+	var n int
+	for _, r := range results {
+		switch r.Action {
+		case SyncActionInstall, SyncActionUpdate, SyncActionUninstall:
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+// rollbackDirPattern names the directories an atomic sync moves replaced
+// installs into, beside each target's skills directory.
+const rollbackDirPattern = ".skillet-rollback-*"
+
+// ErrSyncRolledBack is returned by an atomic sync that undid its changes
+// because a skill failed or the sync was canceled.
+var ErrSyncRolledBack = errors.New("sync rolled back")
+
+// syncJournal records the changes an atomic sync makes to targets, so they
+// can be undone when a later skill fails. Installs it replaces or removes are
+// moved into a rollback directory beside the skills directory instead of
+// being deleted, and files it rewrites, such as sync logs and manifests, are
+// kept in memory. A nil *syncJournal records nothing.
+type syncJournal struct {
+	fs      platformfs.FileSystem
+	changes []journalChange
+	changed map[string]bool
+	// backups maps a target root to its rollback directory.
+	backups map[string]string
+}
+
+// journalChange is a path an atomic sync changed and how to restore it.
+type journalChange struct {
+	path string
+	// backup is where the install at path was moved, or empty when there
+	// was none.
+	backup string
+	// file is set for a rewritten file, whose previous content is data, or
+	// which did not exist when existed is false.
+	file    bool
+	data    []byte
+	existed bool
+}
+
+func newSyncJournal(fsys platformfs.FileSystem) *syncJournal {
+	return &syncJournal{fs: fsys, changed: make(map[string]bool), backups: make(map[string]string)}
+}
+
+// moveAside records that the install at path is about to change, moving it
+// into the rollback directory if there is one. Only the first change to a
+// path is recorded, since rolling back restores what was there before the
+// sync.
+func (j *syncJournal) moveAside(path string) error {
+	if j == nil || j.changed[path] {
+		return nil
+	}
+	change := journalChange{path: path}
+	if _, err := j.fs.Lstat(path); err == nil {
+		root := j.fs.Dir(j.fs.Dir(path))
+		dir, ok := j.backups[root]
+		if !ok {
+			if dir, err = j.fs.MkdirTemp(root, rollbackDirPattern); err != nil {
+				return fmt.Errorf("failed to create rollback directory: %w", err)
+			}
+			j.backups[root] = dir
+		}
+		change.backup = j.fs.Join(dir, strconv.Itoa(len(j.changes)))
+		if err := j.fs.Rename(path, change.backup); err != nil {
+			return fmt.Errorf("failed to move %s aside: %w", path, err)
+		}
+	}
+	j.record(change)
+	return nil
+}
+
+// keepFile records the content of the file at path before it is rewritten.
+func (j *syncJournal) keepFile(path string) error {
+	if j == nil || j.changed[path] {
+		return nil
+	}
+	change := journalChange{path: path, file: true}
+	if j.fs.Exists(path) {
+		data, err := j.fs.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		change.data, change.existed = data, true
+	}
+	j.record(change)
+	return nil
+}
+
+func (j *syncJournal) record(change journalChange) {
+	j.changes = append(j.changes, change)
+	j.changed[change.path] = true
+}
+
+// rollback restores every recorded path, latest change first, and removes
+// the rollback directories. When a path cannot be restored, the rollback
+// directories are kept so nothing moved aside is lost.
+func (j *syncJournal) rollback() error {
+	var errs []error
+	for _, change := range slices.Backward(j.changes) {
+		if err := j.restore(change); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s: %w", change.path, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	slog.Debug("rolled back sync", "changes", len(j.changes))
+	return j.commit()
+}
+
+func (j *syncJournal) restore(change journalChange) error {
+	switch {
+	case change.file && change.existed:
+		return j.fs.WriteFile(change.path, change.data, 0o644)
+	case change.file:
+		if !j.fs.Exists(change.path) {
+			return nil
+		}
+		return j.fs.Remove(change.path)
+	}
+	if err := j.fs.RemoveAll(change.path); err != nil {
+		return err
+	}
+	if change.backup == "" {
+		return nil
+	}
+	return j.fs.Rename(change.backup, change.path)
+}
+
+// commit discards the installs moved aside, once the sync succeeded.
+func (j *syncJournal) commit() error {
+	if j == nil {
+		return nil
+	}
+	var errs []error
+	for _, dir := range j.backups {
+		if err := j.fs.RemoveAll(dir); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove rollback directory: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// LintResult represents lint findings for a single skill.
+type LintResult struct {
+	SkillName string
+	Scope     skill.Scope
+	Path      string
+	Issues    []skill.LintIssue
+	Error     error
+}
+
+// LintOptions contains options for linting skills.
+type LintOptions struct {
+	// Names limits linting to these skills (empty for all)
+	Names []string
+	// Scope limits linting to a specific scope (nil for all)
+	Scope *skill.Scope
+}
+
+// LintService checks skill contents for problems.
+type LintService struct {
+	store *skill.Store
+}
+
+// NewLintService creates a new lint service.
+func NewLintService(fsys platformfs.FileSystem, cfg *config.Config, root string) *LintService {
+	return &LintService{store: skill.NewStore(fsys, cfg, root)}
+}
+
+// Lint checks the selected skills and returns one result per skill.
+func (s *LintService) Lint(opts LintOptions) ([]LintResult, error) {
+	var skills []*skill.Skill
+	if len(opts.Names) > 0 {
+		for _, name := range opts.Names {
+			sk, err := s.store.GetByName(name)
+			if err != nil {
+				return nil, err
+			}
+			skills = append(skills, sk)
+		}
+	} else {
+		var err error
+		skills, err = s.store.GetAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get skills: %w", err)
+		}
+	}
+
+	if opts.Scope != nil {
+		skills = filterSkillsByScope(skills, *opts.Scope)
+	}
+
+	results := make([]LintResult, 0, len(skills))
+	for _, sk := range skills {
+		issues, err := s.store.LintLinks(sk)
+		results = append(results, LintResult{
+			SkillName: sk.Name,
+			Scope:     sk.Scope,
+			Path:      sk.Path,
+			Issues:    issues,
+			Error:     err,
+		})
+	}
+
+	return results, nil
+}
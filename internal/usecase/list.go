@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// ListOptions contains options for listing skills.
+type ListOptions struct {
+	// Scope limits the listing to a specific scope (nil for all)
+	Scope *skill.Scope
+	// Category limits the listing to default or optional skills (nil for both)
+	Category *skill.Category
+	// Tags limits the listing to skills with every one of these tags
+	Tags []string
+}
+
+// SkillInfo describes a skill in the store and where it is installed.
+type SkillInfo struct {
+	Name        string `json:"name"`
+	Scope       string `json:"scope"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	Version     string `json:"version,omitempty"`
+	Path        string `json:"path"`
+	Draft       bool   `json:"draft,omitempty"`
+	Vendored    bool   `json:"vendored,omitempty"`
+	// Tags, Author, and License come from the skill's frontmatter
+	Tags    []string `json:"tags,omitempty"`
+	Author  string   `json:"author,omitempty"`
+	License string   `json:"license,omitempty"`
+	// SupportedTargets lists the only targets the skill works with (empty for all)
+	SupportedTargets []string `json:"supportedTargets,omitempty"`
+	// Targets lists the enabled targets the skill is installed in for its scope, sorted
+	Targets []string `json:"targets"`
+}
+
+// ListService lists skills in the store with their install state.
+type ListService struct {
+	store   *skill.Store
+	targets *TargetRegistry
+}
+
+// NewListService creates a new list service.
+func NewListService(fsys platformfs.FileSystem, cfg *config.Config, root string) *ListService {
+	return &ListService{
+		store:   skill.NewStore(fsys, cfg, root),
+		targets: NewTargetRegistry(fsys, root, cfg),
+	}
+}
+
+// ListSkills returns every skill in the store, including drafts and copies
+// shadowed by a higher-priority scope, in store order (by scope, then name).
+func (s *ListService) ListSkills(opts ListOptions) ([]SkillInfo, error) {
+	var skills []*skill.Skill
+	var err error
+	if opts.Scope != nil {
+		skills, err = s.store.GetByScope(*opts.Scope)
+	} else {
+		skills, err = s.store.GetAll()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	targets := s.targets.GetAll()
+	infos := make([]SkillInfo, 0, len(skills))
+	for _, sk := range skills {
+		if opts.Category != nil && sk.Category != *opts.Category {
+			continue
+		}
+		if !sk.HasTags(opts.Tags) {
+			continue
+		}
+		info := SkillInfo{
+			Name:             sk.Name,
+			Scope:            sk.Scope.String(),
+			Category:         sk.Category.String(),
+			Description:      sk.Description(),
+			Version:          sk.Version(),
+			Path:             sk.Path,
+			Draft:            sk.Draft(),
+			Vendored:         sk.Vendored,
+			Tags:             sk.Tags(),
+			Author:           sk.Author(),
+			License:          sk.License(),
+			SupportedTargets: sk.Targets(),
+			Targets:          []string{},
+		}
+		for _, t := range targets {
+			if t.IsInstalledInScope(sk.Name, sk.Scope) {
+				info.Targets = append(info.Targets, t.Name())
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
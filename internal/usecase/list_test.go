@@ -0,0 +1,75 @@
+package usecase_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestListSkills(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	mock.Dirs["/home/test/.agents/skills/optional/beta"] = true
+	mock.Files["/home/test/.agents/skills/optional/beta/SKILL.md"] = []byte("---\nname: beta\ndescription: Optional beta\n---\n")
+	syncCfg := config.DefaultConfig()
+	claude := syncCfg.Targets["claude"]
+	claude.Optional = []string{"beta"}
+	syncCfg.Targets["claude"] = claude
+	if _, err := usecase.NewSyncService(mock, syncCfg, "").Sync(t.Context(), usecase.SyncOptions{Target: "claude"}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	addGlobalSkill(mock, "gamma")
+	mock.Dirs["/project/.agents/skills"] = true
+	mock.Dirs["/project/.agents/skills/alpha"] = true
+	mock.Files["/project/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\n---\n")
+
+	svc := usecase.NewListService(mock, config.DefaultConfig(), "/project")
+
+	skills, err := svc.ListSkills(usecase.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListSkills() error = %v", err)
+	}
+	type row struct {
+		name, scope, category string
+		targets               []string
+	}
+	want := []row{
+		{"alpha", "global", "default", []string{"claude"}},
+		{"gamma", "global", "default", []string{}},
+		{"beta", "global", "optional", []string{"claude"}},
+		{"alpha", "project", "default", []string{}},
+	}
+	if len(skills) != len(want) {
+		t.Fatalf("ListSkills() = %+v", skills)
+	}
+	for i, w := range want {
+		got := skills[i]
+		if got.Name != w.name || got.Scope != w.scope || got.Category != w.category || !slices.Equal(got.Targets, w.targets) {
+			t.Errorf("skills[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+
+	optional := skill.CategoryOptional
+	project := skill.ScopeProject
+	if skills, _ := svc.ListSkills(usecase.ListOptions{Category: &optional}); len(skills) != 1 || skills[0].Name != "beta" {
+		t.Errorf("ListSkills(optional) = %+v", skills)
+	}
+	if skills, _ := svc.ListSkills(usecase.ListOptions{Scope: &project}); len(skills) != 1 || skills[0].Scope != "project" {
+		t.Errorf("ListSkills(project) = %+v", skills)
+	}
+
+	mock.Files["/home/test/.agents/skills/gamma/SKILL.md"] = []byte("---\nname: gamma\ntags: [infra, go]\nauthor: Jane\n---\n")
+	skills, err = svc.ListSkills(usecase.ListOptions{Tags: []string{"go", "infra"}})
+	if err != nil {
+		t.Fatalf("ListSkills(tags) error = %v", err)
+	}
+	if len(skills) != 1 || skills[0].Name != "gamma" || skills[0].Author != "Jane" {
+		t.Errorf("ListSkills(tags) = %+v, want gamma", skills)
+	}
+	if skills, _ := svc.ListSkills(usecase.ListOptions{Tags: []string{"go", "web"}}); len(skills) != 0 {
+		t.Errorf("ListSkills(go, web) = %+v, want none", skills)
+	}
+}
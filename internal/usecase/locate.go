@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// LocateOptions contains options for locating a skill.
+type LocateOptions struct {
+	// Name is the skill name to locate
+	Name string
+	// Scope limits the lookup to a specific scope (nil to resolve by priority)
+	Scope *skill.Scope
+	// Target locates the installed copy in this target instead of the store
+	Target string
+}
+
+// LocateResult describes where a skill lives.
+type LocateResult struct {
+	Skill *skill.Skill
+	// Dir is the skill directory in the store, or in the target when requested
+	Dir string
+	// File is the SKILL.md inside Dir
+	File string
+}
+
+// LocateService resolves skill names to paths.
+type LocateService struct {
+	fs      platformfs.FileSystem
+	store   *skill.Store
+	targets *TargetRegistry
+}
+
+// NewLocateService creates a new locate service.
+func NewLocateService(fsys platformfs.FileSystem, cfg *config.Config, root string) *LocateService {
+	return &LocateService{
+		fs:      fsys,
+		store:   skill.NewStore(fsys, cfg, root),
+		targets: NewTargetRegistry(fsys, root, cfg),
+	}
+}
+
+// Locate resolves a skill to its store directory, or to its installed copy
+// when a target is given.
+func (s *LocateService) Locate(opts LocateOptions) (*LocateResult, error) {
+	if err := skill.ValidateQualifiedName(opts.Name); err != nil {
+		return nil, fmt.Errorf("invalid skill name: %w", err)
+	}
+
+	var sk *skill.Skill
+	var err error
+	if opts.Scope != nil {
+		sk, err = s.store.FindInScope(opts.Name, *opts.Scope)
+	} else {
+		sk, err = s.store.GetByName(opts.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dir := sk.Path
+	if opts.Target != "" {
+		t, err := s.targets.Lookup(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		dir, err = t.GetInstallPath(sk.Name, sk.Scope)
+		if err != nil {
+			return nil, err
+		}
+		if !s.fs.Exists(dir) {
+			return nil, fmt.Errorf("skill %s is not installed in target %s", sk.Name, t.Name())
+		}
+	}
+
+	return &LocateResult{Skill: sk, Dir: dir, File: s.fs.Join(dir, "SKILL.md")}, nil
+}
+
+// ContentOptions contains options for reading a skill's content.
+type ContentOptions struct {
+	// Name is the skill name to read
+	Name string
+	// Scope limits the lookup to a specific scope (nil to resolve by priority)
+	Scope *skill.Scope
+	// Frontmatter keeps the YAML frontmatter instead of returning only the body
+	Frontmatter bool
+}
+
+// Content returns the SKILL.md of the skill that takes effect for a name,
+// without its frontmatter unless requested.
+func (s *LocateService) Content(opts ContentOptions) ([]byte, error) {
+	result, err := s.Locate(LocateOptions{Name: opts.Name, Scope: opts.Scope})
+	if err != nil {
+		return nil, err
+	}
+	content, err := s.fs.ReadFile(result.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SKILL.md: %w", err)
+	}
+	if opts.Frontmatter {
+		return content, nil
+	}
+	_, body := skill.SplitFrontmatter(string(content))
+	return []byte(body), nil
+}
+
+// WhichResult describes every location of a skill across the store and targets.
+type WhichResult struct {
+	Name string `json:"name"`
+	// Winner is the store copy that takes effect
+	Winner StoreLocation `json:"winner"`
+	// Shadowed lists lower-priority store copies overridden by Winner
+	Shadowed []StoreLocation `json:"shadowed"`
+	// Targets lists the winner's install location in each enabled target
+	Targets []TargetLocation `json:"targets"`
+}
+
+// StoreLocation is a copy of a skill in one scope of the store.
+type StoreLocation struct {
+	Scope    string `json:"scope"`
+	Path     string `json:"path"`
+	Vendored bool   `json:"vendored,omitempty"`
+}
+
+// TargetLocation is where a target has (or would have) a skill installed.
+type TargetLocation struct {
+	Target    string `json:"target"`
+	Path      string `json:"path"`
+	Installed bool   `json:"installed"`
+	// Strategy is the install mechanism ("symlink" or "copy") when installed
+	Strategy config.Strategy `json:"strategy,omitempty"`
+	// LinkTo is the symlink destination for symlinked installs
+	LinkTo string `json:"linkTo,omitempty"`
+}
+
+// Which resolves a skill name to its winning store copy, the copies it
+// shadows, and its install location in each target. Targets are ordered by name.
+func (s *LocateService) Which(name string) (*WhichResult, error) {
+	winner, err := s.store.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	all, err := s.store.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WhichResult{
+		Name:     name,
+		Winner:   storeLocation(winner),
+		Shadowed: []StoreLocation{},
+		Targets:  []TargetLocation{},
+	}
+
+	var shadowed []*skill.Skill
+	for _, sk := range all {
+		if sk.Name == name && sk.Path != winner.Path {
+			shadowed = append(shadowed, sk)
+		}
+	}
+	slices.SortStableFunc(shadowed, func(a, b *skill.Skill) int {
+		return cmp.Compare(b.Priority(), a.Priority())
+	})
+	for _, sk := range shadowed {
+		result.Shadowed = append(result.Shadowed, storeLocation(sk))
+	}
+
+	for _, t := range s.targets.GetAll() {
+		loc := TargetLocation{Target: t.Name()}
+		path, err := t.GetInstallPath(name, winner.Scope)
+		if err != nil {
+			result.Targets = append(result.Targets, loc)
+			continue
+		}
+		loc.Path = path
+		if strategy, ok := t.InstalledStrategy(name, winner.Scope); ok {
+			loc.Installed = true
+			loc.Strategy = strategy
+			if strategy == config.StrategySymlink {
+				loc.LinkTo = s.linkDest(path)
+			}
+		}
+		result.Targets = append(result.Targets, loc)
+	}
+
+	return result, nil
+}
+
+// linkDest returns the destination of the symlink at path, resolving a
+// relative link against the link's directory.
+func (s *LocateService) linkDest(path string) string {
+	link, err := s.fs.Readlink(path)
+	if err != nil {
+		return ""
+	}
+	if !filepath.IsAbs(link) {
+		link = s.fs.Join(s.fs.Dir(path), link)
+	}
+	return link
+}
+
+func storeLocation(sk *skill.Skill) StoreLocation {
+	return StoreLocation{Scope: sk.Scope.String(), Path: sk.Path, Vendored: sk.Vendored}
+}
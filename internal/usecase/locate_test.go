@@ -0,0 +1,122 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestLocateStoreAndTarget(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	mock.Dirs["/project/.agents/skills"] = true
+	mock.Dirs["/project/.agents/skills/alpha"] = true
+	mock.Files["/project/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\n---\n")
+
+	svc := usecase.NewLocateService(mock, config.DefaultConfig(), "/project")
+
+	result, err := svc.Locate(usecase.LocateOptions{Name: "alpha"})
+	if err != nil {
+		t.Fatalf("Locate() error = %v", err)
+	}
+	if result.Dir != "/project/.agents/skills/alpha" || result.File != "/project/.agents/skills/alpha/SKILL.md" {
+		t.Fatalf("Locate() = %+v, want project skill", result)
+	}
+
+	global := skill.ScopeGlobal
+	result, err = svc.Locate(usecase.LocateOptions{Name: "alpha", Scope: &global})
+	if err != nil {
+		t.Fatalf("Locate() error = %v", err)
+	}
+	if result.Dir != "/home/test/.agents/skills/alpha" {
+		t.Fatalf("Locate(global) dir = %s", result.Dir)
+	}
+
+	if _, err := svc.Locate(usecase.LocateOptions{Name: "alpha", Scope: &global, Target: "claude"}); err == nil {
+		t.Fatal("expected error for skill not installed in target")
+	}
+
+	mock.Dirs["/home/test/.claude/skills/alpha"] = true
+	result, err = svc.Locate(usecase.LocateOptions{Name: "alpha", Scope: &global, Target: "claude"})
+	if err != nil {
+		t.Fatalf("Locate(target) error = %v", err)
+	}
+	if result.Dir != "/home/test/.claude/skills/alpha" {
+		t.Fatalf("Locate(target) dir = %s", result.Dir)
+	}
+
+	var unknown *usecase.ErrUnknownTarget
+	if _, err := svc.Locate(usecase.LocateOptions{Name: "alpha", Target: "clade"}); !errors.As(err, &unknown) {
+		t.Fatalf("expected ErrUnknownTarget, got %v", err)
+	}
+}
+
+func TestWhich(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	mock.Dirs["/project/.agents/skills"] = true
+	mock.Dirs["/project/.agents/skills/alpha"] = true
+	mock.Files["/project/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\n---\n")
+	mock.Dirs["/project/.claude/skills"] = true
+	mock.Symlinks["/project/.claude/skills/alpha"] = "/project/.agents/skills/alpha"
+	mock.Dirs["/project/.codex/skills"] = true
+	mock.Symlinks["/project/.codex/skills/alpha"] = "../../.agents/skills/alpha"
+
+	result, err := usecase.NewLocateService(mock, config.DefaultConfig(), "/project").Which("alpha")
+	if err != nil {
+		t.Fatalf("Which() error = %v", err)
+	}
+	if result.Winner.Scope != "project" || result.Winner.Path != "/project/.agents/skills/alpha" {
+		t.Errorf("Winner = %+v, want project copy", result.Winner)
+	}
+	if len(result.Shadowed) != 1 || result.Shadowed[0].Path != "/home/test/.agents/skills/alpha" {
+		t.Errorf("Shadowed = %+v, want global copy", result.Shadowed)
+	}
+
+	want := []usecase.TargetLocation{
+		{Target: "claude", Path: "/project/.claude/skills/alpha", Installed: true, Strategy: config.StrategySymlink, LinkTo: "/project/.agents/skills/alpha"},
+		{Target: "codex", Path: "/project/.codex/skills/alpha", Installed: true, Strategy: config.StrategySymlink, LinkTo: "/project/.agents/skills/alpha"},
+	}
+	if len(result.Targets) != len(want) {
+		t.Fatalf("Targets = %+v", result.Targets)
+	}
+	for i := range want {
+		if result.Targets[i] != want[i] {
+			t.Errorf("Targets[%d] = %+v, want %+v", i, result.Targets[i], want[i])
+		}
+	}
+}
+
+func TestContent(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	mock.Dirs["/project/.agents/skills"] = true
+	mock.Dirs["/project/.agents/skills/alpha"] = true
+	mock.Files["/project/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\n---\n\n# Project alpha\n")
+
+	svc := usecase.NewLocateService(mock, config.DefaultConfig(), "/project")
+
+	body, err := svc.Content(usecase.ContentOptions{Name: "alpha"})
+	if err != nil {
+		t.Fatalf("Content() error = %v", err)
+	}
+	if string(body) != "# Project alpha\n" {
+		t.Fatalf("Content() = %q, want project body", body)
+	}
+
+	global := skill.ScopeGlobal
+	full, err := svc.Content(usecase.ContentOptions{Name: "alpha", Scope: &global, Frontmatter: true})
+	if err != nil {
+		t.Fatalf("Content() error = %v", err)
+	}
+	if string(full) != "---\nname: alpha\n---\n" {
+		t.Fatalf("Content(global) = %q, want the full global file", full)
+	}
+
+	if _, err := svc.Content(usecase.ContentOptions{Name: "missing"}); err == nil {
+		t.Fatal("Content() expected error for a missing skill")
+	}
+}
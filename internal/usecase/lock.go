@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+// LockFileName is the lock file in a store's agents directory.
+const LockFileName = "skillet.lock"
+
+// Lockfile records the provenance of skills copied into a store.
+type Lockfile struct {
+	Version  int           `yaml:"version"`
+	Vendored []LockedSkill `yaml:"vendored,omitempty"`
+	// Sources lists skills added from remote sources (see skillet add).
+	Sources []SourcedSkill `yaml:"sources,omitempty"`
+	// Skills lists the resolved skill set of the last sync (see skillet install).
+	Skills []LockedSkill `yaml:"skills,omitempty"`
+}
+
+// LockedSkill records a single skill and where it came from.
+type LockedSkill struct {
+	Name     string `yaml:"name"`
+	Scope    string `yaml:"scope"`
+	Source   string `yaml:"source"`
+	Checksum string `yaml:"checksum"`
+}
+
+// SourcedSkill records a skill fetched from a remote source.
+type SourcedSkill struct {
+	Name string `yaml:"name"`
+	// Source is the source as given to skillet add (e.g. github.com/org/repo/skill@v1)
+	Source   string `yaml:"source"`
+	Checksum string `yaml:"checksum"`
+}
+
+// lockPath returns the lock file path in an agents directory.
+func lockPath(fsys platformfs.FileSystem, agentsDir string) string {
+	return fsys.Join(agentsDir, LockFileName)
+}
+
+// loadLockfile reads the lock file in an agents directory. A missing file is an empty lock.
+func loadLockfile(fsys platformfs.FileSystem, agentsDir string) (*Lockfile, error) {
+	path := lockPath(fsys, agentsDir)
+	if !fsys.Exists(path) {
+		return &Lockfile{Version: 1}, nil
+	}
+
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	var lock Lockfile
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file: %w", err)
+	}
+	if lock.Version == 0 {
+		lock.Version = 1
+	}
+	return &lock, nil
+}
+
+// saveLockfile writes the lock file in an agents directory with entries sorted by name.
+func saveLockfile(fsys platformfs.FileSystem, agentsDir string, lock *Lockfile) error {
+	slices.SortFunc(lock.Vendored, func(a, b LockedSkill) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	slices.SortFunc(lock.Sources, func(a, b SourcedSkill) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	slices.SortFunc(lock.Skills, func(a, b LockedSkill) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("failed to encode lock file: %w", err)
+	}
+	if err := fsys.WriteFile(lockPath(fsys, agentsDir), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
+
+// setVendored adds or replaces the vendored entry for a skill.
+func (l *Lockfile) setVendored(entry LockedSkill) {
+	for i := range l.Vendored {
+		if l.Vendored[i].Name == entry.Name {
+			l.Vendored[i] = entry
+			return
+		}
+	}
+	l.Vendored = append(l.Vendored, entry)
+}
+
+// source returns the source entry for a skill.
+func (l *Lockfile) source(name string) (SourcedSkill, bool) {
+	for _, entry := range l.Sources {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+	return SourcedSkill{}, false
+}
+
+// setSource adds or replaces the source entry for a skill.
+func (l *Lockfile) setSource(entry SourcedSkill) {
+	for i := range l.Sources {
+		if l.Sources[i].Name == entry.Name {
+			l.Sources[i] = entry
+			return
+		}
+	}
+	l.Sources = append(l.Sources, entry)
+}
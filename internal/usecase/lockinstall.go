@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// LockInstallAction represents the outcome of installing a locked skill.
+type LockInstallAction string
+
+const (
+	LockInstallActionInstalled LockInstallAction = "installed"
+	LockInstallActionUpdated   LockInstallAction = "updated"
+	LockInstallActionUnchanged LockInstallAction = "unchanged"
+	LockInstallActionError     LockInstallAction = "error"
+)
+
+// LockInstallOptions contains options for installing the locked skill set.
+type LockInstallOptions struct {
+	// DryRun only shows what would be done without making changes
+	DryRun bool
+}
+
+// LockInstallResult represents the result of installing a single locked skill.
+type LockInstallResult struct {
+	SkillName string
+	Source    string
+	Action    LockInstallAction
+	Error     error
+}
+
+// LockInstallService restores the skill set recorded in a lock file.
+type LockInstallService struct {
+	fs    platformfs.FileSystem
+	cfg   *config.Config
+	root  string
+	store *skill.Store
+	add   *AddService
+}
+
+// NewLockInstallService creates a new install service that retrieves remote
+// sources with fetcher.
+func NewLockInstallService(fsys platformfs.FileSystem, cfg *config.Config, root string, fetcher fetch.Fetcher) *LockInstallService {
+	return &LockInstallService{
+		fs:    fsys,
+		cfg:   cfg,
+		root:  root,
+		store: skill.NewStore(fsys, cfg, root),
+		add:   NewAddService(fsys, cfg, root, fetcher),
+	}
+}
+
+// Install makes the project's skill set (.agents/skillset.yaml) available,
+// then reads the lock file of the project (or the global store outside a
+// project) and makes every other locked skill match its recorded checksum.
+// Skills with a remote source are fetched again when missing or different.
+// Skills from local paths cannot be restored and are reported as errors.
+// Canceling ctx stops the install between skills.
+func (s *LockInstallService) Install(ctx context.Context, opts LockInstallOptions) ([]LockInstallResult, error) {
+	agentsDir, err := s.cfg.GetAgentsDir(s.fs, s.root)
+	if err != nil {
+		return nil, err
+	}
+	var set *Skillset
+	if s.root != "" {
+		if set, err = loadSkillset(s.fs, agentsDir); err != nil {
+			return nil, err
+		}
+	}
+	hasLock := s.fs.Exists(lockPath(s.fs, agentsDir))
+	if !hasLock && set == nil {
+		if s.root != "" {
+			return nil, fmt.Errorf("no %s or %s found in %s (run skillet sync to create the lock file)", SkillsetFileName, LockFileName, agentsDir)
+		}
+		return nil, fmt.Errorf("no %s found in %s (run skillet sync to create it)", LockFileName, agentsDir)
+	}
+
+	var results []LockInstallResult
+	listed := make(map[string]bool)
+	// Entries without a source are checked once the lock file has restored
+	// the skills it can.
+	var required []SkillsetEntry
+	if set != nil {
+		for _, entry := range set.Skills {
+			if entry.Source == "" {
+				required = append(required, entry)
+				continue
+			}
+			results = append(results, s.installSkillsetEntry(ctx, entry, listed, opts.DryRun))
+		}
+	}
+
+	if hasLock {
+		lockResults, err := s.installLocked(ctx, agentsDir, listed, opts.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, lockResults...)
+	}
+
+	for _, entry := range required {
+		results = append(results, s.installSkillsetEntry(ctx, entry, listed, opts.DryRun))
+	}
+	return results, nil
+}
+
+// installLocked restores the skills in the lock file, except the ones in skip.
+func (s *LockInstallService) installLocked(ctx context.Context, agentsDir string, skip map[string]bool, dryRun bool) ([]LockInstallResult, error) {
+	lock, err := loadLockfile(s.fs, agentsDir)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := s.store.GetResolved()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	resolved := make(map[string]*skill.Skill, len(skills))
+	for _, sk := range skills {
+		resolved[sk.Name] = sk
+	}
+
+	results := make([]LockInstallResult, 0, len(lock.Skills))
+	for _, entry := range lock.Skills {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		if skip[entry.Name] {
+			continue
+		}
+		results = append(results, s.installSkill(ctx, entry, resolved[entry.Name], dryRun))
+	}
+	return results, nil
+}
+
+func (s *LockInstallService) installSkill(ctx context.Context, entry LockedSkill, current *skill.Skill, dryRun bool) LockInstallResult {
+	result := LockInstallResult{SkillName: entry.Name, Source: entry.Source, Action: LockInstallActionInstalled}
+	if current != nil {
+		if sum, err := dirChecksum(s.fs, current.Path); err == nil && sum == entry.Checksum {
+			result.Action = LockInstallActionUnchanged
+			return result
+		}
+		result.Action = LockInstallActionUpdated
+	}
+
+	if isLocalSource(entry.Source) {
+		result.Action = LockInstallActionError
+		if current == nil {
+			result.Error = fmt.Errorf("not available: local source %s", entry.Source)
+		} else {
+			result.Error = fmt.Errorf("differs from the lock file and its local source %s cannot be fetched", entry.Source)
+		}
+		return result
+	}
+	if dryRun {
+		return result
+	}
+
+	scope := skill.ScopeGlobal
+	if entry.Scope == skill.ScopeProject.String() {
+		scope = skill.ScopeProject
+	}
+	added, err := s.add.Add(ctx, AddOptions{Source: entry.Source, Name: entry.Name, Scope: scope, Force: true})
+	switch {
+	case err != nil:
+		result.Action = LockInstallActionError
+		result.Error = err
+	case added.Checksum != entry.Checksum:
+		result.Action = LockInstallActionError
+		result.Error = fmt.Errorf("fetched content does not match the lock file checksum")
+	}
+	return result
+}
+
+// recordInstalled updates the Skills section of the lock file for root with
+// the resolved skills of a sync, replacing the entries for which synced
+// returns true. Checksums are taken from state when it has them (nil for
+// none). Nothing is written when the store does not exist.
+func recordInstalled(fsys platformfs.FileSystem, cfg *config.Config, root string, skills []*skill.Skill, synced func(LockedSkill) bool, state *syncState) error {
+	agentsDir, err := cfg.GetAgentsDir(fsys, root)
+	if err != nil || !fsys.IsDir(agentsDir) {
+		return err
+	}
+	lock, err := loadLockfile(fsys, agentsDir)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]LockedSkill, 0, len(skills))
+	for _, entry := range lock.Skills {
+		if !synced(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sources := make(map[string]*Lockfile)
+	for _, sk := range skills {
+		sum, err := state.checksum(fsys, sk.Path)
+		if err != nil {
+			return fmt.Errorf("failed to checksum %s: %w", sk.Name, err)
+		}
+		entries = append(entries, LockedSkill{
+			Name:     sk.Name,
+			Scope:    sk.Scope.String(),
+			Source:   skillSource(fsys, cfg, root, sk, sources),
+			Checksum: sum,
+		})
+	}
+	lock.Skills = entries
+
+	return saveLockfile(fsys, agentsDir, lock)
+}
+
+// skillSource returns the source recorded by skillet add for a skill, or its
+// local path: relative to the project for project skills, and with the home
+// directory as ~ otherwise. Store lock files are cached in sources.
+func skillSource(fsys platformfs.FileSystem, cfg *config.Config, root string, sk *skill.Skill, sources map[string]*Lockfile) string {
+	if agentsDir, err := scopeAgentsDir(fsys, cfg, root, sk.Scope); err == nil && agentsDir != "" {
+		lock, ok := sources[agentsDir]
+		if !ok {
+			lock, _ = loadLockfile(fsys, agentsDir)
+			sources[agentsDir] = lock
+		}
+		if lock != nil {
+			if entry, ok := lock.source(sk.Name); ok {
+				return entry.Source
+			}
+		}
+	}
+
+	if sk.Scope == skill.ScopeProject && root != "" {
+		if rel, err := fsys.Rel(root, sk.Path); err == nil {
+			return "./" + filepath.ToSlash(rel)
+		}
+	}
+	return config.ContractPath(fsys, sk.Path)
+}
+
+// isLocalSource reports whether a locked source is a path rather than a
+// remote source that can be fetched.
+func isLocalSource(source string) bool {
+	return filepath.IsAbs(source) || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~") || strings.HasPrefix(source, ".")
+}
+
+// scopeAgentsDir returns the agents directory of a scope's store, or an
+// empty string when the scope is not configured.
+func scopeAgentsDir(fsys platformfs.FileSystem, cfg *config.Config, root string, scope skill.Scope) (string, error) {
+	switch scope {
+	case skill.ScopeProject:
+		if root == "" {
+			return "", nil
+		}
+		return config.ProjectAgentsDir(root, fsys), nil
+	case skill.ScopeOrg:
+		return cfg.OrgAgentsDir(fsys)
+	case skill.ScopeSystem:
+		return cfg.SystemAgentsDir(fsys)
+	default:
+		return cfg.AgentsDir(fsys)
+	}
+}
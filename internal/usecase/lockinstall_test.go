@@ -0,0 +1,162 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestSyncRecordsLockAndInstallRestores(t *testing.T) {
+	mock, syncSvc := setupSyncEnv()
+	cfg := config.DefaultConfig()
+	addGlobalSkill(mock, "local")
+	fetcher := &mockFetcher{fs: mock, files: map[string]string{"SKILL.md": "---\nname: remote\n---\nbody\n"}}
+	if _, err := usecase.NewAddService(mock, cfg, "", fetcher).Add(t.Context(), usecase.AddOptions{Source: "github.com/org/skills/remote@v1", Scope: skill.ScopeGlobal}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if _, err := syncSvc.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	lock := string(mock.Files["/home/test/.agents/skillet.lock"])
+	for _, want := range []string{"skills:", "source: ~/.agents/skills/local", "source: github.com/org/skills/remote@v1"} {
+		if !strings.Contains(lock, want) {
+			t.Fatalf("lock file missing %q:\n%s", want, lock)
+		}
+	}
+
+	svc := usecase.NewLockInstallService(mock, cfg, "", fetcher)
+	results, err := svc.Install(t.Context(), usecase.LockInstallOptions{})
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	for _, r := range results {
+		if r.Action != usecase.LockInstallActionUnchanged {
+			t.Fatalf("expected every skill unchanged, got %+v", r)
+		}
+	}
+
+	// A missing remote skill is fetched again; a changed local one cannot be restored.
+	if err := mock.RemoveAll("/home/test/.agents/skills/remote"); err != nil {
+		t.Fatal(err)
+	}
+	mock.Files["/home/test/.agents/skills/local/SKILL.md"] = []byte("---\nname: local\n---\nedited\n")
+	results, err = svc.Install(t.Context(), usecase.LockInstallOptions{})
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	got := make(map[string]usecase.LockInstallAction)
+	for _, r := range results {
+		got[r.SkillName] = r.Action
+	}
+	if got["remote"] != usecase.LockInstallActionInstalled || got["local"] != usecase.LockInstallActionError {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if !mock.Exists("/home/test/.agents/skills/remote/SKILL.md") {
+		t.Fatal("expected remote skill to be fetched again")
+	}
+	if len(fetcher.sources) != 2 || fetcher.sources[1] != "github.com/org/skills/remote@v1" {
+		t.Fatalf("unexpected fetches: %v", fetcher.sources)
+	}
+}
+
+func TestSyncDryRunDoesNotWriteLock(t *testing.T) {
+	mock, syncSvc := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+
+	if _, err := syncSvc.Sync(t.Context(), usecase.SyncOptions{DryRun: true}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if mock.Exists("/home/test/.agents/skillet.lock") {
+		t.Fatal("dry run should not write the lock file")
+	}
+	if _, err := usecase.NewLockInstallService(mock, config.DefaultConfig(), "", nil).Install(t.Context(), usecase.LockInstallOptions{}); err == nil {
+		t.Fatal("Install() expected error without a lock file")
+	}
+}
+
+func TestInstallSkillset(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	cfg := config.DefaultConfig()
+	addGlobalSkill(mock, "shared")
+	mock.Dirs["/project"] = true
+	mock.Dirs["/project/.agents"] = true
+	mock.Dirs["/project/tools/lint"] = true
+	mock.Files["/project/tools/lint/SKILL.md"] = []byte("---\nname: lint\n---\n")
+	mock.Files["/project/.agents/skillset.yaml"] = []byte(`skills:
+  - source: github.com/org/skills/review@v1
+  - source: ./tools/lint
+  - name: shared
+  - name: missing
+  - name: lint
+`)
+	fetcher := &mockFetcher{fs: mock, files: map[string]string{"SKILL.md": "---\nname: review\n---\nbody\n"}}
+	svc := usecase.NewLockInstallService(mock, cfg, "/project", fetcher)
+
+	results, err := svc.Install(t.Context(), usecase.LockInstallOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if len(fetcher.sources) != 0 || mock.Exists("/project/.agents/skills/lint") {
+		t.Fatal("dry run should not fetch or link anything")
+	}
+	if len(results) != 5 || results[0].Action != usecase.LockInstallActionInstalled || results[1].Action != usecase.LockInstallActionInstalled {
+		t.Fatalf("unexpected dry run results: %+v", results)
+	}
+
+	results, err = svc.Install(t.Context(), usecase.LockInstallOptions{})
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	got := make(map[string]usecase.LockInstallResult)
+	for _, r := range results {
+		if _, ok := got[r.SkillName]; !ok {
+			got[r.SkillName] = r
+		}
+	}
+	if got["review"].Action != usecase.LockInstallActionInstalled || !mock.Exists("/project/.agents/skills/review/SKILL.md") {
+		t.Errorf("review = %+v, want fetched into the project store", got["review"])
+	}
+	if target, _ := mock.Readlink("/project/.agents/skills/lint"); got["lint"].Action != usecase.LockInstallActionInstalled || target != "/project/tools/lint" {
+		t.Errorf("lint = %+v linked to %q, want a link to /project/tools/lint", got["lint"], target)
+	}
+	if got["shared"].Action != usecase.LockInstallActionUnchanged {
+		t.Errorf("shared = %+v, want unchanged", got["shared"])
+	}
+	if got["missing"].Action != usecase.LockInstallActionError {
+		t.Errorf("missing = %+v, want an error", got["missing"])
+	}
+	if last := results[len(results)-1]; last.SkillName != "lint" || last.Action != usecase.LockInstallActionError {
+		t.Errorf("duplicate lint entry = %+v, want an error", last)
+	}
+
+	// Installing again changes nothing; a new version replaces the unedited skill.
+	mock.Files["/project/.agents/skillset.yaml"] = []byte(`skills:
+  - source: github.com/org/skills/review@v2
+  - source: ./tools/lint
+`)
+	fetcher.files["SKILL.md"] = "---\nname: review\n---\nv2\n"
+	results, err = svc.Install(t.Context(), usecase.LockInstallOptions{})
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if results[0].Action != usecase.LockInstallActionUpdated || results[1].Action != usecase.LockInstallActionUnchanged {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if fetcher.sources[len(fetcher.sources)-1] != "github.com/org/skills/review@v2" {
+		t.Fatalf("unexpected fetches: %v", fetcher.sources)
+	}
+
+	mock.Files["/project/.agents/skills/review/SKILL.md"] = []byte("---\nname: review\n---\nedited\n")
+	mock.Files["/project/.agents/skillset.yaml"] = []byte("skills:\n  - source: github.com/org/skills/review@v3\n")
+	results, err = svc.Install(t.Context(), usecase.LockInstallOptions{})
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if results[0].Action != usecase.LockInstallActionError {
+		t.Fatalf("edited skill should not be replaced, got %+v", results[0])
+	}
+}
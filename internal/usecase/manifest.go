@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+const (
+	manifestBeginMarker = "BEGIN skillet managed skills"
+	manifestEndMarker   = "END skillet managed skills"
+)
+
+// manifestFormat describes how to render a managed block for a manifest file type.
+type manifestFormat struct {
+	comment func(text string) string
+	body    func(names []string) string
+	// rootTable is set for formats whose keys belong to the last table
+	// header above them, like TOML. Their block is kept above the first
+	// header, so it neither joins a table of the file nor captures the keys
+	// that follow it.
+	rootTable bool
+	// render replaces the marker-delimited block for formats without
+	// comments.
+	render func(content string, names []string) (string, error)
+}
+
+// manifestFormats maps manifest file extensions to their block format.
+var manifestFormats = map[string]manifestFormat{
+	".toml": {comment: hashComment, body: tomlSkillsBody, rootTable: true},
+	".json": {render: renderJSONManifest},
+	".yaml": {comment: hashComment, body: yamlSkillsBody},
+	".yml":  {comment: hashComment, body: yamlSkillsBody},
+	".md":   {comment: htmlComment, body: markdownSkillsBody},
+}
+
+func hashComment(text string) string {
+	return "# " + text
+}
+
+func htmlComment(text string) string {
+	return "<!-- " + text + " -->"
+}
+
+// tomlSkillsBody sets skillet.skills with a dotted key rather than opening a
+// [skillet] table, which would take in the keys after the block.
+func tomlSkillsBody(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = strconv.Quote(name)
+	}
+	return "skillet.skills = [" + strings.Join(quoted, ", ") + "]\n"
+}
+
+// tomlTableHeader matches a [table] or [[array.of.tables]] header line.
+var tomlTableHeader = regexp.MustCompile(`^[ \t]*\[\[?[ \t]*[A-Za-z0-9_.\-"' \t]+\]\]?[ \t]*(#.*)?\r?\n?$`)
+
+// firstTOMLTable returns the offset of the first table header line in
+// content, or -1 when there is none.
+func firstTOMLTable(content string) int {
+	offset := 0
+	for line := range strings.SplitAfterSeq(content, "\n") {
+		if tomlTableHeader.MatchString(line) {
+			return offset
+		}
+		offset += len(line)
+	}
+	return -1
+}
+
+func yamlSkillsBody(names []string) string {
+	if len(names) == 0 {
+		return "skillet:\n  skills: []\n"
+	}
+	var b strings.Builder
+	b.WriteString("skillet:\n  skills:\n")
+	for _, name := range names {
+		b.WriteString("    - " + name + "\n")
+	}
+	return b.String()
+}
+
+func markdownSkillsBody(names []string) string {
+	var b strings.Builder
+	b.WriteString("## Skills\n\n")
+	for _, name := range names {
+		b.WriteString("- " + name + "\n")
+	}
+	return b.String()
+}
+
+// renderManifest replaces the managed block in content with one listing names.
+// Content outside the markers is preserved; the block is appended if absent,
+// or for TOML inserted above the first table.
+func renderManifest(content, ext string, names []string) (string, error) {
+	format, ok := manifestFormats[ext]
+	if !ok {
+		return "", fmt.Errorf("unsupported manifest format: %q", ext)
+	}
+	if format.render != nil {
+		return format.render(content, names)
+	}
+
+	begin := format.comment(manifestBeginMarker)
+	end := format.comment(manifestEndMarker)
+	block := begin + "\n" + format.body(names) + end + "\n"
+
+	start := strings.Index(content, begin)
+	if start >= 0 {
+		stop := strings.Index(content[start:], end)
+		if stop < 0 {
+			return "", fmt.Errorf("manifest has %q without matching %q", begin, end)
+		}
+		stop += start + len(end)
+		if stop < len(content) && content[stop] == '\n' {
+			stop++
+		}
+		if !format.rootTable || firstTOMLTable(content[:start]) < 0 {
+			return content[:start] + block + content[stop:], nil
+		}
+		// A block moved below a table is taken out and inserted again.
+		content = content[:start] + content[stop:]
+	}
+
+	if format.rootTable {
+		if at := firstTOMLTable(content); at >= 0 {
+			return content[:at] + block + "\n" + content[at:], nil
+		}
+	}
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content != "" {
+		content += "\n"
+	}
+	return content + block, nil
+}
+
+// renderJSONManifest sets the "skillet" key of the JSON object in content to
+// the managed skill list. JSON has no comments to hold markers, so the key
+// marks the managed section; the other keys keep their order and values.
+func renderJSONManifest(content string, names []string) (string, error) {
+	type member struct {
+		key   string
+		value json.RawMessage
+	}
+	var members []member
+	if strings.TrimSpace(content) != "" {
+		dec := json.NewDecoder(strings.NewReader(content))
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+			return "", fmt.Errorf("manifest is not a JSON object")
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return "", fmt.Errorf("failed to parse manifest: %w", err)
+			}
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return "", fmt.Errorf("failed to parse manifest: %w", err)
+			}
+			members = append(members, member{tok.(string), value})
+		}
+		if _, err := dec.Token(); err != nil {
+			return "", fmt.Errorf("failed to parse manifest: %w", err)
+		}
+		if _, err := dec.Token(); err != io.EOF {
+			return "", fmt.Errorf("manifest has data after the JSON object")
+		}
+	}
+
+	if names == nil {
+		names = []string{}
+	}
+	managed, err := json.Marshal(map[string][]string{"skills": names})
+	if err != nil {
+		return "", err
+	}
+	i := slices.IndexFunc(members, func(m member) bool { return m.key == "skillet" })
+	if i < 0 {
+		members = append(members, member{key: "skillet"})
+		i = len(members) - 1
+	}
+	members[i].value = managed
+
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, m := range members {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString("\n  ")
+		b.Write(key)
+		b.WriteString(": ")
+		if err := json.Indent(&b, m.value, "  ", "  "); err != nil {
+			return "", fmt.Errorf("failed to format manifest: %w", err)
+		}
+	}
+	b.WriteString("\n}\n")
+	return b.String(), nil
+}
+
+// writeManifest regenerates the target's manifest for a scope.
+// Returns the manifest path and whether the file changed.
+func (t *Target) writeManifest(scope skill.Scope) (string, bool, error) {
+	if t.manifest == "" {
+		return "", false, nil
+	}
+
+	root, err := t.GetRootPath(scope)
+	if err != nil {
+		return "", false, nil
+	}
+	path := t.fs.Join(root, t.manifest)
+
+	names, err := t.ListInstalledInScope(scope)
+	if err != nil {
+		return path, false, err
+	}
+	for i, name := range names {
+		names[i] = t.prefix + name
+	}
+
+	var current string
+	if t.fs.Exists(path) {
+		data, err := t.fs.ReadFile(path)
+		if err != nil {
+			return path, false, fmt.Errorf("failed to read manifest: %w", err)
+		}
+		current = string(data)
+	} else if len(names) == 0 {
+		return path, false, nil
+	}
+
+	updated, err := renderManifest(current, strings.ToLower(filepath.Ext(t.manifest)), names)
+	if err != nil {
+		return path, false, err
+	}
+	if updated == current {
+		return path, false, nil
+	}
+
+	if err := t.fs.MkdirAll(t.fs.Dir(path), 0o755); err != nil {
+		return path, false, fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+	if err := t.journal.keepFile(path); err != nil {
+		return path, false, err
+	}
+	if err := t.fs.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return path, false, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	return path, true, nil
+}
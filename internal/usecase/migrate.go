@@ -1,6 +1,13 @@
 package usecase
 
 import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
 	"github.com/wwwyo/skillet/internal/config"
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
 	"github.com/wwwyo/skillet/internal/skill"
@@ -14,12 +21,62 @@ const (
 	MigrateActionSkipped MigrateAction = "skipped"
 	MigrateActionRemoved MigrateAction = "removed"
 	MigrateActionError   MigrateAction = "error"
+	// MigrateActionAdopted reports a target copy identical to the store copy,
+	// which is removed so sync can link the store copy in its place.
+	MigrateActionAdopted MigrateAction = "adopted"
+	// MigrateActionReplaced reports a target copy that replaced a different
+	// store copy, which was archived.
+	MigrateActionReplaced MigrateAction = "replaced"
+	// MigrateActionRenamed reports a target copy moved into the store under
+	// another name (see MigrateMoveResult.StoreName).
+	MigrateActionRenamed MigrateAction = "renamed"
+	// MigrateActionConflict reports a target copy that differs from the store
+	// copy and was left in place because no resolution was given.
+	MigrateActionConflict MigrateAction = "conflict"
+)
+
+// MigrateConflict is how migrate resolves a target copy of a skill that
+// differs from the store copy of the same name.
+type MigrateConflict string
+
+const (
+	// MigrateConflictKeepStore deletes the target copy.
+	MigrateConflictKeepStore MigrateConflict = "keep-store"
+	// MigrateConflictKeepTarget archives the store copy and moves the target
+	// copy into the store.
+	MigrateConflictKeepTarget MigrateConflict = "keep-target"
+	// MigrateConflictRename moves the target copy into the store as
+	// <name>-from-<target>, next to the store copy.
+	MigrateConflictRename MigrateConflict = "rename"
 )
 
+// MigrateConflicts lists the conflict resolutions in the order they are offered.
+var MigrateConflicts = []MigrateConflict{MigrateConflictKeepStore, MigrateConflictKeepTarget, MigrateConflictRename}
+
+// ParseMigrateConflict parses a conflict resolution name.
+func ParseMigrateConflict(name string) (MigrateConflict, error) {
+	if c := MigrateConflict(name); slices.Contains(MigrateConflicts, c) {
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown conflict resolution %q (use keep-store, keep-target, or rename)", name)
+}
+
 // MigrateOptions contains options for migration.
 type MigrateOptions struct {
 	Scope       skill.Scope
 	ProjectRoot string
+	// DryRun only reports what would be moved, removed, and linked, without
+	// making changes
+	DryRun bool
+	// Conflict resolves target copies that differ from the store copy (empty
+	// leaves them in place)
+	Conflict MigrateConflict
+	// Resolutions overrides Conflict for single copies, keyed by
+	// target/skill name
+	Resolutions map[string]MigrateConflict
+	// AllSources also migrates the entries of the targets' migrate sources,
+	// such as claude's commands and agents (see FindSourcesToMigrate)
+	AllSources bool
 }
 
 // MigrateResult represents the result of a migration operation.
@@ -34,25 +91,35 @@ type MigrateMoveResult struct {
 	SkillName  string
 	FromTarget string
 	Action     MigrateAction
-	Message    string
-	Error      error
+	// StoreName is the name the skill was moved into the store under, when
+	// it was renamed
+	StoreName string
+	// Source is the path, relative to the target root, of the migrate source
+	// entry the skill was made from (empty for the target's skills directory)
+	Source  string
+	Message string
+	Error   error
 }
 
 // MigrateService migrates existing target-local skills into the central agents directory.
 type MigrateService struct {
 	fs      platformfs.FileSystem
+	store   *skill.Store
 	targets *TargetRegistry
 	cfg     *config.Config
 	syncSvc *SyncService
+	dedupe  *DedupeService
 }
 
 // NewMigrateService creates a new migrate service.
 func NewMigrateService(fsys platformfs.FileSystem, cfg *config.Config, root string, syncSvc *SyncService) *MigrateService {
 	return &MigrateService{
 		fs:      fsys,
+		store:   skill.NewStore(fsys, cfg, root),
 		targets: NewTargetRegistry(fsys, root, cfg),
 		cfg:     cfg,
 		syncSvc: syncSvc,
+		dedupe:  NewDedupeService(fsys, cfg, root),
 	}
 }
 
@@ -74,19 +141,50 @@ func (s *MigrateService) FindSkillsToMigrate(opts MigrateOptions) map[string][]s
 }
 
 // Migrate moves skills from targets to the agents directory and syncs.
-func (s *MigrateService) Migrate(opts MigrateOptions, existingSkills map[string][]string) (*MigrateResult, error) {
+// With opts.AllSources, the entries of the targets' migrate sources are
+// moved too.
+// A dry run returns the moves and the sync that would follow them, including
+// the links back to the targets for the moved skills.
+//
+// Target copies that differ from the store copy need a resolution, from
+// opts.Conflict or opts.Resolutions, since the sync would overwrite them; a
+// migration with unresolved conflicts fails before changing anything, and a
+// dry run reports them as conflicts.
+func (s *MigrateService) Migrate(ctx context.Context, opts MigrateOptions, existingSkills map[string][]string) (*MigrateResult, error) {
 	agentsDir, err := s.cfg.GetAgentsDir(s.fs, opts.ProjectRoot)
 	if err != nil {
 		return nil, err
 	}
+	if !opts.DryRun && opts.Conflict == "" {
+		conflicts, err := s.Conflicts(opts, existingSkills)
+		if err != nil {
+			return nil, err
+		}
+		var unresolved []string
+		for _, c := range conflicts {
+			if _, ok := opts.Resolutions[c.FromTarget+"/"+c.SkillName]; !ok {
+				unresolved = append(unresolved, c.FromTarget+"/"+c.SkillName)
+			}
+		}
+		if len(unresolved) > 0 {
+			return nil, fmt.Errorf("copies differ from the store copy: %s (choose keep-store, keep-target, or rename)", strings.Join(unresolved, ", "))
+		}
+	}
 
 	moveResults := s.moveSkillsToAgents(agentsDir, existingSkills, opts)
+	if opts.AllSources {
+		skillsDir := s.fs.Join(agentsDir, config.SkillsDirName)
+		moveResults = append(moveResults, s.migrateSources(skillsDir, s.FindSourcesToMigrate(opts), opts.DryRun)...)
+	}
 
 	// Sync to create links back to targets.
-	syncResults, err := s.syncSvc.Sync(SyncOptions{Force: true})
+	syncResults, err := s.syncSvc.Sync(ctx, SyncOptions{Force: true, DryRun: opts.DryRun})
 	if err != nil {
 		return nil, err
 	}
+	if opts.DryRun {
+		syncResults = s.plannedLinks(moveResults, syncResults)
+	}
 
 	found := existingSkills
 	if found == nil {
@@ -105,13 +203,34 @@ func (r *MigrateResult) HasSkillsToMigrate() bool {
 	return len(r.Found) > 0
 }
 
+// Conflicts returns the target copies among existingSkills that differ from
+// the store copy of the same name, or from the copy another target moves into
+// the store first. Migrate leaves them in place unless a resolution is given.
+func (s *MigrateService) Conflicts(opts MigrateOptions, existingSkills map[string][]string) ([]MigrateMoveResult, error) {
+	agentsDir, err := s.cfg.GetAgentsDir(s.fs, opts.ProjectRoot)
+	if err != nil {
+		return nil, err
+	}
+	opts.DryRun = true
+	opts.Conflict = ""
+	opts.Resolutions = nil
+	results := s.moveSkillsToAgents(agentsDir, existingSkills, opts)
+	return slices.DeleteFunc(results, func(r MigrateMoveResult) bool { return r.Action != MigrateActionConflict }), nil
+}
+
 // moveSkillsToAgents moves skills from targets to the agents directory.
+// Targets are processed in name order so the first target wins duplicates deterministically.
+// Copies of skills already in the store, or moved there from an earlier
+// target, are adopted when identical and otherwise resolved as opts asks.
 func (s *MigrateService) moveSkillsToAgents(agentsDir string, existingSkills map[string][]string, opts MigrateOptions) []MigrateMoveResult {
 	skillsDir := s.fs.Join(agentsDir, config.SkillsDirName)
-	moved := make(map[string]bool)
+	// moved maps each skill moved from a target to its copy in the store, or
+	// on a dry run to the target copy that would be moved.
+	moved := make(map[string]string)
 	var results []MigrateMoveResult
 
-	for targetName, skills := range existingSkills {
+	for _, targetName := range slices.Sorted(maps.Keys(existingSkills)) {
+		skills := existingSkills[targetName]
 		t, ok := s.targets.Get(targetName)
 		if !ok {
 			continue
@@ -131,30 +250,22 @@ func (s *MigrateService) moveSkillsToAgents(agentsDir string, existingSkills map
 			srcPath := s.fs.Join(targetSkillsDir, skillName)
 			dstPath := s.fs.Join(skillsDir, skillName)
 
-			// Skip if already moved from another target.
-			if moved[skillName] {
-				if err := s.fs.RemoveAll(srcPath); err != nil {
-					result.Action = MigrateActionError
-					result.Message = "failed to remove duplicate"
-					result.Error = err
-				} else {
-					result.Action = MigrateActionRemoved
-					result.Message = "removed duplicate"
+			storeCopy, duplicate := moved[skillName]
+			if !duplicate && s.fs.Exists(dstPath) {
+				storeCopy = dstPath
+			}
+			if storeCopy != "" {
+				s.resolveExisting(t, &result, srcPath, storeCopy, skillsDir, duplicate, opts)
+				if result.Action == MigrateActionReplaced && opts.DryRun {
+					moved[skillName] = srcPath
 				}
 				results = append(results, result)
 				continue
 			}
 
-			// Check if destination already exists.
-			if s.fs.Exists(dstPath) {
-				if err := s.fs.RemoveAll(srcPath); err != nil {
-					result.Action = MigrateActionError
-					result.Message = "failed to remove after skip"
-					result.Error = err
-				} else {
-					result.Action = MigrateActionSkipped
-					result.Message = "already exists in agents"
-				}
+			if opts.DryRun {
+				moved[skillName] = srcPath
+				result.Action = MigrateActionMoved
 				results = append(results, result)
 				continue
 			}
@@ -168,7 +279,16 @@ func (s *MigrateService) moveSkillsToAgents(agentsDir string, existingSkills map
 				continue
 			}
 
-			moved[skillName] = true
+			// Copies made by targets with a banner carry it back; the store copy must not.
+			if err := s.stripMovedBanner(dstPath); err != nil {
+				result.Action = MigrateActionError
+				result.Message = "moved, but failed to remove the skillet banner"
+				result.Error = err
+				results = append(results, result)
+				continue
+			}
+
+			moved[skillName] = dstPath
 			result.Action = MigrateActionMoved
 			results = append(results, result)
 		}
@@ -176,3 +296,188 @@ func (s *MigrateService) moveSkillsToAgents(agentsDir string, existingSkills map
 
 	return results
 }
+
+// resolveExisting handles the copy of a skill at srcPath in t whose name
+// already has a copy in the store at storeCopy, moved there from another
+// target when duplicate is set: an identical copy is removed for sync to
+// replace with the store copy, and a different one is resolved as opts asks,
+// or left in place.
+func (s *MigrateService) resolveExisting(t *Target, result *MigrateMoveResult, srcPath, storeCopy, skillsDir string, duplicate bool, opts MigrateOptions) {
+	fail := func(message string, err error) {
+		result.Action = MigrateActionError
+		result.Message = message
+		result.Error = err
+	}
+
+	if s.sameSkill(t, srcPath, storeCopy, opts.Scope) {
+		if err := s.removeAll(srcPath, opts.DryRun); err != nil {
+			fail("failed to remove the target copy", err)
+			return
+		}
+		result.Action = MigrateActionAdopted
+		result.Message = "identical to the store copy"
+		if duplicate {
+			result.Action = MigrateActionRemoved
+			result.Message = "removed duplicate"
+		}
+		return
+	}
+
+	resolution := opts.Conflict
+	if r, ok := opts.Resolutions[t.Name()+"/"+result.SkillName]; ok {
+		resolution = r
+	}
+	switch resolution {
+	case MigrateConflictKeepStore:
+		if err := s.removeAll(srcPath, opts.DryRun); err != nil {
+			fail("failed to remove the target copy", err)
+			return
+		}
+		result.Action = MigrateActionSkipped
+		result.Message = "kept the store copy"
+
+	case MigrateConflictKeepTarget:
+		result.Action = MigrateActionReplaced
+		result.Message = "replaced the store copy"
+		if opts.DryRun {
+			return
+		}
+		archived, err := s.dedupe.Archive(&skill.Skill{Name: result.SkillName, Path: storeCopy, Scope: opts.Scope})
+		if err != nil {
+			fail("failed to archive the store copy", err)
+			return
+		}
+		result.Message = "replaced the store copy, archived to " + archived
+		s.moveIntoStore(result, srcPath, storeCopy, "")
+
+	case MigrateConflictRename:
+		name := result.SkillName + "-from-" + t.Name()
+		dstPath := s.fs.Join(skillsDir, name)
+		if err := skill.ValidateName(name); err != nil {
+			fail("cannot rename the target copy", err)
+			return
+		}
+		if s.fs.Exists(dstPath) {
+			fail("cannot rename the target copy", fmt.Errorf("path already exists: %s", dstPath))
+			return
+		}
+		result.Action = MigrateActionRenamed
+		result.StoreName = name
+		result.Message = "kept as " + name
+		if !opts.DryRun {
+			s.moveIntoStore(result, srcPath, dstPath, name)
+		}
+
+	default:
+		result.Action = MigrateActionConflict
+		result.Message = "differs from the store copy"
+	}
+}
+
+// moveIntoStore moves the target copy at srcPath to dstPath in the store,
+// renaming the skill in its frontmatter when name is set.
+func (s *MigrateService) moveIntoStore(result *MigrateMoveResult, srcPath, dstPath, name string) {
+	if err := s.fs.Rename(srcPath, dstPath); err != nil {
+		result.Action = MigrateActionError
+		result.Message = "failed to move"
+		result.Error = err
+		return
+	}
+	err := s.stripMovedBanner(dstPath)
+	if err == nil && name != "" {
+		err = s.renameMoved(dstPath, name)
+	}
+	if err != nil {
+		result.Action = MigrateActionError
+		result.Message = "moved, but failed to update SKILL.md"
+		result.Error = err
+	}
+}
+
+// sameSkill reports whether the target copy at srcPath holds the same files
+// as the store copy at storeCopy, or as t deploys that store copy.
+func (s *MigrateService) sameSkill(t *Target, srcPath, storeCopy string, scope skill.Scope) bool {
+	srcSum, err := dirChecksum(s.fs, srcPath)
+	if err != nil {
+		return false
+	}
+	if storeSum, err := dirChecksum(s.fs, storeCopy); err == nil && storeSum == srcSum {
+		return true
+	}
+	sk, err := s.store.FindInScope(s.fs.Base(storeCopy), scope)
+	if err != nil || sk.Path != storeCopy {
+		return false
+	}
+	files, err := t.deployedFiles(sk)
+	return err == nil && filesChecksum(files) == srcSum
+}
+
+// removeAll removes path unless dry-running.
+func (s *MigrateService) removeAll(path string, dryRun bool) error {
+	if dryRun {
+		return nil
+	}
+	return s.fs.RemoveAll(path)
+}
+
+// plannedLinks adds to the results of a dry-run sync the installs a sync
+// would make of the skills a dry run only planned to move into the store,
+// which the dry run reported as extra. Results are ordered by target name,
+// then by skill name.
+func (s *MigrateService) plannedLinks(moves []MigrateMoveResult, results []SyncResult) []SyncResult {
+	planned := make(map[string]bool, len(moves))
+	for _, m := range moves {
+		planned[m.SkillName] = true
+	}
+	results = slices.DeleteFunc(results, func(r SyncResult) bool {
+		return r.Action == SyncActionExtra && planned[r.SkillName]
+	})
+	for _, m := range moves {
+		name := m.SkillName
+		switch m.Action {
+		case MigrateActionMoved:
+		case MigrateActionRenamed:
+			name = m.StoreName
+		default:
+			continue
+		}
+		for _, t := range s.targets.GetAll() {
+			results = append(results, SyncResult{SkillName: name, Target: t.Name(), Action: SyncActionInstall})
+		}
+	}
+	slices.SortStableFunc(results, func(a, b SyncResult) int {
+		return cmp.Or(cmp.Compare(a.Target, b.Target), cmp.Compare(a.SkillName, b.SkillName))
+	})
+	return results
+}
+
+// renameMoved sets the name in the SKILL.md frontmatter of a skill moved into
+// the store under a new name.
+func (s *MigrateService) renameMoved(dir, name string) error {
+	path := s.fs.Join(dir, "SKILL.md")
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		// Nested layouts keep SKILL.md deeper; the directory name still applies.
+		return nil
+	}
+	updated, err := skill.SetFrontmatterKey(data, "name", name)
+	if err != nil {
+		return err
+	}
+	return s.fs.WriteFile(path, updated, 0o644)
+}
+
+// stripMovedBanner removes the skillet banner from the SKILL.md of a skill moved into the store.
+func (s *MigrateService) stripMovedBanner(dir string) error {
+	path := s.fs.Join(dir, "SKILL.md")
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		// Nested layouts keep SKILL.md deeper and never carry a banner.
+		return nil
+	}
+	stripped := stripBanner(data)
+	if len(stripped) == len(data) {
+		return nil
+	}
+	return s.fs.WriteFile(path, stripped, 0o644)
+}
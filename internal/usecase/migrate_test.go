@@ -1,6 +1,8 @@
 package usecase_test
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/wwwyo/skillet/internal/config"
@@ -46,3 +48,103 @@ func TestFindSkillsToMigrateFindsDirectorySkill(t *testing.T) {
 		t.Fatalf("expected 1 skill, got %d", len(found["claude"]))
 	}
 }
+
+func TestMigrateDryRun(t *testing.T) {
+	mock, svc := setupMigrateEnv()
+	mock.Dirs["/home/test/.claude/skills/my-skill"] = true
+	mock.Files["/home/test/.claude/skills/my-skill/SKILL.md"] = []byte("---\nname: my-skill\n---\n")
+	mock.Dirs["/home/test/.codex/skills/my-skill"] = true
+	mock.Files["/home/test/.codex/skills/my-skill/SKILL.md"] = []byte("---\nname: my-skill\n---\n")
+
+	opts := usecase.MigrateOptions{Scope: skill.ScopeGlobal, DryRun: true}
+	result, err := svc.Migrate(t.Context(), opts, svc.FindSkillsToMigrate(opts))
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	var actions []string
+	for _, m := range result.MoveResults {
+		actions = append(actions, m.FromTarget+":"+string(m.Action))
+	}
+	if got := strings.Join(actions, ","); got != "claude:moved,codex:removed" {
+		t.Errorf("moves = %s, want claude:moved,codex:removed", got)
+	}
+	var links []string
+	for _, r := range result.SyncResults {
+		links = append(links, r.Target+"/"+r.SkillName+":"+string(r.Action))
+	}
+	if !slices.Contains(links, "claude/my-skill:install") || !slices.Contains(links, "codex/my-skill:install") || slices.ContainsFunc(links, func(l string) bool { return strings.HasSuffix(l, ":extra") }) {
+		t.Errorf("sync results = %v, want installs into claude and codex and no extras", links)
+	}
+	if !mock.Exists("/home/test/.claude/skills/my-skill/SKILL.md") || !mock.Exists("/home/test/.codex/skills/my-skill") || mock.Exists("/home/test/.agents/skills/my-skill") {
+		t.Error("a dry run must not move or remove skills")
+	}
+}
+
+func TestMigrateConflicts(t *testing.T) {
+	setup := func() (*platformfs.MockFileSystem, *usecase.MigrateService) {
+		mock, svc := setupMigrateEnv()
+		addGlobalSkill(mock, "my-skill")
+		// claude holds an identical copy, codex one that differs.
+		mock.Dirs["/home/test/.claude/skills/my-skill"] = true
+		mock.Files["/home/test/.claude/skills/my-skill/SKILL.md"] = []byte("---\nname: my-skill\n---\n")
+		mock.Dirs["/home/test/.codex/skills/my-skill"] = true
+		mock.Files["/home/test/.codex/skills/my-skill/SKILL.md"] = []byte("---\nname: my-skill\n---\nEdited in codex.\n")
+		return mock, svc
+	}
+	opts := usecase.MigrateOptions{Scope: skill.ScopeGlobal}
+
+	mock, svc := setup()
+	found := svc.FindSkillsToMigrate(opts)
+	conflicts, err := svc.Conflicts(opts, found)
+	if err != nil || len(conflicts) != 1 || conflicts[0].FromTarget != "codex" {
+		t.Fatalf("Conflicts() = %+v, %v, want the codex copy", conflicts, err)
+	}
+	if _, err := svc.Migrate(t.Context(), opts, found); err == nil || !strings.Contains(err.Error(), "codex/my-skill") {
+		t.Fatalf("Migrate() error = %v, want the unresolved codex copy", err)
+	}
+	if !mock.Exists("/home/test/.claude/skills/my-skill/SKILL.md") {
+		t.Fatal("a migration with unresolved conflicts must not change anything")
+	}
+
+	tests := []struct {
+		conflict usecase.MigrateConflict
+		action   usecase.MigrateAction
+		store    map[string]string
+	}{
+		{usecase.MigrateConflictKeepStore, usecase.MigrateActionSkipped, map[string]string{
+			"/home/test/.agents/skills/my-skill/SKILL.md": "---\nname: my-skill\n---\n",
+		}},
+		{usecase.MigrateConflictKeepTarget, usecase.MigrateActionReplaced, map[string]string{
+			"/home/test/.agents/skills/my-skill/SKILL.md":   "---\nname: my-skill\n---\nEdited in codex.\n",
+			"/home/test/.agents/.archive/my-skill/SKILL.md": "---\nname: my-skill\n---\n",
+		}},
+		{usecase.MigrateConflictRename, usecase.MigrateActionRenamed, map[string]string{
+			"/home/test/.agents/skills/my-skill/SKILL.md":            "---\nname: my-skill\n---\n",
+			"/home/test/.agents/skills/my-skill-from-codex/SKILL.md": "---\nname: my-skill-from-codex\n---\nEdited in codex.\n",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.conflict), func(t *testing.T) {
+			mock, svc := setup()
+			resolved := opts
+			resolved.Resolutions = map[string]usecase.MigrateConflict{"codex/my-skill": tt.conflict}
+			result, err := svc.Migrate(t.Context(), resolved, svc.FindSkillsToMigrate(opts))
+			if err != nil {
+				t.Fatalf("Migrate() error = %v", err)
+			}
+			var actions []string
+			for _, m := range result.MoveResults {
+				actions = append(actions, m.FromTarget+":"+string(m.Action))
+			}
+			if got, want := strings.Join(actions, ","), "claude:adopted,codex:"+string(tt.action); got != want {
+				t.Errorf("moves = %s, want %s", got, want)
+			}
+			for path, want := range tt.store {
+				if got, err := mock.ReadFile(path); err != nil || string(got) != want {
+					t.Errorf("%s = %q, %v, want %q", path, got, err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// MigrateSourceEntry is an entry of one of a target's migrate sources (see
+// config.MigrateSource), which migrate moves into the store as a skill when
+// MigrateOptions.AllSources is set.
+type MigrateSourceEntry struct {
+	Target string
+	Kind   config.MigrateSourceKind
+	// Source is the entry's path relative to the target root (e.g.
+	// commands/review.md)
+	Source string
+	// Path is the entry's file, or its directory for the skills kind
+	Path string
+	// SkillName is the name of the skill it becomes, in the source's collection
+	SkillName string
+}
+
+// FindSourcesToMigrate finds the entries of the targets' migrate sources, in
+// target and source order.
+func (s *MigrateService) FindSourcesToMigrate(opts MigrateOptions) []MigrateSourceEntry {
+	var entries []MigrateSourceEntry
+	for _, t := range s.targets.GetAll() {
+		root, err := t.GetRootPath(opts.Scope)
+		if err != nil {
+			continue
+		}
+		for _, src := range t.migrateSources {
+			found, err := s.listSource(t, root, src)
+			if err != nil {
+				continue
+			}
+			entries = append(entries, found...)
+		}
+	}
+	return entries
+}
+
+// listSource lists the entries of the migrate source src of t, whose root
+// directory is root.
+func (s *MigrateService) listSource(t *Target, root string, src config.MigrateSource) ([]MigrateSourceEntry, error) {
+	dir := s.fs.Join(root, src.Dir)
+	collection := src.Collection
+	if collection == "" {
+		collection = s.fs.Base(src.Dir)
+	}
+
+	var rels []string
+	var err error
+	if src.Kind == config.MigrateSourceSkills {
+		rels, err = unmanagedSkillDirs(s.fs, dir)
+	} else {
+		rels, err = listCommands(s.fs, dir, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]MigrateSourceEntry, 0, len(rels))
+	for _, rel := range rels {
+		entries = append(entries, MigrateSourceEntry{
+			Target:    t.Name(),
+			Kind:      src.Kind,
+			Source:    s.fs.Join(src.Dir, rel),
+			Path:      s.fs.Join(dir, rel),
+			SkillName: collection + skill.CollectionSeparator + commandSkillName(rel),
+		})
+	}
+	return entries, nil
+}
+
+// migrateSources moves the entries of migrate sources into the store at
+// skillsDir. Entries whose skill the store already has, or gets from an
+// earlier entry, are skipped and left in place.
+func (s *MigrateService) migrateSources(skillsDir string, entries []MigrateSourceEntry, dryRun bool) []MigrateMoveResult {
+	moved := make(map[string]bool)
+	results := make([]MigrateMoveResult, 0, len(entries))
+	for _, e := range entries {
+		result := MigrateMoveResult{SkillName: e.SkillName, FromTarget: e.Target, Source: e.Source}
+		dst := s.fs.Join(append([]string{skillsDir}, strings.Split(e.SkillName, skill.CollectionSeparator)...)...)
+
+		if err := skill.ValidateQualifiedName(e.SkillName); err != nil {
+			result.Action = MigrateActionError
+			result.Message = "invalid skill name"
+			result.Error = err
+		} else if collection := s.fs.Dir(dst); s.fs.Exists(s.fs.Join(collection, "SKILL.md")) {
+			result.Action = MigrateActionError
+			result.Message = "cannot store it in a collection"
+			result.Error = fmt.Errorf("%s is a skill, not a collection", collection)
+		} else if moved[e.SkillName] || s.fs.Exists(dst) {
+			result.Action = MigrateActionSkipped
+			result.Message = "already in the store"
+		} else {
+			moved[e.SkillName] = true
+			s.migrateSourceEntry(&result, e, dst, dryRun)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// migrateSourceEntry moves the entry e into the store at dst: a skill
+// directory as is, and a command or agent file converted into a SKILL.md.
+func (s *MigrateService) migrateSourceEntry(result *MigrateMoveResult, e MigrateSourceEntry, dst string, dryRun bool) {
+	fail := func(message string, err error) {
+		result.Action = MigrateActionError
+		result.Message = message
+		result.Error = err
+	}
+
+	var content []byte
+	if e.Kind != config.MigrateSourceSkills {
+		data, err := s.fs.ReadFile(e.Path)
+		if err != nil {
+			fail("failed to read", err)
+			return
+		}
+		if content, err = sourceSkillFile(e.Kind, path.Base(e.SkillName), data); err != nil {
+			fail("failed to convert", err)
+			return
+		}
+	}
+	result.Action = MigrateActionMoved
+	result.Message = "from " + e.Source
+	if dryRun {
+		return
+	}
+
+	if e.Kind == config.MigrateSourceSkills {
+		if err := s.fs.MkdirAll(s.fs.Dir(dst), 0o755); err != nil {
+			fail("failed to create the collection directory", err)
+			return
+		}
+		if err := s.fs.Rename(e.Path, dst); err != nil {
+			fail("failed to move", err)
+			return
+		}
+		if err := s.stripMovedBanner(dst); err != nil {
+			fail("moved, but failed to remove the skillet banner", err)
+		}
+		return
+	}
+
+	if err := s.fs.MkdirAll(dst, 0o755); err != nil {
+		fail("failed to create the skill directory", err)
+		return
+	}
+	if err := s.fs.WriteFile(s.fs.Join(dst, "SKILL.md"), content, 0o644); err != nil {
+		fail("failed to write SKILL.md", err)
+		return
+	}
+	if err := s.fs.Remove(e.Path); err != nil {
+		fail("converted, but failed to remove the original", err)
+	}
+}
+
+// sourceSkillFile converts a command or agent file into the SKILL.md of the
+// skill name. The description comes from the file's frontmatter or first
+// line, and an agent's tools become the skill's allowed-tools.
+func sourceSkillFile(kind config.MigrateSourceKind, name string, data []byte) ([]byte, error) {
+	description, body := commandDescription(string(data))
+	content, err := skill.FormatSkillFile(name, description, body)
+	if err != nil || kind != config.MigrateSourceAgents {
+		return content, err
+	}
+
+	var meta struct {
+		Tools string `yaml:"tools"`
+	}
+	frontmatter, _ := skill.SplitFrontmatter(string(data))
+	if frontmatter == "" || yaml.Unmarshal([]byte(frontmatter), &meta) != nil || strings.TrimSpace(meta.Tools) == "" {
+		return content, nil
+	}
+	return skill.SetFrontmatterKey(content, "allowed-tools", strings.TrimSpace(meta.Tools))
+}
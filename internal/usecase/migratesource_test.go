@@ -0,0 +1,93 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestMigrateAllSources(t *testing.T) {
+	mock, svc := setupMigrateEnv()
+	mock.Dirs["/home/test/.claude/commands"] = true
+	mock.Dirs["/home/test/.claude/commands/git"] = true
+	mock.Files["/home/test/.claude/commands/review.md"] = []byte("---\ndescription: Review the diff\n---\nReview it.\n")
+	mock.Files["/home/test/.claude/commands/git/commit.md"] = []byte("# Write a commit\n")
+	mock.Dirs["/home/test/.claude/agents"] = true
+	mock.Files["/home/test/.claude/agents/tester.md"] = []byte("---\nname: tester\ndescription: Runs tests\ntools: Bash, Read\n---\nYou run tests.\n")
+
+	opts := usecase.MigrateOptions{Scope: skill.ScopeGlobal, AllSources: true}
+	var found []string
+	for _, e := range svc.FindSourcesToMigrate(opts) {
+		found = append(found, e.Target+":"+e.Source+"="+e.SkillName)
+	}
+	want := "claude:commands/git/commit.md=commands/git-commit,claude:commands/review.md=commands/review,claude:agents/tester.md=agents/tester"
+	if got := strings.Join(found, ","); got != want {
+		t.Fatalf("FindSourcesToMigrate() = %s, want %s", got, want)
+	}
+
+	result, err := svc.Migrate(t.Context(), opts, nil)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	for _, m := range result.MoveResults {
+		if m.Action != usecase.MigrateActionMoved {
+			t.Errorf("%s: action = %s (%s: %v), want moved", m.Source, m.Action, m.Message, m.Error)
+		}
+	}
+
+	review := string(mock.Files["/home/test/.agents/skills/commands/review/SKILL.md"])
+	if !strings.Contains(review, "name: review") || !strings.Contains(review, "description: Review the diff") || !strings.HasSuffix(review, "Review it.\n") {
+		t.Errorf("commands/review SKILL.md = %q", review)
+	}
+	tester := string(mock.Files["/home/test/.agents/skills/agents/tester/SKILL.md"])
+	if !strings.Contains(tester, "allowed-tools: Bash, Read") || !strings.Contains(tester, "description: Runs tests") {
+		t.Errorf("agents/tester SKILL.md = %q", tester)
+	}
+	if mock.Exists("/home/test/.claude/commands/review.md") || mock.Exists("/home/test/.claude/agents/tester.md") {
+		t.Error("migrated files should be removed from the target")
+	}
+	if _, ok := mock.Symlinks["/home/test/.claude/skills/commands-review"]; !ok {
+		t.Error("the migrated command should be synced into claude as commands-review")
+	}
+}
+
+func TestMigrateConfiguredSource(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	for _, dir := range []string{"/home/test/.agents", "/home/test/.agents/skills", "/home/test/.agents/skills/legacy", "/home/test/.codex", "/home/test/.codex/prompts"} {
+		mock.Dirs[dir] = true
+	}
+	mock.Dirs["/home/test/.agents/skills/legacy/existing"] = true
+	mock.Files["/home/test/.agents/skills/legacy/existing/SKILL.md"] = []byte("---\nname: existing\n---\n")
+	for _, name := range []string{"existing", "fresh"} {
+		mock.Dirs["/home/test/.codex/prompts/"+name] = true
+		mock.Files["/home/test/.codex/prompts/"+name+"/SKILL.md"] = []byte("---\nname: " + name + "\n---\n")
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Targets["codex"] = config.TargetConfig{
+		Enabled:        true,
+		GlobalPath:     "~/.codex",
+		MigrateSources: []config.MigrateSource{{Dir: "prompts", Kind: config.MigrateSourceSkills, Collection: "legacy"}},
+	}
+	svc := usecase.NewMigrateService(mock, cfg, "", usecase.NewSyncService(mock, cfg, ""))
+
+	result, err := svc.Migrate(t.Context(), usecase.MigrateOptions{Scope: skill.ScopeGlobal, AllSources: true, DryRun: true}, nil)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	var actions []string
+	for _, m := range result.MoveResults {
+		actions = append(actions, m.SkillName+":"+string(m.Action))
+	}
+	if got := strings.Join(actions, ","); got != "legacy/existing:skipped,legacy/fresh:moved" {
+		t.Errorf("moves = %s, want legacy/existing:skipped,legacy/fresh:moved", got)
+	}
+	if mock.Exists("/home/test/.agents/skills/legacy/fresh") || !mock.Exists("/home/test/.codex/prompts/fresh/SKILL.md") {
+		t.Error("a dry run must not move skills")
+	}
+}
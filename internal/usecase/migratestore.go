@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// storeEntries are the entries of a global agents directory that belong to
+// skillet and move with the store. Anything else, including a legacy
+// skillet.yaml, is left where it is.
+var storeEntries = []string{
+	config.SkillsDirName,
+	templatesDirName,
+	reportsDirName,
+	archiveDirName,
+	LockFileName,
+	SkillsetFileName,
+}
+
+// MigrateStoreOptions contains options for moving the global store.
+type MigrateStoreOptions struct {
+	// DryRun only reports what would be moved and relinked
+	DryRun bool
+}
+
+// RelinkResult is a target symlink into the old store that was pointed at
+// the moved skill.
+type RelinkResult struct {
+	SkillName string
+	Target    string
+	Error     error
+}
+
+// MigrateStoreResult describes a move of the global store.
+type MigrateStoreResult struct {
+	From string
+	To   string
+	// Moved lists the entries of From moved to To
+	Moved []string
+	// Relinked lists the target symlinks into the old store, ordered by
+	// target name, then by skill name
+	Relinked []RelinkResult
+}
+
+// MigrateStoreService moves a global store from the legacy ~/.agents to the XDG
+// data directory.
+type MigrateStoreService struct {
+	fs  platformfs.FileSystem
+	cfg *config.Config
+}
+
+// NewMigrateStoreService creates a new migrate-store service.
+func NewMigrateStoreService(fsys platformfs.FileSystem, cfg *config.Config) *MigrateStoreService {
+	return &MigrateStoreService{fs: fsys, cfg: cfg}
+}
+
+// Migrate moves the skillet entries of the legacy global store to
+// config.DefaultGlobalPath and points the symlinks targets have into the old
+// store at the new one. It refuses when the config sets a global path, which
+// it would not follow, and when a store already exists at the destination.
+func (s *MigrateStoreService) Migrate(opts MigrateStoreOptions) (*MigrateStoreResult, error) {
+	if s.cfg.GlobalPath != "" {
+		return nil, fmt.Errorf("the config sets globalPath to %s; only a store at the default location can be moved", s.cfg.GlobalPath)
+	}
+	from, err := config.ExpandPath(s.fs, config.LegacyGlobalPath)
+	if err != nil {
+		return nil, err
+	}
+	to, err := config.ExpandPath(s.fs, config.DefaultGlobalPath)
+	if err != nil {
+		return nil, err
+	}
+	if config.DetectGlobalPath(s.fs) != config.LegacyGlobalPath {
+		if s.fs.IsDir(s.fs.Join(from, config.SkillsDirName)) {
+			return nil, fmt.Errorf("a store already exists at %s; merge %s into it by hand", to, from)
+		}
+		return nil, fmt.Errorf("no store at %s to move", from)
+	}
+
+	result := &MigrateStoreResult{From: from, To: to}
+	for _, name := range storeEntries {
+		if s.fs.Exists(s.fs.Join(from, name)) {
+			result.Moved = append(result.Moved, name)
+		}
+	}
+
+	// Links are found before the move, while they still resolve.
+	type link struct {
+		target *Target
+		name   string
+	}
+	var links []link
+	skills, err := skill.NewStore(s.fs, s.cfg, "").GetByScope(skill.ScopeGlobal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	for _, t := range NewTargetRegistry(s.fs, "", s.cfg).GetAll() {
+		for _, sk := range skills {
+			path, err := t.GetInstallPath(sk.Name, sk.Scope)
+			if err == nil && s.fs.IsSymlink(path) && t.linksTo(sk) {
+				links = append(links, link{target: t, name: sk.Name})
+				result.Relinked = append(result.Relinked, RelinkResult{SkillName: sk.Name, Target: t.Name()})
+			}
+		}
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if err := s.fs.MkdirAll(to, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", to, err)
+	}
+	for _, name := range result.Moved {
+		if err := s.fs.Rename(s.fs.Join(from, name), s.fs.Join(to, name)); err != nil {
+			return nil, fmt.Errorf("failed to move %s to %s: %w", name, to, err)
+		}
+	}
+	if entries, err := s.fs.ReadDir(from); err == nil && len(entries) == 0 {
+		_ = s.fs.Remove(from)
+	}
+
+	// With the store moved, the global path now resolves to the new location.
+	moved, err := skill.NewStore(s.fs, s.cfg, "").GetByScope(skill.ScopeGlobal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	byName := make(map[string]*skill.Skill, len(moved))
+	for _, sk := range moved {
+		byName[sk.Name] = sk
+	}
+	for i, l := range links {
+		sk, ok := byName[l.name]
+		if !ok {
+			result.Relinked[i].Error = fmt.Errorf("skill %s not found in %s", l.name, to)
+			continue
+		}
+		result.Relinked[i].Error = l.target.Install(sk, InstallOptions{Strategy: config.StrategySymlink, Force: true})
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestMigrateStore(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	addGlobalSkill(mock, "review")
+	mock.Files["/home/test/.agents/skillet.lock"] = []byte("version: 1\n")
+	mock.Files["/home/test/.agents/notes.md"] = []byte("not skillet's\n")
+	if _, err := svc.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	cfg := config.DefaultConfig()
+
+	result, err := usecase.NewMigrateStoreService(mock, cfg).Migrate(usecase.MigrateStoreOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Migrate() dry run error = %v", err)
+	}
+	if strings.Join(result.Moved, ",") != "skills,skillet.lock" || len(result.Relinked) != 2 {
+		t.Errorf("Migrate() dry run = %+v", result)
+	}
+	if !mock.IsDir("/home/test/.agents/skills/review") {
+		t.Fatal("dry run should not move the store")
+	}
+
+	result, err = usecase.NewMigrateStoreService(mock, cfg).Migrate(usecase.MigrateStoreOptions{})
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if result.To != "/home/test/.local/share/skillet" {
+		t.Errorf("Migrate() To = %q", result.To)
+	}
+	for _, r := range result.Relinked {
+		if r.Error != nil {
+			t.Errorf("relinking %s/%s: %v", r.Target, r.SkillName, r.Error)
+		}
+	}
+	if !mock.Exists("/home/test/.local/share/skillet/skills/review/SKILL.md") || !mock.Exists("/home/test/.local/share/skillet/skillet.lock") {
+		t.Error("store was not moved")
+	}
+	if !mock.Exists("/home/test/.agents/notes.md") {
+		t.Error("files skillet does not own should stay in ~/.agents")
+	}
+	for _, target := range []string{"claude", "codex"} {
+		link, err := mock.Readlink("/home/test/." + target + "/skills/review")
+		if err != nil || link != "/home/test/.local/share/skillet/skills/review" {
+			t.Errorf("%s link = %q, %v, want the moved skill", target, link, err)
+		}
+	}
+
+	if _, err := usecase.NewMigrateStoreService(mock, cfg).Migrate(usecase.MigrateStoreOptions{}); err == nil {
+		t.Error("Migrate() with no legacy store should fail")
+	}
+}
+
+func TestMigrateStoreRefuses(t *testing.T) {
+	t.Run("configured global path", func(t *testing.T) {
+		mock, _ := setupSyncEnv()
+		cfg := config.DefaultConfig()
+		cfg.GlobalPath = config.LegacyGlobalPath
+		if _, err := usecase.NewMigrateStoreService(mock, cfg).Migrate(usecase.MigrateStoreOptions{}); err == nil || !strings.Contains(err.Error(), "globalPath") {
+			t.Errorf("Migrate() error = %v, want configured globalPath error", err)
+		}
+	})
+
+	t.Run("existing destination", func(t *testing.T) {
+		mock, _ := setupSyncEnv()
+		mock.Dirs["/home/test/.local/share/skillet/skills"] = true
+		_, err := usecase.NewMigrateStoreService(mock, config.DefaultConfig()).Migrate(usecase.MigrateStoreOptions{})
+		if err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("Migrate() error = %v, want existing store error", err)
+		}
+		if !mock.IsDir("/home/test/.agents/skills") {
+			t.Error("legacy store should be left in place")
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// MoveScopeOptions contains options for moving a skill between the project
+// and global scopes.
+type MoveScopeOptions struct {
+	// Name is the skill to move
+	Name string
+	// To is the scope to move the skill into: global to promote a project
+	// skill, project to demote a global one
+	To skill.Scope
+	// Force archives a skill with the same name in the destination scope
+	// instead of refusing to move
+	Force bool
+	// DryRun only shows what would be done without making changes
+	DryRun bool
+}
+
+// MoveScopeResult describes a skill moved between scopes.
+type MoveScopeResult struct {
+	// Skill is the moved skill, at its new path and scope
+	Skill *skill.Skill
+	// From is the scope the skill was moved out of
+	From skill.Scope
+	// Replaced is the skill of the same name archived from the destination
+	// scope (nil when there was none)
+	Replaced *skill.Skill
+	// ArchivedTo is where Replaced was archived (empty on a dry run)
+	ArchivedTo string
+	// Uninstalled holds the installs of the skill in its old scope that were
+	// removed from targets
+	Uninstalled []SyncResult
+}
+
+// MoveScopeService moves skills between the project and global scopes.
+type MoveScopeService struct {
+	fs     platformfs.FileSystem
+	cfg    *config.Config
+	root   string
+	store  *skill.Store
+	sync   *SyncService
+	dedupe *DedupeService
+}
+
+// NewMoveScopeService creates a new scope move service.
+func NewMoveScopeService(fsys platformfs.FileSystem, cfg *config.Config, root string) *MoveScopeService {
+	return &MoveScopeService{
+		fs:     fsys,
+		cfg:    cfg,
+		root:   root,
+		store:  skill.NewStore(fsys, cfg, root),
+		sync:   NewSyncService(fsys, cfg, root),
+		dedupe: NewDedupeService(fsys, cfg, root),
+	}
+}
+
+// Move moves the named skill directory from the other scope into opts.To,
+// keeping its category and collection, and uninstalls it from the targets'
+// directories for the old scope. A sync of the skill then installs it for the
+// new scope and re-resolves it against the other scopes.
+//
+// A skill with the same name in the destination scope makes Move fail unless
+// opts.Force is set, in which case that skill is moved to the .archive
+// directory of its store first.
+func (s *MoveScopeService) Move(ctx context.Context, opts MoveScopeOptions) (*MoveScopeResult, error) {
+	from := skill.ScopeProject
+	switch opts.To {
+	case skill.ScopeGlobal:
+	case skill.ScopeProject:
+		from = skill.ScopeGlobal
+	default:
+		return nil, fmt.Errorf("skills can only move between the project and global scopes, not to %s", opts.To)
+	}
+	if s.root == "" {
+		return nil, fmt.Errorf("not in a project directory")
+	}
+
+	sk, err := s.store.FindInScope(opts.Name, from)
+	if err != nil {
+		return nil, err
+	}
+	if sk.Vendored {
+		return nil, fmt.Errorf("skill %s is a vendored copy; remove it from the vendor directory instead", sk.Name)
+	}
+
+	_, fromDir, err := writableStoreDirs(s.fs, s.cfg, s.root, from)
+	if err != nil {
+		return nil, err
+	}
+	_, toDir, err := writableStoreDirs(s.fs, s.cfg, s.root, opts.To)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := s.fs.Rel(fromDir, sk.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate skill %s in its store: %w", sk.Name, err)
+	}
+	dest := s.fs.Join(toDir, rel)
+
+	result := &MoveScopeResult{From: from}
+	if existing, err := s.store.FindInScope(sk.Name, opts.To); err == nil {
+		if !opts.Force {
+			return nil, fmt.Errorf("skill %s already exists in %s scope: %s (use --force to archive it and move anyway)", sk.Name, opts.To, existing.Path)
+		}
+		result.Replaced = existing
+	} else if s.fs.Exists(dest) {
+		return nil, fmt.Errorf("path already exists: %s", dest)
+	}
+
+	// Installs for the old scope are removed while they still point at the
+	// store, so symlinks are recognized as skillet's.
+	for _, t := range s.sync.targets.GetAll() {
+		if t.IsInstalledInScope(sk.Name, from) && t.manages(sk) {
+			result.Uninstalled = append(result.Uninstalled, s.sync.uninstall(ctx, t, sk.Name, t.entryName(sk.Name), from, opts.DryRun))
+		}
+	}
+
+	moved := *sk
+	moved.Path = dest
+	moved.Scope = opts.To
+	result.Skill = &moved
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if result.Replaced != nil {
+		if result.ArchivedTo, err = s.dedupe.Archive(result.Replaced); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.fs.MkdirAll(s.fs.Dir(dest), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := s.fs.Rename(sk.Path, dest); err != nil {
+		return nil, fmt.Errorf("failed to move skill: %w", err)
+	}
+	return result, nil
+}
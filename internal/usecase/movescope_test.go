@@ -0,0 +1,85 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestMoveScope(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	for _, dir := range []string{"/project", "/project/.agents", "/project/.agents/skills", "/project/.agents/skills/optional", "/project/.claude", "/project/.claude/skills", "/project/.codex", "/project/.codex/skills"} {
+		mock.Dirs[dir] = true
+	}
+	mock.Dirs["/project/.agents/skills/local"] = true
+	mock.Files["/project/.agents/skills/local/SKILL.md"] = []byte("---\nname: local\n---\nproject copy\n")
+	addGlobalSkill(mock, "shared")
+	mock.Dirs["/project/.agents/skills/shared"] = true
+	mock.Files["/project/.agents/skills/shared/SKILL.md"] = []byte("---\nname: shared\n---\nproject copy\n")
+	cfg := config.DefaultConfig()
+	sync := func() {
+		t.Helper()
+		if _, err := usecase.NewSyncService(mock, cfg, "/project").Sync(t.Context(), usecase.SyncOptions{Names: []string{"local", "shared"}, Force: true}); err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+	}
+	sync()
+	if !mock.IsSymlink("/project/.claude/skills/local") {
+		t.Fatal("local should be installed into the project's claude skills")
+	}
+	svc := usecase.NewMoveScopeService(mock, cfg, "/project")
+
+	result, err := svc.Move(t.Context(), usecase.MoveScopeOptions{Name: "local", To: skill.ScopeGlobal, DryRun: true})
+	if err != nil {
+		t.Fatalf("Move() dry run error = %v", err)
+	}
+	if len(result.Uninstalled) != 2 || !mock.Exists("/project/.agents/skills/local") || !mock.IsSymlink("/project/.claude/skills/local") {
+		t.Fatalf("dry run should plan two uninstalls and change nothing, got %+v", result)
+	}
+
+	result, err = svc.Move(t.Context(), usecase.MoveScopeOptions{Name: "local", To: skill.ScopeGlobal})
+	if err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+	if result.Skill.Scope != skill.ScopeGlobal || result.Skill.Path != "/home/test/.agents/skills/local" {
+		t.Errorf("moved skill = %+v, want it in the global store", result.Skill)
+	}
+	if mock.Exists("/project/.agents/skills/local") || !mock.Exists("/home/test/.agents/skills/local/SKILL.md") {
+		t.Error("local should be moved to the global store")
+	}
+	if mock.Exists("/project/.claude/skills/local") {
+		t.Error("local should be uninstalled from the project's claude skills")
+	}
+	sync()
+	if !mock.IsSymlink("/home/test/.claude/skills/local") {
+		t.Error("local should be installed into the global claude skills after sync")
+	}
+
+	_, err = svc.Move(t.Context(), usecase.MoveScopeOptions{Name: "shared", To: skill.ScopeGlobal})
+	if err == nil || !strings.Contains(err.Error(), "already exists in global scope") {
+		t.Fatalf("Move() error = %v, want a conflict with the global copy", err)
+	}
+	result, err = svc.Move(t.Context(), usecase.MoveScopeOptions{Name: "shared", To: skill.ScopeGlobal, Force: true})
+	if err != nil {
+		t.Fatalf("Move() with force error = %v", err)
+	}
+	if result.ArchivedTo != "/home/test/.agents/.archive/shared" || !mock.Exists("/home/test/.agents/.archive/shared/SKILL.md") {
+		t.Errorf("ArchivedTo = %q, want the global copy archived", result.ArchivedTo)
+	}
+	if got := string(mock.Files["/home/test/.agents/skills/shared/SKILL.md"]); !strings.Contains(got, "project copy") {
+		t.Errorf("global shared = %q, want the project copy", got)
+	}
+
+	if _, err := svc.Move(t.Context(), usecase.MoveScopeOptions{Name: "shared", To: skill.ScopeProject}); err != nil {
+		t.Fatalf("Move() demote error = %v", err)
+	}
+	if !mock.Exists("/project/.agents/skills/shared/SKILL.md") || mock.Exists("/home/test/.agents/skills/shared") {
+		t.Error("shared should be moved back into the project store")
+	}
+	if _, err := usecase.NewMoveScopeService(mock, cfg, "").Move(t.Context(), usecase.MoveScopeOptions{Name: "local", To: skill.ScopeProject}); err == nil {
+		t.Error("Move() outside a project should fail")
+	}
+}
@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// OptionalOptions contains options for enabling or disabling an optional skill.
+type OptionalOptions struct {
+	// Name is the optional skill to enable or disable
+	Name string
+	// Target limits the change to a single target (empty for every enabled target)
+	Target string
+	// Enable installs the skill into the targets when true, and removes it when false
+	Enable bool
+}
+
+// OptionalResult describes the targets an optional skill was enabled or disabled for.
+type OptionalResult struct {
+	Skill *skill.Skill
+	// Changed lists the targets whose setting changed, sorted by name
+	Changed []string
+	// Unchanged lists the targets that already had the requested setting
+	Unchanged []string
+}
+
+// OptionalService enables and disables optional skills per target in the config.
+type OptionalService struct {
+	fs          platformfs.FileSystem
+	cfg         *config.Config
+	store       *skill.Store
+	targets     *TargetRegistry
+	configStore *config.Store
+}
+
+// NewOptionalService creates a new optional skill service.
+func NewOptionalService(fsys platformfs.FileSystem, cfg *config.Config, root string) *OptionalService {
+	return &OptionalService{
+		fs:          fsys,
+		cfg:         cfg,
+		store:       skill.NewStore(fsys, cfg, root),
+		targets:     NewTargetRegistry(fsys, root, cfg),
+		configStore: config.NewStore(fsys),
+	}
+}
+
+// SetEnabled adds the skill to, or removes it from, the optional skills of
+// the selected targets and saves the config to configPath when it changed.
+// Sync then installs or uninstalls the skill.
+func (s *OptionalService) SetEnabled(opts OptionalOptions, configPath string) (*OptionalResult, error) {
+	sk, err := s.store.GetByName(opts.Name)
+	if err != nil {
+		return nil, fmt.Errorf("skill not found: %w", err)
+	}
+	if sk.Category != skill.CategoryOptional {
+		return nil, fmt.Errorf("skill %s is not optional; default skills are installed into every target", sk.Name)
+	}
+
+	targets, err := s.selectTargets(opts.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &OptionalResult{Skill: sk}
+	for _, t := range targets {
+		tc := s.cfg.Targets[t.Name()]
+		enabled := slices.Contains(tc.Optional, sk.Name)
+		if enabled == opts.Enable {
+			result.Unchanged = append(result.Unchanged, t.Name())
+			continue
+		}
+		tc.Optional = setOptional(tc.Optional, sk.Name, opts.Enable)
+		s.cfg.Targets[t.Name()] = tc
+		result.Changed = append(result.Changed, t.Name())
+	}
+
+	if len(result.Changed) > 0 {
+		changes := make([]OptionalChange, 0, len(result.Changed))
+		for _, name := range result.Changed {
+			changes = append(changes, OptionalChange{Skill: sk.Name, Target: name, Enable: opts.Enable})
+		}
+		if err := s.save(configPath, changes); err != nil {
+			return nil, fmt.Errorf("failed to update config file: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// OptionalChoice lists the optional skills that can be enabled for one target.
+type OptionalChoice struct {
+	Target string
+	// Skills holds the names of the optional skills in the store, sorted
+	Skills []string
+	// Enabled holds the names in Skills that are enabled for the target
+	Enabled []string
+}
+
+// OptionalChange is a skill enabled or disabled for a target by Select.
+type OptionalChange struct {
+	Skill  string
+	Target string
+	Enable bool
+}
+
+// Choices returns the optional skills and the ones enabled for every enabled
+// target, or only for target when it is not empty, ordered by target name.
+func (s *OptionalService) Choices(target string) ([]OptionalChoice, error) {
+	skills, err := s.store.GetResolved()
+	var conflictErr *skill.ConflictError
+	if err != nil && !errors.As(err, &conflictErr) {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	var names []string
+	for _, sk := range skills {
+		if sk.Category == skill.CategoryOptional {
+			names = append(names, sk.Name)
+		}
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	targets, err := s.selectTargets(target)
+	if err != nil {
+		return nil, err
+	}
+	choices := make([]OptionalChoice, 0, len(targets))
+	for _, t := range targets {
+		optional := s.cfg.Targets[t.Name()].Optional
+		choice := OptionalChoice{Target: t.Name(), Skills: names}
+		for _, name := range names {
+			if slices.Contains(optional, name) {
+				choice.Enabled = append(choice.Enabled, name)
+			}
+		}
+		choices = append(choices, choice)
+	}
+	return choices, nil
+}
+
+// Select makes the skills listed for each target in selection the enabled
+// optional skills of that target, among the optional skills in the store,
+// and saves the config to configPath when it changed. Targets selection does
+// not list, and enabled skills that are no longer in the store, are left as
+// they are. Changes are ordered by skill name, then by target name.
+func (s *OptionalService) Select(selection map[string][]string, configPath string) ([]OptionalChange, error) {
+	choices, err := s.Choices("")
+	if err != nil {
+		return nil, err
+	}
+	var changes []OptionalChange
+	for _, choice := range choices {
+		selected, ok := selection[choice.Target]
+		if !ok {
+			continue
+		}
+		for _, name := range choice.Skills {
+			enable := slices.Contains(selected, name)
+			if enable != slices.Contains(choice.Enabled, name) {
+				changes = append(changes, OptionalChange{Skill: name, Target: choice.Target, Enable: enable})
+			}
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	slices.SortStableFunc(changes, func(a, b OptionalChange) int { return cmp.Compare(a.Skill, b.Skill) })
+
+	for _, c := range changes {
+		tc := s.cfg.Targets[c.Target]
+		tc.Optional = setOptional(tc.Optional, c.Skill, c.Enable)
+		s.cfg.Targets[c.Target] = tc
+	}
+	if err := s.save(configPath, changes); err != nil {
+		return nil, fmt.Errorf("failed to update config file: %w", err)
+	}
+	return changes, nil
+}
+
+// listing returns the configured targets that list skillName among their
+// optional skills, sorted by name.
+func (s *OptionalService) listing(skillName string) []string {
+	var targets []string
+	for name, tc := range s.cfg.Targets {
+		if slices.Contains(tc.Optional, skillName) {
+			targets = append(targets, name)
+		}
+	}
+	slices.Sort(targets)
+	return targets
+}
+
+// rename replaces oldName with newName in the optional skills of targets and
+// saves the config to configPath.
+func (s *OptionalService) rename(oldName, newName string, targets []string, configPath string) error {
+	changes := make([]OptionalChange, 0, 2*len(targets))
+	for _, name := range targets {
+		changes = append(changes,
+			OptionalChange{Skill: oldName, Target: name, Enable: false},
+			OptionalChange{Skill: newName, Target: name, Enable: true})
+	}
+	for _, c := range changes {
+		tc := s.cfg.Targets[c.Target]
+		tc.Optional = setOptional(tc.Optional, c.Skill, c.Enable)
+		s.cfg.Targets[c.Target] = tc
+	}
+	return s.save(configPath, changes)
+}
+
+// selectTargets returns every enabled target, or only the named one when
+// name is not empty.
+func (s *OptionalService) selectTargets(name string) ([]*Target, error) {
+	if name == "" {
+		return s.targets.GetAll(), nil
+	}
+	t, err := s.targets.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return []*Target{t}, nil
+}
+
+// save records the changes in the config file at configPath, or in the
+// default config when there is no file yet. It never saves s.cfg, which can
+// hold a project's overrides.
+func (s *OptionalService) save(configPath string, changes []OptionalChange) error {
+	cfg := config.DefaultConfig()
+	if s.fs.Exists(configPath) {
+		var err error
+		if cfg, err = s.configStore.Load(configPath); err != nil {
+			return err
+		}
+	}
+	if cfg.Targets == nil {
+		cfg.Targets = make(map[string]config.TargetConfig, len(changes))
+	}
+	for _, c := range changes {
+		tc := cfg.Targets[c.Target]
+		tc.Optional = setOptional(tc.Optional, c.Skill, c.Enable)
+		cfg.Targets[c.Target] = tc
+	}
+	return s.configStore.Save(cfg, configPath)
+}
+
+// setOptional adds skillName to, or removes it from, a sorted list of optional skills.
+func setOptional(optional []string, skillName string, enable bool) []string {
+	if !enable {
+		return slices.DeleteFunc(optional, func(name string) bool { return name == skillName })
+	}
+	if slices.Contains(optional, skillName) {
+		return optional
+	}
+	optional = append(optional, skillName)
+	slices.Sort(optional)
+	return optional
+}
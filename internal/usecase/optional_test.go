@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestOptionalSkillsPerTarget(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "always")
+	mock.Dirs["/home/test/.agents/skills/optional/extra"] = true
+	mock.Files["/home/test/.agents/skills/optional/extra/SKILL.md"] = []byte("---\nname: extra\n---\n")
+	cfg := config.DefaultConfig()
+	configPath := "/home/test/.config/skillet/config.yaml"
+
+	sync := func() []usecase.SyncResult {
+		t.Helper()
+		results, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{})
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		return results
+	}
+
+	sync()
+	for _, target := range []string{"claude", "codex"} {
+		if mock.Exists("/home/test/." + target + "/skills/extra") {
+			t.Fatalf("optional skill should not be installed into %s before it is enabled", target)
+		}
+	}
+
+	svc := usecase.NewOptionalService(mock, cfg, "")
+	result, err := svc.SetEnabled(usecase.OptionalOptions{Name: "extra", Target: "claude", Enable: true}, configPath)
+	if err != nil {
+		t.Fatalf("SetEnabled() error = %v", err)
+	}
+	if !slices.Equal(result.Changed, []string{"claude"}) {
+		t.Errorf("Changed = %v, want [claude]", result.Changed)
+	}
+	saved, err := config.NewStore(mock).Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !slices.Equal(saved.Targets["claude"].Optional, []string{"extra"}) {
+		t.Errorf("saved claude optional = %v, want [extra]", saved.Targets["claude"].Optional)
+	}
+
+	sync()
+	if !mock.Exists("/home/test/.claude/skills/extra") || mock.Exists("/home/test/.codex/skills/extra") {
+		t.Fatal("optional skill should be installed into claude only")
+	}
+	status, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	for _, st := range status {
+		if !st.InSync {
+			t.Errorf("%s status = %+v, want in sync", st.Target, st)
+		}
+	}
+
+	if _, err := svc.SetEnabled(usecase.OptionalOptions{Name: "extra", Enable: false}, configPath); err != nil {
+		t.Fatalf("SetEnabled() error = %v", err)
+	}
+	results := sync()
+	if !slices.ContainsFunc(results, func(r usecase.SyncResult) bool {
+		return r.Target == "claude" && r.SkillName == "extra" && r.Action == usecase.SyncActionUninstall
+	}) {
+		t.Errorf("results = %+v, want extra uninstalled from claude", results)
+	}
+	if mock.Exists("/home/test/.claude/skills/extra") {
+		t.Error("disabled optional skill should be uninstalled")
+	}
+
+	if _, err := svc.SetEnabled(usecase.OptionalOptions{Name: "always", Enable: true}, configPath); err == nil {
+		t.Error("SetEnabled() should fail for a default skill")
+	}
+}
+
+func TestOptionalSelect(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "always")
+	for _, name := range []string{"extra", "more"} {
+		mock.Dirs["/home/test/.agents/skills/optional/"+name] = true
+		mock.Files["/home/test/.agents/skills/optional/"+name+"/SKILL.md"] = []byte("---\nname: " + name + "\n---\n")
+	}
+	cfg := config.DefaultConfig()
+	tc := cfg.Targets["codex"]
+	tc.Optional = []string{"extra"}
+	cfg.Targets["codex"] = tc
+	configPath := "/home/test/.config/skillet/config.yaml"
+	svc := usecase.NewOptionalService(mock, cfg, "")
+
+	choices, err := svc.Choices("")
+	if err != nil {
+		t.Fatalf("Choices() error = %v", err)
+	}
+	if len(choices) != 2 || !slices.Equal(choices[0].Skills, []string{"extra", "more"}) {
+		t.Fatalf("Choices() = %+v, want both optional skills for claude and codex", choices)
+	}
+	if len(choices[0].Enabled) != 0 || !slices.Equal(choices[1].Enabled, []string{"extra"}) {
+		t.Errorf("Choices() = %+v, want extra enabled for codex only", choices)
+	}
+
+	changes, err := svc.Select(map[string][]string{"claude": {"more"}, "codex": {"more"}}, configPath)
+	if err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+	want := []usecase.OptionalChange{
+		{Skill: "extra", Target: "codex", Enable: false},
+		{Skill: "more", Target: "claude", Enable: true},
+		{Skill: "more", Target: "codex", Enable: true},
+	}
+	if !slices.Equal(changes, want) {
+		t.Errorf("Select() = %+v, want %+v", changes, want)
+	}
+	saved, err := config.NewStore(mock).Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !slices.Equal(saved.Targets["codex"].Optional, []string{"more"}) {
+		t.Errorf("saved codex optional = %v, want [more]", saved.Targets["codex"].Optional)
+	}
+
+	if changes, err := svc.Select(map[string][]string{"claude": {"more"}}, configPath); err != nil || len(changes) != 0 {
+		t.Errorf("Select() again = %+v, %v; want no changes", changes, err)
+	}
+}
+
+func TestOptionalSaveLeavesOutProjectOverrides(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	mock.Dirs["/home/test/.agents/skills/optional/extra"] = true
+	mock.Files["/home/test/.agents/skills/optional/extra/SKILL.md"] = []byte("---\nname: extra\n---\n")
+	// cfg stands in for a global config merged with a project's overrides.
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	configPath := "/home/test/.config/skillet/config.yaml"
+
+	svc := usecase.NewOptionalService(mock, cfg, "")
+	if _, err := svc.SetEnabled(usecase.OptionalOptions{Name: "extra", Target: "claude", Enable: true}, configPath); err != nil {
+		t.Fatalf("SetEnabled() error = %v", err)
+	}
+	saved, err := config.NewStore(mock).Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !slices.Equal(saved.Targets["claude"].Optional, []string{"extra"}) {
+		t.Errorf("saved claude optional = %v, want [extra]", saved.Targets["claude"].Optional)
+	}
+	if want := config.DefaultConfig().DefaultStrategy; saved.DefaultStrategy != want {
+		t.Errorf("saved default strategy = %q, want the default %q", saved.DefaultStrategy, want)
+	}
+}
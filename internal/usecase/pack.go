@@ -0,0 +1,395 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/archive"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// PackManifestName is the manifest at the root of a skill archive.
+const PackManifestName = "skillet-pack.yaml"
+
+// packSkillsDir is the archive directory holding one directory per skill.
+const packSkillsDir = "skills"
+
+// PackManifest lists the skills in an archive.
+type PackManifest struct {
+	Version int           `yaml:"version"`
+	Skills  []PackedSkill `yaml:"skills"`
+}
+
+// PackedSkill describes a skill in an archive.
+type PackedSkill struct {
+	Name string `yaml:"name"`
+	// Scope is the scope the skill was packed from
+	Scope    string `yaml:"scope"`
+	Category string `yaml:"category"`
+	// Checksum is the digest of the skill's files, checked on unpack
+	Checksum string `yaml:"checksum"`
+}
+
+// PackOptions contains options for packing skills into an archive.
+type PackOptions struct {
+	// Names lists the skills to pack (empty for every skill)
+	Names []string
+	// Scope limits packing to a specific scope (nil to resolve by priority)
+	Scope *skill.Scope
+	// Output is the archive file; its extension picks the format
+	Output string
+}
+
+// PackResult describes a written archive.
+type PackResult struct {
+	Path   string
+	Format archive.Format
+	Skills []PackedSkill
+}
+
+// UnpackAction represents the outcome of unpacking a single skill.
+type UnpackAction string
+
+const (
+	UnpackActionUnpacked  UnpackAction = "unpacked"
+	UnpackActionReplaced  UnpackAction = "replaced"
+	UnpackActionUnchanged UnpackAction = "unchanged"
+	UnpackActionError     UnpackAction = "error"
+)
+
+// UnpackOptions contains options for unpacking an archive into a store.
+type UnpackOptions struct {
+	// Archive is the archive file to read
+	Archive string
+	// Scope is the store to unpack into (global or project)
+	Scope skill.Scope
+	// Names limits unpacking to these skills (empty for every skill in the archive)
+	Names []string
+	// Force replaces skills of the same name whose content differs
+	Force bool
+	// DryRun only shows what would be done without making changes
+	DryRun bool
+}
+
+// UnpackResult represents the result of unpacking a single skill.
+type UnpackResult struct {
+	SkillName string
+	Path      string
+	Action    UnpackAction
+	Error     error
+}
+
+// PackService moves skills between machines as archives.
+type PackService struct {
+	fs    platformfs.FileSystem
+	cfg   *config.Config
+	root  string
+	store *skill.Store
+}
+
+// NewPackService creates a new pack service.
+func NewPackService(fsys platformfs.FileSystem, cfg *config.Config, root string) *PackService {
+	return &PackService{
+		fs:    fsys,
+		cfg:   cfg,
+		root:  root,
+		store: skill.NewStore(fsys, cfg, root),
+	}
+}
+
+// Pack writes the selected skills and a manifest with their checksums to an
+// archive. Without names, every skill that takes effect (or every skill in
+// the scope) is packed.
+func (s *PackService) Pack(opts PackOptions) (*PackResult, error) {
+	format, err := archive.FormatFor(opts.Output)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := s.selectSkills(opts.Names, opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if len(skills) == 0 {
+		return nil, fmt.Errorf("no skills to pack")
+	}
+
+	files := make(map[string]archive.File)
+	manifest := PackManifest{Version: 1}
+	for _, sk := range skills {
+		skillFiles, err := readSkillFiles(s.fs, sk.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read skill %s: %w", sk.Name, err)
+		}
+		for rel, data := range skillFiles {
+			file := archive.File{Data: data}
+			if info, err := s.fs.Stat(s.fs.Join(sk.Path, rel)); err == nil {
+				file.Mode = info.Mode().Perm()
+			}
+			files[packSkillsDir+"/"+sk.Name+"/"+rel] = file
+		}
+		manifest.Skills = append(manifest.Skills, PackedSkill{
+			Name:     sk.Name,
+			Scope:    sk.Scope.String(),
+			Category: sk.Category.String(),
+			Checksum: filesChecksum(skillFiles),
+		})
+	}
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	files[PackManifestName] = archive.File{Data: data}
+
+	var buf bytes.Buffer
+	if err := archive.Write(&buf, format, files); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := s.fs.WriteFile(opts.Output, buf.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return &PackResult{Path: opts.Output, Format: format, Skills: manifest.Skills}, nil
+}
+
+// selectSkills returns the named skills, or every skill, sorted by name.
+func (s *PackService) selectSkills(names []string, scope *skill.Scope) ([]*skill.Skill, error) {
+	var skills []*skill.Skill
+	var err error
+	switch {
+	case scope != nil:
+		skills, err = s.store.GetByScope(*scope)
+	case len(names) == 0:
+		skills, err = s.store.GetResolved()
+	default:
+		for _, name := range names {
+			sk, err := s.store.GetByName(name)
+			if err != nil {
+				return nil, fmt.Errorf("skill not found: %s", name)
+			}
+			skills = append(skills, sk)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	if scope != nil && len(names) > 0 {
+		byName := skillsByName(skills)
+		skills = skills[:0:0]
+		for _, name := range names {
+			sk, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("skill not found in %s scope: %s", *scope, name)
+			}
+			skills = append(skills, sk)
+		}
+	}
+	slices.SortFunc(skills, func(a, b *skill.Skill) int { return strings.Compare(a.Name, b.Name) })
+	return slices.CompactFunc(skills, func(a, b *skill.Skill) bool { return a.Name == b.Name }), nil
+}
+
+// Unpack checks an archive against its manifest and writes its skills into
+// the scope's store. A skill whose files do not match the manifest checksum
+// is not written. A skill that already exists in the scope is left alone
+// when identical, and only replaced with Force when it differs.
+func (s *PackService) Unpack(opts UnpackOptions) ([]UnpackResult, error) {
+	packed, modes, manifest, err := s.readArchive(opts.Archive)
+	if err != nil {
+		return nil, err
+	}
+	agentsDir, skillsDir, err := writableStoreDirs(s.fs, s.cfg, s.root, opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := manifest.Skills
+	if len(opts.Names) > 0 {
+		byName := make(map[string]PackedSkill, len(entries))
+		for _, entry := range entries {
+			byName[entry.Name] = entry
+		}
+		entries = nil
+		for _, name := range opts.Names {
+			entry, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("skill not in archive: %s", name)
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	results := make([]UnpackResult, 0, len(entries))
+	for _, entry := range entries {
+		result := s.unpackSkill(entry, packed[entry.Name], modes[entry.Name], agentsDir, skillsDir, opts)
+		if result.Error != nil {
+			result.Action = UnpackActionError
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// readArchive reads an archive and returns its files and their permission
+// bits grouped by skill, with the manifest. Files outside the manifest's
+// skills make it invalid.
+func (s *PackService) readArchive(path string) (map[string]skillFiles, map[string]map[string]os.FileMode, *PackManifest, error) {
+	format, err := archive.FormatFor(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	files, err := archive.Read(data, format)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	raw, ok := files[PackManifestName]
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("not a skill archive: no %s found", PackManifestName)
+	}
+	var manifest PackManifest
+	if err := yaml.Unmarshal(raw.Data, &manifest); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse %s: %w", PackManifestName, err)
+	}
+	if manifest.Version != 1 {
+		return nil, nil, nil, fmt.Errorf("unsupported archive version %d", manifest.Version)
+	}
+
+	packed := make(map[string]skillFiles, len(manifest.Skills))
+	modes := make(map[string]map[string]os.FileMode, len(manifest.Skills))
+	for _, entry := range manifest.Skills {
+		if err := skill.ValidateQualifiedName(entry.Name); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid skill in %s: %w", PackManifestName, err)
+		}
+		if _, ok := packed[entry.Name]; ok {
+			return nil, nil, nil, fmt.Errorf("skill listed twice in %s: %s", PackManifestName, entry.Name)
+		}
+		packed[entry.Name] = make(skillFiles)
+		modes[entry.Name] = make(map[string]os.FileMode)
+	}
+	for name, file := range files {
+		if name == PackManifestName {
+			continue
+		}
+		rest, ok := strings.CutPrefix(name, packSkillsDir+"/")
+		skillName, rel, found := packedSkillFile(packed, rest)
+		if !ok || !found {
+			return nil, nil, nil, fmt.Errorf("archive entry not listed in %s: %s", PackManifestName, name)
+		}
+		packed[skillName][rel] = file.Data
+		modes[skillName][rel] = file.Mode
+	}
+	return packed, modes, &manifest, nil
+}
+
+// packedSkillFile splits a path under the archive's skills directory into the
+// listed skill it belongs to and its path within the skill. Skills in
+// collections are stored under their collection directories.
+func packedSkillFile(packed map[string]skillFiles, path string) (name, rel string, ok bool) {
+	for i, c := range path {
+		if c == '/' && packed[path[:i]] != nil {
+			return path[:i], path[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+func (s *PackService) unpackSkill(entry PackedSkill, files skillFiles, modes map[string]os.FileMode, agentsDir, skillsDir string, opts UnpackOptions) UnpackResult {
+	result := UnpackResult{SkillName: entry.Name, Action: UnpackActionUnpacked}
+	if filesChecksum(files) != entry.Checksum {
+		result.Error = fmt.Errorf("content does not match the archive checksum")
+		return result
+	}
+	if _, ok := files["SKILL.md"]; !ok {
+		result.Error = fmt.Errorf("no SKILL.md in archive")
+		return result
+	}
+	category, err := skill.ParseCategory(entry.Category)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	if category == skill.CategoryOptional {
+		skillsDir = s.fs.Join(skillsDir, config.OptionalDirName)
+	}
+	result.Path = s.fs.Join(skillsDir, entry.Name)
+
+	// A vendored copy is not a collision: the unpacked skill overrides it.
+	existing, err := s.store.FindInScope(entry.Name, opts.Scope)
+	if err != nil || existing.Vendored {
+		existing = nil
+	}
+	if existing != nil {
+		if sum, err := dirChecksum(s.fs, existing.Path); err == nil && sum == entry.Checksum {
+			result.Action = UnpackActionUnchanged
+			result.Path = existing.Path
+			return result
+		}
+		if !opts.Force {
+			result.Error = fmt.Errorf("skill already exists in %s scope with different content: %s (use --force to replace it)", opts.Scope, existing.Path)
+			return result
+		}
+		result.Action = UnpackActionReplaced
+	} else if s.fs.Exists(result.Path) {
+		result.Error = fmt.Errorf("path already exists: %s", result.Path)
+		return result
+	}
+	if opts.DryRun {
+		return result
+	}
+
+	// Stage the skill inside the agents directory so a failed write never
+	// leaves a partial skill behind.
+	tmp, err := s.fs.MkdirTemp(agentsDir, ".unpack-*")
+	if err != nil {
+		result.Error = fmt.Errorf("failed to create staging directory: %w", err)
+		return result
+	}
+	defer func() { _ = s.fs.RemoveAll(tmp) }()
+	staged := s.fs.Join(tmp, entry.Name)
+	for _, rel := range sortedPaths(files) {
+		path := s.fs.Join(staged, rel)
+		if err := s.fs.MkdirAll(s.fs.Dir(path), 0o755); err != nil {
+			result.Error = fmt.Errorf("failed to create directory: %w", err)
+			return result
+		}
+		if err := s.fs.WriteFile(path, files[rel], modes[rel]); err != nil {
+			result.Error = fmt.Errorf("failed to write %s: %w", rel, err)
+			return result
+		}
+	}
+
+	// Skills in collections need their collection directories.
+	if err := s.fs.MkdirAll(s.fs.Dir(result.Path), 0o755); err != nil {
+		result.Error = fmt.Errorf("failed to create skills directory: %w", err)
+		return result
+	}
+	// The existing skill is moved into the staging directory, and only
+	// removed with it once the unpacked skill is in place.
+	previous := s.fs.Join(tmp, ".previous")
+	if existing != nil {
+		if err := s.fs.Rename(existing.Path, previous); err != nil {
+			result.Error = fmt.Errorf("failed to move existing skill aside: %w", err)
+			return result
+		}
+	}
+	if err := s.fs.Rename(staged, result.Path); err != nil {
+		result.Error = fmt.Errorf("failed to install skill: %w", err)
+		if existing != nil {
+			if err := s.fs.Rename(previous, existing.Path); err != nil {
+				result.Error = errors.Join(result.Error, fmt.Errorf("failed to restore existing skill: %w", err))
+			}
+		}
+	}
+	return result
+}
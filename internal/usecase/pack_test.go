@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/archive"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestPackUnpack(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	cfg := config.DefaultConfig()
+	addGlobalSkill(mock, "alpha")
+	mock.Dirs["/home/test/.agents/skills/alpha/docs"] = true
+	mock.Files["/home/test/.agents/skills/alpha/docs/ref.md"] = []byte("notes\n")
+	mock.Dirs["/home/test/.agents/skills/alpha/scripts"] = true
+	mock.Files["/home/test/.agents/skills/alpha/scripts/run.sh"] = []byte("#!/bin/sh\n")
+	mock.Modes["/home/test/.agents/skills/alpha/scripts/run.sh"] = 0o755
+	mock.Dirs["/home/test/.agents/skills/optional/beta"] = true
+	mock.Files["/home/test/.agents/skills/optional/beta/SKILL.md"] = []byte("---\nname: beta\n---\n")
+	mock.Dirs["/project"] = true
+	mock.Dirs["/project/.agents"] = true
+	svc := usecase.NewPackService(mock, cfg, "/project")
+
+	packed, err := svc.Pack(usecase.PackOptions{Output: "/tmp/skills.zip"})
+	if err != nil {
+		t.Fatalf("Pack() error = %v", err)
+	}
+	if packed.Format != archive.Zip || len(packed.Skills) != 2 || packed.Skills[1].Category != "optional" {
+		t.Fatalf("Pack() = %+v, want alpha and optional beta in a zip", packed)
+	}
+
+	results, err := svc.Unpack(usecase.UnpackOptions{Archive: "/tmp/skills.zip", Scope: skill.ScopeProject})
+	if err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+	for _, r := range results {
+		if r.Action != usecase.UnpackActionUnpacked {
+			t.Errorf("%s = %+v, want unpacked", r.SkillName, r)
+		}
+	}
+	if string(mock.Files["/project/.agents/skills/alpha/docs/ref.md"]) != "notes\n" {
+		t.Error("expected alpha's files in the project store")
+	}
+	if mode := mock.Modes["/project/.agents/skills/alpha/scripts/run.sh"]; mode != 0o755 {
+		t.Errorf("scripts/run.sh mode = %v, want it to stay executable", mode)
+	}
+	if mode := mock.Modes["/project/.agents/skills/alpha/docs/ref.md"]; mode != 0o644 {
+		t.Errorf("docs/ref.md mode = %v, want 0644", mode)
+	}
+	if !mock.Exists("/project/.agents/skills/optional/beta/SKILL.md") {
+		t.Error("expected beta under optional/ in the project store")
+	}
+
+	// Unpacking again leaves identical skills alone; a changed skill is a
+	// collision unless forced.
+	mock.Files["/project/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\n---\nedited\n")
+	results, err = svc.Unpack(usecase.UnpackOptions{Archive: "/tmp/skills.zip", Scope: skill.ScopeProject})
+	if err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+	if results[0].Action != usecase.UnpackActionError || results[1].Action != usecase.UnpackActionUnchanged {
+		t.Fatalf("Unpack() = %+v, want an alpha collision and beta unchanged", results)
+	}
+	results, err = svc.Unpack(usecase.UnpackOptions{Archive: "/tmp/skills.zip", Scope: skill.ScopeProject, Names: []string{"alpha"}, Force: true})
+	if err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Action != usecase.UnpackActionReplaced {
+		t.Fatalf("Unpack() = %+v, want alpha replaced", results)
+	}
+	if strings.Contains(string(mock.Files["/project/.agents/skills/alpha/SKILL.md"]), "edited") {
+		t.Error("expected the edited alpha to be replaced")
+	}
+}
+
+func TestPackUnpackCollection(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	cfg := config.DefaultConfig()
+	dir := "/home/test/.agents/skills/backend/api-design"
+	mock.Dirs["/home/test/.agents/skills/backend"] = true
+	mock.Dirs[dir] = true
+	mock.Files[dir+"/SKILL.md"] = []byte("---\nname: api-design\n---\n")
+	mock.Dirs["/project"] = true
+	mock.Dirs["/project/.agents"] = true
+	svc := usecase.NewPackService(mock, cfg, "/project")
+
+	if _, err := svc.Pack(usecase.PackOptions{Output: "/tmp/skills.zip"}); err != nil {
+		t.Fatalf("Pack() error = %v", err)
+	}
+	results, err := svc.Unpack(usecase.UnpackOptions{Archive: "/tmp/skills.zip", Scope: skill.ScopeProject})
+	if err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Action != usecase.UnpackActionUnpacked {
+		t.Fatalf("Unpack() = %+v, want backend/api-design unpacked", results)
+	}
+	installed := "/project/.agents/skills/backend/api-design/SKILL.md"
+	if !mock.Exists(installed) {
+		t.Fatal("expected the skill under its collection in the project store")
+	}
+
+	// Replacing the skill keeps it in place until the new copy is installed.
+	mock.Files[installed] = []byte("---\nname: api-design\n---\nedited\n")
+	results, err = svc.Unpack(usecase.UnpackOptions{Archive: "/tmp/skills.zip", Scope: skill.ScopeProject, Force: true})
+	if err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Action != usecase.UnpackActionReplaced {
+		t.Fatalf("Unpack() = %+v, want backend/api-design replaced", results)
+	}
+	if strings.Contains(string(mock.Files[installed]), "edited") {
+		t.Error("expected the edited skill to be replaced")
+	}
+	entries, err := mock.ReadDir("/project/.agents")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".unpack-") {
+			t.Errorf("staging directory %s was left behind", e.Name())
+		}
+	}
+}
+
+func TestUnpackRejectsTamperedArchive(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	cfg := config.DefaultConfig()
+	addGlobalSkill(mock, "alpha")
+	svc := usecase.NewPackService(mock, cfg, "")
+	if _, err := svc.Pack(usecase.PackOptions{Names: []string{"alpha"}, Output: "/tmp/alpha.tar.gz"}); err != nil {
+		t.Fatalf("Pack() error = %v", err)
+	}
+	files, err := archive.Read(mock.Files["/tmp/alpha.tar.gz"], archive.TarGz)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if err := mock.RemoveAll("/home/test/.agents/skills/alpha"); err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(files map[string]archive.File) {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := archive.Write(&buf, archive.TarGz, files); err != nil {
+			t.Fatal(err)
+		}
+		mock.Files["/tmp/alpha.tar.gz"] = buf.Bytes()
+	}
+
+	files["skills/alpha/SKILL.md"] = archive.File{Data: []byte("---\nname: alpha\n---\ntampered\n")}
+	write(files)
+	results, err := svc.Unpack(usecase.UnpackOptions{Archive: "/tmp/alpha.tar.gz", Scope: skill.ScopeGlobal})
+	if err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+	if results[0].Action != usecase.UnpackActionError || !strings.Contains(results[0].Error.Error(), "checksum") {
+		t.Fatalf("Unpack() = %+v, want a checksum error", results)
+	}
+	if mock.Exists("/home/test/.agents/skills/alpha") {
+		t.Fatal("a tampered skill must not be written")
+	}
+
+	files["skills/other/SKILL.md"] = archive.File{Data: []byte("---\nname: other\n---\n")}
+	write(files)
+	if _, err := svc.Unpack(usecase.UnpackOptions{Archive: "/tmp/alpha.tar.gz", Scope: skill.ScopeGlobal}); err == nil || !strings.Contains(err.Error(), "not listed") {
+		t.Fatalf("Unpack() error = %v, want an unlisted entry error", err)
+	}
+}
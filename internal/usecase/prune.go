@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// PruneReason describes why an install was pruned.
+type PruneReason string
+
+const (
+	// PruneBroken is a symlink whose destination no longer exists.
+	PruneBroken PruneReason = "broken"
+	// PruneOutsideStore is a symlink that no longer resolves into a skill store,
+	// such as one left behind when the store moved.
+	PruneOutsideStore PruneReason = "outside-store"
+	// PruneOrphaned is a copy recorded in the sync log whose skill left the store.
+	PruneOrphaned PruneReason = "orphaned"
+)
+
+// PruneOptions contains options for pruning orphaned installs.
+type PruneOptions struct {
+	// DryRun only reports orphans without removing them
+	DryRun bool
+	// Target limits pruning to a single target (empty for all)
+	Target string
+}
+
+// PruneResult describes one orphaned install in a target.
+type PruneResult struct {
+	SkillName string
+	Target    string
+	Scope     skill.Scope
+	Path      string
+	Reason    PruneReason
+	// Removed is true when the install was deleted
+	Removed bool
+	Error   error
+}
+
+// PruneService removes broken links and installs left behind by skills that
+// are no longer in the store.
+type PruneService struct {
+	fs      platformfs.FileSystem
+	store   *skill.Store
+	targets *TargetRegistry
+}
+
+// NewPruneService creates a new prune service.
+func NewPruneService(fsys platformfs.FileSystem, cfg *config.Config, root string) *PruneService {
+	return &PruneService{
+		fs:      fsys,
+		store:   skill.NewStore(fsys, cfg, root),
+		targets: NewTargetRegistry(fsys, root, cfg),
+	}
+}
+
+// Prune finds and, unless dry-running, removes stale installs in each target:
+// symlinks whose destination no longer exists or lies outside every store,
+// and copies recorded in the target's sync log whose skill is no longer in
+// any store. Other entries are left alone.
+// Results are ordered by target name, then by scope and skill name.
+func (s *PruneService) Prune(opts PruneOptions) ([]PruneResult, error) {
+	skills, err := s.store.GetResolved()
+	var conflictErr *skill.ConflictError
+	if errors.As(err, &conflictErr) {
+		err = nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	// Conflicting skills are still in the store, so their installs are kept.
+	known := make(map[string]bool, len(skills))
+	for _, sk := range skills {
+		known[sk.Name] = true
+	}
+	if conflictErr != nil {
+		for _, c := range conflictErr.Conflicts {
+			known[c.Name] = true
+		}
+	}
+
+	storeDirs, err := s.store.Dirs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find store directories: %w", err)
+	}
+
+	targets := s.targets.GetAll()
+	if opts.Target != "" {
+		t, err := s.targets.Lookup(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		targets = []*Target{t}
+	}
+
+	var results []PruneResult
+	for _, t := range targets {
+		for _, scope := range []skill.Scope{skill.ScopeGlobal, skill.ScopeProject} {
+			names, err := t.ListInstalledInScope(scope)
+			if err != nil {
+				results = append(results, PruneResult{Target: t.Name(), Scope: scope, Error: err})
+				continue
+			}
+			synced := t.loadSyncLog(scope)
+			entries := t.entrySet(known)
+			for _, name := range names {
+				path, err := t.entryPath(name, scope)
+				if err != nil {
+					continue
+				}
+				_, logged := synced.Skills[name]
+				reason, stale := s.staleReason(path, entries[name], logged, storeDirs)
+				if !stale {
+					continue
+				}
+				result := PruneResult{SkillName: name, Target: t.Name(), Scope: scope, Path: path, Reason: reason}
+				if !opts.DryRun {
+					s.remove(t, scope, &result)
+				}
+				results = append(results, result)
+			}
+		}
+	}
+	return results, nil
+}
+
+// staleReason reports why the install at path should be pruned, if it should.
+// known tells whether its skill is in the store, and logged whether the
+// target's sync log records it.
+func (s *PruneService) staleReason(path string, known, logged bool, storeDirs []string) (PruneReason, bool) {
+	if !s.fs.IsSymlink(path) {
+		return PruneOrphaned, !known && logged
+	}
+	if !s.fs.IsDir(path) {
+		return PruneBroken, true
+	}
+	dest, err := s.fs.Readlink(path)
+	if err != nil {
+		return "", false
+	}
+	if !filepath.IsAbs(dest) {
+		dest = s.fs.Join(s.fs.Dir(path), dest)
+	}
+	if !withinDirs(s.fs, storeDirs, dest) {
+		return PruneOutsideStore, true
+	}
+	return "", false
+}
+
+// withinDirs reports whether path lies inside one of dirs.
+func withinDirs(fsys platformfs.FileSystem, dirs []string, path string) bool {
+	path = fsys.Join(path)
+	return slices.ContainsFunc(dirs, func(dir string) bool {
+		return strings.HasPrefix(path, fsys.Join(dir)+string(filepath.Separator))
+	})
+}
+
+// remove deletes an orphaned install and its sync log entry, recording the outcome in result.
+func (s *PruneService) remove(t *Target, scope skill.Scope, result *PruneResult) {
+	if err := t.removeEntry(result.SkillName, scope); err != nil {
+		result.Error = err
+		return
+	}
+	result.Removed = true
+}
@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestPrune(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	cfg := config.DefaultConfig()
+	cfg.Targets["codex"] = config.TargetConfig{Enabled: false}
+
+	addGlobalSkill(mock, "kept")
+	addGlobalSkill(mock, "removed")
+	addGlobalSkill(mock, "copied")
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	// Copy one skill so it is recorded in the sync log.
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{
+		Names: []string{"copied"}, Strategy: config.StrategyCopy, Force: true,
+	}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	// Delete two skills from the store, and add a skill skillet did not install.
+	mock.RemoveAll("/home/test/.agents/skills/removed")
+	mock.RemoveAll("/home/test/.agents/skills/copied")
+	// A link into a store location that is no longer configured.
+	mock.Dirs["/home/test/old-store/skills/moved"] = true
+	mock.Symlinks["/home/test/.claude/skills/moved"] = "/home/test/old-store/skills/moved"
+	mock.Dirs["/home/test/.claude/skills/handmade"] = true
+	mock.Files["/home/test/.claude/skills/handmade/SKILL.md"] = []byte("---\nname: handmade\n---\n")
+
+	svc := usecase.NewPruneService(mock, cfg, "")
+	results, err := svc.Prune(usecase.PruneOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	want := map[string]usecase.PruneReason{
+		"copied":  usecase.PruneOrphaned,
+		"moved":   usecase.PruneOutsideStore,
+		"removed": usecase.PruneBroken,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("Prune(DryRun) = %+v, want copied, moved, and removed", results)
+	}
+	for _, r := range results {
+		if r.Reason != want[r.SkillName] {
+			t.Errorf("%s: Reason = %q, want %q", r.SkillName, r.Reason, want[r.SkillName])
+		}
+	}
+	if results[0].Removed || !mock.Exists("/home/test/.claude/skills/copied") {
+		t.Fatal("dry run should not remove orphans")
+	}
+
+	results, err = svc.Prune(usecase.PruneOptions{})
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	for _, r := range results {
+		if !r.Removed || r.Error != nil {
+			t.Errorf("%s: Removed = %v, Error = %v", r.SkillName, r.Removed, r.Error)
+		}
+	}
+	for _, name := range []string{"removed", "copied", "moved"} {
+		if mock.Exists("/home/test/.claude/skills/" + name) {
+			t.Errorf("orphan %s should be removed", name)
+		}
+	}
+	if !mock.Exists("/home/test/.claude/skills/kept") || !mock.Exists("/home/test/.claude/skills/handmade") {
+		t.Error("installed and unmanaged skills should be kept")
+	}
+	if _, ok := usecase.NewTargetRegistry(mock, "", cfg).GetAll()[0].SyncedAt("copied", skill.ScopeGlobal); ok {
+		t.Error("pruned copy should be removed from the sync log")
+	}
+}
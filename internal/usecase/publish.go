@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// PublishOptions contains options for publishing a draft skill.
+type PublishOptions struct {
+	// Name is the skill to publish
+	Name string
+	// Scope limits the lookup to a specific scope (nil for the highest-priority draft)
+	Scope *skill.Scope
+	// DryRun only shows what would be done without making changes
+	DryRun bool
+}
+
+// PublishService turns draft skills into regular ones.
+type PublishService struct {
+	fs    platformfs.FileSystem
+	store *skill.Store
+}
+
+// NewPublishService creates a new publish service.
+func NewPublishService(fsys platformfs.FileSystem, cfg *config.Config, root string) *PublishService {
+	return &PublishService{
+		fs:    fsys,
+		store: skill.NewStore(fsys, cfg, root),
+	}
+}
+
+// Publish removes the draft flag from the named skill's SKILL.md, so the
+// next sync installs it. It returns the published skill.
+func (s *PublishService) Publish(opts PublishOptions) (*skill.Skill, error) {
+	skills, err := s.store.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	var draft *skill.Skill
+	found := false
+	for _, sk := range skills {
+		if sk.Name != opts.Name || (opts.Scope != nil && sk.Scope != *opts.Scope) {
+			continue
+		}
+		found = true
+		if sk.Draft() && (draft == nil || sk.Priority() > draft.Priority()) {
+			draft = sk
+		}
+	}
+	switch {
+	case !found:
+		return nil, fmt.Errorf("skill not found: %s", opts.Name)
+	case draft == nil:
+		return nil, fmt.Errorf("skill %s is not a draft", opts.Name)
+	case draft.ReadOnly():
+		return nil, fmt.Errorf("skill %s is in the read-only %s store", draft.Name, draft.Scope)
+	}
+	if opts.DryRun {
+		return draft, nil
+	}
+
+	path := s.fs.Join(draft.Path, "SKILL.md")
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SKILL.md: %w", err)
+	}
+	updated, err := skill.StripFrontmatterKeys(data, []string{"draft"})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fs.WriteFile(path, updated, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write SKILL.md: %w", err)
+	}
+	meta := draft.Metadata()
+	meta.Draft = false
+	draft.SetMetadata(meta)
+	return draft, nil
+}
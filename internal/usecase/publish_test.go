@@ -0,0 +1,57 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestDraftSkillsAreNotSynced(t *testing.T) {
+	mock, syncSvc := setupSyncEnv()
+	addGlobalSkill(mock, "ready")
+	mock.Dirs["/home/test/.agents/skills/wip"] = true
+	mock.Files["/home/test/.agents/skills/wip/SKILL.md"] = []byte("---\nname: wip\ndraft: true\ndescription: In progress\n---\nbody\n")
+
+	results, err := syncSvc.Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	for _, r := range results {
+		if r.SkillName == "wip" {
+			t.Fatalf("draft skill should not be synced: %+v", r)
+		}
+	}
+	if mock.IsSymlink("/home/test/.claude/skills/wip") {
+		t.Fatal("draft skill should not be installed")
+	}
+
+	svc := usecase.NewPublishService(mock, config.DefaultConfig(), "")
+	if _, err := svc.Publish(usecase.PublishOptions{Name: "ready"}); err == nil {
+		t.Fatal("Publish() expected error for a non-draft skill")
+	}
+	if _, err := svc.Publish(usecase.PublishOptions{Name: "missing"}); err == nil {
+		t.Fatal("Publish() expected error for a missing skill")
+	}
+
+	sk, err := svc.Publish(usecase.PublishOptions{Name: "wip"})
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if sk.Scope != skill.ScopeGlobal || sk.Draft() {
+		t.Fatalf("Publish() = %+v", sk)
+	}
+	content := string(mock.Files["/home/test/.agents/skills/wip/SKILL.md"])
+	if strings.Contains(content, "draft") || !strings.Contains(content, "description: In progress") {
+		t.Fatalf("unexpected SKILL.md after publish:\n%s", content)
+	}
+
+	if _, err := syncSvc.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !mock.IsSymlink("/home/test/.claude/skills/wip") {
+		t.Fatal("published skill should be installed by sync")
+	}
+}
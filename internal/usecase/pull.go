@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// pullSlack absorbs the truncation of sync log times to whole seconds, so a
+// copy written during a sync does not count as edited after it.
+const pullSlack = time.Second
+
+// PullOptions contains options for finding copies edited in targets.
+type PullOptions struct {
+	// Names limits the search to these skills (empty for all)
+	Names []string
+	// Scope limits the search to a specific scope (nil for all)
+	Scope *skill.Scope
+	// Target limits the search to a single target (empty for all)
+	Target string
+}
+
+// PullChange is a copy of a skill in a target that was edited after skillet
+// last synced it.
+type PullChange struct {
+	SkillName string
+	Target    string
+	// Path is the copy in the target
+	Path string
+	// StorePath is the skill's directory in the store
+	StorePath string
+	// EditedAt is when the copy was last changed, and SyncedAt when skillet
+	// last synced it
+	EditedAt time.Time
+	SyncedAt time.Time
+	// StoreChanged is true when the store skill also changed after the sync,
+	// so pulling the copy would discard those changes
+	StoreChanged bool
+	// Files lists the changes the copy makes, as diffs from the files synced
+	// from the store to the copy's
+	Files []FileDiff
+	// Error is why the copy cannot be pulled
+	Error error
+	// skillMD is written to the store in place of the copy's SKILL.md when
+	// the target rewrites SKILL.md (nil to write the copy's)
+	skillMD []byte
+}
+
+// PullService copies changes made to installed copies back into the store.
+type PullService struct {
+	fs      platformfs.FileSystem
+	store   *skill.Store
+	targets *TargetRegistry
+}
+
+// NewPullService creates a new pull service.
+func NewPullService(fsys platformfs.FileSystem, cfg *config.Config, root string) *PullService {
+	return &PullService{
+		fs:      fsys,
+		store:   skill.NewStore(fsys, cfg, root),
+		targets: NewTargetRegistry(fsys, root, cfg),
+	}
+}
+
+// Changes finds the copies of skills that differ from the store and were
+// changed after skillet last synced them, ordered by skill name, then by
+// target name. Symlinks always show the store, and copies that only lag
+// behind the store are left to sync.
+func (s *PullService) Changes(opts PullOptions) ([]PullChange, error) {
+	skills, err := s.store.GetResolved()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	if opts.Scope != nil {
+		skills = filterSkillsByScope(skills, *opts.Scope)
+	}
+	for _, name := range opts.Names {
+		if !slices.ContainsFunc(skills, func(sk *skill.Skill) bool { return sk.Name == name }) {
+			return nil, fmt.Errorf("skill not found: %s", name)
+		}
+	}
+	if len(opts.Names) > 0 {
+		skills = slices.DeleteFunc(skills, func(sk *skill.Skill) bool { return !slices.Contains(opts.Names, sk.Name) })
+	}
+
+	targets := s.targets.GetAll()
+	if opts.Target != "" {
+		t, err := s.targets.Lookup(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		targets = []*Target{t}
+	}
+
+	var changes []PullChange
+	storeSums := make(map[string]string, len(skills))
+	for _, sk := range skills {
+		for _, t := range targets {
+			if !t.IsInstalledInScope(sk.Name, sk.Scope) || !t.manages(sk) || !t.copyOutdated(sk, storeSums) {
+				continue
+			}
+			if change, ok := s.change(t, sk); ok {
+				changes = append(changes, change)
+			}
+		}
+	}
+	slices.SortStableFunc(changes, func(a, b PullChange) int {
+		return cmp.Or(cmp.Compare(a.SkillName, b.SkillName), cmp.Compare(a.Target, b.Target))
+	})
+	return changes, nil
+}
+
+// change describes the copy of sk in t, which differs from the store, when it
+// was edited after its last sync.
+func (s *PullService) change(t *Target, sk *skill.Skill) (PullChange, bool) {
+	path, err := t.GetInstallPath(sk.Name, sk.Scope)
+	if err != nil {
+		return PullChange{}, false
+	}
+	syncedAt, _ := t.SyncedAt(sk.Name, sk.Scope)
+	change := PullChange{
+		SkillName: sk.Name,
+		Target:    t.Name(),
+		Path:      path,
+		StorePath: sk.Path,
+		EditedAt:  latestModTime(s.fs, path),
+		SyncedAt:  syncedAt,
+	}
+	if !change.EditedAt.After(syncedAt.Add(pullSlack)) {
+		slog.Debug("copy differs from the store but was not edited since it was synced", "target", t.Name(), "skill", sk.Name, "copy", path)
+		return PullChange{}, false
+	}
+	change.StoreChanged = latestModTime(s.fs, sk.Path).After(syncedAt.Add(pullSlack))
+
+	switch {
+	case sk.ReadOnly():
+		change.Error = fmt.Errorf("skill %s is in the read-only %s store", sk.Name, sk.Scope)
+		return change, true
+	case sk.Vendored:
+		change.Error = fmt.Errorf("skill %s is a vendored copy; change it in its own store", sk.Name)
+		return change, true
+	case t.layout != nil:
+		change.Error = fmt.Errorf("target %s lays out copies differently from the store; change the store copy instead", t.Name())
+		return change, true
+	}
+
+	synced, err := t.deployedFiles(sk)
+	if err != nil {
+		change.Error = err
+		return change, true
+	}
+	edited, err := readSkillFiles(s.fs, path)
+	if err != nil {
+		change.Error = err
+		return change, true
+	}
+	change.Files = diffFiles(s.fs, sk.Path, path, synced, edited)
+	if t.transformed() && edited["SKILL.md"] != nil && !bytes.Equal(synced["SKILL.md"], edited["SKILL.md"]) {
+		store, err := s.fs.ReadFile(s.fs.Join(sk.Path, "SKILL.md"))
+		if err != nil {
+			change.Error = err
+			return change, true
+		}
+		if change.skillMD, err = storeSkillMD(store, synced["SKILL.md"], edited["SKILL.md"]); err != nil {
+			change.Error = fmt.Errorf("target %s: %w", t.Name(), err)
+		}
+	}
+	return change, true
+}
+
+// storeSkillMD returns the SKILL.md to write into the store for a copy in a
+// target that rewrites it: synced is the SKILL.md as synced into the target,
+// and edited as it is now. When the target changed the frontmatter, such as
+// by stripping keys, only the edited body is kept, under the store's
+// frontmatter, so the target's changes do not leak into the store. Edits to
+// such frontmatter cannot be told from the target's and are refused.
+func storeSkillMD(store, synced, edited []byte) ([]byte, error) {
+	edited = stripBanner(edited)
+	storeEnd, syncedEnd, editedEnd := skill.FrontmatterEnd(store), skill.FrontmatterEnd(synced), skill.FrontmatterEnd(edited)
+	if bytes.Equal(store[:storeEnd], synced[:syncedEnd]) {
+		return edited, nil
+	}
+	if !bytes.Equal(synced[:syncedEnd], edited[:editedEnd]) {
+		return nil, errors.New("the frontmatter of SKILL.md was edited, but the target rewrites it; change the store copy instead")
+	}
+	merged := make([]byte, 0, storeEnd+len(edited)-editedEnd)
+	merged = append(merged, store[:storeEnd]...)
+	return append(merged, edited[editedEnd:]...), nil
+}
+
+// Pull copies the changes of c into the store: files the copy added or
+// changed are written to the store skill, without the skillet banner, and
+// files the copy removed are removed from it. Files the copy did not change
+// keep their store content, and an edited SKILL.md keeps the store's
+// frontmatter when the target rewrote it, so the target's transforms do not
+// leak into the store.
+func (s *PullService) Pull(c PullChange) error {
+	if c.Error != nil {
+		return c.Error
+	}
+	for _, f := range c.Files {
+		dst := s.fs.Join(c.StorePath, f.Path)
+		if f.Kind == FileRemoved {
+			if err := s.fs.Remove(dst); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", f.Path, err)
+			}
+			continue
+		}
+
+		src := s.fs.Join(c.Path, f.Path)
+		data, err := s.fs.ReadFile(src)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.Path, err)
+		}
+		if f.Path == "SKILL.md" {
+			data = stripBanner(data)
+			if c.skillMD != nil {
+				data = c.skillMD
+			}
+		}
+		perm := 0o644
+		if info, err := s.fs.Stat(src); err == nil && info.Mode().Perm()&0o111 != 0 {
+			perm = 0o755
+		}
+		if err := s.fs.MkdirAll(s.fs.Dir(dst), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := s.fs.WriteFile(dst, data, os.FileMode(perm)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestPull(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "edited")
+	addGlobalSkill(mock, "behind")
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	// A teammate edits the claude copy of one skill, while the store copy of
+	// the other moves ahead of its installs.
+	later := time.Now().Add(time.Hour)
+	copyFile := "/home/test/.claude/skills/edited/SKILL.md"
+	mock.Files[copyFile] = []byte("---\nname: edited\n---\nbetter\n")
+	mock.ModTimes[copyFile] = later
+	mock.Files["/home/test/.claude/skills/edited/notes.md"] = []byte("notes\n")
+	mock.ModTimes["/home/test/.claude/skills/edited/notes.md"] = later
+	storeFile := "/home/test/.agents/skills/behind/SKILL.md"
+	mock.Files[storeFile] = []byte("---\nname: behind\n---\nv2\n")
+	mock.ModTimes[storeFile] = later
+
+	svc := usecase.NewPullService(mock, cfg, "")
+	changes, err := svc.Changes(usecase.PullOptions{})
+	if err != nil {
+		t.Fatalf("Changes() error = %v", err)
+	}
+	if len(changes) != 1 || changes[0].SkillName != "edited" || changes[0].Target != "claude" || changes[0].StoreChanged {
+		t.Fatalf("Changes() = %+v, want only the edited claude copy", changes)
+	}
+	var files []string
+	for _, f := range changes[0].Files {
+		files = append(files, f.Path+":"+string(f.Kind))
+	}
+	if got := strings.Join(files, ","); got != "SKILL.md:modified,notes.md:added" {
+		t.Errorf("files = %s, want SKILL.md:modified,notes.md:added", got)
+	}
+	if !strings.Contains(changes[0].Files[0].Diff, "+better") {
+		t.Errorf("diff = %q, want the copy's change", changes[0].Files[0].Diff)
+	}
+
+	if err := svc.Pull(changes[0]); err != nil {
+		t.Fatalf("Pull() error = %v", err)
+	}
+	if got := string(mock.Files["/home/test/.agents/skills/edited/SKILL.md"]); got != "---\nname: edited\n---\nbetter\n" {
+		t.Errorf("store SKILL.md = %q, want the edited copy", got)
+	}
+	if !mock.Exists("/home/test/.agents/skills/edited/notes.md") {
+		t.Error("Pull() should add files the copy added")
+	}
+	if got := string(mock.Files[storeFile]); got != "---\nname: behind\n---\nv2\n" {
+		t.Errorf("a copy behind the store must not be pulled, store = %q", got)
+	}
+}
+
+func TestPullStoreChanged(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "both")
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	later := time.Now().Add(time.Hour)
+	for path, body := range map[string]string{
+		"/home/test/.codex/skills/both/SKILL.md":  "copy",
+		"/home/test/.agents/skills/both/SKILL.md": "store",
+	} {
+		mock.Files[path] = []byte("---\nname: both\n---\n" + body + "\n")
+		mock.ModTimes[path] = later
+	}
+
+	changes, err := usecase.NewPullService(mock, cfg, "").Changes(usecase.PullOptions{Names: []string{"both"}})
+	if err != nil {
+		t.Fatalf("Changes() error = %v", err)
+	}
+	// The claude copy is only behind the store.
+	if len(changes) != 1 || changes[0].Target != "codex" || !changes[0].StoreChanged {
+		t.Errorf("Changes() = %+v, want the codex copy with the store changed too", changes)
+	}
+
+	if _, err := usecase.NewPullService(mock, cfg, "").Changes(usecase.PullOptions{Names: []string{"missing"}}); err == nil {
+		t.Error("Changes() should fail for an unknown skill")
+	}
+}
+
+func TestPullKeepsStrippedKeys(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	storeFile := "/home/test/.agents/skills/tagged/SKILL.md"
+	mock.Dirs["/home/test/.agents/skills/tagged"] = true
+	mock.Files[storeFile] = []byte("---\nname: tagged\ntags: [pdf]\nlicense: MIT\n---\nold\n")
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	cfg.Targets["codex"] = config.TargetConfig{Enabled: false}
+	claude := cfg.Targets["claude"]
+	claude.StripFrontmatterKeys = []string{"tags", "license"}
+	cfg.Targets["claude"] = claude
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	later := time.Now().Add(time.Hour)
+	copyFile := "/home/test/.claude/skills/tagged/SKILL.md"
+	mock.Files[copyFile] = []byte("---\nname: tagged\n---\nnew\n")
+	mock.ModTimes[copyFile] = later
+
+	svc := usecase.NewPullService(mock, cfg, "")
+	changes, err := svc.Changes(usecase.PullOptions{})
+	if err != nil {
+		t.Fatalf("Changes() error = %v", err)
+	}
+	if len(changes) != 1 || changes[0].Error != nil {
+		t.Fatalf("Changes() = %+v, want the edited claude copy", changes)
+	}
+	if err := svc.Pull(changes[0]); err != nil {
+		t.Fatalf("Pull() error = %v", err)
+	}
+	if got := string(mock.Files[storeFile]); got != "---\nname: tagged\ntags: [pdf]\nlicense: MIT\n---\nnew\n" {
+		t.Errorf("store SKILL.md = %q, want the new body under the store's frontmatter", got)
+	}
+
+	// The frontmatter of the copy cannot be pulled, since the target rewrote it.
+	mock.Files[copyFile] = []byte("---\nname: tagged\ndescription: added\n---\nnew\n")
+	changes, err = svc.Changes(usecase.PullOptions{})
+	if err != nil {
+		t.Fatalf("Changes() error = %v", err)
+	}
+	if len(changes) != 1 || changes[0].Error == nil {
+		t.Fatalf("Changes() = %+v, want the frontmatter edit refused", changes)
+	}
+	if err := svc.Pull(changes[0]); err == nil {
+		t.Error("Pull() should refuse a frontmatter edit in a target that rewrites it")
+	}
+}
@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// PushAction represents the outcome of pushing a single skill.
+type PushAction string
+
+const (
+	PushActionUpload    PushAction = "upload"
+	PushActionUpdate    PushAction = "update"
+	PushActionUnchanged PushAction = "unchanged"
+	PushActionDelete    PushAction = "delete"
+	PushActionError     PushAction = "error"
+)
+
+const (
+	// DefaultPushDest is the remote directory skills are mirrored into by default.
+	DefaultPushDest = "~/.agents/skills"
+	// pushStateFile records the checksums of pushed skills in the remote directory.
+	pushStateFile = ".skillet-push.yaml"
+)
+
+// PushOptions contains options for pushing skills to a remote host.
+type PushOptions struct {
+	// Remote is the file system of the destination host
+	Remote platformfs.FileSystem
+	// Dest is the remote directory to mirror skills into (default DefaultPushDest)
+	Dest string
+	// DryRun only shows what would be done without making changes
+	DryRun bool
+}
+
+// PushResult represents the result of pushing a single skill.
+type PushResult struct {
+	SkillName string
+	Action    PushAction
+	Error     error
+}
+
+// pushState is the content of the remote push state file.
+type pushState struct {
+	// Skills maps each pushed skill to the checksum of its pushed content.
+	Skills map[string]string `yaml:"skills"`
+}
+
+// PushService mirrors the resolved skill set to another file system.
+type PushService struct {
+	fs    platformfs.FileSystem
+	store *skill.Store
+}
+
+// NewPushService creates a new push service.
+func NewPushService(fsys platformfs.FileSystem, cfg *config.Config, root string) *PushService {
+	return &PushService{
+		fs:    fsys,
+		store: skill.NewStore(fsys, cfg, root),
+	}
+}
+
+// Push mirrors the resolved skills into opts.Dest on opts.Remote.
+// Only skills whose checksum differs from the last push are transferred, and
+// skills removed locally since the last push are deleted. Entries in Dest that
+// skillet did not push are left alone.
+func (s *PushService) Push(opts PushOptions) ([]PushResult, error) {
+	dest := opts.Dest
+	if dest == "" {
+		dest = DefaultPushDest
+	}
+	dest, err := config.ExpandPath(opts.Remote, dest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve remote directory: %w", err)
+	}
+
+	skills, err := s.store.GetResolved()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	slices.SortFunc(skills, func(a, b *skill.Skill) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	statePath := opts.Remote.Join(dest, pushStateFile)
+	state, err := loadPushState(opts.Remote, statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.DryRun {
+		if err := opts.Remote.MkdirAll(dest, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create remote directory: %w", err)
+		}
+	}
+
+	var results []PushResult
+	pushed := make(map[string]bool, len(skills))
+	for _, sk := range skills {
+		pushed[sk.Name] = true
+		result := PushResult{SkillName: sk.Name}
+
+		sum, err := dirChecksum(s.fs, sk.Path)
+		if err != nil {
+			result.Action = PushActionError
+			result.Error = fmt.Errorf("failed to checksum skill: %w", err)
+			results = append(results, result)
+			continue
+		}
+
+		dst := opts.Remote.Join(dest, sk.Name)
+		prev, known := state.Skills[sk.Name]
+		switch {
+		case known && prev == sum && opts.Remote.IsDir(dst):
+			result.Action = PushActionUnchanged
+			results = append(results, result)
+			continue
+		case known || opts.Remote.Exists(dst):
+			result.Action = PushActionUpdate
+		default:
+			result.Action = PushActionUpload
+		}
+
+		if !opts.DryRun {
+			if err := replaceAcross(s.fs, opts.Remote, sk.Path, dst); err != nil {
+				result.Action = PushActionError
+				result.Error = err
+				results = append(results, result)
+				continue
+			}
+			state.Skills[sk.Name] = sum
+		}
+		results = append(results, result)
+	}
+
+	var stale []string
+	for name := range state.Skills {
+		if !pushed[name] {
+			stale = append(stale, name)
+		}
+	}
+	slices.Sort(stale)
+	for _, name := range stale {
+		result := PushResult{SkillName: name, Action: PushActionDelete}
+		// The state file lives on the remote, so a name from it must not
+		// reach outside dest.
+		if err := skill.ValidateQualifiedName(name); err != nil {
+			result.Action = PushActionError
+			result.Error = fmt.Errorf("invalid skill in push state: %w", err)
+			results = append(results, result)
+			if !opts.DryRun {
+				delete(state.Skills, name)
+			}
+			continue
+		}
+		if !opts.DryRun {
+			if err := opts.Remote.RemoveAll(opts.Remote.Join(dest, name)); err != nil {
+				result.Action = PushActionError
+				result.Error = fmt.Errorf("failed to delete remote skill: %w", err)
+				results = append(results, result)
+				continue
+			}
+			delete(state.Skills, name)
+		}
+		results = append(results, result)
+	}
+
+	if !opts.DryRun {
+		if err := savePushState(opts.Remote, statePath, state); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// replaceAcross replaces dstDir on dst with a copy of srcDir from src.
+func replaceAcross(src, dst platformfs.FileSystem, srcDir, dstDir string) error {
+	if err := dst.RemoveAll(dstDir); err != nil {
+		return fmt.Errorf("failed to remove remote copy: %w", err)
+	}
+	return copyAcross(src, dst, srcDir, dstDir)
+}
+
+// copyAcross copies srcDir on src to dstDir on dst, following symlinks.
+func copyAcross(src, dst platformfs.FileSystem, srcDir, dstDir string) error {
+	entries, err := src.ReadDir(srcDir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+	if err := dst.MkdirAll(dstDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		srcPath := src.Join(srcDir, entry.Name())
+		dstPath := dst.Join(dstDir, entry.Name())
+		if src.IsDir(srcPath) {
+			if err := copyAcross(src, dst, srcPath, dstPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data, err := src.ReadFile(srcPath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", srcPath, err)
+		}
+		perm := 0o644
+		if info, err := src.Stat(srcPath); err == nil && info.Mode().Perm()&0o111 != 0 {
+			perm = 0o755
+		}
+		if err := dst.WriteFile(dstPath, data, os.FileMode(perm)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", dstPath, err)
+		}
+	}
+	return nil
+}
+
+// loadPushState reads the remote push state. A missing file is an empty state.
+func loadPushState(fsys platformfs.FileSystem, path string) (*pushState, error) {
+	state := &pushState{Skills: make(map[string]string)}
+	if !fsys.Exists(path) {
+		return state, nil
+	}
+
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read push state: %w", err)
+	}
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse push state: %w", err)
+	}
+	if state.Skills == nil {
+		state.Skills = make(map[string]string)
+	}
+	return state, nil
+}
+
+// savePushState writes the remote push state.
+func savePushState(fsys platformfs.FileSystem, path string, state *pushState) error {
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal push state: %w", err)
+	}
+	if err := fsys.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write push state: %w", err)
+	}
+	return nil
+}
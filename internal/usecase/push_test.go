@@ -0,0 +1,110 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestPushIncremental(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	addGlobalSkill(mock, "beta")
+
+	remote := platformfs.NewMockFileSystem()
+	remote.HomeDir = "/home/dev"
+	svc := usecase.NewPushService(mock, config.DefaultConfig(), "")
+
+	push := func(dryRun bool) map[string]usecase.PushAction {
+		t.Helper()
+		results, err := svc.Push(usecase.PushOptions{Remote: remote, DryRun: dryRun})
+		if err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+		actions := make(map[string]usecase.PushAction)
+		for _, r := range results {
+			if r.Error != nil {
+				t.Fatalf("Push() %s error = %v", r.SkillName, r.Error)
+			}
+			actions[r.SkillName] = r.Action
+		}
+		return actions
+	}
+
+	if got := push(true); got["alpha"] != usecase.PushActionUpload || remote.Exists("/home/dev/.agents/skills/alpha") {
+		t.Fatalf("dry run = %v, want upload without changes", got)
+	}
+
+	push(false)
+	if string(remote.Files["/home/dev/.agents/skills/alpha/SKILL.md"]) != "---\nname: alpha\n---\n" {
+		t.Fatal("Push() did not copy alpha")
+	}
+
+	mock.Files["/home/test/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\n---\nchanged\n")
+	delete(mock.Dirs, "/home/test/.agents/skills/beta")
+	delete(mock.Files, "/home/test/.agents/skills/beta/SKILL.md")
+	addGlobalSkill(mock, "gamma")
+
+	got := push(false)
+	want := map[string]usecase.PushAction{
+		"alpha": usecase.PushActionUpdate,
+		"beta":  usecase.PushActionDelete,
+		"gamma": usecase.PushActionUpload,
+	}
+	for name, action := range want {
+		if got[name] != action {
+			t.Errorf("%s = %s, want %s", name, got[name], action)
+		}
+	}
+	if remote.Exists("/home/dev/.agents/skills/beta") {
+		t.Error("Push() should delete skills removed locally")
+	}
+
+	if got := push(false); got["alpha"] != usecase.PushActionUnchanged || got["gamma"] != usecase.PushActionUnchanged {
+		t.Errorf("third push = %v, want unchanged", got)
+	}
+}
+
+func TestPushRejectsUnsafeStateNames(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+
+	remote := platformfs.NewMockFileSystem()
+	remote.HomeDir = "/home/dev"
+	remote.Dirs["/home/dev/.agents"] = true
+	remote.Dirs["/home/dev/.agents/skills"] = true
+	remote.Files["/home/dev/.agents/skills/.skillet-push.yaml"] = []byte("skills:\n  ../..: abc\n  /etc: abc\n")
+	remote.Dirs["/home/dev/keep"] = true
+	remote.Files["/home/dev/keep/file"] = []byte("keep\n")
+
+	results, err := usecase.NewPushService(mock, config.DefaultConfig(), "").Push(usecase.PushOptions{Remote: remote})
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	var rejected int
+	for _, r := range results {
+		switch r.SkillName {
+		case "alpha":
+			if r.Action != usecase.PushActionUpload {
+				t.Errorf("alpha = %s, want upload", r.Action)
+			}
+		default:
+			if r.Action != usecase.PushActionError || r.Error == nil || !strings.Contains(r.Error.Error(), "invalid skill") {
+				t.Errorf("%s = %+v, want an invalid skill error", r.SkillName, r)
+			}
+			rejected++
+		}
+	}
+	if rejected != 2 {
+		t.Errorf("rejected %d names, want 2", rejected)
+	}
+	if !remote.Exists("/home/dev/keep/file") {
+		t.Error("Push() must not delete outside the remote skills directory")
+	}
+	if state := string(remote.Files["/home/dev/.agents/skills/.skillet-push.yaml"]); strings.Contains(state, "..") {
+		t.Errorf("push state = %q, want the invalid names dropped", state)
+	}
+}
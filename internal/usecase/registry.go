@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// RegistryIndex is the JSON index a registry publishes.
+type RegistryIndex struct {
+	Version int             `json:"version"`
+	Skills  []RegistrySkill `json:"skills"`
+	// Stale is set when the index could not be downloaded and an expired
+	// cached copy was used instead.
+	Stale bool `json:"-"`
+}
+
+// RegistrySkill is a skill listed in a registry index.
+type RegistrySkill struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Source is where the skill is fetched from, as given to skillet add
+	Source string `json:"source"`
+	// Checksum is the skill's content checksum, as recorded in skillet.lock
+	Checksum string   `json:"checksum"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// RegistryInstallOptions contains options for installing a skill from the registry.
+type RegistryInstallOptions struct {
+	// Name is the skill's name in the registry
+	Name string
+	// Scope is the store to add the skill to (global or project)
+	Scope skill.Scope
+	// Force replaces an existing skill of the same name
+	Force bool
+	// DryRun only looks the skill up without fetching it
+	DryRun bool
+}
+
+// RegistryService searches a registry index and installs skills listed in it.
+type RegistryService struct {
+	fs         platformfs.FileSystem
+	cfg        *config.Config
+	downloader fetch.Downloader
+	add        *AddService
+	now        func() time.Time
+}
+
+// NewRegistryService creates a new registry service that downloads the
+// index with downloader and fetches skills with fetcher.
+func NewRegistryService(fsys platformfs.FileSystem, cfg *config.Config, root string, downloader fetch.Downloader, fetcher fetch.Fetcher) *RegistryService {
+	return &RegistryService{
+		fs:         fsys,
+		cfg:        cfg,
+		downloader: downloader,
+		add:        NewAddService(fsys, cfg, root, fetcher),
+		now:        time.Now,
+	}
+}
+
+// Index returns the registry index. A cached copy younger than the
+// configured cache age is used unless refresh is set; otherwise the index is
+// downloaded and cached. When the download fails, an expired cached copy is
+// used and marked stale.
+func (s *RegistryService) Index(ctx context.Context, refresh bool) (*RegistryIndex, error) {
+	url := s.cfg.Registry.Index
+	if url == "" {
+		return nil, fmt.Errorf("no registry configured (set registry.index in the config file)")
+	}
+	cacheDir, err := config.CachePath(s.fs)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(url))
+	cacheFile := s.fs.Join(cacheDir, "registry", hex.EncodeToString(sum[:8])+".json")
+
+	cached, cachedErr := s.fs.ReadFile(cacheFile)
+	if cachedErr == nil && !refresh {
+		if info, err := s.fs.Stat(cacheFile); err == nil && s.now().Sub(info.ModTime()) < s.cfg.RegistryCacheAge() {
+			return parseRegistryIndex(cached)
+		}
+	}
+
+	data, err := s.downloader.Download(ctx, url)
+	if err != nil {
+		if cachedErr != nil {
+			return nil, err
+		}
+		index, parseErr := parseRegistryIndex(cached)
+		if parseErr != nil {
+			return nil, err
+		}
+		index.Stale = true
+		return index, nil
+	}
+	index, err := parseRegistryIndex(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fs.MkdirAll(s.fs.Dir(cacheFile), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := s.fs.WriteFile(cacheFile, data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to cache registry index: %w", err)
+	}
+	return index, nil
+}
+
+func parseRegistryIndex(data []byte) (*RegistryIndex, error) {
+	var index RegistryIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse registry index: %w", err)
+	}
+	if index.Version != 1 {
+		return nil, fmt.Errorf("unsupported registry index version %d", index.Version)
+	}
+	return &index, nil
+}
+
+// Search returns the skills in index whose name, description, or tags
+// contain query, ignoring case. Skills whose name matches come first; each
+// group is sorted by name. An empty query matches every skill.
+func Search(index *RegistryIndex, query string) []RegistrySkill {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var byName, other []RegistrySkill
+	for _, sk := range index.Skills {
+		switch {
+		case strings.Contains(strings.ToLower(sk.Name), query):
+			byName = append(byName, sk)
+		case strings.Contains(strings.ToLower(sk.Description), query),
+			slices.ContainsFunc(sk.Tags, func(tag string) bool { return strings.Contains(strings.ToLower(tag), query) }):
+			other = append(other, sk)
+		}
+	}
+	byNameOrder := func(a, b RegistrySkill) int { return cmp.Compare(a.Name, b.Name) }
+	slices.SortFunc(byName, byNameOrder)
+	slices.SortFunc(other, byNameOrder)
+	return append(byName, other...)
+}
+
+// Install looks a skill up in the registry and adds it from its source,
+// checking the fetched content against the checksum in the index.
+func (s *RegistryService) Install(ctx context.Context, index *RegistryIndex, opts RegistryInstallOptions) (RegistrySkill, *AddResult, error) {
+	i := slices.IndexFunc(index.Skills, func(sk RegistrySkill) bool { return sk.Name == opts.Name })
+	if i < 0 {
+		return RegistrySkill{}, nil, fmt.Errorf("skill not found in registry: %s", opts.Name)
+	}
+	entry := index.Skills[i]
+	if entry.Source == "" || entry.Checksum == "" {
+		return entry, nil, fmt.Errorf("registry entry for %s needs a source and a checksum", entry.Name)
+	}
+	if opts.DryRun {
+		return entry, nil, nil
+	}
+
+	result, err := s.add.Add(ctx, AddOptions{
+		Source:   entry.Source,
+		Name:     entry.Name,
+		Scope:    opts.Scope,
+		Force:    opts.Force,
+		Checksum: entry.Checksum,
+	})
+	return entry, result, err
+}
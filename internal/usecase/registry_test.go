@@ -0,0 +1,116 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+type mockDownloader struct {
+	content string
+	err     error
+	calls   int
+}
+
+func (d *mockDownloader) Download(context.Context, string) ([]byte, error) {
+	d.calls++
+	return []byte(d.content), d.err
+}
+
+const registryCache = "/home/test/.cache/skillet/registry/"
+
+func TestRegistryIndexCache(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	cfg := config.DefaultConfig()
+	cfg.Registry.Index = "https://registry.example.com/index.json"
+	downloader := &mockDownloader{content: `{"version":1,"skills":[{"name":"pdf","source":"github.com/org/skills/pdf","checksum":"abc"}]}`}
+	svc := usecase.NewRegistryService(mock, cfg, "", downloader, &mockFetcher{fs: mock})
+
+	index, err := svc.Index(t.Context(), false)
+	if err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if len(index.Skills) != 1 || index.Stale || downloader.calls != 1 {
+		t.Fatalf("Index() = %+v after %d downloads, want one skill downloaded once", index, downloader.calls)
+	}
+	var cacheFile string
+	for path := range mock.Files {
+		if strings.HasPrefix(path, registryCache) {
+			cacheFile = path
+		}
+	}
+	if cacheFile == "" {
+		t.Fatal("expected the index to be cached")
+	}
+
+	// A recent cache is used as is; an expired one is downloaded again and,
+	// when that fails, used anyway.
+	mock.ModTimes[cacheFile] = time.Now()
+	if _, err := svc.Index(t.Context(), false); err != nil || downloader.calls != 1 {
+		t.Fatalf("Index() error = %v after %d downloads, want the cached index", err, downloader.calls)
+	}
+	mock.ModTimes[cacheFile] = time.Now().Add(-48 * time.Hour)
+	downloader.err = errors.New("network down")
+	index, err = svc.Index(t.Context(), false)
+	if err != nil || !index.Stale || downloader.calls != 2 {
+		t.Fatalf("Index() = %+v, %v after %d downloads, want the stale cached index", index, err, downloader.calls)
+	}
+
+	delete(mock.Files, cacheFile)
+	if _, err := svc.Index(t.Context(), true); err == nil {
+		t.Fatal("Index() should fail without a download or a cache")
+	}
+}
+
+func TestRegistrySearchAndInstall(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	cfg := config.DefaultConfig()
+	fetcher := &mockFetcher{fs: mock, files: map[string]string{"SKILL.md": "---\nname: pdf-tools\n---\nbody\n"}}
+	added, err := usecase.NewAddService(mock, cfg, "", fetcher).Add(t.Context(), usecase.AddOptions{Source: "github.com/org/skills/pdf-tools", Scope: skill.ScopeGlobal})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := mock.RemoveAll("/home/test/.agents/skills/pdf-tools"); err != nil {
+		t.Fatal(err)
+	}
+
+	index := &usecase.RegistryIndex{Version: 1, Skills: []usecase.RegistrySkill{
+		{Name: "pdf-tools", Source: "github.com/org/skills/pdf-tools", Checksum: added.Checksum},
+		{Name: "reports", Description: "Render PDF reports", Source: "github.com/org/skills/reports", Checksum: "0000"},
+		{Name: "lint", Tags: []string{"go"}, Source: "github.com/org/skills/lint", Checksum: "0000"},
+	}}
+	var names []string
+	for _, sk := range usecase.Search(index, "PDF") {
+		names = append(names, sk.Name)
+	}
+	if strings.Join(names, ",") != "pdf-tools,reports" {
+		t.Errorf("Search(PDF) = %v, want name matches before description matches", names)
+	}
+	if got := usecase.Search(index, "go"); len(got) != 1 || got[0].Name != "lint" {
+		t.Errorf("Search(go) = %+v, want lint by tag", got)
+	}
+
+	svc := usecase.NewRegistryService(mock, cfg, "", &mockDownloader{}, fetcher)
+	if _, result, err := svc.Install(t.Context(), index, usecase.RegistryInstallOptions{Name: "pdf-tools", Scope: skill.ScopeGlobal}); err != nil || result.Action != usecase.AddActionAdded {
+		t.Fatalf("Install() = %+v, %v, want pdf-tools added", result, err)
+	}
+	if !mock.Exists("/home/test/.agents/skills/pdf-tools/SKILL.md") {
+		t.Error("expected pdf-tools in the global store")
+	}
+
+	if _, _, err := svc.Install(t.Context(), index, usecase.RegistryInstallOptions{Name: "reports", Scope: skill.ScopeGlobal}); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Install() error = %v, want a checksum mismatch", err)
+	}
+	if mock.Exists("/home/test/.agents/skills/reports") {
+		t.Error("a skill that fails verification must not be installed")
+	}
+	if _, _, err := svc.Install(t.Context(), index, usecase.RegistryInstallOptions{Name: "missing", Scope: skill.ScopeGlobal}); err == nil {
+		t.Error("Install() should fail for a skill not in the registry")
+	}
+}
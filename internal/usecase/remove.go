@@ -2,25 +2,48 @@ package usecase
 
 import (
 	"fmt"
+	"path"
+	"slices"
+	"strings"
 
 	"github.com/wwwyo/skillet/internal/config"
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
 	"github.com/wwwyo/skillet/internal/skill"
 )
 
-// RemoveOptions contains options for removing a skill.
+// RemoveOptions contains options for removing skills.
 type RemoveOptions struct {
-	// Name is the skill name to remove
-	Name string
-	// Scope limits removal to a specific scope (nil to auto-detect)
+	// Names are the skills to remove, by name or by glob pattern
+	// (e.g. "legacy-*"; * does not match the / of collections)
+	Names []string
+	// All removes every skill in Scope instead of Names
+	All bool
+	// Scope limits removal to a specific scope (nil to auto-detect; required with All)
 	Scope *skill.Scope
+	// Target uninstalls the skills from this target only, keeping them in the
+	// store and other targets (empty for all targets)
+	Target string
+	// KeepStore uninstalls the skills from targets without removing them
+	// from the store
+	KeepStore bool
+	// DryRun only reports what would be removed, without making changes
+	DryRun bool
+}
+
+// Bulk reports whether opts selects skills by pattern or with All, rather than
+// by name only.
+func (o RemoveOptions) Bulk() bool {
+	return o.All || slices.ContainsFunc(o.Names, isPattern)
 }
 
 // RemoveResult represents the result of a remove operation.
 type RemoveResult struct {
-	SkillName     string
-	Scope         skill.Scope
-	StoreRemoved  bool
+	SkillName    string
+	Scope        skill.Scope
+	StoreRemoved bool
+	// StoreKept is true when the store copy was kept on purpose, with
+	// Target or KeepStore
+	StoreKept     bool
 	TargetResults []RemoveTargetResult
 	Error         error
 }
@@ -46,37 +69,173 @@ func NewRemoveService(fsys platformfs.FileSystem, cfg *config.Config, root strin
 	}
 }
 
-// Remove removes a skill from the store and all targets.
-func (s *RemoveService) Remove(opts RemoveOptions) *RemoveResult {
-	if err := skill.ValidateName(opts.Name); err != nil {
-		return &RemoveResult{SkillName: opts.Name, Error: fmt.Errorf("invalid skill name: %w", err)}
+// Match returns the skills opts selects, in the order of Names with the
+// skills matching each pattern sorted by name. Plain names resolve as for a
+// single skill, so each must exist; patterns must match at least one skill.
+// Without Scope, a pattern matches the copy of each skill that takes effect.
+func (s *RemoveService) Match(opts RemoveOptions) ([]*skill.Skill, error) {
+	if opts.All {
+		if opts.Scope == nil {
+			return nil, fmt.Errorf("removing all skills needs a scope")
+		}
+		if len(opts.Names) > 0 {
+			return nil, fmt.Errorf("skill names cannot be given with all")
+		}
+		skills, err := s.store.GetByScope(*opts.Scope)
+		if err != nil {
+			return nil, err
+		}
+		return sortedByName(skills), nil
+	}
+	if len(opts.Names) == 0 {
+		return nil, fmt.Errorf("no skill names given")
 	}
 
-	var sk *skill.Skill
-	var err error
-	if opts.Scope != nil {
-		sk, err = s.store.FindInScope(opts.Name, *opts.Scope)
-		if err != nil {
-			return &RemoveResult{
-				SkillName: opts.Name,
-				Scope:     *opts.Scope,
-				Error:     fmt.Errorf("skill not found in %s scope: %w", *opts.Scope, err),
+	var matched []*skill.Skill
+	seen := make(map[string]bool)
+	add := func(sk *skill.Skill) {
+		key := sk.Scope.String() + ":" + sk.Name
+		if !seen[key] {
+			seen[key] = true
+			matched = append(matched, sk)
+		}
+	}
+	for _, name := range opts.Names {
+		if !isPattern(name) {
+			sk, err := s.find(name, opts.Scope)
+			if err != nil {
+				return nil, err
 			}
+			add(sk)
+			continue
+		}
+
+		skills, err := s.matchPattern(name, opts.Scope)
+		if err != nil {
+			return nil, err
+		}
+		if len(skills) == 0 {
+			return nil, fmt.Errorf("no skills match %q", name)
 		}
+		for _, sk := range skills {
+			add(sk)
+		}
+	}
+	return matched, nil
+}
+
+// find resolves a single skill name, in scope when it is set.
+func (s *RemoveService) find(name string, scope *skill.Scope) (*skill.Skill, error) {
+	if err := skill.ValidateQualifiedName(name); err != nil {
+		return nil, fmt.Errorf("invalid skill name: %w", err)
+	}
+	if scope != nil {
+		sk, err := s.store.FindInScope(name, *scope)
+		if err != nil {
+			return nil, fmt.Errorf("skill not found in %s scope: %w", *scope, err)
+		}
+		return sk, nil
+	}
+	sk, err := s.store.GetByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("skill not found: %w", err)
+	}
+	return sk, nil
+}
+
+// matchPattern returns the skills whose names match pattern, sorted by name.
+func (s *RemoveService) matchPattern(pattern string, scope *skill.Scope) ([]*skill.Skill, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	var candidates []*skill.Skill
+	var err error
+	if scope != nil {
+		candidates, err = s.store.GetByScope(*scope)
 	} else {
-		sk, err = s.store.GetByName(opts.Name)
+		candidates, err = s.store.GetAll()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	var matched []*skill.Skill
+	seen := make(map[string]bool)
+	for _, sk := range candidates {
+		if ok, _ := path.Match(pattern, sk.Name); !ok || seen[sk.Name] {
+			continue
+		}
+		seen[sk.Name] = true
+		if scope == nil {
+			// The copy that takes effect, as for a plain name.
+			if sk, err = s.store.GetByName(sk.Name); err != nil {
+				return nil, err
+			}
+		}
+		matched = append(matched, sk)
+	}
+	return sortedByName(matched), nil
+}
+
+// isPattern reports whether name is a glob pattern rather than a skill name.
+func isPattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// sortedByName sorts skills by name in place and returns them.
+func sortedByName(skills []*skill.Skill) []*skill.Skill {
+	slices.SortFunc(skills, func(a, b *skill.Skill) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return skills
+}
+
+// Remove removes the skills opts selects from the store and all targets, or
+// with Target or KeepStore set, only uninstalls them from targets. Nothing is
+// removed when the selection fails; otherwise each skill has a result, which
+// records its own error. A dry run returns the same results without removing
+// anything.
+func (s *RemoveService) Remove(opts RemoveOptions) ([]*RemoveResult, error) {
+	targets := s.targets.GetAll()
+	if opts.Target != "" {
+		t, err := s.targets.Lookup(opts.Target)
 		if err != nil {
-			return &RemoveResult{SkillName: opts.Name, Error: fmt.Errorf("skill not found: %w", err)}
+			return nil, err
+		}
+		targets = []*Target{t}
+	}
+	skills, err := s.Match(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*RemoveResult, 0, len(skills))
+	for _, sk := range skills {
+		results = append(results, s.removeSkill(sk, targets, opts.KeepStore || opts.Target != "", opts.DryRun))
+	}
+	return results, nil
+}
+
+// removeSkill uninstalls sk from targets and, unless keepStore is set,
+// deletes it from the store. A dry run only checks what would be removed.
+func (s *RemoveService) removeSkill(sk *skill.Skill, targets []*Target, keepStore, dryRun bool) *RemoveResult {
+	if sk.ReadOnly() && !keepStore {
+		return &RemoveResult{
+			SkillName: sk.Name,
+			Scope:     sk.Scope,
+			Error:     fmt.Errorf("skill %s is in the read-only %s store", sk.Name, sk.Scope),
 		}
 	}
 
 	// Remove from targets first, before removing from store.
 	// This prevents leaving broken symlinks that would be skipped by exists checks.
-	targetResults := make([]RemoveTargetResult, 0, len(s.targets.GetAll()))
-	for _, t := range s.targets.GetAll() {
+	targetResults := make([]RemoveTargetResult, 0, len(targets))
+	for _, t := range targets {
 		result := RemoveTargetResult{Target: t.Name()}
 		if t.IsInstalled(sk.Name) {
-			if err := t.Uninstall(sk.Name); err != nil {
+			if dryRun {
+				result.Removed = true
+			} else if err := t.Uninstall(sk.Name); err != nil {
 				result.Error = err
 			} else {
 				result.Removed = true
@@ -84,12 +243,22 @@ func (s *RemoveService) Remove(opts RemoveOptions) *RemoveResult {
 		}
 		targetResults = append(targetResults, result)
 	}
-
-	if err := s.store.Remove(sk); err != nil {
+	if keepStore {
 		return &RemoveResult{
-			SkillName: sk.Name,
-			Scope:     sk.Scope,
-			Error:     fmt.Errorf("failed to remove from store: %w", err),
+			SkillName:     sk.Name,
+			Scope:         sk.Scope,
+			StoreKept:     true,
+			TargetResults: targetResults,
+		}
+	}
+
+	if !dryRun {
+		if err := s.store.Remove(sk); err != nil {
+			return &RemoveResult{
+				SkillName: sk.Name,
+				Scope:     sk.Scope,
+				Error:     fmt.Errorf("failed to remove from store: %w", err),
+			}
 		}
 	}
 
@@ -103,5 +272,5 @@ func (s *RemoveService) Remove(opts RemoveOptions) *RemoveResult {
 
 // Success returns true if the removal was successful.
 func (r *RemoveResult) Success() bool {
-	return r.StoreRemoved && r.Error == nil
+	return (r.StoreRemoved || r.StoreKept) && r.Error == nil
 }
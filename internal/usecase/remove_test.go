@@ -1,10 +1,12 @@
 package usecase_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/wwwyo/skillet/internal/config"
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
 	"github.com/wwwyo/skillet/internal/usecase"
 )
 
@@ -27,7 +29,11 @@ func TestRemoveExistingSkill(t *testing.T) {
 	cfg := config.DefaultConfig()
 	svc := usecase.NewRemoveService(mock, cfg, "")
 
-	result := svc.Remove(usecase.RemoveOptions{Name: "remove-me"})
+	results, err := svc.Remove(usecase.RemoveOptions{Names: []string{"remove-me"}})
+	if err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	result := results[0]
 	if result.Error != nil {
 		t.Fatalf("Remove() error = %v", result.Error)
 	}
@@ -38,3 +44,193 @@ func TestRemoveExistingSkill(t *testing.T) {
 		t.Fatal("skill should be removed from store")
 	}
 }
+
+func TestRemoveFromTargetsOnly(t *testing.T) {
+	setup := func() *platformfs.MockFileSystem {
+		mock := platformfs.NewMockFileSystem()
+		mock.HomeDir = "/home/test"
+		for _, dir := range []string{
+			"/home/test/.agents",
+			"/home/test/.agents/skills",
+			"/home/test/.agents/skills/keep-me",
+			"/home/test/.claude/skills/keep-me",
+			"/home/test/.codex/skills/keep-me",
+		} {
+			mock.Dirs[dir] = true
+		}
+		mock.Files["/home/test/.agents/skills/keep-me/SKILL.md"] = []byte("---\nname: keep-me\n---\n")
+		return mock
+	}
+
+	t.Run("one target", func(t *testing.T) {
+		mock := setup()
+		results, err := usecase.NewRemoveService(mock, config.DefaultConfig(), "").Remove(usecase.RemoveOptions{Names: []string{"keep-me"}, Target: "claude"})
+		if err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		result := results[0]
+		if result.StoreRemoved || !result.StoreKept || !result.Success() {
+			t.Errorf("Remove() = %+v, want the store kept", result)
+		}
+		if len(result.TargetResults) != 1 || result.TargetResults[0].Target != "claude" || !result.TargetResults[0].Removed {
+			t.Errorf("TargetResults = %+v, want claude only", result.TargetResults)
+		}
+		if mock.Exists("/home/test/.claude/skills/keep-me") {
+			t.Error("skill should be removed from claude")
+		}
+		if !mock.Exists("/home/test/.codex/skills/keep-me") || !mock.Exists("/home/test/.agents/skills/keep-me") {
+			t.Error("skill should stay in the store and codex")
+		}
+	})
+
+	t.Run("keep store", func(t *testing.T) {
+		mock := setup()
+		results, err := usecase.NewRemoveService(mock, config.DefaultConfig(), "").Remove(usecase.RemoveOptions{Names: []string{"keep-me"}, KeepStore: true})
+		if err != nil || len(results) != 1 || results[0].Error != nil || !results[0].StoreKept || len(results[0].TargetResults) != 2 {
+			t.Fatalf("Remove() = %+v, %v", results, err)
+		}
+		if mock.Exists("/home/test/.claude/skills/keep-me") || mock.Exists("/home/test/.codex/skills/keep-me") {
+			t.Error("skill should be removed from every target")
+		}
+		if !mock.Exists("/home/test/.agents/skills/keep-me") {
+			t.Error("skill should stay in the store")
+		}
+	})
+
+	t.Run("unknown target", func(t *testing.T) {
+		mock := setup()
+		_, err := usecase.NewRemoveService(mock, config.DefaultConfig(), "").Remove(usecase.RemoveOptions{Names: []string{"keep-me"}, Target: "nope"})
+		if err == nil {
+			t.Fatal("Remove() expected error for unknown target")
+		}
+		if !mock.Exists("/home/test/.claude/skills/keep-me") {
+			t.Error("nothing should be removed")
+		}
+	})
+}
+
+func TestRemoveMany(t *testing.T) {
+	setup := func() *platformfs.MockFileSystem {
+		mock := platformfs.NewMockFileSystem()
+		mock.HomeDir = "/home/test"
+		mock.Dirs["/home/test/.agents"] = true
+		mock.Dirs["/home/test/.agents/skills"] = true
+		mock.Dirs["/project/.agents"] = true
+		mock.Dirs["/project/.agents/skills"] = true
+		for _, dir := range []string{
+			"/home/test/.agents/skills/legacy-b",
+			"/home/test/.agents/skills/legacy-a",
+			"/home/test/.agents/skills/review",
+			"/project/.agents/skills/legacy-a",
+			"/project/.agents/skills/lint",
+		} {
+			mock.Dirs[dir] = true
+			mock.Files[dir+"/SKILL.md"] = []byte("---\nname: " + mock.Base(dir) + "\n---\n")
+		}
+		return mock
+	}
+	names := func(results []*usecase.RemoveResult) []string {
+		var out []string
+		for _, r := range results {
+			if r.Error != nil {
+				t.Errorf("removing %s: %v", r.SkillName, r.Error)
+			}
+			out = append(out, r.Scope.String()+":"+r.SkillName)
+		}
+		return out
+	}
+
+	t.Run("names and patterns", func(t *testing.T) {
+		mock := setup()
+		svc := usecase.NewRemoveService(mock, config.DefaultConfig(), "/project")
+		opts := usecase.RemoveOptions{Names: []string{"review", "legacy-*", "legacy-b"}}
+		if !opts.Bulk() {
+			t.Error("Bulk() = false for a pattern")
+		}
+		results, err := svc.Remove(opts)
+		if err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		// The pattern takes the project copy of legacy-a, as a plain name would.
+		want := []string{"global:review", "project:legacy-a", "global:legacy-b"}
+		if got := names(results); !slices.Equal(got, want) {
+			t.Errorf("Remove() removed %q, want %q", got, want)
+		}
+		if mock.Exists("/project/.agents/skills/legacy-a") || !mock.Exists("/home/test/.agents/skills/legacy-a") {
+			t.Error("only the project copy of legacy-a should be removed")
+		}
+	})
+
+	t.Run("pattern in scope", func(t *testing.T) {
+		mock := setup()
+		scope := skill.ScopeGlobal
+		results, err := usecase.NewRemoveService(mock, config.DefaultConfig(), "/project").Remove(usecase.RemoveOptions{Names: []string{"legacy-?"}, Scope: &scope})
+		if err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		if got := names(results); !slices.Equal(got, []string{"global:legacy-a", "global:legacy-b"}) {
+			t.Errorf("Remove() removed %q", got)
+		}
+	})
+
+	t.Run("all in scope", func(t *testing.T) {
+		mock := setup()
+		scope := skill.ScopeProject
+		svc := usecase.NewRemoveService(mock, config.DefaultConfig(), "/project")
+		if _, err := svc.Remove(usecase.RemoveOptions{All: true}); err == nil {
+			t.Error("Remove() with All and no scope should fail")
+		}
+		results, err := svc.Remove(usecase.RemoveOptions{All: true, Scope: &scope})
+		if err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		if got := names(results); !slices.Equal(got, []string{"project:legacy-a", "project:lint"}) {
+			t.Errorf("Remove() removed %q", got)
+		}
+		if !mock.Exists("/home/test/.agents/skills/review") {
+			t.Error("global skills should be kept")
+		}
+	})
+
+	t.Run("nothing removed when one name fails", func(t *testing.T) {
+		for _, names := range [][]string{{"review", "missing"}, {"review", "nomatch-*"}, {"review", "[x"}} {
+			mock := setup()
+			if _, err := usecase.NewRemoveService(mock, config.DefaultConfig(), "/project").Remove(usecase.RemoveOptions{Names: names}); err == nil {
+				t.Errorf("Remove(%q) expected error", names)
+			}
+			if !mock.Exists("/home/test/.agents/skills/review") {
+				t.Errorf("Remove(%q) should not remove review", names)
+			}
+		}
+	})
+}
+
+func TestRemoveDryRun(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	mock.Dirs["/home/test/.agents"] = true
+	mock.Dirs["/home/test/.agents/skills"] = true
+	mock.Dirs["/home/test/.agents/skills/remove-me"] = true
+	mock.Files["/home/test/.agents/skills/remove-me/SKILL.md"] = []byte("---\nname: remove-me\n---\n")
+	mock.Dirs["/home/test/.claude"] = true
+	mock.Dirs["/home/test/.claude/skills"] = true
+	mock.Dirs["/home/test/.claude/skills/remove-me"] = true
+
+	svc := usecase.NewRemoveService(mock, config.DefaultConfig(), "")
+	results, err := svc.Remove(usecase.RemoveOptions{Names: []string{"remove-me"}, DryRun: true})
+	if err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if len(results) != 1 || !results[0].Success() || !results[0].StoreRemoved {
+		t.Fatalf("Remove() = %+v, want the store removal planned", results)
+	}
+	removed := slices.ContainsFunc(results[0].TargetResults, func(tr usecase.RemoveTargetResult) bool {
+		return tr.Target == "claude" && tr.Removed
+	})
+	if !removed {
+		t.Errorf("target results = %+v, want claude planned", results[0].TargetResults)
+	}
+	if !mock.Exists("/home/test/.agents/skills/remove-me") || !mock.Exists("/home/test/.claude/skills/remove-me") {
+		t.Error("a dry run must not remove anything")
+	}
+}
@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// RenameOptions contains options for renaming a skill.
+type RenameOptions struct {
+	// Name is the skill to rename
+	Name string
+	// NewName is the name to give it; a collection/name moves it between
+	// collections
+	NewName string
+	// Scope limits the lookup to a specific scope (nil for the skill in effect)
+	Scope *skill.Scope
+	// DryRun only shows what would be done without making changes
+	DryRun bool
+}
+
+// RenameResult describes a renamed skill.
+type RenameResult struct {
+	// Skill is the renamed skill, with its new name and path
+	Skill *skill.Skill
+	// OldName and OldPath are the skill's name and directory before the rename
+	OldName string
+	OldPath string
+	// Targets lists the targets the skill was reinstalled into under its new name
+	Targets []string
+	// OptionalTargets lists the targets whose optional skills listed the old name
+	OptionalTargets []string
+	// Dependents lists the skills that require the skill by its old name
+	Dependents []string
+	// Warnings holds installs under the old name that could not be removed
+	Warnings []string
+}
+
+// RenameService renames skills in the store and their installs in targets.
+type RenameService struct {
+	fs       platformfs.FileSystem
+	cfg      *config.Config
+	root     string
+	store    *skill.Store
+	targets  *TargetRegistry
+	optional *OptionalService
+}
+
+// NewRenameService creates a new rename service.
+func NewRenameService(fsys platformfs.FileSystem, cfg *config.Config, root string) *RenameService {
+	return &RenameService{
+		fs:       fsys,
+		cfg:      cfg,
+		root:     root,
+		store:    skill.NewStore(fsys, cfg, root),
+		targets:  NewTargetRegistry(fsys, root, cfg),
+		optional: NewOptionalService(fsys, cfg, root),
+	}
+}
+
+// Rename renames a skill's store directory, sets the name in its SKILL.md
+// frontmatter, and reinstalls it under the new name in every target it is
+// installed in, with the same strategy. Targets that list the skill among
+// their optional skills are updated in the config file at configPath, and
+// the skill's entries in skillet.lock files are renamed.
+//
+// Until the old installs are removed, any failure undoes the steps already
+// taken. Old installs that cannot be removed afterwards are reported as
+// warnings.
+func (s *RenameService) Rename(opts RenameOptions, configPath string) (*RenameResult, error) {
+	if err := skill.ValidateQualifiedName(opts.NewName); err != nil {
+		return nil, err
+	}
+	var sk *skill.Skill
+	var err error
+	if opts.Scope != nil {
+		sk, err = s.store.FindInScope(opts.Name, *opts.Scope)
+	} else {
+		sk, err = s.store.GetByName(opts.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("skill not found: %w", err)
+	}
+	switch {
+	case sk.ReadOnly():
+		return nil, fmt.Errorf("skill %s is in the read-only %s store", sk.Name, sk.Scope)
+	case sk.Vendored:
+		return nil, fmt.Errorf("skill %s is a vendored copy; rename it in its own store", sk.Name)
+	case opts.NewName == sk.Name:
+		return nil, fmt.Errorf("skill %s already has that name", sk.Name)
+	}
+	if existing, err := s.store.FindInScope(opts.NewName, sk.Scope); err == nil {
+		return nil, fmt.Errorf("skill already exists in %s scope: %s", sk.Scope, existing.Path)
+	}
+
+	// The directory of a skill in a collection is nested once per collection.
+	base := sk.Path
+	for range strings.Count(sk.Name, skill.CollectionSeparator) + 1 {
+		base = s.fs.Dir(base)
+	}
+	dest := s.fs.Join(append([]string{base}, strings.Split(opts.NewName, skill.CollectionSeparator)...)...)
+	if s.fs.Exists(dest) {
+		return nil, fmt.Errorf("path already exists: %s", dest)
+	}
+
+	renamed := *sk
+	renamed.Name = opts.NewName
+	renamed.Path = dest
+	result := &RenameResult{Skill: &renamed, OldName: sk.Name, OldPath: sk.Path}
+
+	installed := make(map[string]config.Strategy)
+	for _, t := range s.targets.GetAll() {
+		if !t.IsInstalledInScope(sk.Name, sk.Scope) || !t.manages(sk) {
+			continue
+		}
+		if t.IsInstalledInScope(renamed.Name, renamed.Scope) {
+			return nil, fmt.Errorf("target %s already has a skill installed as %s", t.Name(), t.installedName(renamed.Name))
+		}
+		installed[t.Name()], _ = t.InstalledStrategy(sk.Name, sk.Scope)
+		result.Targets = append(result.Targets, t.Name())
+	}
+	result.OptionalTargets = s.optional.listing(sk.Name)
+	skills, err := s.store.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	for _, other := range skills {
+		if slices.Contains(other.Requires(), sk.Name) && !slices.Contains(result.Dependents, other.Name) {
+			result.Dependents = append(result.Dependents, other.Name)
+		}
+	}
+	slices.Sort(result.Dependents)
+	if opts.DryRun {
+		return result, nil
+	}
+
+	var undo []func()
+	rollback := func(err error) (*RenameResult, error) {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+		return nil, err
+	}
+
+	skillFile := s.fs.Join(sk.Path, "SKILL.md")
+	original, err := s.fs.ReadFile(skillFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SKILL.md: %w", err)
+	}
+	updated, err := skill.SetFrontmatterKey(original, "name", path.Base(opts.NewName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update SKILL.md: %w", err)
+	}
+	if err := s.fs.WriteFile(skillFile, updated, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write SKILL.md: %w", err)
+	}
+	undo = append(undo, func() { _ = s.fs.WriteFile(skillFile, original, 0o644) })
+
+	if err := s.fs.MkdirAll(s.fs.Dir(dest), 0o755); err != nil {
+		return rollback(fmt.Errorf("failed to create directory: %w", err))
+	}
+	if err := s.fs.Rename(sk.Path, dest); err != nil {
+		return rollback(fmt.Errorf("failed to rename skill directory: %w", err))
+	}
+	undo = append(undo, func() { _ = s.fs.Rename(dest, sk.Path) })
+
+	for _, name := range result.Targets {
+		t, _ := s.targets.Lookup(name)
+		if err := t.Install(&renamed, InstallOptions{Strategy: installed[name]}); err != nil {
+			return rollback(fmt.Errorf("failed to install into %s: %w", name, err))
+		}
+		undo = append(undo, func() { _ = t.uninstallFromScope(renamed.Name, renamed.Scope) })
+	}
+
+	if len(result.OptionalTargets) > 0 {
+		if err := s.optional.rename(sk.Name, renamed.Name, result.OptionalTargets, configPath); err != nil {
+			return rollback(fmt.Errorf("failed to update config file: %w", err))
+		}
+	}
+
+	restore, err := s.renameLocked(sk, &renamed)
+	if err != nil {
+		return rollback(err)
+	}
+	undo = append(undo, restore)
+
+	for _, name := range result.Targets {
+		t, _ := s.targets.Lookup(name)
+		if err := t.uninstallFromScope(sk.Name, sk.Scope); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	return result, nil
+}
+
+// renameLocked renames sk to renamed in the lock files: its source entry in
+// the lock of its store, and its entry in the skill set of the last sync,
+// whose checksum and local source change with the rename. It returns a
+// function that restores the lock files.
+func (s *RenameService) renameLocked(sk, renamed *skill.Skill) (func(), error) {
+	var restores []func()
+	restore := func() {
+		for i := len(restores) - 1; i >= 0; i-- {
+			restores[i]()
+		}
+	}
+	update := func(agentsDir string, rename func(lock *Lockfile) (bool, error)) error {
+		if agentsDir == "" || !s.fs.Exists(lockPath(s.fs, agentsDir)) {
+			return nil
+		}
+		path := lockPath(s.fs, agentsDir)
+		original, err := s.fs.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read lock file: %w", err)
+		}
+		lock, err := loadLockfile(s.fs, agentsDir)
+		if err != nil {
+			return err
+		}
+		changed, err := rename(lock)
+		if err != nil || !changed {
+			return err
+		}
+		if err := saveLockfile(s.fs, agentsDir, lock); err != nil {
+			return err
+		}
+		restores = append(restores, func() { _ = s.fs.WriteFile(path, original, 0o644) })
+		return nil
+	}
+
+	storeDir, err := scopeAgentsDir(s.fs, s.cfg, s.root, sk.Scope)
+	if err == nil {
+		err = update(storeDir, func(lock *Lockfile) (bool, error) {
+			for i := range lock.Sources {
+				if lock.Sources[i].Name == sk.Name {
+					lock.Sources[i].Name = renamed.Name
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+	}
+	if err != nil {
+		restore()
+		return nil, err
+	}
+
+	rootDir, err := s.cfg.GetAgentsDir(s.fs, s.root)
+	if err == nil {
+		err = update(rootDir, func(lock *Lockfile) (bool, error) {
+			for i, entry := range lock.Skills {
+				if entry.Name != sk.Name || entry.Scope != sk.Scope.String() {
+					continue
+				}
+				sum, err := dirChecksum(s.fs, renamed.Path)
+				if err != nil {
+					return false, fmt.Errorf("failed to checksum %s: %w", renamed.Name, err)
+				}
+				lock.Skills[i] = LockedSkill{
+					Name:     renamed.Name,
+					Scope:    entry.Scope,
+					Source:   skillSource(s.fs, s.cfg, s.root, renamed, make(map[string]*Lockfile)),
+					Checksum: sum,
+				}
+				return true, nil
+			}
+			return false, nil
+		})
+	}
+	if err != nil {
+		restore()
+		return nil, err
+	}
+	return restore, nil
+}
@@ -0,0 +1,89 @@
+package usecase_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestRename(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "old")
+	addGlobalSkill(mock, "taken")
+	mock.Dirs["/home/test/.agents/skills/user"] = true
+	mock.Files["/home/test/.agents/skills/user/SKILL.md"] = []byte("---\nname: user\nrequires: [old]\n---\n")
+	mock.Dirs["/home/test/.agents/skills/optional/extra"] = true
+	mock.Files["/home/test/.agents/skills/optional/extra/SKILL.md"] = []byte("---\nname: extra\ndescription: Extra\n---\nbody\n")
+	cfg := config.DefaultConfig()
+	tc := cfg.Targets["claude"]
+	tc.Optional = []string{"extra"}
+	cfg.Targets["claude"] = tc
+	configPath := "/home/test/.config/skillet/config.yaml"
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	svc := usecase.NewRenameService(mock, cfg, "")
+
+	for _, newName := range []string{"taken", "bad name", "old"} {
+		if _, err := svc.Rename(usecase.RenameOptions{Name: "old", NewName: newName}, configPath); err == nil {
+			t.Errorf("Rename() to %q should fail", newName)
+		}
+	}
+
+	result, err := svc.Rename(usecase.RenameOptions{Name: "old", NewName: "new"}, configPath)
+	if err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if !slices.Equal(result.Targets, []string{"claude", "codex"}) || !slices.Equal(result.Dependents, []string{"user"}) {
+		t.Errorf("Rename() = %+v, want both targets and user as a dependent", result)
+	}
+	if mock.Exists("/home/test/.agents/skills/old") || !strings.Contains(string(mock.Files["/home/test/.agents/skills/new/SKILL.md"]), "name: new") {
+		t.Error("store directory and frontmatter should use the new name")
+	}
+	for _, target := range []string{"claude", "codex"} {
+		if mock.Exists("/home/test/." + target + "/skills/old") {
+			t.Errorf("old install should be removed from %s", target)
+		}
+		if got, _ := mock.Readlink("/home/test/." + target + "/skills/new"); got != "/home/test/.agents/skills/new" {
+			t.Errorf("%s link = %q, want the renamed skill", target, got)
+		}
+	}
+
+	lock := string(mock.Files["/home/test/.agents/"+usecase.LockFileName])
+	if strings.Contains(lock, "old") || !strings.Contains(lock, "name: new") || !strings.Contains(lock, "source: ~/.agents/skills/new") {
+		t.Errorf("lock file still records the old name:\n%s", lock)
+	}
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if synced := string(mock.Files["/home/test/.agents/"+usecase.LockFileName]); synced != lock {
+		t.Errorf("lock file after sync =\n%s\nwant it unchanged from the rename:\n%s", synced, lock)
+	}
+
+	// A skill added from a remote source keeps its source under the new name.
+	lockPath := "/home/test/.agents/" + usecase.LockFileName
+	mock.Files[lockPath] = []byte(strings.Replace(lock, "version: 1\n", "version: 1\nsources:\n    - name: extra\n      source: github.com/org/repo/extra@v1\n      checksum: abc\n", 1))
+	result, err = svc.Rename(usecase.RenameOptions{Name: "extra", NewName: "bonus"}, configPath)
+	if err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if lock := string(mock.Files[lockPath]); strings.Contains(lock, "extra\n") || strings.Count(lock, "source: github.com/org/repo/extra@v1") != 2 {
+		t.Errorf("lock file should list bonus with its remote source:\n%s", lock)
+	}
+	if !slices.Equal(result.OptionalTargets, []string{"claude"}) || !mock.Exists("/home/test/.agents/skills/optional/bonus/SKILL.md") {
+		t.Errorf("Rename() = %+v, want the optional skill renamed for claude", result)
+	}
+	saved, err := config.NewStore(mock).Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !slices.Equal(saved.Targets["claude"].Optional, []string{"bonus"}) {
+		t.Errorf("saved claude optional = %v, want [bonus]", saved.Targets["claude"].Optional)
+	}
+	if !mock.IsSymlink("/home/test/.claude/skills/bonus") || mock.Exists("/home/test/.codex/skills/bonus") {
+		t.Error("bonus should be installed into claude only")
+	}
+}
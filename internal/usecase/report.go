@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+// reportsDirName is the directory under .agents that holds run reports.
+const reportsDirName = ".reports"
+
+// RunReport summarizes a sync or migrate run for auditing.
+type RunReport struct {
+	Command   string              `json:"command"`
+	StartedAt time.Time           `json:"startedAt"`
+	Actions   []ReportAction      `json:"actions"`
+	State     []ReportTargetState `json:"state"`
+}
+
+// ReportAction records a single action taken during a run.
+type ReportAction struct {
+	Target  string `json:"target,omitempty"`
+	Skill   string `json:"skill"`
+	Action  string `json:"action"`
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ReportTargetState records a target's status after a run.
+type ReportTargetState struct {
+	Target    string   `json:"target"`
+	InSync    bool     `json:"inSync"`
+	Installed []string `json:"installed"`
+	Missing   []string `json:"missing"`
+	Extra     []string `json:"extra"`
+	Stale     []string `json:"stale"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// NewRunReport creates an empty report for a command started at startedAt.
+func NewRunReport(command string, startedAt time.Time) *RunReport {
+	return &RunReport{Command: command, StartedAt: startedAt}
+}
+
+// AddSyncResults records sync results. Skips are omitted unless they carry warnings.
+func (r *RunReport) AddSyncResults(results []SyncResult) {
+	for _, res := range results {
+		if res.Action == SyncActionSkip && len(res.Warnings) == 0 {
+			continue
+		}
+		r.Actions = append(r.Actions, ReportAction{
+			Target:  res.Target,
+			Skill:   res.SkillName,
+			Action:  string(res.Action),
+			Message: strings.Join(res.Warnings, "; "),
+			Error:   errorString(res.Error),
+		})
+	}
+}
+
+// AddMigrateResult records the moves and follow-up sync of a migration.
+func (r *RunReport) AddMigrateResult(result *MigrateResult) {
+	for _, res := range result.MoveResults {
+		r.Actions = append(r.Actions, ReportAction{
+			Target:  res.FromTarget,
+			Skill:   res.SkillName,
+			Action:  string(res.Action),
+			Message: res.Message,
+			Error:   errorString(res.Error),
+		})
+	}
+	r.AddSyncResults(result.SyncResults)
+}
+
+// ErrorCount returns the number of failed actions.
+func (r *RunReport) ErrorCount() int {
+	n := 0
+	for _, a := range r.Actions {
+		if a.Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// ReportService writes run reports to the agents directory.
+type ReportService struct {
+	fs     platformfs.FileSystem
+	cfg    *config.Config
+	root   string
+	status *StatusService
+}
+
+// NewReportService creates a new report service.
+func NewReportService(fsys platformfs.FileSystem, cfg *config.Config, root string) *ReportService {
+	return &ReportService{
+		fs:     fsys,
+		cfg:    cfg,
+		root:   root,
+		status: NewStatusService(fsys, cfg, root),
+	}
+}
+
+// Enabled reports whether report files are configured.
+func (s *ReportService) Enabled() bool {
+	return s.cfg.Reports.Enabled
+}
+
+// Write records the resulting target state in r and writes it to
+// <agents>/.reports/<timestamp>-<command>.<ext>, using the project store when
+// a project root is set. Returns the report path, or "" when reports are disabled.
+func (s *ReportService) Write(r *RunReport) (string, error) {
+	if !s.Enabled() {
+		return "", nil
+	}
+
+	format := s.cfg.Reports.Format
+	if format == "" {
+		format = config.ReportFormatMarkdown
+	}
+
+	statuses, err := s.status.GetStatus()
+	if err != nil {
+		return "", fmt.Errorf("failed to get status for report: %w", err)
+	}
+	r.State = r.State[:0]
+	for _, st := range statuses {
+		state := ReportTargetState{
+			Target:    st.Target,
+			InSync:    st.InSync,
+			Installed: st.Installed,
+			Missing:   st.Missing,
+			Extra:     st.Extra,
+			Error:     errorString(st.Error),
+		}
+		for _, stale := range st.Stale {
+			state.Stale = append(state.Stale, stale.SkillName)
+		}
+		r.State = append(r.State, state)
+	}
+
+	var data []byte
+	var ext string
+	switch format {
+	case config.ReportFormatJSON:
+		data, err = json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode report: %w", err)
+		}
+		data = append(data, '\n')
+		ext = ".json"
+	case config.ReportFormatMarkdown:
+		data = []byte(renderMarkdownReport(r))
+		ext = ".md"
+	default:
+		return "", fmt.Errorf("unsupported report format: %q", format)
+	}
+
+	agentsDir, err := s.cfg.GetAgentsDir(s.fs, s.root)
+	if err != nil {
+		return "", err
+	}
+	dir := s.fs.Join(agentsDir, reportsDirName)
+	if err := s.fs.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	name := r.StartedAt.UTC().Format("20060102T150405Z") + "-" + r.Command + ext
+	path := s.fs.Join(dir, name)
+	if err := s.fs.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return path, nil
+}
+
+// renderMarkdownReport renders a report as a markdown document.
+func renderMarkdownReport(r *RunReport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# skillet %s report\n\n", r.Command)
+	fmt.Fprintf(&b, "- Started: %s\n", r.StartedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Actions: %d (%d errors)\n", len(r.Actions), r.ErrorCount())
+
+	b.WriteString("\n## Actions\n\n")
+	if len(r.Actions) == 0 {
+		b.WriteString("No actions.\n")
+	} else {
+		b.WriteString("| Target | Skill | Action | Details |\n")
+		b.WriteString("|--------|-------|--------|---------|\n")
+		for _, a := range r.Actions {
+			details := a.Message
+			if a.Error != "" {
+				details = "error: " + a.Error
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", a.Target, a.Skill, a.Action, markdownCell(details))
+		}
+	}
+
+	b.WriteString("\n## Resulting state\n\n")
+	b.WriteString("| Target | In sync | Installed | Missing | Extra | Stale |\n")
+	b.WriteString("|--------|---------|-----------|---------|-------|-------|\n")
+	for _, st := range r.State {
+		inSync := fmt.Sprintf("%t", st.InSync)
+		if st.Error != "" {
+			inSync = "error: " + markdownCell(st.Error)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s | %s | %s |\n",
+			st.Target, inSync, len(st.Installed),
+			strings.Join(st.Missing, ", "), strings.Join(st.Extra, ", "), strings.Join(st.Stale, ", "))
+	}
+
+	return b.String()
+}
+
+// markdownCell escapes text for use inside a markdown table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
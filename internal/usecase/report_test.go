@@ -0,0 +1,86 @@
+package usecase_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestReportWriteDisabled(t *testing.T) {
+	mock, _ := setupSyncEnv()
+
+	path, err := usecase.NewReportService(mock, config.DefaultConfig(), "").Write(usecase.NewRunReport("sync", time.Now()))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if path != "" || mock.Exists("/home/test/.agents/.reports") {
+		t.Fatalf("expected no report when disabled, got %q", path)
+	}
+}
+
+func TestReportWriteMarkdown(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Reports.Enabled = true
+	report := usecase.NewRunReport("sync", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	report.AddSyncResults(results)
+
+	path, err := usecase.NewReportService(mock, cfg, "").Write(report)
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if path != "/home/test/.agents/.reports/20260102T030405Z-sync.md" {
+		t.Fatalf("report path = %q", path)
+	}
+
+	content := string(mock.Files[path])
+	for _, want := range []string{
+		"# skillet sync report",
+		"| claude | alpha | install |",
+		"| codex | alpha | install |",
+		"| claude | true | 1 |",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("report missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+
+	cfg := config.DefaultConfig()
+	cfg.Reports = config.ReportConfig{Enabled: true, Format: config.ReportFormatJSON}
+	report := usecase.NewRunReport("migrate", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	report.AddMigrateResult(&usecase.MigrateResult{
+		MoveResults: []usecase.MigrateMoveResult{{SkillName: "alpha", FromTarget: "claude", Action: usecase.MigrateActionMoved}},
+	})
+
+	path, err := usecase.NewReportService(mock, cfg, "").Write(report)
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var got usecase.RunReport
+	if err := json.Unmarshal(mock.Files[path], &got); err != nil {
+		t.Fatalf("report is not valid JSON: %v", err)
+	}
+	if got.Command != "migrate" || len(got.Actions) != 1 || got.Actions[0].Action != "moved" {
+		t.Fatalf("unexpected report actions: %+v", got)
+	}
+	if len(got.State) != 2 || got.State[0].Target != "claude" || len(got.State[0].Missing) != 1 {
+		t.Fatalf("unexpected report state: %+v", got.State)
+	}
+}
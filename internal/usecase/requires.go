@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// withRequires returns names and, transitively, the skills they require,
+// sorted by name. Required names that are not in skills are left out.
+func withRequires(skills []*skill.Skill, names []string) []string {
+	byName := skillsByName(skills)
+	seen := make(map[string]bool, len(names))
+	var visit func(name string)
+	visit = func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		if sk, ok := byName[name]; ok {
+			for _, dep := range sk.Requires() {
+				if _, ok := byName[dep]; ok {
+					visit(dep)
+				}
+			}
+		}
+	}
+	for _, name := range names {
+		visit(name)
+	}
+
+	out := make([]string, 0, len(seen))
+	for name := range seen {
+		out = append(out, name)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// wantedSkills returns the names of the skills that belong in t: the ones it
+// wants, plus the skills they require, even optional ones not enabled for t.
+// Skills t does not support (see Target.supports) never belong in it, even
+// when required.
+func wantedSkills(t *Target, skills []*skill.Skill) map[string]bool {
+	var names []string
+	for _, sk := range skills {
+		if t.wants(sk) && t.supports(sk) {
+			names = append(names, sk.Name)
+		}
+	}
+	byName := skillsByName(skills)
+	wanted := make(map[string]bool, len(skills))
+	for _, name := range withRequires(skills, names) {
+		if t.supports(byName[name]) {
+			wanted[name] = true
+		}
+	}
+	return wanted
+}
+
+// unwantedReason explains why sk does not belong in t (see wantedSkills).
+func unwantedReason(t *Target, sk *skill.Skill) string {
+	if !sk.SupportsTarget(t.Name()) {
+		return "skill does not support the target"
+	}
+	if t.excluded[sk.Name] {
+		return "skill excluded from the target in the config"
+	}
+	return "optional skill not enabled for the target"
+}
+
+// missingRequires returns the skills sk requires that are not in known.
+func missingRequires(sk *skill.Skill, known map[string]bool) []string {
+	var missing []string
+	for _, dep := range sk.Requires() {
+		if !known[dep] {
+			missing = append(missing, dep)
+		}
+	}
+	return missing
+}
+
+// requireCycles maps each skill on a dependency cycle to the cycle it is on,
+// written as "a -> b -> a".
+func requireCycles(skills []*skill.Skill) map[string]string {
+	byName := skillsByName(skills)
+	cycles := make(map[string]string)
+	done := make(map[string]bool, len(skills))
+	var path []string
+	var visit func(name string)
+	visit = func(name string) {
+		if i := slices.Index(path, name); i >= 0 {
+			cycle := append(slices.Clone(path[i:]), name)
+			for _, n := range path[i:] {
+				if _, ok := cycles[n]; !ok {
+					cycles[n] = strings.Join(cycle, " -> ")
+				}
+			}
+			return
+		}
+		if done[name] {
+			return
+		}
+		sk, ok := byName[name]
+		if !ok {
+			return
+		}
+		path = append(path, name)
+		for _, dep := range sk.Requires() {
+			visit(dep)
+		}
+		path = path[:len(path)-1]
+		done[name] = true
+	}
+	for _, sk := range skills {
+		visit(sk.Name)
+	}
+	return cycles
+}
+
+func missingRequiresWarning(names []string) string {
+	return "required skills not found: " + strings.Join(names, ", ")
+}
+
+func requireCycleError(cycle string) error {
+	return fmt.Errorf("dependency cycle: %s", cycle)
+}
+
+func skillsByName(skills []*skill.Skill) map[string]*skill.Skill {
+	byName := make(map[string]*skill.Skill, len(skills))
+	for _, sk := range skills {
+		byName[sk.Name] = sk
+	}
+	return byName
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/platform/proc"
+)
+
+const (
+	// RunLockFileName is the file in an agents directory that a sync holds
+	// while it changes the targets, so runs from several terminals or a
+	// sync --watch do not race.
+	RunLockFileName = ".skillet-run.lock"
+	// staleRunLockAge is how old a run lock gets before it is taken over
+	// even though its process cannot be checked, e.g. one on another host.
+	staleRunLockAge = time.Hour
+	// runLockPollInterval is how often a waiting run checks the lock.
+	runLockPollInterval = 200 * time.Millisecond
+	// unreadableRunLockAge is how long a run lock whose content cannot be
+	// read is left to the run writing it.
+	unreadableRunLockAge = 10 * time.Second
+)
+
+// RunLockedError is returned when another skillet run holds a run lock.
+type RunLockedError struct {
+	Path    string
+	PID     int
+	Host    string
+	Started time.Time
+}
+
+func (e *RunLockedError) Error() string {
+	if e.PID == 0 {
+		return fmt.Sprintf("another skillet run holds %s", e.Path)
+	}
+	return fmt.Sprintf("another skillet run (pid %d on %s, started %s) holds %s", e.PID, e.Host, e.Started.Local().Format(time.DateTime), e.Path)
+}
+
+// runLockInfo is the content of a run lock.
+type runLockInfo struct {
+	PID     int       `json:"pid"`
+	Host    string    `json:"host"`
+	Started time.Time `json:"started"`
+}
+
+// stale reports whether the run that took the lock is gone: its process no
+// longer runs on this host, or the lock is older than staleRunLockAge.
+func (info runLockInfo) stale(host string) bool {
+	if time.Since(info.Started) > staleRunLockAge {
+		return true
+	}
+	return info.Host == host && !proc.Alive(info.PID)
+}
+
+// runLock is a set of run locks held by this process.
+type runLock struct {
+	fs    platformfs.FileSystem
+	paths []string
+}
+
+// lockAgentsDirs takes the run lock of the global agents directory and, in a
+// project, of the project's, skipping directories that do not exist. When
+// another run holds one and wait is set, it waits for the run to finish
+// until ctx is done; otherwise it fails with a *RunLockedError. Stale locks
+// are taken over.
+func lockAgentsDirs(ctx context.Context, fsys platformfs.FileSystem, cfg *config.Config, root string, wait bool) (*runLock, error) {
+	var dirs []string
+	if dir, err := cfg.AgentsDir(fsys); err == nil {
+		dirs = append(dirs, dir)
+	}
+	if root != "" {
+		dirs = append(dirs, config.ProjectAgentsDir(root, fsys))
+	}
+
+	host, _ := os.Hostname()
+	data, err := json.Marshal(runLockInfo{PID: os.Getpid(), Host: host, Started: time.Now().UTC()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal run lock: %w", err)
+	}
+
+	// Directories are locked in the same order by every run, so two runs
+	// never wait for each other.
+	lock := &runLock{fs: fsys}
+	for _, dir := range dirs {
+		if !fsys.IsDir(dir) {
+			continue
+		}
+		path := fsys.Join(dir, RunLockFileName)
+		for {
+			err := tryRunLock(fsys, path, data, host)
+			var locked *RunLockedError
+			if err == nil {
+				lock.paths = append(lock.paths, path)
+				break
+			}
+			if !wait || !errors.As(err, &locked) {
+				lock.release()
+				return nil, err
+			}
+			slog.Debug("waiting for run lock", "path", path, "pid", locked.PID, "host", locked.Host)
+			select {
+			case <-ctx.Done():
+				lock.release()
+				return nil, ctx.Err()
+			case <-time.After(runLockPollInterval):
+			}
+		}
+	}
+	return lock, nil
+}
+
+// tryRunLock creates the run lock at path with data, taking over a stale one.
+func tryRunLock(fsys platformfs.FileSystem, path string, data []byte, host string) error {
+	for {
+		err := fsys.WriteNewFile(path, data, 0o644)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("failed to create run lock: %w", err)
+		}
+
+		held, err := fsys.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read run lock: %w", err)
+		}
+		// A lock being written is briefly empty, and one left half written
+		// by a crash is stale once the write is long over.
+		var info runLockInfo
+		if json.Unmarshal(held, &info) != nil {
+			if stat, err := fsys.Stat(path); err != nil || time.Since(stat.ModTime()) < unreadableRunLockAge {
+				return &RunLockedError{Path: path}
+			}
+		} else if !info.stale(host) {
+			return &RunLockedError{Path: path, PID: info.PID, Host: info.Host, Started: info.Started}
+		}
+		// Another run may have taken over the stale lock since it was read.
+		if current, err := fsys.ReadFile(path); err != nil || !bytes.Equal(current, held) {
+			continue
+		}
+		slog.Debug("removing stale run lock", "path", path, "pid", info.PID, "host", info.Host, "started", info.Started)
+		if err := fsys.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove stale run lock: %w", err)
+		}
+	}
+}
+
+// release removes the run locks held, in the reverse order they were taken.
+// A nil *runLock holds nothing.
+func (l *runLock) release() {
+	if l == nil {
+		return
+	}
+	for i := len(l.paths) - 1; i >= 0; i-- {
+		if err := l.fs.Remove(l.paths[i]); err != nil {
+			slog.Debug("could not remove run lock", "path", l.paths[i], "error", err)
+		}
+	}
+	l.paths = nil
+}
@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+const runLockPath = "/home/test/.agents/" + usecase.RunLockFileName
+
+func runLockJSON(t *testing.T, pid int, started time.Time) []byte {
+	t.Helper()
+	host, _ := os.Hostname()
+	data, err := json.Marshal(map[string]any{"pid": pid, "host": host, "started": started})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestSyncFailsWhileRunLocked(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	held := runLockJSON(t, os.Getpid(), time.Now())
+	mock.Files[runLockPath] = held
+
+	_, err := svc.Sync(t.Context(), usecase.SyncOptions{})
+	var locked *usecase.RunLockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("Sync() error = %v, want *RunLockedError", err)
+	}
+	if locked.PID != os.Getpid() || locked.Path != runLockPath {
+		t.Errorf("RunLockedError = %+v, want pid %d at %s", locked, os.Getpid(), runLockPath)
+	}
+	if mock.Exists("/home/test/.claude/skills/alpha") {
+		t.Error("alpha should not be synced while the lock is held")
+	}
+	if string(mock.Files[runLockPath]) != string(held) {
+		t.Error("the lock of the other run should be left alone")
+	}
+}
+
+func TestSyncWaitsForRunLock(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	mock.Files[runLockPath] = runLockJSON(t, os.Getpid(), time.Now())
+
+	ctx, cancel := context.WithTimeout(t.Context(), 500*time.Millisecond)
+	defer cancel()
+	_, err := svc.Sync(ctx, usecase.SyncOptions{WaitForLock: true})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Sync() error = %v, want it to wait until the deadline", err)
+	}
+}
+
+func TestSyncTakesOverStaleRunLock(t *testing.T) {
+	tests := []struct {
+		name string
+		pid  int
+		at   time.Time
+	}{
+		{"process gone", 1 << 30, time.Now()},
+		{"too old", os.Getpid(), time.Now().Add(-2 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, svc := setupSyncEnv()
+			addGlobalSkill(mock, "alpha")
+			mock.Files[runLockPath] = runLockJSON(t, tt.pid, tt.at)
+
+			if _, err := svc.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+			if !mock.Exists("/home/test/.claude/skills/alpha") {
+				t.Error("alpha should be synced")
+			}
+			if mock.Exists(runLockPath) {
+				t.Error("the run lock should be released after the sync")
+			}
+		})
+	}
+}
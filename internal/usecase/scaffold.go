@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// templatesDirName is the directory in the global agents directory holding skill templates.
+const templatesDirName = "templates"
+
+// templateExt marks template files that are rendered; the extension is removed.
+const templateExt = ".tmpl"
+
+// ScaffoldOptions contains options for creating a new skill.
+type ScaffoldOptions struct {
+	// Name is the skill name
+	Name string
+	// Description is written to the frontmatter
+	Description string
+	// Scope is the store to create the skill in (global or project)
+	Scope skill.Scope
+	// Category places the skill under skills/ or skills/optional/
+	Category skill.Category
+	// Template names a directory under the global agents directory's templates/ (empty for none)
+	Template string
+}
+
+// ScaffoldResult represents a newly created skill.
+type ScaffoldResult struct {
+	SkillName string
+	Scope     skill.Scope
+	Category  skill.Category
+	Path      string
+	// Files lists the created files, sorted by path
+	Files []string
+}
+
+// scaffoldData is the data passed to template files.
+type scaffoldData struct {
+	Name        string
+	Description string
+	Scope       string
+	Category    string
+}
+
+// defaultSkillBody is the SKILL.md body of skills created without a template.
+const defaultSkillBody = `# %s
+
+Describe when to use this skill and the steps to follow.
+`
+
+// ScaffoldService creates new skills in a store.
+type ScaffoldService struct {
+	fs    platformfs.FileSystem
+	cfg   *config.Config
+	root  string
+	store *skill.Store
+}
+
+// NewScaffoldService creates a new scaffold service.
+func NewScaffoldService(fsys platformfs.FileSystem, cfg *config.Config, root string) *ScaffoldService {
+	return &ScaffoldService{
+		fs:    fsys,
+		cfg:   cfg,
+		root:  root,
+		store: skill.NewStore(fsys, cfg, root),
+	}
+}
+
+// Create writes a new skill directory into the scope's store. Files in the
+// template ending in .tmpl are rendered with text/template and lose the
+// extension; other files are copied as they are. A SKILL.md with the name and
+// description is written when the template has none.
+func (s *ScaffoldService) Create(opts ScaffoldOptions) (*ScaffoldResult, error) {
+	if err := skill.ValidateName(opts.Name); err != nil {
+		return nil, err
+	}
+	if existing, err := s.store.FindInScope(opts.Name, opts.Scope); err == nil {
+		return nil, fmt.Errorf("skill already exists in %s scope: %s", opts.Scope, existing.Path)
+	}
+	_, skillsDir, err := writableStoreDirs(s.fs, s.cfg, s.root, opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Category == skill.CategoryOptional {
+		skillsDir = s.fs.Join(skillsDir, config.OptionalDirName)
+	}
+	dest := s.fs.Join(skillsDir, opts.Name)
+	if s.fs.Exists(dest) {
+		return nil, fmt.Errorf("path already exists: %s", dest)
+	}
+
+	data := scaffoldData{
+		Name:        opts.Name,
+		Description: opts.Description,
+		Scope:       opts.Scope.String(),
+		Category:    opts.Category.String(),
+	}
+	files, err := s.renderTemplate(opts.Template, data)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := files["SKILL.md"]; !ok {
+		content, err := skill.FormatSkillFile(opts.Name, opts.Description, fmt.Sprintf(defaultSkillBody, opts.Name))
+		if err != nil {
+			return nil, err
+		}
+		files["SKILL.md"] = content
+	}
+
+	result := &ScaffoldResult{SkillName: opts.Name, Scope: opts.Scope, Category: opts.Category, Path: dest}
+	for _, rel := range sortedPaths(files) {
+		path := s.fs.Join(dest, rel)
+		if err := s.fs.MkdirAll(s.fs.Dir(path), 0o755); err != nil {
+			_ = s.fs.RemoveAll(dest)
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := s.fs.WriteFile(path, files[rel], 0o644); err != nil {
+			_ = s.fs.RemoveAll(dest)
+			return nil, fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+		result.Files = append(result.Files, rel)
+	}
+	return result, nil
+}
+
+// renderTemplate returns the files of the named template rendered with data.
+// An empty name yields no files.
+func (s *ScaffoldService) renderTemplate(name string, data scaffoldData) (skillFiles, error) {
+	if name == "" {
+		return make(skillFiles), nil
+	}
+	if err := skill.ValidateName(name); err != nil {
+		return nil, fmt.Errorf("invalid template name: %w", err)
+	}
+	dir, err := s.templatesDir()
+	if err != nil {
+		return nil, err
+	}
+	dir = s.fs.Join(dir, name)
+	if !s.fs.IsDir(dir) {
+		return nil, fmt.Errorf("template not found: %s", dir)
+	}
+	files, err := readSkillFiles(s.fs, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(skillFiles, len(files))
+	for rel, content := range files {
+		target, ok := strings.CutSuffix(rel, templateExt)
+		if !ok {
+			out[rel] = content
+			continue
+		}
+		tmpl, err := template.New(rel).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", rel, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render template %s: %w", rel, err)
+		}
+		out[target] = buf.Bytes()
+	}
+	return out, nil
+}
+
+// templatesDir returns the directory holding skill templates.
+func (s *ScaffoldService) templatesDir() (string, error) {
+	agentsDir, err := s.cfg.AgentsDir(s.fs)
+	if err != nil {
+		return "", err
+	}
+	return s.fs.Join(agentsDir, templatesDirName), nil
+}
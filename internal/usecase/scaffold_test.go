@@ -0,0 +1,81 @@
+package usecase_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestScaffoldCreate(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	svc := usecase.NewScaffoldService(mock, config.DefaultConfig(), "")
+
+	result, err := svc.Create(usecase.ScaffoldOptions{
+		Name:        "review",
+		Description: "Review pull requests",
+		Scope:       skill.ScopeGlobal,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if result.Path != "/home/test/.agents/skills/review" || !slices.Equal(result.Files, []string{"SKILL.md"}) {
+		t.Fatalf("Create() = %+v", result)
+	}
+	content := string(mock.Files["/home/test/.agents/skills/review/SKILL.md"])
+	if !strings.HasPrefix(content, "---\nname: review\ndescription: Review pull requests\n---\n") {
+		t.Errorf("SKILL.md = %q", content)
+	}
+
+	sk, err := skill.NewStore(mock, config.DefaultConfig(), "").GetByName("review")
+	if err != nil || sk.Description() != "Review pull requests" {
+		t.Errorf("GetByName() = %+v, %v", sk, err)
+	}
+
+	if _, err := svc.Create(usecase.ScaffoldOptions{Name: "review", Scope: skill.ScopeGlobal, Category: skill.CategoryOptional}); err == nil {
+		t.Error("Create() should fail for a skill that already exists in the scope")
+	}
+	if _, err := svc.Create(usecase.ScaffoldOptions{Name: "review", Scope: skill.ScopeProject}); err == nil {
+		t.Error("Create() should fail for project scope outside a project")
+	}
+}
+
+func TestScaffoldCreateFromTemplate(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	tmpl := "/home/test/.agents/templates/runbook"
+	mock.Dirs["/home/test/.agents/templates"] = true
+	mock.Dirs[tmpl] = true
+	mock.Dirs[tmpl+"/scripts"] = true
+	mock.Files[tmpl+"/SKILL.md.tmpl"] = []byte("---\nname: {{.Name}}\ndescription: {{.Description}}\n---\n\n# {{.Name}} ({{.Category}})\n")
+	mock.Files[tmpl+"/scripts/run.sh"] = []byte("echo {{.Name}}\n")
+	svc := usecase.NewScaffoldService(mock, config.DefaultConfig(), "")
+
+	result, err := svc.Create(usecase.ScaffoldOptions{
+		Name:        "deploy",
+		Description: "Deploy the app",
+		Scope:       skill.ScopeGlobal,
+		Category:    skill.CategoryOptional,
+		Template:    "runbook",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if want := []string{"SKILL.md", "scripts/run.sh"}; !slices.Equal(result.Files, want) {
+		t.Errorf("Files = %v, want %v", result.Files, want)
+	}
+
+	dir := "/home/test/.agents/skills/optional/deploy"
+	if got := string(mock.Files[dir+"/SKILL.md"]); got != "---\nname: deploy\ndescription: Deploy the app\n---\n\n# deploy (optional)\n" {
+		t.Errorf("SKILL.md = %q", got)
+	}
+	if got := string(mock.Files[dir+"/scripts/run.sh"]); got != "echo {{.Name}}\n" {
+		t.Errorf("scripts/run.sh = %q, want it copied unrendered", got)
+	}
+
+	if _, err := svc.Create(usecase.ScaffoldOptions{Name: "other", Scope: skill.ScopeGlobal, Template: "missing"}); err == nil {
+		t.Error("Create() should fail for a missing template")
+	}
+}
@@ -35,6 +35,9 @@ func (s *SetupService) SetupGlobal(params SetupGlobalParams) (*config.Config, er
 	if err != nil {
 		return nil, err
 	}
+	// The detected path is left unset so the config follows the store if it is
+	// moved with migrate-store.
+	detected := params.GlobalPath == config.DetectGlobalPath(s.fs)
 
 	// Create directory structure.
 	dirs := []string{
@@ -54,7 +57,7 @@ func (s *SetupService) SetupGlobal(params SetupGlobalParams) (*config.Config, er
 		if err != nil {
 			return nil, err
 		}
-		if params.GlobalPath != config.DefaultGlobalPath {
+		if !detected {
 			cfg.GlobalPath = params.GlobalPath
 		}
 		cfg.DefaultStrategy = params.Strategy
@@ -62,6 +65,16 @@ func (s *SetupService) SetupGlobal(params SetupGlobalParams) (*config.Config, er
 			target.Enabled = params.EnabledTargets[name]
 			cfg.Targets[name] = target
 		}
+		// Built-in targets added after the config was written are added when selected.
+		for name, target := range config.DefaultConfig().Targets {
+			if _, ok := cfg.Targets[name]; !ok && params.EnabledTargets[name] {
+				target.Enabled = true
+				if cfg.Targets == nil {
+					cfg.Targets = make(map[string]config.TargetConfig)
+				}
+				cfg.Targets[name] = target
+			}
+		}
 		if err := s.configStore.Save(cfg, params.ConfigPath); err != nil {
 			return nil, fmt.Errorf("failed to update config file: %w", err)
 		}
@@ -70,7 +83,7 @@ func (s *SetupService) SetupGlobal(params SetupGlobalParams) (*config.Config, er
 
 	// Create new config.
 	cfg := config.DefaultConfig()
-	if params.GlobalPath != config.DefaultGlobalPath {
+	if !detected {
 		cfg.GlobalPath = params.GlobalPath
 	}
 	cfg.DefaultStrategy = params.Strategy
@@ -87,6 +100,37 @@ func (s *SetupService) SetupGlobal(params SetupGlobalParams) (*config.Config, er
 	return cfg, nil
 }
 
+// InitializedGlobal returns the config at configPath when it exists and the
+// store directories it points to are in place, and nil otherwise.
+func (s *SetupService) InitializedGlobal(configPath string) *config.Config {
+	if !s.fs.Exists(configPath) {
+		return nil
+	}
+	cfg, err := s.configStore.Load(configPath)
+	if err != nil {
+		return nil
+	}
+	agentsDir, err := cfg.AgentsDir(s.fs)
+	if err != nil {
+		return nil
+	}
+	for _, dir := range []string{
+		s.fs.Join(agentsDir, config.SkillsDirName),
+		s.fs.Join(agentsDir, config.SkillsDirName, config.OptionalDirName),
+	} {
+		if !s.fs.IsDir(dir) {
+			return nil
+		}
+	}
+	return cfg
+}
+
+// ProjectInitialized reports whether the project store directories exist.
+func (s *SetupService) ProjectInitialized(projectRoot string) bool {
+	return s.fs.IsDir(config.ProjectSkillsDir(projectRoot, s.fs, "")) &&
+		s.fs.IsDir(config.ProjectSkillsDir(projectRoot, s.fs, config.OptionalDirName))
+}
+
 // SetupProject performs project initialization.
 func (s *SetupService) SetupProject(projectRoot string) error {
 	agentsDir := config.ProjectAgentsDir(projectRoot, s.fs)
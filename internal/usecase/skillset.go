@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// SkillsetFileName is the manifest in a project's agents directory that
+// lists the skills the project needs.
+const SkillsetFileName = "skillset.yaml"
+
+// Skillset lists the skills a project needs and where to get them.
+type Skillset struct {
+	Skills []SkillsetEntry `yaml:"skills"`
+}
+
+// SkillsetEntry is a skill required by a skill set.
+type SkillsetEntry struct {
+	// Name is the skill name; derived from Source when empty
+	Name string `yaml:"name,omitempty"`
+	// Source is a remote source as given to skillet add, or a local path
+	// (relative to the project, absolute, or starting with ~). Without a
+	// source, the skill must come from the global, org, or system store.
+	Source string `yaml:"source,omitempty"`
+}
+
+// skillName returns the entry's name, derived from its source when not given.
+func (e SkillsetEntry) skillName(fsys platformfs.FileSystem) (string, error) {
+	name := e.Name
+	switch {
+	case name != "":
+	case e.Source == "":
+		return "", fmt.Errorf("skill set entry needs a name or a source")
+	case isLocalSource(e.Source):
+		name = fsys.Base(e.Source)
+	default:
+		src, err := fetch.ParseGitSource(e.Source)
+		if err != nil {
+			return "", err
+		}
+		name = src.Name()
+	}
+	// Skills already in a store may be in a collection.
+	validate := skill.ValidateName
+	if e.Source == "" {
+		validate = skill.ValidateQualifiedName
+	}
+	if err := validate(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// loadSkillset reads the skill set in a project's agents directory. It
+// returns nil when the project has none.
+func loadSkillset(fsys platformfs.FileSystem, agentsDir string) (*Skillset, error) {
+	path := fsys.Join(agentsDir, SkillsetFileName)
+	if !fsys.Exists(path) {
+		return nil, nil
+	}
+
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", SkillsetFileName, err)
+	}
+	var set Skillset
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", SkillsetFileName, err)
+	}
+	return &set, nil
+}
+
+// installSkillsetEntry makes a skill set entry available in the project:
+// remote sources are added to the project store, local paths are linked
+// into it, and entries without a source must already resolve from a store.
+func (s *LockInstallService) installSkillsetEntry(ctx context.Context, entry SkillsetEntry, seen map[string]bool, dryRun bool) LockInstallResult {
+	result := LockInstallResult{SkillName: entry.Name, Source: entry.Source, Action: LockInstallActionUnchanged}
+	name, err := entry.skillName(s.fs)
+	if err != nil {
+		result.Action = LockInstallActionError
+		result.Error = err
+		return result
+	}
+	result.SkillName = name
+	if seen[name] {
+		result.Action = LockInstallActionError
+		result.Error = fmt.Errorf("listed more than once in %s", SkillsetFileName)
+		return result
+	}
+	seen[name] = true
+
+	switch {
+	case entry.Source == "":
+		if _, err := s.store.GetByName(name); err != nil {
+			result.Action = LockInstallActionError
+			result.Error = fmt.Errorf("not found in any store (give it a source in %s)", SkillsetFileName)
+		}
+	case isLocalSource(entry.Source):
+		result.Action, result.Error = s.linkSkillsetEntry(name, entry.Source, dryRun)
+	default:
+		result.Action, result.Error = s.addSkillsetEntry(ctx, name, entry.Source, dryRun)
+	}
+	if result.Error != nil {
+		result.Action = LockInstallActionError
+	}
+	return result
+}
+
+// linkSkillsetEntry links the skill directory at source into the project store.
+func (s *LockInstallService) linkSkillsetEntry(name, source string, dryRun bool) (LockInstallAction, error) {
+	path, err := config.ExpandPath(s.fs, source)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = s.fs.Join(s.root, path)
+	}
+	if !s.fs.Exists(s.fs.Join(path, "SKILL.md")) {
+		return "", fmt.Errorf("no SKILL.md found in %s", source)
+	}
+
+	skillsDir := s.cfg.ProjectSkillsDir(s.fs, s.root)
+	dest := s.fs.Join(skillsDir, name)
+	action := LockInstallActionInstalled
+	if s.fs.IsSymlink(dest) {
+		if target, err := s.fs.Readlink(dest); err == nil && target == path {
+			return LockInstallActionUnchanged, nil
+		}
+		action = LockInstallActionUpdated
+	} else if s.fs.Exists(dest) {
+		return "", fmt.Errorf("skill already exists in project scope and is not a link to %s", source)
+	}
+	if dryRun {
+		return action, nil
+	}
+
+	if action == LockInstallActionUpdated {
+		if err := s.fs.Remove(dest); err != nil {
+			return "", fmt.Errorf("failed to remove existing link: %w", err)
+		}
+	}
+	if err := s.fs.MkdirAll(skillsDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create skills directory: %w", err)
+	}
+	if err := s.fs.Symlink(path, dest); err != nil {
+		return "", fmt.Errorf("failed to link skill: %w", err)
+	}
+	return action, nil
+}
+
+// addSkillsetEntry adds a remote skill to the project store. A skill added
+// from a different source is replaced when it has not been edited since,
+// so changing the version in the skill set updates the skill.
+func (s *LockInstallService) addSkillsetEntry(ctx context.Context, name, source string, dryRun bool) (LockInstallAction, error) {
+	agentsDir := config.ProjectAgentsDir(s.root, s.fs)
+	dest := s.fs.Join(s.cfg.ProjectSkillsDir(s.fs, s.root), name)
+	lock, err := loadLockfile(s.fs, agentsDir)
+	if err != nil {
+		return "", err
+	}
+
+	force := false
+	if entry, ok := lock.source(name); ok && entry.Source != source && s.fs.Exists(dest) {
+		if sum, err := dirChecksum(s.fs, dest); err != nil || sum != entry.Checksum {
+			return "", fmt.Errorf("skill %s was modified since it was added from %s", name, entry.Source)
+		}
+		force = true
+	}
+	if dryRun {
+		switch {
+		case force:
+			return LockInstallActionUpdated, nil
+		case s.fs.Exists(dest):
+			return LockInstallActionUnchanged, nil
+		default:
+			return LockInstallActionInstalled, nil
+		}
+	}
+
+	added, err := s.add.Add(ctx, AddOptions{Source: source, Name: name, Scope: skill.ScopeProject, Force: force})
+	if err != nil {
+		return "", err
+	}
+	switch added.Action {
+	case AddActionAdded:
+		return LockInstallActionInstalled, nil
+	case AddActionReplaced:
+		return LockInstallActionUpdated, nil
+	default:
+		return LockInstallActionUnchanged, nil
+	}
+}
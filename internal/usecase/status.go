@@ -1,7 +1,11 @@
 package usecase
 
 import (
+	"cmp"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/wwwyo/skillet/internal/config"
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
@@ -13,19 +17,101 @@ type StatusResult struct {
 	Target    string
 	Installed []string
 	Missing   []string
-	Extra     []string
-	InSync    bool
-	Error     error
+	// Excluded lists skills kept out of the target by their frontmatter
+	// targets or the skills settings in the config.
+	Excluded []string
+	// Extra lists entries for skills not in the store, and optional skills
+	// installed but not enabled for the target.
+	Extra []string
+	// Current lists copied installs whose content matches the store.
+	Current []string
+	// Stale lists copied installs whose content differs from the store.
+	Stale []StaleCopy
+	// Mismatched lists installs not made with the strategy their scope expects.
+	Mismatched []StrategyMismatch
+	// Stats breaks the target's entries down by how they are deployed.
+	Stats DeploymentStats
+	// Plan lists the steps that bring the target back in sync (see SyncService.Fix).
+	Plan   []FixStep
+	InSync bool
+	Error  error
+}
+
+// FixAction is the kind of a remediation step.
+type FixAction string
+
+const (
+	// FixInstall installs a missing skill, replacing a broken link.
+	FixInstall FixAction = "install"
+	// FixReinstall reinstalls a stale copy or an install made with the wrong strategy.
+	FixReinstall FixAction = "reinstall"
+	// FixUninstall removes an optional skill that is not enabled, or an extra
+	// entry when StatusOptions.RemoveExtras is set.
+	FixUninstall FixAction = "uninstall"
+)
+
+// FixStep is a step of a target's remediation plan.
+type FixStep struct {
+	SkillName string
+	Scope     skill.Scope
+	Action    FixAction
+	// Reason explains the drift the step repairs.
+	Reason string
+	// Extra is set on uninstalls of entries whose skill is not in the store.
+	Extra bool
+}
+
+// DeploymentStats counts a target's skill entries by how they are deployed.
+type DeploymentStats struct {
+	// Symlinks are links into the store.
+	Symlinks int
+	// Copies are copies that match the store.
+	Copies int
+	// Diverged are copies that differ from the store and links pointing elsewhere.
+	Diverged int
+	// Unmanaged are entries for skills not in the store.
+	Unmanaged int
+}
+
+// StaleCopy describes a copied install that no longer matches the store.
+type StaleCopy struct {
+	SkillName string
+	// Since is when the copy was last synced (zero if unknown).
+	Since time.Time
+	// Run is the ID of the sync run that last copied it (empty if unknown).
+	Run string
+	// DiffersFrom lists the other targets whose copy of the skill has
+	// different content.
+	DiffersFrom []string
+	// Behind is how much later the store last changed than Since.
+	Behind time.Duration
+	// Overdue is true when Behind exceeds the configured stale copy age.
+	Overdue bool
+	// Version is the version the copy declares, and StoreVersion the one the
+	// store declares (empty when none).
+	Version      string
+	StoreVersion string
+}
+
+// StrategyMismatch describes an install made with an unexpected strategy.
+type StrategyMismatch struct {
+	SkillName string
+	Want      config.Strategy
+	Got       config.Strategy
 }
 
 // StatusOptions contains options for getting status.
 type StatusOptions struct {
 	// Scope limits status to a specific scope (nil for all)
 	Scope *skill.Scope
+	// RemoveExtras plans to uninstall entries for skills not in the store
+	RemoveExtras bool
 }
 
 // StatusService returns synchronization status across targets.
 type StatusService struct {
+	fs      platformfs.FileSystem
+	cfg     *config.Config
 	store   *skill.Store
 	targets *TargetRegistry
 }
@@ -33,20 +119,28 @@ type StatusService struct {
 // NewStatusService creates a new status service.
 func NewStatusService(fsys platformfs.FileSystem, cfg *config.Config, root string) *StatusService {
 	return &StatusService{
+		fs:      fsys,
+		cfg:     cfg,
 		store:   skill.NewStore(fsys, cfg, root),
 		targets: NewTargetRegistry(fsys, root, cfg),
 	}
 }
 
 // GetStatus returns the synchronization status for all targets.
+// Results are ordered by target name, and skill lists by skill name.
 func (s *StatusService) GetStatus(opts ...StatusOptions) ([]*StatusResult, error) {
 	skills, err := s.store.GetResolved()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get skills: %w", err)
 	}
+	all := skills
 
-	if len(opts) > 0 && opts[0].Scope != nil {
-		skills = filterSkillsByScope(skills, *opts[0].Scope)
+	var opt StatusOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Scope != nil {
+		skills = filterSkillsByScope(skills, *opt.Scope)
 	}
 
 	skillNames := make(map[string]bool, len(skills))
@@ -56,6 +150,8 @@ func (s *StatusService) GetStatus(opts ...StatusOptions) ([]*StatusResult, error
 
 	targets := s.targets.GetAll()
 	statuses := make([]*StatusResult, 0, len(targets))
+	storeSums := make(map[string]string, len(skills))
+	copies := copySums(targets, skills)
 
 	for _, t := range targets {
 		installed, err := t.ListInstalled()
@@ -67,35 +163,165 @@ func (s *StatusService) GetStatus(opts ...StatusOptions) ([]*StatusResult, error
 			continue
 		}
 
-		installedSet := make(map[string]bool, len(installed))
-		for _, name := range installed {
-			installedSet[name] = true
-		}
-
-		var installedList, missingList []string
+		var installedList, missingList, currentList []string
+		var staleList []StaleCopy
+		var mismatchList []StrategyMismatch
+		var stats DeploymentStats
+		var disabledList, excludedList []string
+		var plan []FixStep
+		wanted := wantedSkills(t, all)
 		for _, sk := range skills {
+			if !wanted[sk.Name] {
+				// Sync uninstalls optional skills that are not enabled for, or
+				// required by another skill in, the target, and skills that do
+				// not support it.
+				if t.IsInstalledInScope(sk.Name, sk.Scope) && t.manages(sk) {
+					disabledList = append(disabledList, sk.Name)
+					plan = append(plan, FixStep{SkillName: sk.Name, Scope: sk.Scope, Action: FixUninstall, Reason: unwantedReason(t, sk)})
+				} else if t.wants(sk) && !t.supports(sk) {
+					excludedList = append(excludedList, sk.Name)
+				}
+				continue
+			}
 			if t.IsInstalledInScope(sk.Name, sk.Scope) {
 				installedList = append(installedList, sk.Name)
+				want := strategyForSkill(s.cfg, sk)
+				got, _ := t.InstalledStrategy(sk.Name, sk.Scope)
+				stale, isStale := s.checkCopy(t, sk, storeSums, copies)
+				switch {
+				case got != config.StrategySymlink && isStale:
+					stats.Diverged++
+				case got != config.StrategySymlink:
+					stats.Copies++
+					currentList = append(currentList, sk.Name)
+				case t.linksTo(sk):
+					stats.Symlinks++
+				default:
+					stats.Diverged++
+				}
+				if strategyMismatch(want, got) {
+					mismatchList = append(mismatchList, StrategyMismatch{SkillName: sk.Name, Want: want, Got: got})
+					plan = append(plan, FixStep{SkillName: sk.Name, Scope: sk.Scope, Action: FixReinstall, Reason: fmt.Sprintf("installed as %s, expected %s", got, want)})
+				} else if isStale {
+					staleList = append(staleList, stale)
+					plan = append(plan, FixStep{SkillName: sk.Name, Scope: sk.Scope, Action: FixReinstall, Reason: "copy differs from the store"})
+				}
 			} else {
 				missingList = append(missingList, sk.Name)
+				reason := "missing"
+				if path, err := t.GetInstallPath(sk.Name, sk.Scope); err == nil && t.fs.IsSymlink(path) {
+					reason = "broken link"
+				}
+				plan = append(plan, FixStep{SkillName: sk.Name, Scope: sk.Scope, Action: FixInstall, Reason: reason})
 			}
 		}
+		if opt.RemoveExtras {
+			extras, err := findExtras(s.store, []*Target{t}, opt.Scope)
+			if err != nil {
+				return nil, err
+			}
+			for _, extra := range extras {
+				plan = append(plan, FixStep{SkillName: extra.SkillName, Scope: extra.Scope, Action: FixUninstall, Reason: "not in the store", Extra: true})
+			}
+		}
+		slices.SortStableFunc(plan, func(a, b FixStep) int { return cmp.Compare(a.SkillName, b.SkillName) })
 
 		var extraList []string
-		for name := range installedSet {
-			if !skillNames[name] {
+		entries := t.entrySet(skillNames)
+		for _, name := range installed {
+			if !entries[name] {
 				extraList = append(extraList, name)
 			}
 		}
 
+		stats.Unmanaged = len(extraList)
+		if len(disabledList) > 0 {
+			extraList = append(extraList, disabledList...)
+			slices.Sort(extraList)
+		}
+
 		statuses = append(statuses, &StatusResult{
-			Target:    t.Name(),
-			Installed: installedList,
-			Missing:   missingList,
-			Extra:     extraList,
-			InSync:    len(missingList) == 0 && len(extraList) == 0,
+			Target:     t.Name(),
+			Stats:      stats,
+			Installed:  installedList,
+			Missing:    missingList,
+			Excluded:   excludedList,
+			Extra:      extraList,
+			Current:    currentList,
+			Stale:      staleList,
+			Mismatched: mismatchList,
+			Plan:       plan,
+			InSync: len(missingList) == 0 && len(extraList) == 0 &&
+				len(staleList) == 0 && len(mismatchList) == 0,
 		})
 	}
 
 	return statuses, nil
 }
+
+// checkCopy compares a copied install against the store by checksum, and a
+// stale one against the copies in other targets (see copySums). Symlinked
+// installs always reflect the store and are never stale.
+func (s *StatusService) checkCopy(t *Target, sk *skill.Skill, storeSums map[string]string, copies map[string]map[string]string) (StaleCopy, bool) {
+	if !t.copyOutdated(sk, storeSums) {
+		return StaleCopy{}, false
+	}
+	installed, err := t.GetInstallPath(sk.Name, sk.Scope)
+	if err != nil {
+		return StaleCopy{}, false
+	}
+
+	stale := StaleCopy{
+		SkillName:    sk.Name,
+		Since:        latestModTime(s.fs, installed),
+		Run:          t.SyncedIn(sk.Name, sk.Scope),
+		Version:      t.installedVersion(sk),
+		StoreVersion: sk.Version(),
+	}
+	if sum, ok := copies[sk.Path][t.Name()]; ok {
+		for _, other := range slices.Sorted(maps.Keys(copies[sk.Path])) {
+			if other != t.Name() && copies[sk.Path][other] != sum {
+				stale.DiffersFrom = append(stale.DiffersFrom, other)
+			}
+		}
+	}
+	if at, ok := t.SyncedAt(sk.Name, sk.Scope); ok {
+		stale.Since = at
+	}
+	if storeMod := latestModTime(s.fs, sk.Path); !stale.Since.IsZero() && storeMod.After(stale.Since) {
+		stale.Behind = storeMod.Sub(stale.Since)
+		maxAge := s.cfg.StaleCopyAge()
+		stale.Overdue = maxAge > 0 && stale.Behind > maxAge
+	}
+	return stale, true
+}
+
+// copySums returns the checksum of every copied install of skills, keyed by
+// skill path and then by target name. Targets that post-process copies are
+// left out, since their copies differ from the others' by design.
+func copySums(targets []*Target, skills []*skill.Skill) map[string]map[string]string {
+	sums := make(map[string]map[string]string, len(skills))
+	for _, t := range targets {
+		if t.transformed() {
+			continue
+		}
+		for _, sk := range skills {
+			if !t.IsInstalledInScope(sk.Name, sk.Scope) {
+				continue
+			}
+			installed, err := t.GetInstallPath(sk.Name, sk.Scope)
+			if err != nil || t.fs.IsSymlink(installed) {
+				continue
+			}
+			sum, err := dirChecksum(t.fs, installed)
+			if err != nil {
+				continue
+			}
+			if sums[sk.Path] == nil {
+				sums[sk.Path] = make(map[string]string)
+			}
+			sums[sk.Path][t.Name()] = sum
+		}
+	}
+	return sums
+}
@@ -1,7 +1,9 @@
 package usecase_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/wwwyo/skillet/internal/config"
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
@@ -62,3 +64,196 @@ func TestGetStatusExtra(t *testing.T) {
 		t.Fatal("claude target not found")
 	}
 }
+
+func TestGetStatusStaleCopy(t *testing.T) {
+	mock, svc := setupStatusEnv()
+	mock.Dirs["/home/test/.agents/skills/copied"] = true
+	mock.Files["/home/test/.agents/skills/copied/SKILL.md"] = []byte("---\nname: copied\nversion: 2.0.0\n---\nv2\n")
+
+	mock.Dirs["/home/test/.claude/skills/copied"] = true
+	mock.Files["/home/test/.claude/skills/copied/SKILL.md"] = []byte("---\nname: copied\nversion: 1.0.0\n---\nv1\n")
+	mock.Dirs["/home/test/.codex/skills/copied"] = true
+	mock.Files["/home/test/.codex/skills/copied/SKILL.md"] = []byte("---\nname: copied\nversion: 2.0.0\n---\nv2\n")
+
+	statuses, err := svc.GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+
+	for _, s := range statuses {
+		switch s.Target {
+		case "claude":
+			if len(s.Stale) != 1 || s.Stale[0].SkillName != "copied" || s.InSync {
+				t.Errorf("claude status = %+v, want stale copy and out of sync", s)
+			} else if s.Stale[0].Version != "1.0.0" || s.Stale[0].StoreVersion != "2.0.0" {
+				t.Errorf("claude stale copy = %+v, want version 1.0.0 behind 2.0.0", s.Stale[0])
+			}
+		case "codex":
+			if len(s.Stale) != 0 || !s.InSync {
+				t.Errorf("codex status = %+v, want current and in sync", s)
+			}
+		}
+	}
+}
+
+func TestGetStatusOverdueCopy(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "copied")
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	cfg.Status.StaleCopyDays = 10
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !mock.Exists("/home/test/.claude/skills/.skillet-synced.yaml") {
+		t.Fatal("Sync() should record when copies were made")
+	}
+
+	// The store changes well after the copy was made.
+	storeFile := "/home/test/.agents/skills/copied/SKILL.md"
+	mock.Files[storeFile] = []byte("---\nname: copied\n---\nv2\n")
+	mock.ModTimes[storeFile] = time.Now().Add(20 * 24 * time.Hour)
+
+	statuses, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	for _, s := range statuses {
+		if len(s.Stale) != 1 || !s.Stale[0].Overdue || s.Stale[0].Behind < 19*24*time.Hour {
+			t.Errorf("%s stale = %+v, want one overdue copy", s.Target, s.Stale)
+		}
+	}
+
+	cfg.Status.StaleCopyDays = -1
+	statuses, err = usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	if statuses[0].Stale[0].Overdue {
+		t.Error("negative staleCopyDays should disable the warning")
+	}
+}
+
+func TestGetStatusComparesCopiesAcrossTargets(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "copied")
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	sync := usecase.NewSyncService(mock, cfg, "")
+	if _, err := sync.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	// Only claude picks up the store's new content.
+	mock.Files["/home/test/.agents/skills/copied/SKILL.md"] = []byte("---\nname: copied\n---\nv2\n")
+	if _, err := sync.Sync(t.Context(), usecase.SyncOptions{Target: "claude", Force: true}); err != nil {
+		t.Fatalf("Sync(claude) error = %v", err)
+	}
+
+	statuses, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	for _, s := range statuses {
+		switch s.Target {
+		case "claude":
+			if len(s.Stale) != 0 || strings.Join(s.Current, ",") != "copied" {
+				t.Errorf("claude current = %v, stale = %+v, want copied current", s.Current, s.Stale)
+			}
+		case "codex":
+			if len(s.Stale) != 1 || len(s.Current) != 0 {
+				t.Fatalf("codex current = %v, stale = %+v, want copied stale", s.Current, s.Stale)
+			}
+			stale := s.Stale[0]
+			if _, err := time.Parse("20060102T150405Z", stale.Run); err != nil {
+				t.Errorf("Run = %q, want the ID of the first sync run", stale.Run)
+			}
+			if strings.Join(stale.DiffersFrom, ",") != "claude" {
+				t.Errorf("DiffersFrom = %v, want [claude]", stale.DiffersFrom)
+			}
+		}
+	}
+}
+
+func TestGetStatusDeploymentStats(t *testing.T) {
+	mock, svc := setupStatusEnv()
+	for _, name := range []string{"linked", "copied", "diverged", "foreign"} {
+		mock.Dirs["/home/test/.agents/skills/"+name] = true
+		mock.Files["/home/test/.agents/skills/"+name+"/SKILL.md"] = []byte("---\nname: " + name + "\n---\n")
+	}
+	mock.Symlinks["/home/test/.claude/skills/linked"] = "/home/test/.agents/skills/linked"
+	mock.Symlinks["/home/test/.claude/skills/foreign"] = "/elsewhere/foreign"
+	mock.Dirs["/home/test/.claude/skills/copied"] = true
+	mock.Files["/home/test/.claude/skills/copied/SKILL.md"] = []byte("---\nname: copied\n---\n")
+	mock.Dirs["/home/test/.claude/skills/diverged"] = true
+	mock.Files["/home/test/.claude/skills/diverged/SKILL.md"] = []byte("---\nname: diverged\n---\nedited\n")
+	mock.Dirs["/home/test/.claude/skills/unmanaged"] = true
+
+	statuses, err := svc.GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	for _, s := range statuses {
+		if s.Target != "claude" {
+			continue
+		}
+		want := usecase.DeploymentStats{Symlinks: 1, Copies: 1, Diverged: 2, Unmanaged: 1}
+		if s.Stats != want {
+			t.Errorf("Stats = %+v, want %+v", s.Stats, want)
+		}
+	}
+}
+
+func TestGetStatusPlanAndFix(t *testing.T) {
+	mock, _ := setupStatusEnv()
+	for _, name := range []string{"missing", "stale"} {
+		mock.Dirs["/home/test/.agents/skills/"+name] = true
+		mock.Files["/home/test/.agents/skills/"+name+"/SKILL.md"] = []byte("---\nname: " + name + "\n---\n")
+	}
+	mock.Dirs["/home/test/.claude/skills/stale"] = true
+	mock.Files["/home/test/.claude/skills/stale/SKILL.md"] = []byte("---\nname: stale\n---\nedited\n")
+	mock.Dirs["/home/test/.claude/skills/handmade"] = true
+	mock.Files["/home/test/.claude/skills/handmade/SKILL.md"] = []byte("---\nname: handmade\n---\n")
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	cfg.Targets["codex"] = config.TargetConfig{Enabled: false}
+	svc := usecase.NewStatusService(mock, cfg, "")
+	opts := usecase.StatusOptions{RemoveExtras: true}
+	statuses, err := svc.GetStatus(opts)
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("GetStatus() = %d statuses, want claude only", len(statuses))
+	}
+	var plan []string
+	for _, step := range statuses[0].Plan {
+		plan = append(plan, step.SkillName+":"+string(step.Action))
+	}
+	if want := "handmade:uninstall,missing:install,stale:reinstall"; strings.Join(plan, ",") != want {
+		t.Fatalf("Plan = %v, want %s", plan, want)
+	}
+
+	results, err := usecase.NewSyncService(mock, cfg, "").Fix(t.Context(), statuses, usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	actions := make(map[string]usecase.SyncAction)
+	for _, r := range results {
+		actions[r.SkillName] = r.Action
+	}
+	if actions["handmade"] != usecase.SyncActionUninstall || actions["missing"] != usecase.SyncActionInstall || actions["stale"] != usecase.SyncActionUpdate {
+		t.Errorf("Fix() results = %+v", results)
+	}
+
+	after, err := svc.GetStatus(opts)
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	if !after[0].InSync || len(after[0].Plan) != 0 {
+		t.Errorf("status after Fix() = %+v, want in sync", after[0])
+	}
+}
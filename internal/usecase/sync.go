@@ -1,10 +1,21 @@
 package usecase
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/wwwyo/skillet/internal/config"
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/platform/hook"
 	"github.com/wwwyo/skillet/internal/skill"
 )
 
@@ -17,14 +28,38 @@ const (
 	SyncActionUninstall SyncAction = "uninstall"
 	SyncActionSkip      SyncAction = "skip"
 	SyncActionError     SyncAction = "error"
+	SyncActionManifest  SyncAction = "manifest"
+	// SyncActionExtra reports a skill in a target that is not in the store.
+	// Sync never removes it; see SyncService.RemoveExtras.
+	SyncActionExtra SyncAction = "extra"
+	// SyncActionExcluded reports a skill left out of a target by its
+	// frontmatter targets or the skills settings in the config.
+	SyncActionExcluded SyncAction = "excluded"
 )
 
 // SyncResult represents the result of a sync operation for a single skill.
+// Manifest results carry the manifest path in SkillName.
 type SyncResult struct {
 	SkillName string
 	Target    string
 	Action    SyncAction
 	Error     error
+	// Warnings holds non-fatal problems, such as missing required commands.
+	Warnings []string
+	// FromVersion and ToVersion are set when an update replaces a copy with
+	// an older version than the store's.
+	FromVersion string
+	ToVersion   string
+	// RolledBack is set when an atomic sync undid the change.
+	RolledBack bool
+}
+
+// ExtraSkill is an entry in a target's skills directory whose skill is not in the store.
+type ExtraSkill struct {
+	SkillName string
+	Target    string
+	Scope     skill.Scope
+	Path      string
 }
 
 // SyncOptions contains options for synchronization.
@@ -35,55 +70,650 @@ type SyncOptions struct {
 	Force bool
 	// Scope limits sync to a specific scope (nil for all)
 	Scope *skill.Scope
+	// Target limits sync to a single target (empty for all)
+	Target string
+	// Names limits sync to these skills (empty for all)
+	Names []string
+	// Selection limits each target to the skills listed under its name, and
+	// the skills they require, on top of Names (nil for no limit). Targets
+	// it does not list get no skills.
+	Selection map[string][]string
+	// SkipMissingCommands skips skills whose required commands are not on PATH
+	SkipMissingCommands bool
+	// Strategy overrides the configured strategy for every skill (empty for config)
+	Strategy config.Strategy
+	// NoCache compares every copy with the store instead of trusting the sync
+	// state cache, which is then rebuilt
+	NoCache bool
+	// Atomic stops at the first skill that fails, or when the sync is
+	// canceled, and undoes the changes made to the targets so far
+	Atomic bool
+	// WaitForLock waits for another run holding the run lock (see
+	// RunLockFileName) to finish, instead of failing with a *RunLockedError
+	WaitForLock bool
 }
 
 // SyncService synchronizes skills to targets.
 type SyncService struct {
+	fs      platformfs.FileSystem
 	store   *skill.Store
 	targets *TargetRegistry
 	cfg     *config.Config
+	root    string
+	hooks   hook.Runner
 }
 
 // NewSyncService creates a new sync service.
 func NewSyncService(fsys platformfs.FileSystem, cfg *config.Config, root string) *SyncService {
 	return &SyncService{
+		fs:      fsys,
 		store:   skill.NewStore(fsys, cfg, root),
 		targets: NewTargetRegistry(fsys, root, cfg),
 		cfg:     cfg,
+		root:    root,
+		hooks:   hook.ShellRunner{},
 	}
 }
 
+// SetHookRunner replaces the runner of the commands configured under hooks
+// (hook.ShellRunner by default).
+func (s *SyncService) SetHookRunner(r hook.Runner) {
+	s.hooks = r
+}
+
 // Sync synchronizes skills to targets.
-func (s *SyncService) Sync(opts SyncOptions) ([]SyncResult, error) {
+// Skills in a target that are not in the store are reported as extra and
+// left in place, unless Names limits the sync.
+// Results are ordered by target name, then by skill name.
+// Unless dry-running, the synced skill set is recorded in the lock file
+// of the project, or of the global store outside a project, and the
+// configured hooks run: preSync before any change, whose failure aborts the
+// sync, postInstall and postRemove for each skill, and postSync at the end.
+// Copies are compared with the store unless the sync state cache records
+// that neither changed since they last matched (see SyncOptions.NoCache).
+// Canceling ctx stops the sync between skills; the results so far are
+// returned with ctx's error.
+// Unless dry-running, the sync holds the run lock of the agents directories
+// (see RunLockFileName) throughout.
+// An atomic sync (see SyncOptions.Atomic) that fails is rolled back: the
+// results are returned with ErrSyncRolledBack, the lock file, the sync state
+// cache, and the postSync hooks are left alone, and hooks that already ran
+// are not undone.
+func (s *SyncService) Sync(ctx context.Context, opts SyncOptions) ([]SyncResult, error) {
+	if !opts.DryRun {
+		lock, err := lockAgentsDirs(ctx, s.fs, s.cfg, s.root, opts.WaitForLock)
+		if err != nil {
+			return nil, err
+		}
+		defer lock.release()
+	}
+
+	// Conflicts under the error-on-conflict policy are reported per target
+	// while the remaining skills still sync.
 	skills, err := s.store.GetResolved()
+	var conflictErr *skill.ConflictError
+	if errors.As(err, &conflictErr) {
+		err = nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get skills: %w", err)
 	}
+	var conflicts []skill.Conflict
+	if conflictErr != nil {
+		conflicts = conflictErr.Conflicts
+	}
+
+	// Dependencies are resolved against every skill, so a filtered sync still
+	// installs the skills it requires from outside the filter.
+	all := slices.Clone(skills)
+	known := make(map[string]bool, len(all)+len(conflicts))
+	for _, sk := range all {
+		known[sk.Name] = true
+	}
+	for _, c := range conflicts {
+		known[c.Name] = true
+	}
+	cycles := requireCycles(all)
 
 	if opts.Scope != nil {
 		skills = filterSkillsByScope(skills, *opts.Scope)
+		conflicts = slices.DeleteFunc(conflicts, func(c skill.Conflict) bool {
+			return !slices.ContainsFunc(c.Skills, func(sk *skill.Skill) bool { return sk.Scope == *opts.Scope })
+		})
+	}
+	names := opts.Names
+	if len(names) > 0 {
+		names = withRequires(all, names)
+		skills = slices.DeleteFunc(skills, func(sk *skill.Skill) bool {
+			return !slices.Contains(names, sk.Name)
+		})
+		conflicts = slices.DeleteFunc(conflicts, func(c skill.Conflict) bool {
+			return !slices.Contains(names, c.Name)
+		})
 	}
 
 	targets := s.targets.GetAll()
+	if opts.Target != "" {
+		t, err := s.targets.Lookup(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		targets = []*Target{t}
+	}
 	results := make([]SyncResult, 0, len(targets)*len(skills))
+	storeSums := make(map[string]string, len(skills))
+
+	var extras []ExtraSkill
+	if len(names) == 0 {
+		if extras, err = findExtras(s.store, targets, opts.Scope); err != nil {
+			return nil, err
+		}
+	}
+
+	missing := make(map[string][]string, len(skills))
+	missingDeps := make(map[string][]string, len(skills))
+	for _, sk := range skills {
+		missing[sk.Name] = sk.MissingCommands(exec.LookPath)
+		missingDeps[sk.Name] = missingRequires(sk, known)
+	}
 
+	state, err := loadSyncState(s.fs, !opts.NoCache)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("syncing", "skills", len(skills), "targets", targetNames(targets), "dryRun", opts.DryRun, "force", opts.Force, "cache", !opts.NoCache)
+	hookEnv := syncHookEnv(skills, targets)
+	if !opts.DryRun {
+		if err := s.runHooks(ctx, hookPreSync, s.cfg.Hooks.PreSync, hookEnv); err != nil {
+			return nil, err
+		}
+	}
+
+	run := newRunID(time.Now())
+	for _, t := range targets {
+		t.run = run
+	}
+	defer func() {
+		for _, t := range targets {
+			t.run = ""
+		}
+	}()
+
+	var journal *syncJournal
+	if opts.Atomic && !opts.DryRun {
+		journal = newSyncJournal(s.fs)
+		for _, t := range targets {
+			t.journal = journal
+		}
+		defer func() {
+			for _, t := range targets {
+				t.journal = nil
+			}
+		}()
+	}
+	failed := func() bool {
+		return journal != nil && firstSyncError(results) != nil
+	}
+
+targets:
 	for _, t := range targets {
+		if failed() {
+			break
+		}
+		targetStart := len(results)
+		selected := func(string) bool { return true }
+		if opts.Selection != nil {
+			names := withRequires(all, opts.Selection[t.Name()])
+			selected = func(name string) bool { return slices.Contains(names, name) }
+		}
+		for _, c := range conflicts {
+			if !selected(c.Name) {
+				continue
+			}
+			results = append(results, SyncResult{
+				SkillName: c.Name,
+				Target:    t.Name(),
+				Action:    SyncActionError,
+				Error:     conflictError(c),
+			})
+		}
+		wanted := wantedSkills(t, all)
+		collisions := entryCollisions(t, all, wanted)
+		listed := t.entrySet(known)
+		unaliased := make(map[string]bool)
 		for _, sk := range skills {
+			if ctx.Err() != nil || failed() {
+				break targets
+			}
+			if !selected(sk.Name) {
+				continue
+			}
+			if !wanted[sk.Name] {
+				switch {
+				case t.IsInstalledInScope(sk.Name, sk.Scope) && t.manages(sk):
+					results = append(results, s.uninstallSkill(ctx, t, sk, opts))
+				case t.wants(sk) && !t.supports(sk):
+					results = append(results, SyncResult{SkillName: sk.Name, Target: t.Name(), Action: SyncActionExcluded})
+				default:
+					slog.Debug("skipping skill", "skill", sk.Name, "target", t.Name(), "reason", unwantedReason(t, sk))
+				}
+				continue
+			}
+			if cycle, ok := cycles[sk.Name]; ok {
+				results = append(results, SyncResult{
+					SkillName: sk.Name,
+					Target:    t.Name(),
+					Action:    SyncActionError,
+					Error:     requireCycleError(cycle),
+				})
+				continue
+			}
+			if other, ok := collisions[sk.Name]; ok {
+				results = append(results, SyncResult{
+					SkillName: sk.Name,
+					Target:    t.Name(),
+					Action:    SyncActionError,
+					Error:     fmt.Errorf("installs as %s, like %s (see collections in the config)", t.installedName(sk.Name), other),
+				})
+				continue
+			}
+			if len(missing[sk.Name]) > 0 && opts.SkipMissingCommands {
+				slog.Debug("skipping skill", "skill", sk.Name, "target", t.Name(), "reason", "required commands not found", "commands", missing[sk.Name])
+				results = append(results, SyncResult{
+					SkillName: sk.Name,
+					Target:    t.Name(),
+					Action:    SyncActionSkip,
+					Warnings:  []string{missingCommandsWarning(missing[sk.Name])},
+				})
+				continue
+			}
+			// An install left under the skill's own name after the target
+			// aliased it is removed, unless another skill is listed there.
+			if entry, ok := t.unaliasedEntry(sk); ok && !listed[entry] {
+				results = append(results, s.uninstall(ctx, t, entry, entry, sk.Scope, opts.DryRun))
+				if path, err := t.entryPath(entry, sk.Scope); err == nil {
+					unaliased[path] = true
+				}
+			}
 			isInstalled := t.IsInstalledInScope(sk.Name, sk.Scope)
-			result := s.syncSkill(t, sk, isInstalled, opts)
+			result := s.syncSkill(ctx, t, sk, isInstalled, storeSums, state, opts)
+			if len(missing[sk.Name]) > 0 {
+				result.Warnings = append(result.Warnings, missingCommandsWarning(missing[sk.Name]))
+			}
+			if len(missingDeps[sk.Name]) > 0 {
+				result.Warnings = append(result.Warnings, missingRequiresWarning(missingDeps[sk.Name]))
+			}
 			results = append(results, result)
 		}
+		for _, extra := range extras {
+			if extra.Target == t.Name() && !unaliased[extra.Path] {
+				results = append(results, SyncResult{SkillName: extra.SkillName, Target: t.Name(), Action: SyncActionExtra})
+			}
+		}
+		slices.SortStableFunc(results[targetStart:], func(a, b SyncResult) int {
+			return cmp.Compare(a.SkillName, b.SkillName)
+		})
+		if !opts.DryRun {
+			results = append(results, s.syncManifests(t)...)
+		}
+	}
+
+	if journal != nil {
+		if r := firstSyncError(results); r != nil {
+			return rollBack(journal, results, fmt.Errorf("%s in %s: %w", r.SkillName, r.Target, r.Error))
+		}
+		if err := ctx.Err(); err != nil {
+			return rollBack(journal, results, err)
+		}
+	}
+
+	// A canceled sync keeps the copies it checked cached, but leaves the lock
+	// file and the postSync hooks to the next full sync.
+	if err := ctx.Err(); err != nil {
+		if !opts.DryRun {
+			_ = state.save()
+		}
+		return results, err
+	}
+	if !opts.DryRun {
+		synced := func(entry LockedSkill) bool {
+			return (opts.Scope == nil || entry.Scope == opts.Scope.String()) &&
+				(len(names) == 0 || slices.Contains(names, entry.Name))
+		}
+		if err := recordInstalled(s.fs, s.cfg, s.root, skills, synced, state); err != nil {
+			err = fmt.Errorf("failed to update lock file: %w", err)
+			if journal != nil {
+				return rollBack(journal, results, err)
+			}
+			return results, err
+		}
+		// A rollback directory left behind only holds replaced installs.
+		_ = journal.commit()
+		if err := state.save(); err != nil {
+			return results, err
+		}
+		hookEnv = append(hookEnv, "SKILLET_CHANGED="+strconv.Itoa(changedCount(results)))
+		if err := s.runHooks(ctx, hookPostSync, s.cfg.Hooks.PostSync, hookEnv); err != nil {
+			return results, err
+		}
 	}
 
 	return results, nil
 }
 
-func (s *SyncService) syncSkill(t *Target, sk *skill.Skill, isInstalled bool, opts SyncOptions) SyncResult {
+// SyncChoice lists the skills a sync could install into one target.
+type SyncChoice struct {
+	Target string
+	// Skills holds the names of the skills the target would get, sorted
+	Skills []string
+	// Installed holds the names in Skills that are installed in the target now
+	Installed []string
+}
+
+// Choices returns, for each target opts selects, the skills a sync with
+// opts would install into it, limited to opts.Scope. Optional skills that are
+// not enabled for a target are left out. Results are ordered by target name.
+func (s *SyncService) Choices(opts SyncOptions) ([]SyncChoice, error) {
+	skills, err := s.store.GetResolved()
+	var conflictErr *skill.ConflictError
+	if err != nil && !errors.As(err, &conflictErr) {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	all := slices.Clone(skills)
+	if opts.Scope != nil {
+		skills = filterSkillsByScope(skills, *opts.Scope)
+	}
+	slices.SortFunc(skills, func(a, b *skill.Skill) int { return cmp.Compare(a.Name, b.Name) })
+
+	targets := s.targets.GetAll()
+	if opts.Target != "" {
+		t, err := s.targets.Lookup(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		targets = []*Target{t}
+	}
+
+	choices := make([]SyncChoice, 0, len(targets))
+	for _, t := range targets {
+		choice := SyncChoice{Target: t.Name()}
+		wanted := wantedSkills(t, all)
+		for _, sk := range skills {
+			if !wanted[sk.Name] {
+				continue
+			}
+			choice.Skills = append(choice.Skills, sk.Name)
+			if t.IsInstalledInScope(sk.Name, sk.Scope) {
+				choice.Installed = append(choice.Installed, sk.Name)
+			}
+		}
+		choices = append(choices, choice)
+	}
+	return choices, nil
+}
+
+// Extras returns the skills installed in targets that are not in the store,
+// limited to opts.Target and to the target directories opts.Scope installs into.
+// Results are ordered by target name, then by scope and skill name.
+func (s *SyncService) Extras(opts SyncOptions) ([]ExtraSkill, error) {
+	targets := s.targets.GetAll()
+	if opts.Target != "" {
+		t, err := s.targets.Lookup(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		targets = []*Target{t}
+	}
+	return findExtras(s.store, targets, opts.Scope)
+}
+
+// RemoveExtras uninstalls extra skills found by Extras, stopping early when
+// ctx is canceled.
+func (s *SyncService) RemoveExtras(ctx context.Context, extras []ExtraSkill) []SyncResult {
+	results := make([]SyncResult, 0, len(extras))
+	for _, extra := range extras {
+		if ctx.Err() != nil {
+			break
+		}
+		t, err := s.targets.Lookup(extra.Target)
+		if err != nil {
+			results = append(results, SyncResult{SkillName: extra.SkillName, Target: extra.Target, Action: SyncActionError, Error: err})
+			continue
+		}
+		results = append(results, s.uninstall(ctx, t, extra.SkillName, extra.SkillName, extra.Scope, false))
+	}
+	return results
+}
+
+// Fix carries out the remediation plans of statuses (see StatusResult.Plan)
+// with the sync engine: missing skills are installed, drifted installs are
+// reinstalled from the store, and planned uninstalls are removed. Each
+// target's manifest is then regenerated. Results follow the plans' order.
+// Hooks and cancellation work as they do for Sync.
+func (s *SyncService) Fix(ctx context.Context, statuses []*StatusResult, opts SyncOptions) ([]SyncResult, error) {
+	skills, err := s.store.GetResolved()
+	var conflictErr *skill.ConflictError
+	if err != nil && !errors.As(err, &conflictErr) {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	byKey := make(map[string]*skill.Skill, len(skills))
+	for _, sk := range skills {
+		byKey[sk.Scope.String()+"\x00"+sk.Name] = sk
+	}
+
+	var planned []*StatusResult
+	var targets []*Target
+	var fixed []*skill.Skill
+	for _, status := range statuses {
+		if status.Error != nil || len(status.Plan) == 0 {
+			continue
+		}
+		t, err := s.targets.Lookup(status.Target)
+		if err != nil {
+			return nil, err
+		}
+		planned = append(planned, status)
+		targets = append(targets, t)
+		for _, step := range status.Plan {
+			if sk := byKey[step.Scope.String()+"\x00"+step.SkillName]; sk != nil && !slices.Contains(fixed, sk) {
+				fixed = append(fixed, sk)
+			}
+		}
+	}
+	if len(planned) == 0 {
+		return nil, nil
+	}
+	hookEnv := syncHookEnv(fixed, targets)
+	if !opts.DryRun {
+		if err := s.runHooks(ctx, hookPreSync, s.cfg.Hooks.PreSync, hookEnv); err != nil {
+			return nil, err
+		}
+	}
+
+	var results []SyncResult
+	storeSums := make(map[string]string, len(skills))
+	for i, status := range planned {
+		t := targets[i]
+		for _, step := range status.Plan {
+			if err := ctx.Err(); err != nil {
+				return results, err
+			}
+			if step.Action == FixUninstall {
+				entry := t.entryName(step.SkillName)
+				if step.Extra {
+					entry = step.SkillName
+				}
+				results = append(results, s.uninstall(ctx, t, step.SkillName, entry, step.Scope, opts.DryRun))
+				continue
+			}
+			sk := byKey[step.Scope.String()+"\x00"+step.SkillName]
+			if sk == nil {
+				results = append(results, SyncResult{SkillName: step.SkillName, Target: t.Name(), Action: SyncActionError, Error: fmt.Errorf("skill not found in %s scope", step.Scope)})
+				continue
+			}
+			results = append(results, s.syncSkill(ctx, t, sk, step.Action == FixReinstall, storeSums, nil, SyncOptions{Force: true, DryRun: opts.DryRun}))
+		}
+		if !opts.DryRun {
+			results = append(results, s.syncManifests(t)...)
+		}
+	}
+	if !opts.DryRun {
+		hookEnv = append(hookEnv, "SKILLET_CHANGED="+strconv.Itoa(changedCount(results)))
+		if err := s.runHooks(ctx, hookPostSync, s.cfg.Hooks.PostSync, hookEnv); err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// findExtras returns the entries of the targets' skill directories whose
+// skill is not in the store, drafts included. Hidden entries, such as a
+// target's own bundled skills, are ignored.
+func findExtras(store *skill.Store, targets []*Target, scope *skill.Scope) ([]ExtraSkill, error) {
+	stored, err := store.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	known := make(map[string]bool, len(stored))
+	for _, sk := range stored {
+		known[sk.Name] = true
+	}
+
+	// System, org, and global skills all install into a target's global directory.
+	scopes := []skill.Scope{skill.ScopeGlobal, skill.ScopeProject}
+	if scope != nil {
+		scopes = []skill.Scope{skill.ScopeGlobal}
+		if *scope == skill.ScopeProject {
+			scopes = []skill.Scope{skill.ScopeProject}
+		}
+	}
+
+	var extras []ExtraSkill
+	for _, t := range targets {
+		for _, sc := range scopes {
+			installed, err := t.ListInstalledInScope(sc)
+			if err != nil {
+				return nil, err
+			}
+			entries := t.entrySet(known)
+			for _, name := range installed {
+				if entries[name] || strings.HasPrefix(name, ".") {
+					continue
+				}
+				path, err := t.entryPath(name, sc)
+				if err != nil {
+					continue
+				}
+				extras = append(extras, ExtraSkill{SkillName: name, Target: t.Name(), Scope: sc, Path: path})
+			}
+		}
+	}
+	return extras, nil
+}
+
+// StoreDirs returns the existing store directories whose skills are synced.
+func (s *SyncService) StoreDirs() ([]string, error) {
+	return s.store.Dirs()
+}
+
+// SkillsAt returns the sorted names of the store skills containing any of paths.
+// Paths outside every skill, including those of deleted skills, are ignored.
+func (s *SyncService) SkillsAt(paths []string) ([]string, error) {
+	skills, err := s.store.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	var names []string
+	for _, sk := range skills {
+		if slices.Contains(names, sk.Name) {
+			continue
+		}
+		if slices.ContainsFunc(paths, func(p string) bool {
+			return p == sk.Path || strings.HasPrefix(p, sk.Path+string(filepath.Separator))
+		}) {
+			names = append(names, sk.Name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// syncManifests regenerates the target's manifest in each scope after installs.
+func (s *SyncService) syncManifests(t *Target) []SyncResult {
+	var results []SyncResult
+	for _, scope := range []skill.Scope{skill.ScopeGlobal, skill.ScopeProject} {
+		path, changed, err := t.writeManifest(scope)
+		switch {
+		case err != nil:
+			results = append(results, SyncResult{SkillName: path, Target: t.Name(), Action: SyncActionError, Error: err})
+		case changed:
+			results = append(results, SyncResult{SkillName: path, Target: t.Name(), Action: SyncActionManifest})
+		}
+	}
+	return results
+}
+
+// syncSkill installs sk into t, or updates an install that no longer matches.
+// storeSums caches store checksums across skills and targets (see copyOutdated),
+// and state across syncs (nil for none).
+func (s *SyncService) syncSkill(ctx context.Context, t *Target, sk *skill.Skill, isInstalled bool, storeSums map[string]string, state *syncState, opts SyncOptions) SyncResult {
 	result := SyncResult{SkillName: sk.Name, Target: t.Name()}
 
+	// A symlink where the skill's scope expects a copy is replaced with a copy,
+	// and a copy skillet made is refreshed once the store content changes. Any
+	// copy declaring an older version than the store's is updated; a copy
+	// declaring a newer one is never downgraded.
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = strategyForSkill(s.cfg, sk)
+	}
 	if isInstalled && !opts.Force {
-		result.Action = SyncActionSkip
-		return result
+		got, _ := t.InstalledStrategy(sk.Name, sk.Scope)
+		// Copies the state cache records as matching the store are not
+		// compared again while neither changed. Copies a target post-processes
+		// are always compared.
+		installed, _ := t.GetInstallPath(sk.Name, sk.Scope)
+		cacheable := got == config.StrategyCopy && !t.transformed()
+		cached := cacheable && state.copyCurrent(installed, sk.Path)
+		outdated := got == config.StrategyCopy && !cached && t.copyOutdated(sk, storeSums)
+		if cacheable && !cached && !outdated {
+			state.recordCopy(installed, sk.Path)
+		}
+		var installedVersion string
+		var cmp int
+		if outdated {
+			installedVersion = t.installedVersion(sk)
+			cmp = skill.CompareVersions(sk.Version(), installedVersion)
+		}
+		older := installedVersion != "" && cmp > 0
+		drifted := outdated && (older || cmp == 0 && t.manages(sk))
+		if !strategyMismatch(strategy, got) && !drifted {
+			if outdated && cmp < 0 {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("installed copy is newer (%s) than the store (%s); not updated", installedVersion, versionOrNone(sk.Version())))
+			}
+			var reason string
+			switch {
+			case got == config.StrategySymlink:
+				reason = "symlink to the store"
+			case cached:
+				reason = "copy matches the store (cached)"
+			case !outdated:
+				reason = "copy matches the store"
+			case cmp < 0:
+				reason = "installed copy is newer than the store"
+			case cmp > 0:
+				reason = "installed copy declares no version"
+			default:
+				reason = "copy differs from the store but was not made by skillet"
+			}
+			slog.Debug("skipping skill", "skill", sk.Name, "target", t.Name(), "path", installed, "reason", reason)
+			result.Action = SyncActionSkip
+			return result
+		}
+		if older {
+			result.FromVersion, result.ToVersion = installedVersion, sk.Version()
+		}
 	}
 
 	if isInstalled {
@@ -91,25 +721,132 @@ func (s *SyncService) syncSkill(t *Target, sk *skill.Skill, isInstalled bool, op
 	} else {
 		result.Action = SyncActionInstall
 	}
+	slog.Debug("syncing skill", "skill", sk.Name, "target", t.Name(), "action", string(result.Action), "strategy", string(strategy), "force", opts.Force)
 
 	if opts.DryRun {
 		return result
 	}
 
-	strategy := s.cfg.DefaultStrategy
-	if strategy == "" {
-		strategy = config.StrategySymlink
-	}
-
 	installOpts := InstallOptions{Strategy: strategy, Force: opts.Force || isInstalled}
 	if err := t.Install(sk, installOpts); err != nil {
 		result.Action = SyncActionError
 		result.Error = err
+		return result
+	}
+	if got, _ := t.InstalledStrategy(sk.Name, sk.Scope); got == config.StrategyCopy && !t.transformed() {
+		if installed, err := t.GetInstallPath(sk.Name, sk.Scope); err == nil {
+			state.recordCopy(installed, sk.Path)
+		}
+	}
+	if err := s.runHooks(ctx, hookPostInstall, s.cfg.Hooks.PostInstall, skillHookEnv(t, result.Action, sk.Name, sk.Scope, sk.Path)); err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+	}
+
+	return result
+}
+
+// firstSyncError returns the first failed result, or nil when none failed.
+func firstSyncError(results []SyncResult) *SyncResult {
+	i := slices.IndexFunc(results, func(r SyncResult) bool { return r.Action == SyncActionError })
+	if i < 0 {
+		return nil
+	}
+	return &results[i]
+}
+
+// rollBack undoes the changes journal recorded after an atomic sync failed
+// with cause, and marks the results of the changes undone.
+func rollBack(journal *syncJournal, results []SyncResult, cause error) ([]SyncResult, error) {
+	if err := journal.rollback(); err != nil {
+		return results, fmt.Errorf("sync failed after %w, and could not be rolled back (replaced installs are kept in %s directories beside the skills directories): %w", cause, rollbackDirPattern, err)
+	}
+	for i, r := range results {
+		switch r.Action {
+		case SyncActionInstall, SyncActionUpdate, SyncActionUninstall, SyncActionManifest:
+			results[i].RolledBack = true
+		}
+	}
+	return results, fmt.Errorf("%w after %w", ErrSyncRolledBack, cause)
+}
+
+// versionOrNone returns v, or "none" for a skill that declares no version.
+func versionOrNone(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
+
+// entryCollisions maps each wanted skill that t would install under the same
+// name as a wanted skill before it in skills to that skill's name. Skills in
+// collections collide with each other, or with a skill outside them, when
+// their entry names match. System, org, and global skills share a directory.
+func entryCollisions(t *Target, skills []*skill.Skill, wanted map[string]bool) map[string]string {
+	seen := make(map[string]string, len(skills))
+	collisions := make(map[string]string)
+	for _, sk := range skills {
+		if !wanted[sk.Name] {
+			continue
+		}
+		key := t.entryName(sk.Name)
+		if sk.Scope == skill.ScopeProject {
+			key = "project/" + key
+		}
+		if first, ok := seen[key]; ok {
+			collisions[sk.Name] = first
+			continue
+		}
+		seen[key] = sk.Name
+	}
+	return collisions
+}
+
+// strategyForSkill returns the strategy sk is installed with: the one its
+// frontmatter asks for, or else the one configured for its scope.
+func strategyForSkill(cfg *config.Config, sk *skill.Skill) config.Strategy {
+	if sk.Strategy() != "" {
+		return config.Strategy(sk.Strategy())
 	}
+	return cfg.StrategyFor(sk.Scope.String())
+}
+
+// uninstallSkill removes an optional skill that is not enabled for the target.
+func (s *SyncService) uninstallSkill(ctx context.Context, t *Target, sk *skill.Skill, opts SyncOptions) SyncResult {
+	return s.uninstall(ctx, t, sk.Name, t.entryName(sk.Name), sk.Scope, opts.DryRun)
+}
 
+// uninstall removes the named skill, listed as entry (see entryName), from
+// t's directory for scope and runs the postRemove hooks.
+func (s *SyncService) uninstall(ctx context.Context, t *Target, name, entry string, scope skill.Scope, dryRun bool) SyncResult {
+	result := SyncResult{SkillName: name, Target: t.Name(), Action: SyncActionUninstall}
+	if dryRun {
+		return result
+	}
+	if err := t.removeEntry(entry, scope); err != nil {
+		result.Action = SyncActionError
+		result.Error = err
+		return result
+	}
+	slog.Debug("uninstalled skill", "skill", name, "target", t.Name(), "scope", scope.String())
+	if err := s.runHooks(ctx, hookPostRemove, s.cfg.Hooks.PostRemove, skillHookEnv(t, result.Action, name, scope, "")); err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+	}
 	return result
 }
 
+// conflictError describes a skill left unsynced by the error-on-conflict policy.
+func conflictError(c skill.Conflict) error {
+	scopes := make([]string, 0, len(c.Skills))
+	for _, sk := range c.Skills {
+		scopes = append(scopes, sk.Scope.String())
+	}
+	return fmt.Errorf("defined in %s scopes (resolution policy is %s)", strings.Join(scopes, ", "), skill.ResolveErrorOnConflict)
+}
+
+func missingCommandsWarning(commands []string) string {
+	return "required commands not found: " + strings.Join(commands, ", ")
+}
+
 func filterSkillsByScope(skills []*skill.Skill, scope skill.Scope) []*skill.Skill {
 	filtered := make([]*skill.Skill, 0, len(skills))
 	for _, s := range skills {
@@ -119,3 +856,12 @@ func filterSkillsByScope(skills []*skill.Skill, scope skill.Scope) []*skill.Skil
 	}
 	return filtered
 }
+
+// targetNames returns the names of targets.
+func targetNames(targets []*Target) []string {
+	names := make([]string, 0, len(targets))
+	for _, t := range targets {
+		names = append(names, t.Name())
+	}
+	return names
+}
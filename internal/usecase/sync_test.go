@@ -1,7 +1,12 @@
 package usecase_test
 
 import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/wwwyo/skillet/internal/config"
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
@@ -48,7 +53,7 @@ func TestSyncInstall(t *testing.T) {
 	cfg := config.DefaultConfig()
 	svc := usecase.NewSyncService(mock, cfg, "")
 
-	results, err := svc.Sync(usecase.SyncOptions{})
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{})
 	if err != nil {
 		t.Fatalf("Sync() error = %v", err)
 	}
@@ -69,7 +74,7 @@ func TestSyncDryRun(t *testing.T) {
 	mock, svc := setupSyncEnv()
 	addGlobalSkill(mock, "dry-run-skill")
 
-	results, err := svc.Sync(usecase.SyncOptions{DryRun: true})
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{DryRun: true})
 	if err != nil {
 		t.Fatalf("Sync() error = %v", err)
 	}
@@ -111,7 +116,7 @@ func TestSyncScopeFilter(t *testing.T) {
 	svc := usecase.NewSyncService(mock, cfg, "/project")
 
 	scope := skill.ScopeGlobal
-	results, err := svc.Sync(usecase.SyncOptions{Scope: &scope})
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{Scope: &scope})
 	if err != nil {
 		t.Fatalf("Sync() error = %v", err)
 	}
@@ -122,3 +127,1228 @@ func TestSyncScopeFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestSyncWritesTargetManifest(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+
+	mock.Dirs["/home/test/.agents"] = true
+	mock.Dirs["/home/test/.agents/skills"] = true
+	mock.Dirs["/home/test/.codex"] = true
+	mock.Files["/home/test/.codex/config.toml"] = []byte("model = \"o3\"\n")
+	addGlobalSkill(mock, "beta")
+	addGlobalSkill(mock, "alpha")
+
+	cfg := config.DefaultConfig()
+	cfg.Targets["claude"] = config.TargetConfig{Enabled: false}
+	codex := cfg.Targets["codex"]
+	codex.Manifest = "config.toml"
+	cfg.Targets["codex"] = codex
+
+	svc := usecase.NewSyncService(mock, cfg, "")
+	if _, err := svc.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	want := "model = \"o3\"\n\n" +
+		"# BEGIN skillet managed skills\n" +
+		"skillet.skills = [\"alpha\", \"beta\"]\n" +
+		"# END skillet managed skills\n"
+	if got := string(mock.Files["/home/test/.codex/config.toml"]); got != want {
+		t.Fatalf("manifest = %q, want %q", got, want)
+	}
+
+	// A second sync must leave the manifest untouched.
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	for _, r := range results {
+		if r.Action == usecase.SyncActionManifest {
+			t.Fatalf("second Sync() rewrote manifest %s", r.SkillName)
+		}
+	}
+	if got := string(mock.Files["/home/test/.codex/config.toml"]); got != want {
+		t.Fatalf("manifest after resync = %q, want %q", got, want)
+	}
+}
+
+func TestSyncManifestFormats(t *testing.T) {
+	block := "# BEGIN skillet managed skills\n" +
+		"skillet.skills = [\"alpha\"]\n" +
+		"# END skillet managed skills\n"
+	tests := []struct {
+		name     string
+		manifest string
+		content  string
+		want     string
+	}{
+		{
+			name:     "toml block above the first table",
+			manifest: "config.toml",
+			content:  "model = \"o3\"\n\n[profiles.fast]\nmodel = \"o4\"\n",
+			want:     "model = \"o3\"\n\n" + block + "\n[profiles.fast]\nmodel = \"o4\"\n",
+		},
+		{
+			name:     "toml keys after the block stay in the root table",
+			manifest: "config.toml",
+			content:  "# BEGIN skillet managed skills\n[skillet]\nskills = []\n# END skillet managed skills\napproval = \"never\"\n",
+			want:     block + "approval = \"never\"\n",
+		},
+		{
+			name:     "toml block moved out of a table",
+			manifest: "config.toml",
+			content:  "[mcp]\nx = 1\n" + block + "y = 2\n",
+			want:     block + "\n[mcp]\nx = 1\ny = 2\n",
+		},
+		{
+			name:     "json keeps the other keys in order",
+			manifest: "settings.json",
+			content:  "{\"theme\": \"dark\", \"skillet\": {\"skills\": []}, \"editor\": {\"tabs\": [2, 4]}}",
+			want:     "{\n  \"theme\": \"dark\",\n  \"skillet\": {\n    \"skills\": [\n      \"alpha\"\n    ]\n  },\n  \"editor\": {\n    \"tabs\": [\n      2,\n      4\n    ]\n  }\n}\n",
+		},
+		{
+			name:     "new json file",
+			manifest: "skills.json",
+			want:     "{\n  \"skillet\": {\n    \"skills\": [\n      \"alpha\"\n    ]\n  }\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, _ := setupSyncEnv()
+			addGlobalSkill(mock, "alpha")
+			path := "/home/test/.codex/" + tt.manifest
+			if tt.content != "" {
+				mock.Files[path] = []byte(tt.content)
+			}
+			cfg := config.DefaultConfig()
+			cfg.Targets["claude"] = config.TargetConfig{Enabled: false}
+			codex := cfg.Targets["codex"]
+			codex.Manifest = tt.manifest
+			cfg.Targets["codex"] = codex
+			svc := usecase.NewSyncService(mock, cfg, "")
+
+			for range 2 {
+				if _, err := svc.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+					t.Fatalf("Sync() error = %v", err)
+				}
+				if got := string(mock.Files[path]); got != tt.want {
+					t.Fatalf("manifest = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncResultsOrdered(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	for _, name := range []string{"charlie", "alpha", "bravo"} {
+		addGlobalSkill(mock, name)
+	}
+
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Target+"/"+r.SkillName)
+	}
+	want := []string{
+		"claude/alpha", "claude/bravo", "claude/charlie",
+		"codex/alpha", "codex/bravo", "codex/charlie",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("Sync() order = %v, want %v", got, want)
+	}
+}
+
+func TestSyncSingleTarget(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{DryRun: true, Target: "codex"})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Target != "codex" {
+		t.Fatalf("expected a single codex result, got %+v", results)
+	}
+
+	var unknown *usecase.ErrUnknownTarget
+	if _, err := svc.Sync(t.Context(), usecase.SyncOptions{Target: "clade"}); !errors.As(err, &unknown) {
+		t.Fatalf("expected ErrUnknownTarget, got %v", err)
+	}
+}
+
+func TestSyncSkipMissingCommands(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	skillDir := "/home/test/.agents/skills/needs-tool"
+	mock.Dirs[skillDir] = true
+	mock.Files[skillDir+"/SKILL.md"] = []byte("---\nname: needs-tool\nrequiresCommands: [skillet-missing-command-for-test]\n---\n")
+
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{SkipMissingCommands: true})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	for _, r := range results {
+		if r.Action != usecase.SyncActionSkip || len(r.Warnings) == 0 {
+			t.Fatalf("Sync() result for %s/%s = %s with warnings %v, want skip with warning", r.Target, r.SkillName, r.Action, r.Warnings)
+		}
+	}
+	if mock.Exists("/home/test/.claude/skills/needs-tool") {
+		t.Fatal("skill with missing commands should not be installed")
+	}
+}
+
+func TestSyncStrategyByScope(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	mock.Dirs["/project/.agents"] = true
+	mock.Dirs["/project/.agents/skills"] = true
+	addGlobalSkill(mock, "global-skill")
+	mock.Dirs["/project/.agents/skills/project-skill"] = true
+	mock.Files["/project/.agents/skills/project-skill/SKILL.md"] = []byte("---\nname: project-skill\n---\n")
+	// A symlink left over from an earlier symlink-strategy sync.
+	mock.Dirs["/project/.claude/skills"] = true
+	mock.Symlinks["/project/.claude/skills/project-skill"] = "/project/.agents/skills/project-skill"
+
+	cfg := config.DefaultConfig()
+	cfg.Targets["codex"] = config.TargetConfig{Enabled: false}
+	cfg.StrategyByScope = map[string]config.Strategy{"project": config.StrategyCopy}
+
+	status, err := usecase.NewStatusService(mock, cfg, "/project").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	if len(status[0].Mismatched) != 1 || status[0].InSync {
+		t.Fatalf("status = %+v, want one strategy mismatch", status[0])
+	}
+
+	results, err := usecase.NewSyncService(mock, cfg, "/project").Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	for _, r := range results {
+		if r.SkillName == "project-skill" && r.Action != usecase.SyncActionUpdate {
+			t.Fatalf("project-skill action = %s, want update", r.Action)
+		}
+	}
+
+	if !mock.IsSymlink("/home/test/.claude/skills/global-skill") {
+		t.Error("global skill should be symlinked")
+	}
+	if mock.IsSymlink("/project/.claude/skills/project-skill") || !mock.IsDir("/project/.claude/skills/project-skill") {
+		t.Error("project skill should be copied")
+	}
+}
+
+func TestSyncStripFrontmatterKeys(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	storeFile := "/home/test/.agents/skills/tagged/SKILL.md"
+	content := "---\nname: tagged\ndescription: Tagged skill\ntags: [infra]\ntargets: [claude, codex]\n---\n\nBody\n"
+	mock.Dirs["/home/test/.agents/skills/tagged"] = true
+	mock.Files[storeFile] = []byte(content)
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	claude := cfg.Targets["claude"]
+	claude.StripFrontmatterKeys = []string{"tags", "targets"}
+	cfg.Targets["claude"] = claude
+
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	want := "---\nname: tagged\ndescription: Tagged skill\n---\n\nBody\n"
+	if got := string(mock.Files["/home/test/.claude/skills/tagged/SKILL.md"]); got != want {
+		t.Errorf("claude SKILL.md = %q, want %q", got, want)
+	}
+	if got := string(mock.Files["/home/test/.codex/skills/tagged/SKILL.md"]); got != content {
+		t.Errorf("codex SKILL.md = %q, want store content", got)
+	}
+	if got := string(mock.Files[storeFile]); got != content {
+		t.Errorf("store SKILL.md changed to %q", got)
+	}
+
+	statuses, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	for _, s := range statuses {
+		if !s.InSync {
+			t.Errorf("%s status = %+v, want in sync", s.Target, s)
+		}
+	}
+}
+
+func TestSyncReportsConflicts(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "shared")
+	addGlobalSkill(mock, "solo")
+	mock.Dirs["/project/.agents/skills"] = true
+	mock.Dirs["/project/.agents/skills/shared"] = true
+	mock.Files["/project/.agents/skills/shared/SKILL.md"] = []byte("---\nname: shared\n---\n")
+
+	cfg := config.DefaultConfig()
+	cfg.Resolution = "error-on-conflict"
+	results, err := usecase.NewSyncService(mock, cfg, "/project").Sync(t.Context(), usecase.SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	var got []string
+	for _, r := range results {
+		if r.Target == "claude" {
+			got = append(got, r.SkillName+":"+string(r.Action))
+		}
+	}
+	want := []string{"shared:error", "solo:install"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("claude results = %v, want %v", got, want)
+	}
+}
+
+func TestSyncCursorMatchesInstalledName(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "review")
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	cfg.Targets["cursor"] = config.TargetConfig{Enabled: true, Prefix: "team-"}
+
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if got := string(mock.Files["/home/test/.cursor/skills/team-review/SKILL.md"]); got != "---\nname: team-review\n---\n" {
+		t.Errorf("cursor SKILL.md = %q, want the installed name", got)
+	}
+	if got := string(mock.Files["/home/test/.claude/skills/review/SKILL.md"]); got != "---\nname: review\n---\n" {
+		t.Errorf("claude SKILL.md = %q, want store content", got)
+	}
+
+	statuses, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	for _, s := range statuses {
+		if !s.InSync {
+			t.Errorf("%s status = %+v, want in sync", s.Target, s)
+		}
+	}
+}
+
+func TestSyncTransformLayouts(t *testing.T) {
+	skillMD := "---\nname: guide\ndescription: A guide\n---\n\nStart here.\n"
+	tests := []struct {
+		name      string
+		transform config.TransformConfig
+		want      map[string]string
+	}{
+		{
+			name:      "flatten",
+			transform: config.TransformConfig{Type: config.TransformFlatten, File: "{name}.md"},
+			want: map[string]string{
+				"guide.md": "---\nname: guide\ndescription: A guide\n---\n\nStart here.\n\n## references/api.md\n\n# API notes\n",
+			},
+		},
+		{
+			name:      "rename",
+			transform: config.TransformConfig{Type: config.TransformRename, File: "AGENTS.md"},
+			want: map[string]string{
+				"AGENTS.md":         skillMD,
+				"references/api.md": "# API notes\n",
+				"scripts/run.sh":    "#!/bin/sh\n",
+			},
+		},
+		{
+			name:      "template",
+			transform: config.TransformConfig{Type: config.TransformTemplate, File: "skill.json", Template: "~/guide.tmpl"},
+			want: map[string]string{
+				"skill.json":        `{"name":"guide","description":"A guide","files":["references/api.md","scripts/run.sh"]}`,
+				"references/api.md": "# API notes\n",
+				"scripts/run.sh":    "#!/bin/sh\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, _ := setupSyncEnv()
+			dir := "/home/test/.agents/skills/guide"
+			mock.Dirs[dir] = true
+			mock.Dirs[dir+"/references"] = true
+			mock.Dirs[dir+"/scripts"] = true
+			mock.Files[dir+"/SKILL.md"] = []byte(skillMD)
+			mock.Files[dir+"/references/api.md"] = []byte("# API notes\n")
+			mock.Files[dir+"/scripts/run.sh"] = []byte("#!/bin/sh\n")
+			mock.Files["/home/test/guide.tmpl"] = []byte(`{"name":"{{.InstalledName}}","description":"{{.Description}}","files":[{{range $i, $f := .Files}}{{if $i}},{{end}}"{{$f}}"{{end}}]}`)
+
+			cfg := config.DefaultConfig()
+			claude := cfg.Targets["claude"]
+			claude.Transform = tt.transform
+			cfg.Targets["claude"] = claude
+
+			if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			installed := "/home/test/.claude/skills/guide"
+			if mock.IsSymlink(installed) {
+				t.Fatal("transformed target should receive a copy")
+			}
+			var got []string
+			for path := range mock.Files {
+				if rel, ok := strings.CutPrefix(path, installed+"/"); ok && rel != ".skillet-synced.yaml" {
+					got = append(got, rel)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("installed files = %v, want %d files", got, len(tt.want))
+			}
+			for rel, want := range tt.want {
+				if content := string(mock.Files[installed+"/"+rel]); content != want {
+					t.Errorf("%s = %q, want %q", rel, content, want)
+				}
+			}
+			if !mock.IsSymlink("/home/test/.codex/skills/guide") {
+				t.Error("codex should keep the symlink strategy")
+			}
+
+			statuses, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+			if err != nil {
+				t.Fatalf("GetStatus() error = %v", err)
+			}
+			for _, s := range statuses {
+				if !s.InSync {
+					t.Errorf("%s status = %+v, want in sync", s.Target, s)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncBanner(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	content := "---\nname: guide\n---\n\n# Guide\n"
+	mock.Dirs["/home/test/.agents/skills/guide"] = true
+	mock.Files["/home/test/.agents/skills/guide/SKILL.md"] = []byte(content)
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	claude := cfg.Targets["claude"]
+	claude.Banner = true
+	cfg.Targets["claude"] = claude
+
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	want := "---\nname: guide\n---\n<!-- managed by skillet - edit ~/.agents/skills/guide instead; changes here are overwritten by sync -->\n\n# Guide\n"
+	if got := string(mock.Files["/home/test/.claude/skills/guide/SKILL.md"]); got != want {
+		t.Errorf("claude SKILL.md = %q, want %q", got, want)
+	}
+	if got := string(mock.Files["/home/test/.codex/skills/guide/SKILL.md"]); got != content {
+		t.Errorf("codex SKILL.md = %q, want store content", got)
+	}
+
+	statuses, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	for _, s := range statuses {
+		if !s.InSync {
+			t.Errorf("%s status = %+v, want in sync", s.Target, s)
+		}
+	}
+
+	// Pulling the copy back into an empty store drops the banner.
+	delete(mock.Dirs, "/home/test/.agents/skills/guide")
+	delete(mock.Files, "/home/test/.agents/skills/guide/SKILL.md")
+	migrateSvc := usecase.NewMigrateService(mock, cfg, "", usecase.NewSyncService(mock, cfg, ""))
+	opts := usecase.MigrateOptions{Scope: skill.ScopeGlobal}
+	found := map[string][]string{"claude": {"guide"}}
+	if _, err := migrateSvc.Migrate(t.Context(), opts, found); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if got := string(mock.Files["/home/test/.agents/skills/guide/SKILL.md"]); got != content {
+		t.Errorf("migrated SKILL.md = %q, want the content without banner", got)
+	}
+}
+
+func TestSyncSkillsAt(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	addGlobalSkill(mock, "review")
+	addGlobalSkill(mock, "review-extra")
+	addGlobalSkill(mock, "deploy")
+
+	names, err := svc.SkillsAt([]string{
+		"/home/test/.agents/skills/review/SKILL.md",
+		"/home/test/.agents/skills/deploy",
+		"/home/test/.agents/skills/deleted/SKILL.md",
+		"/home/test/.agents/skills/review/references/notes.md",
+	})
+	if err != nil {
+		t.Fatalf("SkillsAt() error = %v", err)
+	}
+	if want := []string{"deploy", "review"}; !slices.Equal(names, want) {
+		t.Errorf("SkillsAt() = %v, want %v", names, want)
+	}
+}
+
+func TestSyncRequires(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	addGlobalSkill(mock, "base")
+	mock.Dirs["/home/test/.agents/skills/app"] = true
+	mock.Files["/home/test/.agents/skills/app/SKILL.md"] = []byte("---\nname: app\nrequires: [helper, absent]\n---\n")
+	mock.Dirs["/home/test/.agents/skills/optional/helper"] = true
+	mock.Files["/home/test/.agents/skills/optional/helper/SKILL.md"] = []byte("---\nname: helper\nrequires: [base]\n---\n")
+	mock.Dirs["/home/test/.agents/skills/ping"] = true
+	mock.Files["/home/test/.agents/skills/ping/SKILL.md"] = []byte("---\nname: ping\nrequires: [pong]\n---\n")
+	mock.Dirs["/home/test/.agents/skills/pong"] = true
+	mock.Files["/home/test/.agents/skills/pong/SKILL.md"] = []byte("---\nname: pong\nrequires: [ping]\n---\n")
+
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{Names: []string{"app", "ping"}, Target: "claude"})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	got := make(map[string]usecase.SyncResult, len(results))
+	for _, r := range results {
+		got[r.SkillName] = r
+	}
+	for _, name := range []string{"app", "helper", "base"} {
+		if got[name].Action != usecase.SyncActionInstall {
+			t.Errorf("%s action = %s, want install", name, got[name].Action)
+		}
+	}
+	if !mock.Exists("/home/test/.claude/skills/helper") {
+		t.Error("optional dependency should be installed with the skill requiring it")
+	}
+	if w := got["app"].Warnings; len(w) != 1 || !strings.Contains(w[0], "absent") {
+		t.Errorf("app warnings = %v, want missing dependency warning", w)
+	}
+	for _, name := range []string{"ping", "pong"} {
+		if r := got[name]; r.Action != usecase.SyncActionError || !strings.Contains(r.Error.Error(), "dependency cycle") {
+			t.Errorf("%s result = %+v, want dependency cycle error", name, r)
+		}
+	}
+}
+
+func TestSyncSupportedTargets(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	addGlobalSkill(mock, "hooks")
+	mock.Dirs["/home/test/.agents/skills/app"] = true
+	mock.Files["/home/test/.agents/skills/app/SKILL.md"] = []byte("---\nname: app\nrequires: [hooks]\n---\n")
+	if _, err := svc.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	// hooks now declares that it only works with claude, so it leaves codex
+	// even though app, which codex keeps, requires it.
+	mock.Files["/home/test/.agents/skills/hooks/SKILL.md"] = []byte("---\nname: hooks\ntargets: [claude]\n---\n")
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	for _, r := range results {
+		if r.SkillName == "hooks" && r.Target == "codex" && r.Action != usecase.SyncActionUninstall {
+			t.Errorf("codex hooks action = %s, want uninstall", r.Action)
+		}
+	}
+	if !mock.Exists("/home/test/.claude/skills/hooks") || mock.Exists("/home/test/.codex/skills/hooks") {
+		t.Error("hooks should be installed only in claude")
+	}
+	if !mock.Exists("/home/test/.codex/skills/app") {
+		t.Error("app should stay installed in codex")
+	}
+}
+
+func TestSyncSkillTargetsConfig(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "only-claude")
+	addGlobalSkill(mock, "no-codex")
+	addGlobalSkill(mock, "everywhere")
+
+	cfg := config.DefaultConfig()
+	cfg.Skills = map[string]config.SkillConfig{
+		"only-claude": {Targets: []string{"claude"}},
+		"no-codex":    {ExcludeTargets: []string{"codex"}},
+	}
+	results, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Target+":"+r.SkillName+":"+string(r.Action))
+	}
+	want := "claude:everywhere:install,claude:no-codex:install,claude:only-claude:install,codex:everywhere:install,codex:no-codex:excluded,codex:only-claude:excluded"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Sync() = %s, want %s", strings.Join(got, ","), want)
+	}
+
+	statuses, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	for _, s := range statuses {
+		if s.Target == "codex" && (!s.InSync || len(s.Missing) != 0 || !slices.Equal(s.Excluded, []string{"no-codex", "only-claude"})) {
+			t.Errorf("codex status = %+v, want in sync with two excluded skills", s)
+		}
+	}
+}
+
+func TestSyncAliases(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "review")
+
+	cfg := config.DefaultConfig()
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	codex := cfg.Targets["codex"]
+	codex.Aliases = map[string]string{"review": "code-review"}
+	cfg.Targets["codex"] = codex
+	results, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Target+":"+r.SkillName+":"+string(r.Action))
+	}
+	if want := "claude:review:skip,codex:review:uninstall,codex:review:install"; strings.Join(got, ",") != want {
+		t.Errorf("Sync() = %s, want %s", strings.Join(got, ","), want)
+	}
+	if _, ok := mock.Symlinks["/home/test/.codex/skills/code-review"]; !ok {
+		t.Error("codex should have review installed as code-review")
+	}
+	if mock.Exists("/home/test/.codex/skills/review") || !mock.Exists("/home/test/.claude/skills/review") {
+		t.Error("only the codex install under the old name should be removed")
+	}
+
+	statuses, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	for _, s := range statuses {
+		if !s.InSync || !slices.Equal(s.Installed, []string{"review"}) {
+			t.Errorf("%s status = %+v, want review installed and in sync", s.Target, s)
+		}
+	}
+}
+
+func TestSyncUpdatesChangedCopies(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "review")
+	addGlobalSkill(mock, "handmade")
+	// A copy that was not made by skillet is left alone.
+	mock.Dirs["/home/test/.claude/skills/handmade"] = true
+	mock.Files["/home/test/.claude/skills/handmade/SKILL.md"] = []byte("---\nname: handmade\n---\nLocal notes\n")
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	svc := usecase.NewSyncService(mock, cfg, "")
+	actions := func() map[string]usecase.SyncAction {
+		t.Helper()
+		results, err := svc.Sync(t.Context(), usecase.SyncOptions{Target: "claude"})
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		got := make(map[string]usecase.SyncAction, len(results))
+		for _, r := range results {
+			got[r.SkillName] = r.Action
+		}
+		return got
+	}
+
+	actions()
+	if got := actions(); got["review"] != usecase.SyncActionSkip {
+		t.Errorf("unchanged copy action = %s, want skip", got["review"])
+	}
+
+	updated := "---\nname: review\n---\nNew steps\n"
+	mock.Files["/home/test/.agents/skills/review/SKILL.md"] = []byte(updated)
+	got := actions()
+	if got["review"] != usecase.SyncActionUpdate {
+		t.Errorf("changed copy action = %s, want update", got["review"])
+	}
+	if got["handmade"] != usecase.SyncActionSkip {
+		t.Errorf("unmanaged copy action = %s, want skip", got["handmade"])
+	}
+	if content := string(mock.Files["/home/test/.claude/skills/review/SKILL.md"]); content != updated {
+		t.Errorf("copy SKILL.md = %q, want %q", content, updated)
+	}
+	if content := string(mock.Files["/home/test/.claude/skills/handmade/SKILL.md"]); !strings.Contains(content, "Local notes") {
+		t.Errorf("unmanaged copy was overwritten: %q", content)
+	}
+}
+
+func TestSyncUpdatesOlderVersions(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	store := "/home/test/.agents/skills/"
+	installed := "/home/test/.claude/skills/"
+	for name, versions := range map[string][2]string{
+		"review": {"1.2.0", "1.1.0"},
+		"lint":   {"1.0.0", "2.0.0"},
+	} {
+		mock.Dirs[store+name] = true
+		mock.Files[store+name+"/SKILL.md"] = []byte("---\nname: " + name + "\nversion: " + versions[0] + "\n---\nStore\n")
+		// Copies not made by skillet.
+		mock.Dirs[installed+name] = true
+		mock.Files[installed+name+"/SKILL.md"] = []byte("---\nname: " + name + "\nversion: " + versions[1] + "\n---\nCopy\n")
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	results, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{Target: "claude"})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	got := make(map[string]usecase.SyncResult, len(results))
+	for _, r := range results {
+		got[r.SkillName] = r
+	}
+
+	if r := got["review"]; r.Action != usecase.SyncActionUpdate || r.FromVersion != "1.1.0" || r.ToVersion != "1.2.0" {
+		t.Errorf("older copy result = %+v, want update from 1.1.0 to 1.2.0", r)
+	}
+	if content := string(mock.Files[installed+"review/SKILL.md"]); !strings.Contains(content, "Store") {
+		t.Errorf("older copy was not updated: %q", content)
+	}
+	if r := got["lint"]; r.Action != usecase.SyncActionSkip || len(r.Warnings) != 1 {
+		t.Errorf("newer copy result = %+v, want skip with a warning", r)
+	}
+	if content := string(mock.Files[installed+"lint/SKILL.md"]); !strings.Contains(content, "Copy") {
+		t.Errorf("newer copy was downgraded: %q", content)
+	}
+}
+
+func TestSyncStateCache(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "review")
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	svc := usecase.NewSyncService(mock, cfg, "")
+	action := func(opts usecase.SyncOptions) usecase.SyncAction {
+		t.Helper()
+		opts.Target = "claude"
+		results, err := svc.Sync(t.Context(), opts)
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		for _, r := range results {
+			if r.SkillName == "review" {
+				return r.Action
+			}
+		}
+		return ""
+	}
+
+	if got := action(usecase.SyncOptions{}); got != usecase.SyncActionInstall {
+		t.Fatalf("first sync action = %s, want install", got)
+	}
+	if !mock.Exists("/home/test/.cache/skillet/state.json") {
+		t.Fatal("expected the sync state to be cached")
+	}
+
+	// An edit that leaves the copy's size and modification times alone goes
+	// unnoticed until the cache is bypassed.
+	copyFile := "/home/test/.claude/skills/review/SKILL.md"
+	mock.Files[copyFile] = []byte(strings.Replace(string(mock.Files[copyFile]), "review", "REVIEW", 1))
+	if got := action(usecase.SyncOptions{}); got != usecase.SyncActionSkip {
+		t.Errorf("cached sync action = %s, want skip", got)
+	}
+	if got := action(usecase.SyncOptions{NoCache: true}); got != usecase.SyncActionUpdate {
+		t.Errorf("uncached sync action = %s, want update", got)
+	}
+
+	// A store change is noticed through its modification time.
+	storeFile := "/home/test/.agents/skills/review/SKILL.md"
+	mock.Files[storeFile] = []byte("---\nname: review\n---\nNew\n")
+	mock.ModTimes[storeFile] = time.Now()
+	if got := action(usecase.SyncOptions{}); got != usecase.SyncActionUpdate {
+		t.Errorf("sync action after a store change = %s, want update", got)
+	}
+	if got := action(usecase.SyncOptions{}); got != usecase.SyncActionSkip {
+		t.Errorf("sync action after the update = %s, want skip", got)
+	}
+}
+
+type hookCall struct {
+	command string
+	env     []string
+}
+
+type recordingRunner struct {
+	calls []hookCall
+	fail  string
+	// cancel is called after the command stop runs
+	stop   string
+	cancel context.CancelFunc
+}
+
+func (r *recordingRunner) Run(_ context.Context, command, _ string, env []string) error {
+	r.calls = append(r.calls, hookCall{command, env})
+	if command == r.stop {
+		r.cancel()
+	}
+	if command == r.fail {
+		return errors.New("exit status 1")
+	}
+	return nil
+}
+
+func (r *recordingRunner) commands() string {
+	var commands []string
+	for _, c := range r.calls {
+		commands = append(commands, c.command)
+	}
+	return strings.Join(commands, ",")
+}
+
+func TestSyncHooks(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "review")
+	cfg := config.DefaultConfig()
+	cfg.Hooks = config.HooksConfig{
+		PreSync:     []string{"lint"},
+		PostSync:    []string{"restart"},
+		PostInstall: []string{"installed"},
+		PostRemove:  []string{"removed"},
+	}
+	svc := usecase.NewSyncService(mock, cfg, "")
+	runner := &recordingRunner{}
+	svc.SetHookRunner(runner)
+
+	if _, err := svc.Sync(t.Context(), usecase.SyncOptions{Target: "claude", DryRun: true}); err != nil || len(runner.calls) != 0 {
+		t.Fatalf("dry-run Sync() error = %v with hooks %q, want no hooks", err, runner.commands())
+	}
+	if _, err := svc.Sync(t.Context(), usecase.SyncOptions{Target: "claude"}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := runner.commands(); got != "lint,installed,restart" {
+		t.Fatalf("hooks = %q, want lint,installed,restart", got)
+	}
+	for _, want := range []string{"SKILLET_HOOK=postInstall", "SKILLET_ACTION=install", "SKILLET_SKILL=review", "SKILLET_TARGET=claude", "SKILLET_PATH=/home/test/.claude/skills/review"} {
+		if !slices.Contains(runner.calls[1].env, want) {
+			t.Errorf("postInstall env = %v, want %s", runner.calls[1].env, want)
+		}
+	}
+	if !slices.Contains(runner.calls[2].env, "SKILLET_CHANGED=1") {
+		t.Errorf("postSync env = %v, want SKILLET_CHANGED=1", runner.calls[2].env)
+	}
+
+	runner.calls = nil
+	mock.Dirs["/home/test/.claude/skills/old"] = true
+	results := svc.RemoveExtras(t.Context(), []usecase.ExtraSkill{{SkillName: "old", Target: "claude", Scope: skill.ScopeGlobal}})
+	if len(results) != 1 || results[0].Action != usecase.SyncActionUninstall || runner.commands() != "removed" {
+		t.Fatalf("RemoveExtras() = %+v with hooks %q, want an uninstall and removed", results, runner.commands())
+	}
+
+	// A failing preSync hook aborts the sync; other hooks only warn.
+	addGlobalSkill(mock, "lint")
+	runner.fail = "lint"
+	if _, err := svc.Sync(t.Context(), usecase.SyncOptions{Target: "claude"}); err == nil || !strings.Contains(err.Error(), "preSync hook failed") {
+		t.Fatalf("Sync() error = %v, want a preSync failure", err)
+	}
+	if mock.Exists("/home/test/.claude/skills/lint") {
+		t.Error("a failed preSync hook must not let the sync install skills")
+	}
+	runner.fail = "installed"
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{Target: "claude"})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	for _, r := range results {
+		if r.SkillName == "lint" && (r.Action != usecase.SyncActionInstall || len(r.Warnings) != 1) {
+			t.Errorf("lint result = %+v, want an install with a hook warning", r)
+		}
+	}
+}
+
+func TestSyncCanceled(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	addGlobalSkill(mock, "beta")
+	cfg := config.DefaultConfig()
+	cfg.Hooks = config.HooksConfig{
+		PostSync:    []string{"restart"},
+		PostInstall: []string{"installed"},
+	}
+	svc := usecase.NewSyncService(mock, cfg, "")
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	runner := &recordingRunner{stop: "installed", cancel: cancel}
+	svc.SetHookRunner(runner)
+
+	// Canceling after the first install stops the sync before the next skill.
+	results, err := svc.Sync(ctx, usecase.SyncOptions{Target: "claude"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Sync() error = %v, want context.Canceled", err)
+	}
+	if len(results) != 1 || results[0].SkillName != "alpha" || results[0].Action != usecase.SyncActionInstall {
+		t.Errorf("Sync() = %+v, want only the alpha install", results)
+	}
+	if mock.Exists("/home/test/.claude/skills/beta") {
+		t.Error("a canceled sync must not install the remaining skills")
+	}
+	if got := runner.commands(); got != "installed" {
+		t.Errorf("hooks = %q, want installed without postSync", got)
+	}
+}
+
+func TestSyncCollections(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		layout    config.CollectionLayout
+		installed []string
+		collision string
+	}{
+		{"namespace by default", "", []string{"backend-api", "frontend-api"}, ""},
+		{"flatten", config.CollectionsFlatten, []string{"api"}, "frontend/api"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, _ := setupSyncEnv()
+			for _, collection := range []string{"backend", "frontend"} {
+				dir := "/home/test/.agents/skills/" + collection
+				mock.Dirs[dir] = true
+				mock.Dirs[dir+"/api"] = true
+				mock.Files[dir+"/api/SKILL.md"] = []byte("---\nname: api\n---\n")
+			}
+
+			cfg := config.DefaultConfig()
+			cfg.Collections = tt.layout
+			svc := usecase.NewSyncService(mock, cfg, "")
+			results, err := svc.Sync(t.Context(), usecase.SyncOptions{Target: "claude"})
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+			for _, r := range results {
+				switch {
+				case r.SkillName == tt.collision:
+					if r.Action != usecase.SyncActionError {
+						t.Errorf("colliding skill result = %+v, want error", r)
+					}
+				case r.Action != usecase.SyncActionInstall:
+					t.Errorf("result = %+v, want install", r)
+				}
+			}
+			for _, name := range tt.installed {
+				if !mock.IsSymlink("/home/test/.claude/skills/" + name) {
+					t.Errorf("expected %s installed in claude", name)
+				}
+			}
+
+			// Installed collection skills are neither missing nor extra.
+			extras, err := svc.Extras(usecase.SyncOptions{Target: "claude"})
+			if err != nil || len(extras) != 0 {
+				t.Errorf("Extras() = %+v, %v, want none", extras, err)
+			}
+		})
+	}
+}
+
+func TestSyncSkillStrategyOverride(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "plain")
+	mock.Dirs["/home/test/.agents/skills/assets"] = true
+	mock.Files["/home/test/.agents/skills/assets/SKILL.md"] = []byte("---\nname: assets\nstrategy: copy\n---\n")
+
+	cfg := config.DefaultConfig()
+	cfg.Targets["codex"] = config.TargetConfig{Enabled: false}
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if mock.IsSymlink("/home/test/.claude/skills/assets") || !mock.Exists("/home/test/.claude/skills/assets/SKILL.md") {
+		t.Error("skill with strategy: copy should be copied")
+	}
+	if !mock.IsSymlink("/home/test/.claude/skills/plain") {
+		t.Error("other skills should keep the configured symlink strategy")
+	}
+
+	status, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	if !status[0].InSync {
+		t.Errorf("status = %+v, want in sync", status[0])
+	}
+	checks, err := usecase.NewVerifyLinksService(mock, cfg, "").VerifyLinks(usecase.VerifyLinksOptions{})
+	if err != nil {
+		t.Fatalf("VerifyLinks() error = %v", err)
+	}
+	for _, c := range checks {
+		if c.State != usecase.LinkOK {
+			t.Errorf("%s: State = %s, want ok", c.SkillName, c.State)
+		}
+	}
+}
+
+func TestSyncHardlinkStrategy(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	// A symlink left over from an earlier symlink-strategy sync.
+	mock.Symlinks["/home/test/.claude/skills/alpha"] = "/home/test/.agents/skills/alpha"
+
+	cfg := config.DefaultConfig()
+	cfg.Targets["codex"] = config.TargetConfig{Enabled: false}
+	cfg.DefaultStrategy = config.StrategyHardlink
+	svc := usecase.NewSyncService(mock, cfg, "")
+
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Action != usecase.SyncActionUpdate {
+		t.Fatalf("results = %+v, want the symlink updated", results)
+	}
+	if mock.IsSymlink("/home/test/.claude/skills/alpha") {
+		t.Error("alpha should no longer be a symlink")
+	}
+	if got := mock.Links["/home/test/.claude/skills/alpha/SKILL.md"]; got != "/home/test/.agents/skills/alpha/SKILL.md" {
+		t.Errorf("SKILL.md links to %q, want the store file", got)
+	}
+
+	status, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	if !status[0].InSync {
+		t.Errorf("status = %+v, want in sync", status[0])
+	}
+	checks, err := usecase.NewVerifyLinksService(mock, cfg, "").VerifyLinks(usecase.VerifyLinksOptions{})
+	if err != nil {
+		t.Fatalf("VerifyLinks() error = %v", err)
+	}
+	if len(checks) != 1 || checks[0].State != usecase.LinkOK {
+		t.Errorf("checks = %+v, want alpha ok", checks)
+	}
+
+	// The store file is replaced, as editors that save through a new file
+	// do, so the link no longer shares its content.
+	mock.Files["/home/test/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\n---\nv2\n")
+	results, err = svc.Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Action != usecase.SyncActionUpdate {
+		t.Fatalf("results = %+v, want alpha relinked", results)
+	}
+	if got := string(mock.Files["/home/test/.claude/skills/alpha/SKILL.md"]); got != "---\nname: alpha\n---\nv2\n" {
+		t.Errorf("SKILL.md = %q, want the new store content", got)
+	}
+}
+
+func TestSyncRelativeSymlinks(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	addGlobalSkill(mock, "beta")
+	// A link written before symlinkMode was set to relative.
+	mock.Symlinks["/home/test/.claude/skills/beta"] = "/home/test/.agents/skills/beta"
+
+	cfg := config.DefaultConfig()
+	cfg.Targets["codex"] = config.TargetConfig{Enabled: false}
+	cfg.SymlinkMode = config.SymlinkRelative
+	results, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	actions := make(map[string]usecase.SyncAction)
+	for _, r := range results {
+		actions[r.SkillName] = r.Action
+	}
+	if actions["alpha"] != usecase.SyncActionInstall || actions["beta"] != usecase.SyncActionSkip {
+		t.Errorf("actions = %v, want alpha installed and the absolute beta link kept", actions)
+	}
+	if got := mock.Symlinks["/home/test/.claude/skills/alpha"]; got != "../../.agents/skills/alpha" {
+		t.Errorf("alpha links to %q, want a path relative to the link", got)
+	}
+
+	status, err := usecase.NewStatusService(mock, cfg, "").GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	if !status[0].InSync || status[0].Stats.Symlinks != 2 {
+		t.Errorf("status = %+v, want both links in sync", status[0])
+	}
+	findings, err := usecase.NewDoctorService(mock, cfg, "").Diagnose(usecase.DoctorOptions{})
+	if err != nil {
+		t.Fatalf("Diagnose() error = %v", err)
+	}
+	for _, f := range findings {
+		if f.Check == usecase.DoctorCheckBrokenSymlink || f.Check == usecase.DoctorCheckForeignSymlink {
+			t.Errorf("doctor finding = %+v, want both links valid", f)
+		}
+	}
+}
+
+func TestSyncReportsExtras(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	addGlobalSkill(mock, "stored")
+	mock.Dirs["/home/test/.claude/skills/handmade"] = true
+	mock.Files["/home/test/.claude/skills/handmade/SKILL.md"] = []byte("---\nname: handmade\n---\n")
+	mock.Dirs["/home/test/.codex/skills/.system"] = true
+
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	var extras []string
+	for _, r := range results {
+		if r.Action == usecase.SyncActionExtra {
+			extras = append(extras, r.Target+"/"+r.SkillName)
+		}
+	}
+	if !slices.Equal(extras, []string{"claude/handmade"}) {
+		t.Errorf("extra results = %v, want [claude/handmade]", extras)
+	}
+	if !mock.Exists("/home/test/.claude/skills/handmade/SKILL.md") {
+		t.Fatal("sync should not remove extra skills")
+	}
+
+	found, err := svc.Extras(usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Extras() error = %v", err)
+	}
+	if len(found) != 1 || found[0].Path != "/home/test/.claude/skills/handmade" {
+		t.Fatalf("Extras() = %+v", found)
+	}
+	removed := svc.RemoveExtras(t.Context(), found)
+	if len(removed) != 1 || removed[0].Action != usecase.SyncActionUninstall {
+		t.Fatalf("RemoveExtras() = %+v", removed)
+	}
+	if mock.Exists("/home/test/.claude/skills/handmade") || !mock.Exists("/home/test/.claude/skills/stored") {
+		t.Error("RemoveExtras() should remove only the extra skill")
+	}
+}
+
+func TestSyncSelection(t *testing.T) {
+	mock, svc := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	addGlobalSkill(mock, "beta")
+	addGlobalSkill(mock, "gamma")
+	mock.Files["/home/test/.agents/skills/beta/SKILL.md"] = []byte("---\nname: beta\nrequires: [gamma]\n---\n")
+	if _, err := svc.Sync(t.Context(), usecase.SyncOptions{Names: []string{"alpha"}, Target: "codex"}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	choices, err := svc.Choices(usecase.SyncOptions{})
+	if err != nil {
+		t.Fatalf("Choices() error = %v", err)
+	}
+	if len(choices) != 2 || choices[1].Target != "codex" {
+		t.Fatalf("Choices() = %+v, want claude and codex", choices)
+	}
+	if !slices.Equal(choices[1].Skills, []string{"alpha", "beta", "gamma"}) || !slices.Equal(choices[1].Installed, []string{"alpha"}) {
+		t.Errorf("codex choice = %+v, want all three skills with alpha installed", choices[1])
+	}
+	if len(choices[0].Installed) != 0 {
+		t.Errorf("claude installed = %v, want none", choices[0].Installed)
+	}
+
+	_, err = svc.Sync(t.Context(), usecase.SyncOptions{
+		Names:     []string{"beta"},
+		Selection: map[string][]string{"claude": {"beta"}},
+	})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	for _, name := range []string{"beta", "gamma"} {
+		if !mock.Exists("/home/test/.claude/skills/" + name) {
+			t.Errorf("%s should be installed into claude", name)
+		}
+		if mock.Exists("/home/test/.codex/skills/" + name) {
+			t.Errorf("%s should not be installed into codex, which was not selected", name)
+		}
+	}
+	if mock.Exists("/home/test/.claude/skills/alpha") || !mock.Exists("/home/test/.codex/skills/alpha") {
+		t.Error("alpha should stay installed into codex only")
+	}
+}
+
+// failingCopyFS fails to copy into one directory.
+type failingCopyFS struct {
+	*platformfs.MockFileSystem
+	failDst string
+}
+
+func (f *failingCopyFS) CopyDir(src, dst string) error {
+	if dst == f.failDst {
+		return errors.New("permission denied")
+	}
+	return f.MockFileSystem.CopyDir(src, dst)
+}
+
+func TestSyncAtomicRollsBack(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	addGlobalSkill(mock, "beta")
+	cfg := config.DefaultConfig()
+	cfg.DefaultStrategy = config.StrategyCopy
+	if _, err := usecase.NewSyncService(mock, cfg, "").Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	syncLog := string(mock.Files["/home/test/.claude/skills/.skillet-synced.yaml"])
+
+	// alpha changes in the store and gamma is new, but gamma cannot be copied
+	// into codex, which syncs after claude.
+	mock.Files["/home/test/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\n---\nv2\n")
+	addGlobalSkill(mock, "gamma")
+	fsys := &failingCopyFS{MockFileSystem: mock, failDst: "/home/test/.codex/skills/gamma"}
+	svc := usecase.NewSyncService(fsys, cfg, "")
+
+	results, err := svc.Sync(t.Context(), usecase.SyncOptions{Atomic: true})
+	if !errors.Is(err, usecase.ErrSyncRolledBack) {
+		t.Fatalf("Sync() error = %v, want ErrSyncRolledBack", err)
+	}
+	if !strings.Contains(err.Error(), "gamma in codex") {
+		t.Errorf("Sync() error = %v, want the failed skill", err)
+	}
+	var rolledBack []string
+	for _, r := range results {
+		if r.RolledBack {
+			rolledBack = append(rolledBack, r.Target+"/"+r.SkillName)
+		}
+	}
+	if !slices.Equal(rolledBack, []string{"claude/alpha", "claude/gamma", "codex/alpha"}) {
+		t.Errorf("rolled back results = %v, want claude/alpha, claude/gamma, codex/alpha", rolledBack)
+	}
+	for _, target := range []string{"claude", "codex"} {
+		if got := string(mock.Files["/home/test/."+target+"/skills/alpha/SKILL.md"]); got != "---\nname: alpha\n---\n" {
+			t.Errorf("%s alpha = %q, want the copy from before the sync", target, got)
+		}
+		if mock.Exists("/home/test/." + target + "/skills/gamma") {
+			t.Errorf("%s gamma should be removed by the rollback", target)
+		}
+		entries, _ := mock.ReadDir("/home/test/." + target)
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), ".skillet-rollback-") {
+				t.Errorf("rollback directory %s left in %s", entry.Name(), target)
+			}
+		}
+	}
+	if got := string(mock.Files["/home/test/.claude/skills/.skillet-synced.yaml"]); got != syncLog {
+		t.Errorf("claude sync log = %q, want %q", got, syncLog)
+	}
+
+	// Without the failure, the sync goes through and cleans up.
+	fsys.failDst = ""
+	if _, err := svc.Sync(t.Context(), usecase.SyncOptions{Atomic: true}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := string(mock.Files["/home/test/.codex/skills/alpha/SKILL.md"]); got != "---\nname: alpha\n---\nv2\n" || !mock.Exists("/home/test/.codex/skills/gamma") {
+		t.Errorf("codex alpha = %q, want the synced copy with gamma installed", got)
+	}
+	entries, _ := mock.ReadDir("/home/test/.codex")
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".skillet-rollback-") {
+			t.Errorf("rollback directory %s left after a successful sync", entry.Name())
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// syncLogFileName records when skills were last copied into a target skills directory.
+const syncLogFileName = ".skillet-synced.yaml"
+
+// runIDLayout formats the ID of a sync run from the time it started, matching
+// the timestamp run reports are named with.
+const runIDLayout = "20060102T150405Z"
+
+// newRunID returns the ID of a sync run started at start.
+func newRunID(start time.Time) string {
+	return start.UTC().Format(runIDLayout)
+}
+
+// syncLog is the content of a target's sync log, keyed by entry name (see entryName).
+type syncLog struct {
+	Skills map[string]time.Time `yaml:"skills"`
+	// Runs holds the ID of the sync run that last copied each entry.
+	Runs map[string]string `yaml:"runs,omitempty"`
+}
+
+// syncLogPath returns the sync log path for a scope of this target.
+func (t *Target) syncLogPath(scope skill.Scope) (string, error) {
+	dir, err := t.GetSkillsPath(scope)
+	if err != nil {
+		return "", err
+	}
+	return t.fs.Join(dir, syncLogFileName), nil
+}
+
+// loadSyncLog reads the sync log for a scope. A missing or unreadable log is empty.
+func (t *Target) loadSyncLog(scope skill.Scope) *syncLog {
+	log := &syncLog{Skills: make(map[string]time.Time), Runs: make(map[string]string)}
+	path, err := t.syncLogPath(scope)
+	if err != nil || !t.fs.Exists(path) {
+		return log
+	}
+	data, err := t.fs.ReadFile(path)
+	if err != nil {
+		return log
+	}
+	if err := yaml.Unmarshal(data, log); err != nil || log.Skills == nil {
+		log.Skills = make(map[string]time.Time)
+	}
+	if log.Runs == nil {
+		log.Runs = make(map[string]string)
+	}
+	return log
+}
+
+// recordSynced stores the time a skill was copied into this target, and the
+// sync run that copied it. Installs made outside a sync run get an ID of
+// their own, from at.
+func (t *Target) recordSynced(skillName string, scope skill.Scope, at time.Time) error {
+	path, err := t.syncLogPath(scope)
+	if err != nil {
+		return err
+	}
+	run := t.run
+	if run == "" {
+		run = newRunID(at)
+	}
+	log := t.loadSyncLog(scope)
+	entry := t.entryName(skillName)
+	log.Skills[entry] = at.UTC().Truncate(time.Second)
+	log.Runs[entry] = run
+	return t.saveSyncLog(path, log)
+}
+
+// forgetEntry removes an entry (see entryName) from the sync log of a scope,
+// if it is listed.
+func (t *Target) forgetEntry(entry string, scope skill.Scope) error {
+	path, err := t.syncLogPath(scope)
+	if err != nil {
+		return err
+	}
+	log := t.loadSyncLog(scope)
+	if _, ok := log.Skills[entry]; !ok {
+		return nil
+	}
+	delete(log.Skills, entry)
+	delete(log.Runs, entry)
+	return t.saveSyncLog(path, log)
+}
+
+// saveSyncLog writes log to path.
+func (t *Target) saveSyncLog(path string, log *syncLog) error {
+	data, err := yaml.Marshal(log)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync log: %w", err)
+	}
+	if err := t.journal.keepFile(path); err != nil {
+		return err
+	}
+	if err := t.fs.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write sync log: %w", err)
+	}
+	return nil
+}
+
+// SyncedAt returns when a copied skill was last synced into this target.
+func (t *Target) SyncedAt(skillName string, scope skill.Scope) (time.Time, bool) {
+	at, ok := t.loadSyncLog(scope).Skills[t.entryName(skillName)]
+	return at, ok
+}
+
+// SyncedIn returns the ID of the sync run that last copied a skill into this
+// target, or "" when the sync log does not record it.
+func (t *Target) SyncedIn(skillName string, scope skill.Scope) string {
+	return t.loadSyncLog(scope).Runs[t.entryName(skillName)]
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+)
+
+const (
+	// syncStateFileName is the incremental sync cache in skillet's cache directory.
+	syncStateFileName = "state.json"
+	// syncStateVersion is the format version of the sync state file. A file
+	// of another version is ignored and rewritten.
+	syncStateVersion = 1
+)
+
+// syncState caches what repeated syncs would otherwise recompute by reading
+// every file: the checksum of each store skill directory, and the copies
+// known to match the store. Each entry carries the stamps (see treeStamp) of
+// the directories it was recorded for, and is only used while they match.
+// A nil *syncState caches nothing.
+type syncState struct {
+	Version int `json:"version"`
+	// Stores maps a store skill directory to its checksum.
+	Stores map[string]storeState `json:"stores"`
+	// Copies maps an installed copy to the store directory it matches.
+	Copies map[string]copyState `json:"copies"`
+
+	fs     platformfs.FileSystem
+	path   string
+	stamps map[string]string
+	dirty  bool
+}
+
+type storeState struct {
+	Stamp    string `json:"stamp"`
+	Checksum string `json:"checksum"`
+}
+
+type copyState struct {
+	Stamp      string `json:"stamp"`
+	Store      string `json:"store"`
+	StoreStamp string `json:"storeStamp"`
+}
+
+// loadSyncState reads the sync state from the cache directory. With reuse
+// false, the recorded state is ignored but the returned state still records
+// this sync, to refresh the cache. A missing, unreadable, or outdated file
+// gives an empty state.
+func loadSyncState(fsys platformfs.FileSystem, reuse bool) (*syncState, error) {
+	cacheDir, err := config.CachePath(fsys)
+	if err != nil {
+		return nil, err
+	}
+	st := &syncState{fs: fsys, path: fsys.Join(cacheDir, syncStateFileName)}
+	if data, err := fsys.ReadFile(st.path); reuse && err == nil {
+		if json.Unmarshal(data, st) != nil || st.Version != syncStateVersion {
+			st.Stores, st.Copies = nil, nil
+		}
+	}
+	st.Version = syncStateVersion
+	if st.Stores == nil {
+		st.Stores = make(map[string]storeState)
+	}
+	if st.Copies == nil {
+		st.Copies = make(map[string]copyState)
+	}
+	st.stamps = make(map[string]string)
+	slog.Debug("loaded sync state", "path", st.path, "reuse", reuse, "stores", len(st.Stores), "copies", len(st.Copies))
+	return st, nil
+}
+
+// stamp returns the stamp of dir, computed once per sync.
+func (st *syncState) stamp(dir string) string {
+	s, ok := st.stamps[dir]
+	if !ok {
+		s = treeStamp(st.fs, dir)
+		st.stamps[dir] = s
+	}
+	return s
+}
+
+// checksum returns the checksum of the store skill directory dir, reading
+// its files only when they changed since the checksum was recorded.
+func (st *syncState) checksum(fsys platformfs.FileSystem, dir string) (string, error) {
+	if st == nil {
+		return dirChecksum(fsys, dir)
+	}
+	stamp := st.stamp(dir)
+	if entry, ok := st.Stores[dir]; ok && stamp != "" && entry.Stamp == stamp {
+		return entry.Checksum, nil
+	}
+	sum, err := dirChecksum(fsys, dir)
+	if err != nil {
+		return "", err
+	}
+	st.Stores[dir] = storeState{Stamp: stamp, Checksum: sum}
+	st.dirty = true
+	return sum, nil
+}
+
+// copyCurrent reports whether the copy at installed was recorded as
+// matching the store directory storeDir, and neither changed since.
+func (st *syncState) copyCurrent(installed, storeDir string) bool {
+	if st == nil {
+		return false
+	}
+	entry, ok := st.Copies[installed]
+	return ok && entry.Stamp != "" && entry.Store == storeDir &&
+		entry.StoreStamp == st.stamp(storeDir) &&
+		entry.Stamp == treeStamp(st.fs, installed)
+}
+
+// recordCopy records that the copy at installed matches storeDir.
+func (st *syncState) recordCopy(installed, storeDir string) {
+	if st == nil {
+		return
+	}
+	st.Copies[installed] = copyState{
+		Stamp:      treeStamp(st.fs, installed),
+		Store:      storeDir,
+		StoreStamp: st.stamp(storeDir),
+	}
+	st.dirty = true
+}
+
+// save writes the state to the cache directory when it changed, dropping
+// entries for directories that no longer exist.
+func (st *syncState) save() error {
+	if st == nil || !st.dirty {
+		return nil
+	}
+	maps.DeleteFunc(st.Stores, func(dir string, _ storeState) bool { return !st.fs.Exists(dir) })
+	maps.DeleteFunc(st.Copies, func(dir string, _ copyState) bool { return !st.fs.Exists(dir) })
+	data, err := json.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync state: %w", err)
+	}
+	if err := st.fs.MkdirAll(st.fs.Dir(st.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := st.fs.WriteFile(st.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	st.dirty = false
+	return nil
+}
@@ -1,8 +1,15 @@
 package usecase
 
 import (
+	"bytes"
 	"fmt"
+	"log/slog"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/wwwyo/skillet/internal/config"
 	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
@@ -11,38 +18,131 @@ import (
 
 // InstallOptions contains options for installing a skill.
 type InstallOptions struct {
+	// Strategy is the strategy to install with (empty for the skill's own, or symlink)
 	Strategy config.Strategy
 	Force    bool
 }
 
 // TargetDef defines default paths for a target.
 type TargetDef struct {
-	GlobalPath  string
+	// GlobalPaths are candidate user-level roots; the first that exists is used.
+	GlobalPaths []string
 	ProjectPath string
 	SkillsDir   string
+	// MigrateSources are the directories besides SkillsDir that migrate
+	// --all-sources moves into the store.
+	MigrateSources []config.MigrateSource
+	// transform adapts copies to a built-in target's skill format (nil when
+	// the target reads SKILL.md as is).
+	transform contentTransform
 }
 
-// defaultTargets contains default definitions for all supported targets.
+// defaultTargets contains default definitions for built-in targets.
 var defaultTargets = map[string]TargetDef{
-	"claude": {GlobalPath: "~/.claude", ProjectPath: ".claude", SkillsDir: "skills"},
-	"codex":  {GlobalPath: "~/.codex", ProjectPath: ".codex", SkillsDir: "skills"},
+	"claude": {
+		GlobalPaths: []string{"~/.claude"},
+		ProjectPath: ".claude",
+		SkillsDir:   "skills",
+		MigrateSources: []config.MigrateSource{
+			{Dir: commandsDirName, Kind: config.MigrateSourceCommands},
+			{Dir: "agents", Kind: config.MigrateSourceAgents},
+		},
+	},
+	"codex": {GlobalPaths: []string{"~/.codex"}, ProjectPath: ".codex", SkillsDir: "skills"},
+	// Cursor rejects skills whose frontmatter name differs from their directory.
+	"cursor": {
+		GlobalPaths: []string{"~/.cursor", "~/Library/Application Support/Cursor"},
+		ProjectPath: ".cursor",
+		SkillsDir:   "skills",
+		transform:   matchInstalledName,
+	},
+}
+
+// contentTransform rewrites a file of sk as it is copied into t.
+// relPath is relative to the skill directory.
+type contentTransform func(t *Target, sk *skill.Skill, relPath string, data []byte) ([]byte, error)
+
+// matchInstalledName sets the frontmatter name in SKILL.md to the directory
+// the skill is installed under, which differs when the target has a prefix.
+func matchInstalledName(t *Target, sk *skill.Skill, relPath string, data []byte) ([]byte, error) {
+	installed := t.installedName(sk.Name)
+	if relPath != "SKILL.md" || installed == sk.Name {
+		return data, nil
+	}
+	return skill.SetFrontmatterKey(data, "name", installed)
+}
+
+// stripFrontmatterKeys removes keys from the frontmatter of SKILL.md.
+func stripFrontmatterKeys(keys []string) contentTransform {
+	return func(_ *Target, _ *skill.Skill, relPath string, data []byte) ([]byte, error) {
+		if relPath != "SKILL.md" {
+			return data, nil
+		}
+		return skill.StripFrontmatterKeys(data, keys)
+	}
+}
+
+// targetDef returns the definition of a target named in config: the built-in
+// defaults (or ~/.<name>, .<name>, and skills for other names) overridden by
+// any paths set in tc.
+func targetDef(name string, tc config.TargetConfig) TargetDef {
+	def, ok := defaultTargets[name]
+	if !ok {
+		def = TargetDef{GlobalPaths: []string{"~/." + name}, ProjectPath: "." + name, SkillsDir: config.SkillsDirName}
+	}
+	switch {
+	case tc.GlobalPath != "":
+		def.GlobalPaths = []string{tc.GlobalPath}
+	case len(tc.GlobalPaths) > 0:
+		def.GlobalPaths = tc.GlobalPaths
+	}
+	if tc.ProjectPath != "" {
+		def.ProjectPath = tc.ProjectPath
+	}
+	if tc.SkillsDir != "" {
+		def.SkillsDir = tc.SkillsDir
+	}
+	if len(tc.MigrateSources) > 0 {
+		def.MigrateSources = tc.MigrateSources
+	}
+	return def
 }
 
 // Target manages skill deployment to a single target.
 type Target struct {
 	name        string
-	globalPath  string
+	globalPaths []string
 	projectPath string
 	skillsDir   string
-	fs          platformfs.FileSystem
-	projectRoot string
+	// projectSkillsDir replaces skillsDir under the project root when set
+	projectSkillsDir string
+	manifest         string
+	prefix           string
+	collections      config.CollectionLayout
+	transforms       []contentTransform
+	layout           layoutTransform
+	optional         map[string]bool
+	// relativeLinks writes symlinks to the store relative to the link
+	relativeLinks bool
+	// aliases maps skill names to the names they are listed under instead
+	aliases map[string]string
+	// excluded holds the skills the config keeps out of this target
+	excluded       map[string]bool
+	migrateSources []config.MigrateSource
+	fs             platformfs.FileSystem
+	projectRoot    string
+	// journal records the changes of an atomic sync (nil outside one)
+	journal *syncJournal
+	// run is the ID of the sync run installing into the target (empty
+	// outside one)
+	run string
 }
 
 // newTarget creates a new Target.
-func newTarget(name, globalPath, projectPath, skillsDir string, fsys platformfs.FileSystem, projectRoot string) *Target {
+func newTarget(name string, globalPaths []string, projectPath, skillsDir string, fsys platformfs.FileSystem, projectRoot string) *Target {
 	return &Target{
 		name:        name,
-		globalPath:  globalPath,
+		globalPaths: globalPaths,
 		projectPath: projectPath,
 		skillsDir:   skillsDir,
 		fs:          fsys,
@@ -54,37 +154,121 @@ func (t *Target) Name() string {
 	return t.name
 }
 
-// GetSkillsPath returns the skills directory path for the given scope.
-func (t *Target) GetSkillsPath(scope skill.Scope) (string, error) {
+// installedName returns the directory name a skill is installed under in this target.
+func (t *Target) installedName(skillName string) string {
+	return t.prefix + t.entryName(skillName)
+}
+
+// entryName returns the name a skill is listed under in this target: its
+// alias, if the target has one for it, or else its unaliased name (see
+// unaliasedName).
+func (t *Target) entryName(skillName string) string {
+	if alias, ok := t.aliases[skillName]; ok {
+		return alias
+	}
+	return t.unaliasedName(skillName)
+}
+
+// unaliasedName returns the name a skill is listed under without an alias.
+// The target's skills directory is flat, so a skill in a collection is named
+// after it (backend/api as backend-api) or, with the flatten layout, after
+// itself alone (api). Other names are returned unchanged.
+func (t *Target) unaliasedName(skillName string) string {
+	i := strings.LastIndex(skillName, skill.CollectionSeparator)
+	if i < 0 {
+		return skillName
+	}
+	if t.collections == config.CollectionsFlatten {
+		return skillName[i+1:]
+	}
+	return strings.ReplaceAll(skillName, skill.CollectionSeparator, "-")
+}
+
+// entrySet returns the entry names (see entryName) of the skills in names.
+func (t *Target) entrySet(names map[string]bool) map[string]bool {
+	entries := make(map[string]bool, len(names))
+	for name := range names {
+		entries[t.entryName(name)] = true
+	}
+	return entries
+}
+
+// skillName maps an installed directory name back to the name it is listed
+// under (see entryName). Returns false for entries without the target's
+// prefix, which skillet does not manage.
+func (t *Target) skillName(installedName string) (string, bool) {
+	name, ok := strings.CutPrefix(installedName, t.prefix)
+	return name, ok && name != ""
+}
+
+// GetRootPath returns the target's root directory for the given scope.
+// System and org skills are user-level, so they share the target's global directory.
+func (t *Target) GetRootPath(scope skill.Scope) (string, error) {
 	switch scope {
-	case skill.ScopeGlobal:
-		expanded, err := config.ExpandPath(t.fs, t.globalPath)
-		if err != nil {
-			return "", err
-		}
-		return t.fs.Join(expanded, t.skillsDir), nil
+	case skill.ScopeGlobal, skill.ScopeOrg, skill.ScopeSystem:
+		return t.globalRoot()
 	case skill.ScopeProject:
 		if t.projectRoot == "" {
 			return "", fmt.Errorf("project root not set")
 		}
-		return t.fs.Join(t.projectRoot, t.projectPath, t.skillsDir), nil
+		return t.fs.Join(t.projectRoot, t.projectPath), nil
 	default:
 		return "", fmt.Errorf("unknown scope: %v", scope)
 	}
 }
 
-// GetInstalledPath returns the path where a skill is installed (checks all scopes).
-func (t *Target) GetInstalledPath(skillName string) string {
-	if path, err := t.GetSkillsPath(skill.ScopeProject); err == nil {
-		fullPath := t.fs.Join(path, skillName)
-		if t.fs.Exists(fullPath) {
-			return fullPath
+// globalRoot returns the first candidate global root that exists, or the
+// first candidate when none do.
+func (t *Target) globalRoot() (string, error) {
+	var first string
+	for i, candidate := range t.globalPaths {
+		path, err := config.ExpandPath(t.fs, candidate)
+		if err != nil {
+			return "", err
+		}
+		if t.fs.IsDir(path) {
+			return path, nil
+		}
+		if i == 0 {
+			first = path
 		}
 	}
+	return first, nil
+}
 
-	if path, err := t.GetSkillsPath(skill.ScopeGlobal); err == nil {
-		fullPath := t.fs.Join(path, skillName)
-		if t.fs.Exists(fullPath) {
+// GetSkillsPath returns the skills directory path for the given scope.
+func (t *Target) GetSkillsPath(scope skill.Scope) (string, error) {
+	root, err := t.GetRootPath(scope)
+	if err != nil {
+		return "", err
+	}
+	if scope == skill.ScopeProject && t.projectSkillsDir != "" {
+		return t.fs.Join(root, t.projectSkillsDir), nil
+	}
+	return t.fs.Join(root, t.skillsDir), nil
+}
+
+// GetInstallPath returns the path a skill is (or would be) installed at in the given scope.
+func (t *Target) GetInstallPath(skillName string, scope skill.Scope) (string, error) {
+	return t.entryPath(t.entryName(skillName), scope)
+}
+
+// entryPath returns the path of the entry listed as entry (see
+// ListInstalledInScope) in the given scope. Unlike GetInstallPath, it does
+// not look up aliases, so it finds entries left behind under a skill's own
+// name after it was aliased.
+func (t *Target) entryPath(entry string, scope skill.Scope) (string, error) {
+	dir, err := t.GetSkillsPath(scope)
+	if err != nil {
+		return "", err
+	}
+	return t.fs.Join(dir, t.prefix+entry), nil
+}
+
+// GetInstalledPath returns the path where a skill is installed (checks all scopes).
+func (t *Target) GetInstalledPath(skillName string) string {
+	for _, scope := range []skill.Scope{skill.ScopeProject, skill.ScopeGlobal} {
+		if fullPath, err := t.GetInstallPath(skillName, scope); err == nil && t.fs.Exists(fullPath) {
 			return fullPath
 		}
 	}
@@ -99,11 +283,280 @@ func (t *Target) IsInstalled(skillName string) bool {
 
 // IsInstalledInScope checks if a skill is installed in the specified scope.
 func (t *Target) IsInstalledInScope(skillName string, scope skill.Scope) bool {
-	path, err := t.GetSkillsPath(scope)
+	path, err := t.GetInstallPath(skillName, scope)
 	if err != nil {
 		return false
 	}
-	return t.fs.Exists(t.fs.Join(path, skillName))
+	return t.fs.Exists(path)
+}
+
+// transformed reports whether copies installed into this target differ from the store.
+func (t *Target) transformed() bool {
+	return len(t.transforms) > 0 || t.layout != nil
+}
+
+// deployedFiles returns the files of sk as copied into this target: each
+// file rewritten by the content transforms, then laid out.
+func (t *Target) deployedFiles(sk *skill.Skill) (skillFiles, error) {
+	files, err := readSkillFiles(t.fs, sk.Path)
+	if err != nil {
+		return nil, err
+	}
+	for rel, data := range files {
+		for _, transform := range t.transforms {
+			if data, err = transform(t, sk, rel, data); err != nil {
+				return nil, fmt.Errorf("failed to transform %s for target %s: %w", rel, t.name, err)
+			}
+		}
+		files[rel] = data
+	}
+	if t.layout == nil {
+		return files, nil
+	}
+	if files, err = t.layout(t, sk.Name, files); err != nil {
+		return nil, fmt.Errorf("failed to transform skill for target %s: %w", t.name, err)
+	}
+	return files, nil
+}
+
+// copySkill copies a skill to destPath as deployed in this target.
+// Files kept at their store path keep their permissions.
+func (t *Target) copySkill(s *skill.Skill, destPath string) error {
+	if !t.transformed() {
+		return t.fs.CopyDir(s.Path, destPath)
+	}
+	files, err := t.deployedFiles(s)
+	if err != nil {
+		return err
+	}
+	for _, rel := range sortedPaths(files) {
+		dest := t.fs.Join(destPath, rel)
+		if err := t.fs.MkdirAll(t.fs.Dir(dest), 0o755); err != nil {
+			return err
+		}
+		if err := t.fs.WriteFile(dest, files[rel], t.storePerm(s, rel)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// symlinkSkill creates destPath as a symlink to s in the store, relative to
+// destPath when the target writes relative links. A relative link that does
+// not resolve to the skill, as when a directory above destPath is itself a
+// symlink to elsewhere, is replaced with an absolute one.
+func (t *Target) symlinkSkill(s *skill.Skill, destPath string) error {
+	if !t.relativeLinks {
+		return t.fs.Symlink(s.Path, destPath)
+	}
+	rel, err := t.fs.Rel(t.fs.Dir(destPath), s.Path)
+	if err != nil {
+		slog.Debug("could not make link relative; linking the absolute path", "target", t.name, "link", destPath, "error", err)
+		return t.fs.Symlink(s.Path, destPath)
+	}
+	if err := t.fs.Symlink(rel, destPath); err != nil {
+		return err
+	}
+	if t.fs.IsDir(destPath) {
+		return nil
+	}
+	slog.Debug("relative link does not resolve; linking the absolute path", "target", t.name, "link", destPath, "to", rel)
+	if err := t.fs.Remove(destPath); err != nil {
+		return err
+	}
+	return t.fs.Symlink(s.Path, destPath)
+}
+
+// linkSkill installs s at destPath as a directory of hard links to the files
+// in the store. Files this target rewrites are written instead, and so are
+// symlinks in the skill, which may not resolve from destPath. Once a file
+// cannot be linked, for example because the target is on another file system
+// than the store, it and the files after it are copied.
+func (t *Target) linkSkill(s *skill.Skill, destPath string) error {
+	files, err := t.deployedFiles(s)
+	if err != nil {
+		return err
+	}
+	linking := true
+	for _, rel := range sortedPaths(files) {
+		src, dest := t.fs.Join(s.Path, rel), t.fs.Join(destPath, rel)
+		if err := t.fs.MkdirAll(t.fs.Dir(dest), 0o755); err != nil {
+			return err
+		}
+		if linking && t.linkable(src, files[rel]) {
+			err := t.fs.Link(src, dest)
+			if err == nil {
+				continue
+			}
+			slog.Debug("could not create hard link; copying instead", "target", t.name, "link", dest, "error", err)
+			linking = false
+		}
+		if err := t.fs.WriteFile(dest, files[rel], t.storePerm(s, rel)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkable reports whether the store file at src, deployed with data, can be
+// installed as a hard link: it is a regular file that this target does not
+// rewrite.
+func (t *Target) linkable(src string, data []byte) bool {
+	info, err := t.fs.Lstat(src)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if !t.transformed() {
+		return true
+	}
+	stored, err := t.fs.ReadFile(src)
+	return err == nil && bytes.Equal(stored, data)
+}
+
+// storePerm returns the permissions of the file at rel in the store copy of
+// s, for files written in its place.
+func (t *Target) storePerm(s *skill.Skill, rel string) os.FileMode {
+	if info, err := t.fs.Stat(t.fs.Join(s.Path, rel)); err == nil && info.Mode().Perm() != 0 {
+		return info.Mode().Perm()
+	}
+	return 0o644
+}
+
+// InstalledStrategy reports the mechanism a skill is installed with in the
+// given scope. Installs made of hard links are directories like copies, and
+// are reported as copies.
+func (t *Target) InstalledStrategy(skillName string, scope skill.Scope) (config.Strategy, bool) {
+	path, err := t.GetInstallPath(skillName, scope)
+	if err != nil || !t.fs.Exists(path) {
+		return "", false
+	}
+	if t.fs.IsSymlink(path) {
+		return config.StrategySymlink, true
+	}
+	return config.StrategyCopy, true
+}
+
+// linksTo reports whether the install of sk in this target is a symlink to the skill in the store.
+func (t *Target) linksTo(sk *skill.Skill) bool {
+	return t.entryLinksTo(t.entryName(sk.Name), sk)
+}
+
+// entryLinksTo reports whether the entry listed as entry in sk's scope is a
+// symlink to sk in the store.
+func (t *Target) entryLinksTo(entry string, sk *skill.Skill) bool {
+	installed, err := t.entryPath(entry, sk.Scope)
+	if err != nil {
+		return false
+	}
+	link, err := t.fs.Readlink(installed)
+	if err != nil {
+		return false
+	}
+	if !filepath.IsAbs(link) {
+		link = t.fs.Join(t.fs.Dir(installed), link)
+	}
+	return t.fs.Join(link) == t.fs.Join(sk.Path)
+}
+
+// copyOutdated reports whether the copy of sk installed in its scope differs
+// from the store, comparing the checksum of the copy with that of the files
+// it would be installed with. Symlinks always reflect the store.
+// storeSums caches store checksums by skill path (and target, for post-processed copies).
+func (t *Target) copyOutdated(sk *skill.Skill, storeSums map[string]string) bool {
+	installed, err := t.GetInstallPath(sk.Name, sk.Scope)
+	if err != nil || t.fs.IsSymlink(installed) {
+		return false
+	}
+
+	// Targets that post-process copies are compared against the processed store content.
+	key := sk.Path
+	if t.transformed() {
+		key = t.name + "\x00" + sk.Path
+	}
+	storeSum, ok := storeSums[key]
+	if !ok {
+		files, err := t.deployedFiles(sk)
+		if err != nil {
+			return false
+		}
+		storeSum = filesChecksum(files)
+		storeSums[key] = storeSum
+	}
+
+	copySum, err := dirChecksum(t.fs, installed)
+	if err != nil {
+		slog.Debug("could not compare copy with the store", "target", t.name, "copy", installed, "store", sk.Path, "error", err)
+		return false
+	}
+	slog.Debug("compared copy with the store", "target", t.name, "copy", installed, "store", sk.Path, "match", copySum == storeSum)
+	return copySum != storeSum
+}
+
+// installedVersion returns the version declared by the copy of sk installed
+// in its scope, or an empty string when it declares none. Symlinks and
+// targets that rewrite SKILL.md have no version of their own.
+func (t *Target) installedVersion(sk *skill.Skill) string {
+	installed, err := t.GetInstallPath(sk.Name, sk.Scope)
+	if err != nil || t.fs.IsSymlink(installed) {
+		return ""
+	}
+	data, err := t.fs.ReadFile(t.fs.Join(installed, "SKILL.md"))
+	if err != nil {
+		return ""
+	}
+	return skill.VersionOf(data)
+}
+
+// wants reports whether sk belongs in this target: default skills always do,
+// optional skills only when enabled for the target.
+func (t *Target) wants(sk *skill.Skill) bool {
+	return sk.Category != skill.CategoryOptional || t.optional[sk.Name]
+}
+
+// supports reports whether sk may be installed into this target: its
+// frontmatter does not leave the target out, and neither does the config.
+func (t *Target) supports(sk *skill.Skill) bool {
+	return sk.SupportsTarget(t.name) && !t.excluded[sk.Name]
+}
+
+// unaliasedEntry returns the entry skillet installed sk under before this
+// target gave it an alias, when it is still there.
+func (t *Target) unaliasedEntry(sk *skill.Skill) (string, bool) {
+	entry := t.unaliasedName(sk.Name)
+	if entry == t.entryName(sk.Name) {
+		return "", false
+	}
+	if path, err := t.entryPath(entry, sk.Scope); err != nil || (!t.fs.Exists(path) && !t.fs.IsSymlink(path)) {
+		return "", false
+	}
+	_, logged := t.loadSyncLog(sk.Scope).Skills[entry]
+	return entry, logged || t.entryLinksTo(entry, sk)
+}
+
+// manages reports whether the install of sk in its scope was made by skillet:
+// a symlink to the store, or a copy recorded in the sync log.
+func (t *Target) manages(sk *skill.Skill) bool {
+	if t.linksTo(sk) {
+		return true
+	}
+	_, ok := t.SyncedAt(sk.Name, sk.Scope)
+	return ok
+}
+
+// strategyFor returns the strategy skills are installed with when want is
+// configured. A symlink would expose the store layout, so laid-out targets get copies.
+func (t *Target) strategyFor(want config.Strategy) config.Strategy {
+	if t.layout != nil {
+		return config.StrategyCopy
+	}
+	return want
+}
+
+// strategyMismatch reports whether an install made with got does not satisfy want.
+// The symlink and hardlink strategies fall back to copying, so only a symlink
+// where a copy or hard links are expected counts as a mismatch.
+func strategyMismatch(want, got config.Strategy) bool {
+	return (want == config.StrategyCopy || want == config.StrategyHardlink) && got == config.StrategySymlink
 }
 
 // Install installs a skill to this target.
@@ -113,40 +566,71 @@ func (t *Target) Install(s *skill.Skill, opts InstallOptions) error {
 		return err
 	}
 
-	destPath := t.fs.Join(destDir, s.Name)
+	installedName := t.installedName(s.Name)
+	if err := skill.ValidateName(installedName); err != nil {
+		return fmt.Errorf("invalid installed name for target %s: %w", t.name, err)
+	}
+	destPath := t.fs.Join(destDir, installedName)
 
-	if t.fs.Exists(destPath) {
-		if !opts.Force {
-			return fmt.Errorf("skill already installed: %s", s.Name)
+	if t.fs.Exists(destPath) && !opts.Force {
+		return fmt.Errorf("skill already installed: %s", s.Name)
+	}
+	if err := t.journal.moveAside(destPath); err != nil {
+		return err
+	}
+
+	// A dangling symlink (e.g. into a home directory on another machine) is replaced.
+	if t.fs.IsSymlink(destPath) && !t.fs.Exists(destPath) {
+		if err := t.fs.Remove(destPath); err != nil {
+			return fmt.Errorf("failed to remove dangling link: %w", err)
 		}
+		slog.Debug("removed dangling link", "target", t.name, "path", destPath)
+	}
+
+	if t.fs.Exists(destPath) {
 		if err := t.fs.RemoveAll(destPath); err != nil {
 			return fmt.Errorf("failed to remove existing skill: %w", err)
 		}
+		slog.Debug("removed existing install", "target", t.name, "path", destPath)
 	}
 
 	if err := t.fs.MkdirAll(destDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create skills directory: %w", err)
 	}
 
-	switch opts.Strategy {
-	case config.StrategySymlink:
-		if err := t.fs.Symlink(s.Path, destPath); err != nil {
-			if err := t.fs.CopyDir(s.Path, destPath); err != nil {
-				return fmt.Errorf("failed to install skill: %w", err)
-			}
-		}
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = config.Strategy(s.Strategy())
+	}
+	switch t.strategyFor(strategy) {
 	case config.StrategyCopy:
-		if err := t.fs.CopyDir(s.Path, destPath); err != nil {
+		if err := t.copySkill(s, destPath); err != nil {
 			return fmt.Errorf("failed to copy skill: %w", err)
 		}
+		slog.Debug("copied skill", "target", t.name, "from", s.Path, "to", destPath, "transformed", t.transformed())
+	case config.StrategyHardlink:
+		if err := t.linkSkill(s, destPath); err != nil {
+			return fmt.Errorf("failed to link skill: %w", err)
+		}
+		slog.Debug("linked skill", "target", t.name, "from", s.Path, "to", destPath, "transformed", t.transformed())
 	default:
-		if err := t.fs.Symlink(s.Path, destPath); err != nil {
-			if err := t.fs.CopyDir(s.Path, destPath); err != nil {
+		if err := t.symlinkSkill(s, destPath); err != nil {
+			slog.Debug("could not create symlink; copying instead", "target", t.name, "link", destPath, "error", err)
+			if err := t.copySkill(s, destPath); err != nil {
 				return fmt.Errorf("failed to install skill: %w", err)
 			}
+			slog.Debug("copied skill", "target", t.name, "from", s.Path, "to", destPath, "transformed", t.transformed())
+		} else {
+			slog.Debug("created symlink", "target", t.name, "link", destPath, "to", s.Path)
 		}
 	}
 
+	// Copies can fall behind the store, so status needs to know their age.
+	// The log is advisory and a failure to write it does not fail the install.
+	if !t.fs.IsSymlink(destPath) {
+		_ = t.recordSynced(s.Name, s.Scope, time.Now())
+	}
+
 	return nil
 }
 
@@ -164,44 +648,71 @@ func (t *Target) Uninstall(skillName string) error {
 	return nil
 }
 
-// ListInstalled returns all installed skills from all scopes.
+// uninstallFromScope removes a skill installed in the given scope and its sync log entry.
+func (t *Target) uninstallFromScope(skillName string, scope skill.Scope) error {
+	return t.removeEntry(t.entryName(skillName), scope)
+}
+
+// removeEntry removes the entry listed as entry in the given scope and its
+// sync log entry.
+func (t *Target) removeEntry(entry string, scope skill.Scope) error {
+	path, err := t.entryPath(entry, scope)
+	if err != nil {
+		return err
+	}
+	if err := t.journal.moveAside(path); err != nil {
+		return err
+	}
+	if err := t.fs.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to uninstall skill: %w", err)
+	}
+	return t.forgetEntry(entry, scope)
+}
+
+// ListInstalled returns the sorted names of installed skills from all scopes.
 func (t *Target) ListInstalled() ([]string, error) {
 	skillSet := make(map[string]bool)
 
-	addFromDir := func(dir string) error {
-		if dir == "" || !t.fs.Exists(dir) {
-			return nil
-		}
-		entries, err := t.fs.ReadDir(dir)
+	for _, scope := range []skill.Scope{skill.ScopeGlobal, skill.ScopeProject} {
+		names, err := t.ListInstalledInScope(scope)
 		if err != nil {
-			return fmt.Errorf("failed to read skills directory: %w", err)
+			return nil, err
 		}
-		for _, entry := range entries {
-			if entry.IsDir() || entry.Type()&os.ModeSymlink != 0 {
-				skillSet[entry.Name()] = true
-			}
+		for _, name := range names {
+			skillSet[name] = true
 		}
-		return nil
 	}
 
-	if globalPath, err := t.GetSkillsPath(skill.ScopeGlobal); err == nil {
-		if err := addFromDir(globalPath); err != nil {
-			return nil, err
-		}
+	return slices.Sorted(maps.Keys(skillSet)), nil
+}
+
+// ListInstalledInScope returns the sorted names of skills installed in a single scope.
+// A scope without a resolvable or existing skills directory has no installed skills.
+// Names are entry names (see entryName) with the target prefix removed;
+// unprefixed entries are ignored.
+func (t *Target) ListInstalledInScope(scope skill.Scope) ([]string, error) {
+	dir, err := t.GetSkillsPath(scope)
+	if err != nil || !t.fs.Exists(dir) {
+		return nil, nil
 	}
 
-	if projectPath, err := t.GetSkillsPath(skill.ScopeProject); err == nil {
-		if err := addFromDir(projectPath); err != nil {
-			return nil, err
-		}
+	entries, err := t.fs.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skills directory: %w", err)
 	}
 
-	skills := make([]string, 0, len(skillSet))
-	for name := range skillSet {
-		skills = append(skills, name)
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && !t.fs.IsSymlink(t.fs.Join(dir, entry.Name())) {
+			continue
+		}
+		if name, ok := t.skillName(entry.Name()); ok {
+			names = append(names, name)
+		}
 	}
+	slices.Sort(names)
 
-	return skills, nil
+	return names, nil
 }
 
 // ListMigratable returns skill names that can be migrated from a specific scope.
@@ -210,20 +721,25 @@ func (t *Target) ListMigratable(scope skill.Scope) ([]string, error) {
 	if err != nil || targetSkillsDir == "" {
 		return nil, err
 	}
+	return unmanagedSkillDirs(t.fs, targetSkillsDir)
+}
 
-	if !t.fs.Exists(targetSkillsDir) || !t.fs.IsDir(targetSkillsDir) {
+// unmanagedSkillDirs returns the names of the skill directories in dir that
+// are not symlinks, sorted.
+func unmanagedSkillDirs(fsys platformfs.FileSystem, dir string) ([]string, error) {
+	if !fsys.Exists(dir) || !fsys.IsDir(dir) {
 		return nil, nil
 	}
 
-	entries, err := t.fs.ReadDir(targetSkillsDir)
+	entries, err := fsys.ReadDir(dir)
 	if err != nil {
 		return nil, err
 	}
 
 	var names []string
 	for _, entry := range entries {
-		// Skip symlinks (already managed by skillet).
-		if entry.Type()&os.ModeSymlink != 0 {
+		// Skip symlinks and junctions (already managed by skillet).
+		if fsys.IsSymlink(fsys.Join(dir, entry.Name())) {
 			continue
 		}
 		if !entry.IsDir() {
@@ -235,11 +751,12 @@ func (t *Target) ListMigratable(scope skill.Scope) ([]string, error) {
 			continue
 		}
 
-		skillDir := t.fs.Join(targetSkillsDir, skillName)
-		if isValidSkillDir(t.fs, skillDir) {
+		skillDir := fsys.Join(dir, skillName)
+		if isValidSkillDir(fsys, skillDir) {
 			names = append(names, skillName)
 		}
 	}
+	slices.Sort(names)
 
 	return names, nil
 }
@@ -247,23 +764,68 @@ func (t *Target) ListMigratable(scope skill.Scope) ([]string, error) {
 // TargetRegistry manages multiple targets.
 type TargetRegistry struct {
 	targets map[string]*Target
+	// disabled holds configured targets that are not enabled.
+	disabled map[string]bool
 }
 
-// NewTargetRegistry creates a new registry with default targets.
+// NewTargetRegistry creates a registry with a target for every enabled entry
+// under targets in config, or with the built-in targets when cfg is nil.
 func NewTargetRegistry(fsys platformfs.FileSystem, projectRoot string, cfg *config.Config) *TargetRegistry {
-	r := &TargetRegistry{targets: make(map[string]*Target)}
+	r := &TargetRegistry{targets: make(map[string]*Target), disabled: make(map[string]bool)}
+
+	if cfg == nil {
+		for name, def := range defaultTargets {
+			t := newTarget(name, def.GlobalPaths, def.ProjectPath, def.SkillsDir, fsys, projectRoot)
+			t.migrateSources = def.MigrateSources
+			if def.transform != nil {
+				t.transforms = append(t.transforms, def.transform)
+			}
+			r.targets[name] = t
+		}
+		return r
+	}
 
-	for name, def := range defaultTargets {
-		if cfg != nil && !cfg.Targets[name].Enabled {
+	for name, tc := range cfg.Targets {
+		if !tc.Enabled {
+			r.disabled[name] = true
 			continue
 		}
 
-		globalPath := def.GlobalPath
-		if cfg != nil && cfg.Targets[name].GlobalPath != "" {
-			globalPath = cfg.Targets[name].GlobalPath
+		def := targetDef(name, tc)
+		t := newTarget(name, def.GlobalPaths, def.ProjectPath, def.SkillsDir, fsys, projectRoot)
+		t.projectSkillsDir = tc.ProjectSkillsDir
+		t.manifest = tc.Manifest
+		t.prefix = tc.Prefix
+		t.collections = cfg.Collections
+		t.relativeLinks = cfg.SymlinkMode == config.SymlinkRelative
+		t.migrateSources = def.MigrateSources
+		if def.transform != nil {
+			t.transforms = append(t.transforms, def.transform)
 		}
-
-		r.targets[name] = newTarget(name, globalPath, def.ProjectPath, def.SkillsDir, fsys, projectRoot)
+		if len(tc.StripFrontmatterKeys) > 0 {
+			t.transforms = append(t.transforms, stripFrontmatterKeys(tc.StripFrontmatterKeys))
+		}
+		if tc.Banner {
+			t.transforms = append(t.transforms, addBanner)
+		}
+		t.layout = newLayout(tc.Transform)
+		for _, skillName := range tc.Optional {
+			if t.optional == nil {
+				t.optional = make(map[string]bool, len(tc.Optional))
+			}
+			t.optional[skillName] = true
+		}
+		t.aliases = tc.Aliases
+		for skillName, sc := range cfg.Skills {
+			if !sc.Excludes(name) {
+				continue
+			}
+			if t.excluded == nil {
+				t.excluded = make(map[string]bool)
+			}
+			t.excluded[skillName] = true
+		}
+		r.targets[name] = t
 	}
 
 	return r
@@ -275,22 +837,46 @@ func (r *TargetRegistry) Get(name string) (*Target, bool) {
 	return target, ok
 }
 
-// GetAll returns all registered targets.
+// ErrUnknownTarget is returned when a target name does not match an enabled target.
+type ErrUnknownTarget struct {
+	Name string
+	// Valid lists the enabled target names
+	Valid []string
+	// Disabled is true when the target is supported but disabled in config
+	Disabled bool
+}
+
+func (e *ErrUnknownTarget) Error() string {
+	if e.Disabled {
+		return fmt.Sprintf("target %q is disabled", e.Name)
+	}
+	if len(e.Valid) == 0 {
+		return fmt.Sprintf("unknown target %q (no targets are enabled)", e.Name)
+	}
+	return fmt.Sprintf("unknown target %q (valid targets: %s)", e.Name, strings.Join(e.Valid, ", "))
+}
+
+// Lookup returns a target by name, or an *ErrUnknownTarget if it is not enabled.
+func (r *TargetRegistry) Lookup(name string) (*Target, error) {
+	if target, ok := r.targets[name]; ok {
+		return target, nil
+	}
+	_, builtin := defaultTargets[name]
+	return nil, &ErrUnknownTarget{Name: name, Valid: r.Names(), Disabled: r.disabled[name] || builtin}
+}
+
+// GetAll returns all registered targets sorted by name.
 func (r *TargetRegistry) GetAll() []*Target {
 	targets := make([]*Target, 0, len(r.targets))
-	for _, t := range r.targets {
-		targets = append(targets, t)
+	for _, name := range r.Names() {
+		targets = append(targets, r.targets[name])
 	}
 	return targets
 }
 
-// Names returns all registered target names.
+// Names returns all registered target names in sorted order.
 func (r *TargetRegistry) Names() []string {
-	names := make([]string, 0, len(r.targets))
-	for name := range r.targets {
-		names = append(names, name)
-	}
-	return names
+	return slices.Sorted(maps.Keys(r.targets))
 }
 
 const maxValidationDepth = 5
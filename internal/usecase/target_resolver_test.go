@@ -1,6 +1,10 @@
 package usecase_test
 
 import (
+	"errors"
+	"maps"
+	"os"
+	"slices"
 	"testing"
 
 	"github.com/wwwyo/skillet/internal/config"
@@ -27,6 +31,35 @@ func TestNewTargetRegistryRespectsEnabledTargets(t *testing.T) {
 	}
 }
 
+func TestTargetRegistryLookupUnknown(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	cfg := config.DefaultConfig()
+
+	codex := cfg.Targets["codex"]
+	codex.Enabled = false
+	cfg.Targets["codex"] = codex
+
+	registry := usecase.NewTargetRegistry(mock, "", cfg)
+
+	if _, err := registry.Lookup("claude"); err != nil {
+		t.Fatalf("Lookup(claude) error = %v", err)
+	}
+
+	var unknown *usecase.ErrUnknownTarget
+	_, err := registry.Lookup("clade")
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected ErrUnknownTarget, got %v", err)
+	}
+	if unknown.Disabled || !slices.Equal(unknown.Valid, []string{"claude"}) {
+		t.Errorf("unexpected error details: %+v", unknown)
+	}
+
+	_, err = registry.Lookup("codex")
+	if !errors.As(err, &unknown) || !unknown.Disabled {
+		t.Errorf("expected disabled ErrUnknownTarget for codex, got %v", err)
+	}
+}
+
 func TestTargetGetSkillsPathUsesConfigOverride(t *testing.T) {
 	mock := platformfs.NewMockFileSystem()
 	cfg := config.DefaultConfig()
@@ -50,6 +83,74 @@ func TestTargetGetSkillsPathUsesConfigOverride(t *testing.T) {
 	}
 }
 
+func TestTargetRegistryBuildsConfiguredTargets(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	cfg := config.DefaultConfig()
+	cfg.Targets["cursor"] = config.TargetConfig{Enabled: true}
+	cfg.Targets["gemini"] = config.TargetConfig{
+		Enabled:     true,
+		GlobalPath:  "~/.config/gemini",
+		ProjectPath: ".gemini",
+		SkillsDir:   "extensions/skills",
+	}
+	cfg.Targets["windsurf"] = config.TargetConfig{Enabled: false}
+
+	registry := usecase.NewTargetRegistry(mock, "/project", cfg)
+	if got := registry.Names(); !slices.Equal(got, []string{"claude", "codex", "cursor", "gemini"}) {
+		t.Fatalf("Names() = %v", got)
+	}
+
+	tests := []struct {
+		target string
+		scope  skill.Scope
+		want   string
+	}{
+		{"cursor", skill.ScopeGlobal, "/home/test/.cursor/skills"},
+		{"cursor", skill.ScopeProject, "/project/.cursor/skills"},
+		{"gemini", skill.ScopeGlobal, "/home/test/.config/gemini/extensions/skills"},
+		{"gemini", skill.ScopeProject, "/project/.gemini/extensions/skills"},
+	}
+	for _, tt := range tests {
+		target, _ := registry.Get(tt.target)
+		path, err := target.GetSkillsPath(tt.scope)
+		if err != nil || path != tt.want {
+			t.Errorf("%s GetSkillsPath(%s) = %q, %v; want %q", tt.target, tt.scope, path, err, tt.want)
+		}
+	}
+
+	var unknown *usecase.ErrUnknownTarget
+	if _, err := registry.Lookup("windsurf"); !errors.As(err, &unknown) || !unknown.Disabled {
+		t.Errorf("expected disabled ErrUnknownTarget for windsurf, got %v", err)
+	}
+}
+
+func TestTargetRegistryAppliesProjectConfig(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	cfg := config.DefaultConfig()
+	cfg.Targets["codex"] = config.TargetConfig{Enabled: false}
+	enabled, disabled := true, false
+	cfg = cfg.WithProject(&config.ProjectConfig{Targets: map[string]config.ProjectTargetConfig{
+		"claude": {Enabled: &disabled},
+		"codex":  {Enabled: &enabled, ProjectPath: "tools/codex", SkillsDir: "agent-skills"},
+	}})
+
+	registry := usecase.NewTargetRegistry(mock, "/project", cfg)
+	if got := registry.Names(); !slices.Equal(got, []string{"codex"}) {
+		t.Fatalf("Names() = %v, want [codex]", got)
+	}
+	target, _ := registry.Get("codex")
+	for scope, want := range map[skill.Scope]string{
+		skill.ScopeProject: "/project/tools/codex/agent-skills",
+		skill.ScopeGlobal:  "/home/test/.codex/skills",
+	} {
+		if path, err := target.GetSkillsPath(scope); err != nil || path != want {
+			t.Errorf("GetSkillsPath(%s) = %q, %v; want %q", scope, path, err, want)
+		}
+	}
+}
+
 func TestTargetGetSkillsPathProjectRequiresRoot(t *testing.T) {
 	mock := platformfs.NewMockFileSystem()
 	cfg := config.DefaultConfig()
@@ -105,3 +206,172 @@ func TestTargetInstallCopyAndUninstall(t *testing.T) {
 		t.Fatal("expected skill to be removed from target path")
 	}
 }
+
+// crossDeviceFS fails every hard link, as linking across file systems does.
+type crossDeviceFS struct {
+	*platformfs.MockFileSystem
+}
+
+func (f *crossDeviceFS) Link(oldname, newname string) error {
+	return &os.LinkError{Op: "link", Old: oldname, New: newname, Err: errors.New("invalid cross-device link")}
+}
+
+func TestTargetInstallHardlink(t *testing.T) {
+	tests := []struct {
+		name      string
+		crossDev  bool
+		wantLinks map[string]string
+	}{
+		{
+			name: "same file system",
+			// SKILL.md is rewritten for the target, so only the script is linked.
+			wantLinks: map[string]string{"/home/test/.claude/skills/tool/scripts/run.sh": "/home/test/.agents/skills/tool/scripts/run.sh"},
+		},
+		{name: "across file systems", crossDev: true, wantLinks: map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := platformfs.NewMockFileSystem()
+			mock.Dirs["/home/test/.agents/skills/tool"] = true
+			mock.Dirs["/home/test/.agents/skills/tool/scripts"] = true
+			mock.Files["/home/test/.agents/skills/tool/SKILL.md"] = []byte("---\nname: tool\ntags: [x]\n---\n")
+			mock.Files["/home/test/.agents/skills/tool/scripts/run.sh"] = []byte("echo run\n")
+			var fsys platformfs.FileSystem = mock
+			if tt.crossDev {
+				fsys = &crossDeviceFS{MockFileSystem: mock}
+			}
+
+			cfg := config.DefaultConfig()
+			claude := cfg.Targets["claude"]
+			claude.StripFrontmatterKeys = []string{"tags"}
+			cfg.Targets["claude"] = claude
+			target, _ := usecase.NewTargetRegistry(fsys, "", cfg).Get("claude")
+			sk, err := skill.NewSkill("tool", "", "/home/test/.agents/skills/tool", skill.ScopeGlobal, skill.CategoryDefault)
+			if err != nil {
+				t.Fatalf("NewSkill() error = %v", err)
+			}
+
+			if err := target.Install(sk, usecase.InstallOptions{Strategy: config.StrategyHardlink}); err != nil {
+				t.Fatalf("Install() error = %v", err)
+			}
+			if !maps.Equal(mock.Links, tt.wantLinks) {
+				t.Errorf("links = %v, want %v", mock.Links, tt.wantLinks)
+			}
+			if got := string(mock.Files["/home/test/.claude/skills/tool/SKILL.md"]); got != "---\nname: tool\n---\n" {
+				t.Errorf("SKILL.md = %q, want it without tags", got)
+			}
+			if got := string(mock.Files["/home/test/.claude/skills/tool/scripts/run.sh"]); got != "echo run\n" {
+				t.Errorf("run.sh = %q, want the store content", got)
+			}
+			if got, _ := target.InstalledStrategy("tool", skill.ScopeGlobal); got != config.StrategyCopy {
+				t.Errorf("InstalledStrategy() = %q, want copy", got)
+			}
+			if _, ok := target.SyncedAt("tool", skill.ScopeGlobal); !ok {
+				t.Error("linked install should be recorded in the sync log like a copy")
+			}
+		})
+	}
+}
+
+func TestTargetPrefixMapsInstalledNames(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	mock.Dirs["/home/test/.agents/skills/review"] = true
+	mock.Files["/home/test/.agents/skills/review/SKILL.md"] = []byte("---\nname: review\n---\n")
+	// A skill the target owns itself, without the prefix.
+	mock.Dirs["/home/test/.claude/skills/review"] = true
+	mock.Files["/home/test/.claude/skills/review/SKILL.md"] = []byte("---\nname: review\n---\n")
+
+	cfg := config.DefaultConfig()
+	claude := cfg.Targets["claude"]
+	claude.Prefix = "team-"
+	cfg.Targets["claude"] = claude
+
+	registry := usecase.NewTargetRegistry(mock, "", cfg)
+	target, ok := registry.Get("claude")
+	if !ok {
+		t.Fatal("claude target not found")
+	}
+
+	if target.IsInstalled("review") {
+		t.Fatal("unprefixed target skill should not count as installed")
+	}
+
+	sk, err := skill.NewSkill("review", "", "/home/test/.agents/skills/review", skill.ScopeGlobal, skill.CategoryDefault)
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	if err := target.Install(sk, usecase.InstallOptions{Strategy: config.StrategyCopy}); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if !mock.Exists("/home/test/.claude/skills/team-review/SKILL.md") {
+		t.Fatal("expected skill to be installed under the prefixed name")
+	}
+
+	names, err := target.ListInstalled()
+	if err != nil {
+		t.Fatalf("ListInstalled() error = %v", err)
+	}
+	if !slices.Equal(names, []string{"review"}) {
+		t.Fatalf("ListInstalled() = %v, want [review]", names)
+	}
+
+	if err := target.Uninstall("review"); err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+	if mock.Exists("/home/test/.claude/skills/team-review") {
+		t.Fatal("expected prefixed install to be removed")
+	}
+	if !mock.Exists("/home/test/.claude/skills/review") {
+		t.Fatal("target's own skill must be left untouched")
+	}
+}
+
+func TestTargetGlobalPathProbing(t *testing.T) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	cfg := config.DefaultConfig()
+	cfg.Targets["cursor"] = config.TargetConfig{Enabled: true}
+	cfg.Targets["app"] = config.TargetConfig{
+		Enabled:     true,
+		GlobalPaths: []string{"~/.app", "~/Library/Application Support/App"},
+	}
+
+	skillsPath := func(name string) string {
+		target, _ := usecase.NewTargetRegistry(mock, "", cfg).Get(name)
+		path, err := target.GetSkillsPath(skill.ScopeGlobal)
+		if err != nil {
+			t.Fatalf("%s GetSkillsPath() error = %v", name, err)
+		}
+		return path
+	}
+
+	// Without any candidate on disk, the first one is used.
+	if got := skillsPath("cursor"); got != "/home/test/.cursor/skills" {
+		t.Errorf("cursor = %q, want first candidate", got)
+	}
+	if got := skillsPath("app"); got != "/home/test/.app/skills" {
+		t.Errorf("app = %q, want first candidate", got)
+	}
+
+	mock.Dirs["/home/test/Library/Application Support/Cursor"] = true
+	mock.Dirs["/home/test/Library/Application Support/App"] = true
+	if got := skillsPath("cursor"); got != "/home/test/Library/Application Support/Cursor/skills" {
+		t.Errorf("cursor = %q, want the existing app folder", got)
+	}
+	if got := skillsPath("app"); got != "/home/test/Library/Application Support/App/skills" {
+		t.Errorf("app = %q, want the existing app folder", got)
+	}
+
+	mock.Dirs["/home/test/.app"] = true
+	if got := skillsPath("app"); got != "/home/test/.app/skills" {
+		t.Errorf("app = %q, want the first existing candidate", got)
+	}
+
+	app := cfg.Targets["app"]
+	app.GlobalPath = "/opt/app"
+	cfg.Targets["app"] = app
+	if got := skillsPath("app"); got != "/opt/app/skills" {
+		t.Errorf("app = %q, want globalPath override", got)
+	}
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// skillFiles maps paths relative to a skill directory to their contents.
+type skillFiles map[string][]byte
+
+// layoutTransform rewrites the files of skill name as they are installed in t.
+type layoutTransform func(t *Target, name string, files skillFiles) (skillFiles, error)
+
+// newLayout returns the layout transform declared by tc, or nil for passthrough.
+func newLayout(tc config.TransformConfig) layoutTransform {
+	file := tc.File
+	if file == "" {
+		file = "SKILL.md"
+	}
+	switch tc.Type {
+	case "", config.TransformPassthrough:
+		return nil
+	case config.TransformFlatten:
+		return func(t *Target, name string, files skillFiles) (skillFiles, error) {
+			return flattenSkill(files, layoutFile(file, t.installedName(name)))
+		}
+	case config.TransformRename:
+		return func(t *Target, name string, files skillFiles) (skillFiles, error) {
+			return renameSkillFile(files, layoutFile(file, t.installedName(name)))
+		}
+	case config.TransformTemplate:
+		return func(t *Target, name string, files skillFiles) (skillFiles, error) {
+			return renderSkillTemplate(t, name, files, tc.Template, layoutFile(file, t.installedName(name)))
+		}
+	default:
+		return func(*Target, string, skillFiles) (skillFiles, error) {
+			return nil, fmt.Errorf("unknown transform %q (use passthrough, flatten, rename, or template)", tc.Type)
+		}
+	}
+}
+
+// layoutFile expands "{name}" in a configured file name.
+func layoutFile(file, installedName string) string {
+	return strings.ReplaceAll(file, "{name}", installedName)
+}
+
+// renameSkillFile moves SKILL.md to file, keeping every other file.
+func renameSkillFile(files skillFiles, file string) (skillFiles, error) {
+	data, ok := files["SKILL.md"]
+	if !ok {
+		return nil, fmt.Errorf("SKILL.md not found")
+	}
+	out := maps.Clone(files)
+	delete(out, "SKILL.md")
+	out[file] = data
+	return out, nil
+}
+
+// flattenSkill merges SKILL.md and the skill's other markdown files into a
+// single file. Each appended file follows a heading with its relative path;
+// files that are not markdown are left out.
+func flattenSkill(files skillFiles, file string) (skillFiles, error) {
+	data, ok := files["SKILL.md"]
+	if !ok {
+		return nil, fmt.Errorf("SKILL.md not found")
+	}
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimRight(data, "\n"))
+	for _, rel := range sortedPaths(files) {
+		if rel == "SKILL.md" || path.Ext(rel) != ".md" {
+			continue
+		}
+		fmt.Fprintf(&buf, "\n\n## %s\n\n", rel)
+		buf.Write(bytes.TrimSpace(files[rel]))
+	}
+	buf.WriteByte('\n')
+	return skillFiles{file: buf.Bytes()}, nil
+}
+
+// skillTemplateData is the data passed to template transforms.
+type skillTemplateData struct {
+	// Name is the skill name in the store
+	Name string
+	// InstalledName is the name the skill is installed under (with the target prefix)
+	InstalledName string
+	Description   string
+	// Frontmatter holds every frontmatter key of SKILL.md
+	Frontmatter map[string]any
+	// Body is SKILL.md without its frontmatter
+	Body string
+	// Files lists the skill's other files, sorted by path
+	Files []string
+}
+
+// renderSkillTemplate replaces SKILL.md with the output of the template at
+// tmplPath, written as file. The skill's other files are kept.
+func renderSkillTemplate(t *Target, name string, files skillFiles, tmplPath, file string) (skillFiles, error) {
+	if tmplPath == "" {
+		return nil, fmt.Errorf("template transform requires a template path")
+	}
+	expanded, err := config.ExpandPath(t.fs, tmplPath)
+	if err != nil {
+		return nil, err
+	}
+	text, err := t.fs.ReadFile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	tmpl, err := template.New(t.fs.Base(expanded)).Option("missingkey=zero").Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	data, ok := files["SKILL.md"]
+	if !ok {
+		return nil, fmt.Errorf("SKILL.md not found")
+	}
+	frontmatter, body := skill.SplitFrontmatter(string(data))
+	td := skillTemplateData{Name: name, InstalledName: t.installedName(name), Body: body}
+	if err := yaml.Unmarshal([]byte(frontmatter), &td.Frontmatter); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML frontmatter: %w", err)
+	}
+	if desc, ok := td.Frontmatter["description"].(string); ok {
+		td.Description = strings.TrimSpace(desc)
+	}
+	for _, rel := range sortedPaths(files) {
+		if rel != "SKILL.md" {
+			td.Files = append(td.Files, rel)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, td); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	out := maps.Clone(files)
+	delete(out, "SKILL.md")
+	out[file] = buf.Bytes()
+	return out, nil
+}
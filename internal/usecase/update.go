@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/platform/fetch"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// UpdateAction represents the outcome of updating a single skill.
+type UpdateAction string
+
+const (
+	UpdateActionUpdated   UpdateAction = "updated"
+	UpdateActionUnchanged UpdateAction = "unchanged"
+	UpdateActionError     UpdateAction = "error"
+)
+
+// FileChangeKind describes how a file differs between two versions of a skill.
+type FileChangeKind string
+
+const (
+	FileAdded    FileChangeKind = "added"
+	FileRemoved  FileChangeKind = "removed"
+	FileModified FileChangeKind = "modified"
+)
+
+// FileChange is a single file difference between two versions of a skill.
+type FileChange struct {
+	Path string
+	Kind FileChangeKind
+}
+
+// UpdateOptions contains options for updating skills from their sources.
+type UpdateOptions struct {
+	// Names limits the update to these skills (empty for every added skill)
+	Names []string
+	// DryRun only shows what would change without making changes
+	DryRun bool
+	// Force replaces skills edited locally since they were added
+	Force bool
+}
+
+// UpdateResult represents the result of updating a single skill.
+type UpdateResult struct {
+	SkillName string
+	Scope     skill.Scope
+	Source    string
+	Action    UpdateAction
+	// Changes lists the files that differ from the fetched version, sorted by path
+	Changes []FileChange
+	Error   error
+}
+
+// UpdateService refreshes skills added from remote sources.
+type UpdateService struct {
+	fs   platformfs.FileSystem
+	cfg  *config.Config
+	root string
+	add  *AddService
+}
+
+// NewUpdateService creates a new update service that retrieves sources with fetcher.
+func NewUpdateService(fsys platformfs.FileSystem, cfg *config.Config, root string, fetcher fetch.Fetcher) *UpdateService {
+	return &UpdateService{
+		fs:   fsys,
+		cfg:  cfg,
+		root: root,
+		add:  NewAddService(fsys, cfg, root, fetcher),
+	}
+}
+
+// Update fetches the recorded source of every skill added to the global and
+// project stores and replaces skills whose content changed. Results are
+// ordered by scope (project first), then by name. Canceling ctx stops the
+// update between skills, keeping the ones already replaced.
+func (s *UpdateService) Update(ctx context.Context, opts UpdateOptions) ([]UpdateResult, error) {
+	scopes := []skill.Scope{skill.ScopeGlobal}
+	if s.root != "" {
+		scopes = append([]skill.Scope{skill.ScopeProject}, scopes...)
+	}
+
+	var results []UpdateResult
+	found := make(map[string]bool)
+	for _, scope := range scopes {
+		agentsDir, skillsDir, err := s.add.storeDirs(scope)
+		if err != nil {
+			return nil, err
+		}
+		lock, err := loadLockfile(s.fs, agentsDir)
+		if err != nil {
+			return nil, err
+		}
+
+		changed := false
+		for i, entry := range lock.Sources {
+			if ctx.Err() != nil {
+				break
+			}
+			if len(opts.Names) > 0 && !slices.Contains(opts.Names, entry.Name) {
+				continue
+			}
+			found[entry.Name] = true
+			result := s.updateSkill(ctx, &lock.Sources[i], scope, agentsDir, s.fs.Join(skillsDir, entry.Name), opts)
+			if result.Action == UpdateActionUpdated && !opts.DryRun {
+				changed = true
+			}
+			results = append(results, result)
+		}
+
+		if changed {
+			if err := saveLockfile(s.fs, agentsDir, lock); err != nil {
+				return results, err
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+	}
+
+	for _, name := range opts.Names {
+		if !found[name] {
+			results = append(results, UpdateResult{SkillName: name, Action: UpdateActionError, Error: fmt.Errorf("skill %s was not added from a remote source", name)})
+		}
+	}
+	slices.SortStableFunc(results, func(a, b UpdateResult) int {
+		if a.Scope != b.Scope {
+			return cmp.Compare(b.Scope, a.Scope)
+		}
+		return cmp.Compare(a.SkillName, b.SkillName)
+	})
+	return results, nil
+}
+
+// updateSkill fetches entry's source and replaces dest when it differs,
+// recording the new checksum in entry.
+func (s *UpdateService) updateSkill(ctx context.Context, entry *SourcedSkill, scope skill.Scope, agentsDir, dest string, opts UpdateOptions) UpdateResult {
+	result := UpdateResult{SkillName: entry.Name, Scope: scope, Source: entry.Source, Action: UpdateActionError}
+
+	if !s.fs.IsDir(dest) {
+		result.Error = fmt.Errorf("skill is missing from the %s store (run skillet add again)", scope)
+		return result
+	}
+	current, err := dirChecksum(s.fs, dest)
+	if err != nil {
+		result.Error = fmt.Errorf("failed to checksum skill: %w", err)
+		return result
+	}
+	if current != entry.Checksum && !opts.Force {
+		result.Error = fmt.Errorf("skill was modified since it was added (use --force to replace it)")
+		return result
+	}
+
+	staged, cleanup, err := s.add.fetchStaged(ctx, entry.Source, entry.Name, agentsDir)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer cleanup()
+
+	sum, err := dirChecksum(s.fs, staged)
+	if err != nil {
+		result.Error = fmt.Errorf("failed to checksum skill: %w", err)
+		return result
+	}
+	if sum == current {
+		result.Action = UpdateActionUnchanged
+		return result
+	}
+
+	if result.Changes, err = diffDirs(s.fs, dest, staged); err != nil {
+		result.Error = err
+		return result
+	}
+	result.Action = UpdateActionUpdated
+	if opts.DryRun {
+		return result
+	}
+
+	if err := s.fs.RemoveAll(dest); err != nil {
+		result.Action = UpdateActionError
+		result.Error = fmt.Errorf("failed to remove previous version: %w", err)
+		return result
+	}
+	if err := s.fs.Rename(staged, dest); err != nil {
+		result.Action = UpdateActionError
+		result.Error = fmt.Errorf("failed to install skill: %w", err)
+		return result
+	}
+	entry.Checksum = sum
+	return result
+}
+
+// diffDirs lists the files that differ between oldDir and newDir, sorted by path.
+func diffDirs(fsys platformfs.FileSystem, oldDir, newDir string) ([]FileChange, error) {
+	before, err := fileDigests(fsys, oldDir)
+	if err != nil {
+		return nil, err
+	}
+	after, err := fileDigests(fsys, newDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FileChange
+	for path, sum := range after {
+		switch old, ok := before[path]; {
+		case !ok:
+			changes = append(changes, FileChange{Path: path, Kind: FileAdded})
+		case old != sum:
+			changes = append(changes, FileChange{Path: path, Kind: FileModified})
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changes = append(changes, FileChange{Path: path, Kind: FileRemoved})
+		}
+	}
+	slices.SortFunc(changes, func(a, b FileChange) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+	return changes, nil
+}
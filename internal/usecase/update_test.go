@@ -0,0 +1,79 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestUpdateReplacesChangedSkills(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	cfg := config.DefaultConfig()
+	fetcher := &mockFetcher{fs: mock, files: map[string]string{
+		"SKILL.md": "---\nname: remote\n---\nv1\n",
+		"old.md":   "old\n",
+	}}
+	if _, err := usecase.NewAddService(mock, cfg, "", fetcher).Add(t.Context(), usecase.AddOptions{Source: "github.com/org/skills/remote", Scope: skill.ScopeGlobal}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	svc := usecase.NewUpdateService(mock, cfg, "", fetcher)
+
+	results, err := svc.Update(t.Context(), usecase.UpdateOptions{})
+	if err != nil || len(results) != 1 || results[0].Action != usecase.UpdateActionUnchanged {
+		t.Fatalf("Update() = %+v, %v; want unchanged", results, err)
+	}
+
+	fetcher.files = map[string]string{
+		"SKILL.md": "---\nname: remote\n---\nv2\n",
+		"new.md":   "new\n",
+	}
+	results, err = svc.Update(t.Context(), usecase.UpdateOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want := []usecase.FileChange{
+		{Path: "SKILL.md", Kind: usecase.FileModified},
+		{Path: "new.md", Kind: usecase.FileAdded},
+		{Path: "old.md", Kind: usecase.FileRemoved},
+	}
+	if results[0].Action != usecase.UpdateActionUpdated || len(results[0].Changes) != len(want) {
+		t.Fatalf("Update() dry run = %+v", results)
+	}
+	for i, c := range want {
+		if results[0].Changes[i] != c {
+			t.Errorf("Changes[%d] = %+v, want %+v", i, results[0].Changes[i], c)
+		}
+	}
+	if !mock.Exists("/home/test/.agents/skills/remote/old.md") {
+		t.Fatal("dry run should not replace the skill")
+	}
+
+	if _, err := svc.Update(t.Context(), usecase.UpdateOptions{Names: []string{"remote"}}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if mock.Exists("/home/test/.agents/skills/remote/old.md") || !mock.Exists("/home/test/.agents/skills/remote/new.md") {
+		t.Fatal("expected the skill to be replaced with the fetched version")
+	}
+	results, err = svc.Update(t.Context(), usecase.UpdateOptions{})
+	if err != nil || results[0].Action != usecase.UpdateActionUnchanged {
+		t.Fatalf("Update() after update = %+v, %v; want unchanged", results, err)
+	}
+
+	// Local edits are kept unless forced.
+	mock.Files["/home/test/.agents/skills/remote/SKILL.md"] = []byte("---\nname: remote\n---\nmine\n")
+	results, _ = svc.Update(t.Context(), usecase.UpdateOptions{})
+	if results[0].Action != usecase.UpdateActionError {
+		t.Fatalf("Update() of an edited skill = %+v, want error", results[0])
+	}
+	results, _ = svc.Update(t.Context(), usecase.UpdateOptions{Force: true})
+	if results[0].Action != usecase.UpdateActionUpdated {
+		t.Fatalf("forced Update() = %+v, want updated", results[0])
+	}
+
+	results, _ = svc.Update(t.Context(), usecase.UpdateOptions{Names: []string{"unknown"}})
+	if len(results) != 1 || results[0].Action != usecase.UpdateActionError {
+		t.Fatalf("Update() of an unknown skill = %+v, want error", results)
+	}
+}
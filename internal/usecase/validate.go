@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// ValidateOptions contains options for validating skills.
+type ValidateOptions struct {
+	// Args are skill directories, SKILL.md files, or names of skills in the
+	// store (empty for every skill in the store)
+	Args []string
+	// Scope limits validation of store skills to a specific scope (nil for all)
+	Scope *skill.Scope
+	// MaxAssetSize is the largest file allowed in a skill, in bytes (0 for
+	// skill.DefaultMaxAssetSize)
+	MaxAssetSize int64
+}
+
+// ValidateResult represents the problems found in a single skill.
+type ValidateResult struct {
+	// SkillName is the store name of the skill, or the argument naming its directory
+	SkillName string
+	Path      string
+	Problems  []skill.Problem
+	Error     error
+}
+
+// ValidateService checks skill directories against the frontmatter schema
+// and for broken links and oversized files.
+type ValidateService struct {
+	fs    platformfs.FileSystem
+	store *skill.Store
+}
+
+// NewValidateService creates a new validate service.
+func NewValidateService(fsys platformfs.FileSystem, cfg *config.Config, root string) *ValidateService {
+	// Validation reports schema problems itself, so skills that strict
+	// frontmatter checking would refuse to load must still be found.
+	lenient := *cfg
+	lenient.Frontmatter.Strict = false
+	return &ValidateService{fs: fsys, store: skill.NewStore(fsys, &lenient, root)}
+}
+
+// Validate checks the skills named by opts.Args and returns one result per
+// skill. An argument naming an existing file or directory is validated as a
+// skill directory (a SKILL.md file stands for its directory); any other
+// argument is looked up in the store.
+func (s *ValidateService) Validate(opts ValidateOptions) ([]ValidateResult, error) {
+	inScope := func(sk *skill.Skill) bool { return opts.Scope == nil || sk.Scope == *opts.Scope }
+
+	if len(opts.Args) == 0 {
+		skills, err := s.store.GetAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get skills: %w", err)
+		}
+		results := make([]ValidateResult, 0, len(skills))
+		for _, sk := range skills {
+			if inScope(sk) {
+				results = append(results, s.validate(sk.Name, sk.Path, opts.MaxAssetSize))
+			}
+		}
+		return results, nil
+	}
+
+	results := make([]ValidateResult, 0, len(opts.Args))
+	for _, arg := range opts.Args {
+		if s.fs.Exists(arg) {
+			dir := arg
+			if !s.fs.IsDir(arg) && s.fs.Base(arg) == "SKILL.md" {
+				dir = s.fs.Dir(arg)
+			}
+			results = append(results, s.validate(arg, dir, opts.MaxAssetSize))
+			continue
+		}
+		if err := skill.ValidateQualifiedName(arg); err != nil {
+			return nil, fmt.Errorf("no such skill directory or skill: %s", arg)
+		}
+		sk, err := s.store.GetByName(arg)
+		if err != nil {
+			return nil, err
+		}
+		if inScope(sk) {
+			results = append(results, s.validate(sk.Name, sk.Path, opts.MaxAssetSize))
+		}
+	}
+	return results, nil
+}
+
+func (s *ValidateService) validate(name, dir string, maxAssetSize int64) ValidateResult {
+	problems, err := skill.Validate(s.fs, dir, maxAssetSize)
+	return ValidateResult{SkillName: name, Path: dir, Problems: problems, Error: err}
+}
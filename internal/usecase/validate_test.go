@@ -0,0 +1,49 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestValidateSkills(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "review")
+	mock.Files["/home/test/.agents/skills/review/SKILL.md"] = []byte("---\nname: review\ndescription: Review code\n---\n")
+	// A skill strict frontmatter checking would refuse to load.
+	addGlobalSkill(mock, "loose")
+	mock.Dirs["/work/new-skill"] = true
+	mock.Files["/work/new-skill/SKILL.md"] = []byte("---\nname: new-skill\ndescription: Draft\n---\nSee [notes](notes.md).\n")
+
+	cfg := config.DefaultConfig()
+	cfg.Frontmatter.Strict = true
+	svc := usecase.NewValidateService(mock, cfg, "")
+
+	results, err := svc.Validate(usecase.ValidateOptions{})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	problems := make(map[string]int, len(results))
+	for _, r := range results {
+		if r.Error != nil {
+			t.Fatalf("%s: error = %v", r.SkillName, r.Error)
+		}
+		problems[r.SkillName] = len(r.Problems)
+	}
+	if len(problems) != 2 || problems["review"] != 0 || problems["loose"] != 1 {
+		t.Errorf("problems by skill = %v, want none in review and a missing description in loose", problems)
+	}
+
+	results, err = svc.Validate(usecase.ValidateOptions{Args: []string{"/work/new-skill/SKILL.md", "review"}})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Path != "/work/new-skill" || len(results[0].Problems) != 1 || results[1].SkillName != "review" {
+		t.Fatalf("Validate() = %+v, want the directory's broken link, then review", results)
+	}
+
+	if _, err := svc.Validate(usecase.ValidateOptions{Args: []string{"missing"}}); err == nil {
+		t.Error("Validate() should fail for an unknown skill")
+	}
+}
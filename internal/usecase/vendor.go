@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// VendorAction represents the outcome of vendoring a single skill.
+type VendorAction string
+
+const (
+	VendorActionVendored  VendorAction = "vendored"
+	VendorActionUpdated   VendorAction = "updated"
+	VendorActionUnchanged VendorAction = "unchanged"
+	VendorActionError     VendorAction = "error"
+)
+
+// VendorOptions contains options for vendoring skills into a project.
+type VendorOptions struct {
+	// Names limits vendoring to these skills (empty for every non-project skill)
+	Names []string
+	// DryRun only shows what would be done without making changes
+	DryRun bool
+}
+
+// VendorResult represents the result of vendoring a single skill.
+type VendorResult struct {
+	SkillName string
+	Scope     skill.Scope
+	Action    VendorAction
+	Error     error
+}
+
+// VendorService copies global, org, and system skills into a project.
+type VendorService struct {
+	fs    platformfs.FileSystem
+	cfg   *config.Config
+	store *skill.Store
+	root  string
+}
+
+// NewVendorService creates a new vendor service.
+func NewVendorService(fsys platformfs.FileSystem, cfg *config.Config, root string) *VendorService {
+	return &VendorService{
+		fs:    fsys,
+		cfg:   cfg,
+		store: skill.NewStore(fsys, cfg, root),
+		root:  root,
+	}
+}
+
+// Vendor copies the resolved non-project skills into <project>/.agents/vendor/
+// and records their source and checksum in the project lock file.
+// Skills defined by the project itself are never vendored.
+func (s *VendorService) Vendor(opts VendorOptions) ([]VendorResult, error) {
+	if s.root == "" {
+		return nil, fmt.Errorf("not in a project directory")
+	}
+
+	all, err := s.store.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	projectDefined := make(map[string]bool)
+	sources := make(map[string]*skill.Skill)
+	for _, sk := range all {
+		if sk.Draft() {
+			continue
+		}
+		if sk.Scope == skill.ScopeProject {
+			if !sk.Vendored {
+				projectDefined[sk.Name] = true
+			}
+			continue
+		}
+		if cur, ok := sources[sk.Name]; !ok || sk.Priority() > cur.Priority() {
+			sources[sk.Name] = sk
+		}
+	}
+
+	names := opts.Names
+	if len(names) == 0 {
+		for name := range sources {
+			if !projectDefined[name] {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+
+	agentsDir := config.ProjectAgentsDir(s.root, s.fs)
+	lock, err := loadLockfile(s.fs, agentsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	vendorDir := s.cfg.ProjectVendorDir(s.fs, s.root)
+	results := make([]VendorResult, 0, len(names))
+	for _, name := range names {
+		sk, ok := sources[name]
+		switch {
+		case !ok:
+			results = append(results, VendorResult{SkillName: name, Action: VendorActionError, Error: fmt.Errorf("skill not found outside project scope: %s", name)})
+			continue
+		case projectDefined[name]:
+			results = append(results, VendorResult{SkillName: name, Scope: sk.Scope, Action: VendorActionError, Error: fmt.Errorf("skill %s is defined by the project", name)})
+			continue
+		}
+
+		result, entry := s.vendorSkill(sk, s.fs.Join(vendorDir, name), opts.DryRun)
+		if result.Error == nil {
+			lock.setVendored(entry)
+		}
+		results = append(results, result)
+	}
+
+	if !opts.DryRun {
+		if err := saveLockfile(s.fs, agentsDir, lock); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// vendorSkill copies a single skill to dst unless an identical copy is already there.
+func (s *VendorService) vendorSkill(sk *skill.Skill, dst string, dryRun bool) (VendorResult, LockedSkill) {
+	result := VendorResult{SkillName: sk.Name, Scope: sk.Scope}
+
+	sum, err := dirChecksum(s.fs, sk.Path)
+	if err != nil {
+		result.Action = VendorActionError
+		result.Error = fmt.Errorf("failed to checksum skill: %w", err)
+		return result, LockedSkill{}
+	}
+	entry := LockedSkill{Name: sk.Name, Scope: sk.Scope.String(), Source: sk.Path, Checksum: sum}
+
+	result.Action = VendorActionVendored
+	if s.fs.Exists(dst) {
+		if existing, err := dirChecksum(s.fs, dst); err == nil && existing == sum {
+			result.Action = VendorActionUnchanged
+			return result, entry
+		}
+		result.Action = VendorActionUpdated
+	}
+	if dryRun {
+		return result, entry
+	}
+
+	if err := s.fs.RemoveAll(dst); err != nil {
+		result.Action = VendorActionError
+		result.Error = fmt.Errorf("failed to remove previous copy: %w", err)
+		return result, entry
+	}
+	if err := s.fs.MkdirAll(s.fs.Dir(dst), 0o755); err != nil {
+		result.Action = VendorActionError
+		result.Error = fmt.Errorf("failed to create vendor directory: %w", err)
+		return result, entry
+	}
+	if err := s.fs.CopyDir(sk.Path, dst); err != nil {
+		result.Action = VendorActionError
+		result.Error = fmt.Errorf("failed to copy skill: %w", err)
+		return result, entry
+	}
+
+	return result, entry
+}
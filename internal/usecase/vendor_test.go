@@ -0,0 +1,77 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestVendorCopiesGlobalSkillsIntoProject(t *testing.T) {
+	mock, _ := setupSyncEnv()
+	addGlobalSkill(mock, "alpha")
+	addGlobalSkill(mock, "shadowed")
+	mock.Dirs["/project/.agents"] = true
+	mock.Dirs["/project/.agents/skills"] = true
+	mock.Dirs["/project/.agents/skills/shadowed"] = true
+	mock.Files["/project/.agents/skills/shadowed/SKILL.md"] = []byte("---\nname: shadowed\n---\nproject\n")
+
+	cfg := config.DefaultConfig()
+	svc := usecase.NewVendorService(mock, cfg, "/project")
+
+	results, err := svc.Vendor(usecase.VendorOptions{})
+	if err != nil {
+		t.Fatalf("Vendor() error = %v", err)
+	}
+	if len(results) != 1 || results[0].SkillName != "alpha" || results[0].Action != usecase.VendorActionVendored {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if !mock.Exists("/project/.agents/vendor/alpha/SKILL.md") {
+		t.Fatal("expected alpha to be copied into the vendor directory")
+	}
+
+	lock := string(mock.Files["/project/.agents/skillet.lock"])
+	if !strings.Contains(lock, "name: alpha") || !strings.Contains(lock, "source: /home/test/.agents/skills/alpha") {
+		t.Fatalf("unexpected lock file:\n%s", lock)
+	}
+
+	// Vendored copies resolve as project skills.
+	resolved, err := skill.NewStore(mock, cfg, "/project").GetByName("alpha")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if resolved.Scope != skill.ScopeProject || !resolved.Vendored {
+		t.Fatalf("expected vendored project skill, got %+v", resolved)
+	}
+
+	// A second run leaves identical copies alone.
+	results, err = svc.Vendor(usecase.VendorOptions{})
+	if err != nil {
+		t.Fatalf("Vendor() error = %v", err)
+	}
+	if results[0].Action != usecase.VendorActionUnchanged {
+		t.Fatalf("expected unchanged on second run, got %s", results[0].Action)
+	}
+
+	mock.Files["/home/test/.agents/skills/alpha/SKILL.md"] = []byte("---\nname: alpha\n---\nchanged\n")
+	results, err = svc.Vendor(usecase.VendorOptions{Names: []string{"alpha", "shadowed"}})
+	if err != nil {
+		t.Fatalf("Vendor() error = %v", err)
+	}
+	if results[0].Action != usecase.VendorActionUpdated {
+		t.Fatalf("expected updated after source change, got %s", results[0].Action)
+	}
+	if results[1].Action != usecase.VendorActionError {
+		t.Fatalf("expected project-defined skill to be refused, got %s", results[1].Action)
+	}
+}
+
+func TestVendorRequiresProject(t *testing.T) {
+	mock, _ := setupSyncEnv()
+
+	if _, err := usecase.NewVendorService(mock, config.DefaultConfig(), "").Vendor(usecase.VendorOptions{}); err == nil {
+		t.Fatal("expected error outside a project")
+	}
+}
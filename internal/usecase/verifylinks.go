@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// LinkState describes how an installed skill compares to its configured strategy.
+type LinkState string
+
+const (
+	// LinkOK is an install made with the configured strategy.
+	LinkOK LinkState = "ok"
+	// LinkMismatch is an install made with another strategy, such as a copy
+	// left by a symlink that could not be created.
+	LinkMismatch LinkState = "mismatch"
+	// LinkBroken is a symlink that does not point at the skill in the store.
+	LinkBroken LinkState = "broken"
+)
+
+// VerifyLinksOptions contains options for verifying installed skills.
+type VerifyLinksOptions struct {
+	// Fix reinstalls mismatched and broken installs with the configured strategy
+	Fix bool
+	// Target limits verification to a single target (empty for all)
+	Target string
+}
+
+// LinkCheck is the verification result of one installed skill in one target.
+type LinkCheck struct {
+	SkillName string
+	Target    string
+	Scope     skill.Scope
+	// Want is the configured strategy for the skill in this target
+	Want config.Strategy
+	// Got is the mechanism the skill is installed with (after fixing, when Fixed)
+	Got   config.Strategy
+	State LinkState
+	// Fixed is true when the install was replaced and now matches Want
+	Fixed bool
+	Error error
+}
+
+// VerifyLinksService checks installed skills against their configured strategy.
+type VerifyLinksService struct {
+	fs      platformfs.FileSystem
+	store   *skill.Store
+	targets *TargetRegistry
+	cfg     *config.Config
+}
+
+// NewVerifyLinksService creates a new verify-links service.
+func NewVerifyLinksService(fsys platformfs.FileSystem, cfg *config.Config, root string) *VerifyLinksService {
+	return &VerifyLinksService{
+		fs:      fsys,
+		store:   skill.NewStore(fsys, cfg, root),
+		targets: NewTargetRegistry(fsys, root, cfg),
+		cfg:     cfg,
+	}
+}
+
+// VerifyLinks checks every installed skill in each target and, with Fix,
+// reinstalls those not matching their configured strategy.
+// Results are ordered by target name, then by skill name.
+func (s *VerifyLinksService) VerifyLinks(opts VerifyLinksOptions) ([]LinkCheck, error) {
+	skills, err := s.store.GetResolved()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	targets := s.targets.GetAll()
+	if opts.Target != "" {
+		t, err := s.targets.Lookup(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		targets = []*Target{t}
+	}
+
+	var checks []LinkCheck
+	for _, t := range targets {
+		for _, sk := range skills {
+			path, err := t.GetInstallPath(sk.Name, sk.Scope)
+			if err != nil || (!s.fs.Exists(path) && !s.fs.IsSymlink(path)) {
+				continue
+			}
+			check := s.checkLink(t, sk, path)
+			if opts.Fix && check.State != LinkOK {
+				s.fixLink(t, sk, &check)
+			}
+			checks = append(checks, check)
+		}
+	}
+	return checks, nil
+}
+
+// checkLink compares the install of sk in t, at path, against its configured strategy.
+// Dangling symlinks count as broken.
+func (s *VerifyLinksService) checkLink(t *Target, sk *skill.Skill, path string) LinkCheck {
+	check := LinkCheck{
+		SkillName: sk.Name,
+		Target:    t.Name(),
+		Scope:     sk.Scope,
+		Want:      t.strategyFor(strategyForSkill(s.cfg, sk)),
+		Got:       config.StrategyCopy,
+		State:     LinkOK,
+	}
+	if s.fs.IsSymlink(path) {
+		check.Got = config.StrategySymlink
+	}
+	switch {
+	case check.Got == config.StrategySymlink && !t.linksTo(sk):
+		check.State = LinkBroken
+	case check.Want == config.StrategyHardlink && check.Got == config.StrategyCopy:
+		// Hard links are not told apart from the copies they fall back to.
+	case check.Got != check.Want:
+		check.State = LinkMismatch
+	}
+	return check
+}
+
+// fixLink reinstalls sk in t with the configured strategy and records the outcome in check.
+func (s *VerifyLinksService) fixLink(t *Target, sk *skill.Skill, check *LinkCheck) {
+	if err := t.Install(sk, InstallOptions{Strategy: check.Want, Force: true}); err != nil {
+		check.Error = err
+		return
+	}
+	check.Got, _ = t.InstalledStrategy(sk.Name, sk.Scope)
+	if check.Got != check.Want {
+		// Install falls back to copying when symlinks cannot be created.
+		check.Error = fmt.Errorf("could not create a symlink in target %s, installed as a %s", t.Name(), check.Got)
+		return
+	}
+	check.Fixed = true
+}
@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/wwwyo/skillet/internal/config"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func TestVerifyLinks(t *testing.T) {
+	mock, syncSvc := setupSyncEnv()
+	addGlobalSkill(mock, "linked")
+	addGlobalSkill(mock, "copied")
+	addGlobalSkill(mock, "dangling")
+	if _, err := syncSvc.Sync(t.Context(), usecase.SyncOptions{}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	// A copy left where the symlink could not be created, and a link into a
+	// store that no longer exists.
+	delete(mock.Symlinks, "/home/test/.claude/skills/copied")
+	mock.Dirs["/home/test/.claude/skills/copied"] = true
+	mock.Files["/home/test/.claude/skills/copied/SKILL.md"] = []byte("---\nname: copied\n---\n")
+	mock.Symlinks["/home/test/.claude/skills/dangling"] = "/old/home/.agents/skills/dangling"
+
+	svc := usecase.NewVerifyLinksService(mock, config.DefaultConfig(), "")
+	checks, err := svc.VerifyLinks(usecase.VerifyLinksOptions{Target: "claude"})
+	if err != nil {
+		t.Fatalf("VerifyLinks() error = %v", err)
+	}
+	want := map[string]usecase.LinkState{
+		"copied":   usecase.LinkMismatch,
+		"dangling": usecase.LinkBroken,
+		"linked":   usecase.LinkOK,
+	}
+	if len(checks) != len(want) {
+		t.Fatalf("VerifyLinks() = %+v", checks)
+	}
+	for _, c := range checks {
+		if c.State != want[c.SkillName] || c.Fixed {
+			t.Errorf("%s: state = %s, fixed = %v; want %s", c.SkillName, c.State, c.Fixed, want[c.SkillName])
+		}
+	}
+	if c := checks[0]; c.SkillName != "copied" || c.Got != config.StrategyCopy || c.Want != config.StrategySymlink {
+		t.Errorf("copied check = %+v", c)
+	}
+
+	checks, err = svc.VerifyLinks(usecase.VerifyLinksOptions{Fix: true})
+	if err != nil {
+		t.Fatalf("VerifyLinks(fix) error = %v", err)
+	}
+	for _, c := range checks {
+		if c.Error != nil || (c.State != usecase.LinkOK) != c.Fixed {
+			t.Errorf("%s/%s after fix = %+v", c.Target, c.SkillName, c)
+		}
+	}
+	for _, name := range []string{"copied", "dangling"} {
+		if got := mock.Symlinks["/home/test/.claude/skills/"+name]; got != "/home/test/.agents/skills/"+name {
+			t.Errorf("%s link = %q, want the store", name, got)
+		}
+	}
+
+	checks, err = svc.VerifyLinks(usecase.VerifyLinksOptions{})
+	if err != nil {
+		t.Fatalf("VerifyLinks() error = %v", err)
+	}
+	for _, c := range checks {
+		if c.State != usecase.LinkOK {
+			t.Errorf("%s/%s still %s", c.Target, c.SkillName, c.State)
+		}
+	}
+}
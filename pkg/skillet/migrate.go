@@ -0,0 +1,108 @@
+package skillet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/skill"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// MigrateOptions contains options for Migrate.
+type MigrateOptions struct {
+	// Scope is the store to move skills into: global (the default) takes
+	// skills from the targets' user directories, project from the project's
+	Scope Scope
+	// DryRun only reports what would be moved and linked, without making
+	// changes
+	DryRun bool
+	// Conflict resolves target copies that differ from the store copy of the
+	// same name: keep-store deletes the target copy, keep-target archives the
+	// store copy and moves the target copy in, and rename moves the target
+	// copy in as <name>-from-<target>. Empty reports them as conflicts on a
+	// dry run and makes Migrate fail otherwise.
+	Conflict string
+	// AllSources also migrates the entries of the targets' migrate sources,
+	// such as claude's commands and agents, into skills in the commands and
+	// agents collections
+	AllSources bool
+}
+
+// MigrateResult is the outcome of Migrate.
+type MigrateResult struct {
+	// Found maps each target to the skills found in it that are not managed
+	// by skillet
+	Found map[string][]string
+	// Moved holds what happened to each found skill, or on a dry run what
+	// would happen
+	Moved []MigratedSkill
+	// Synced holds the results of the sync that links the moved skills back
+	// into the targets, or on a dry run the links it would make
+	Synced []SyncResult
+}
+
+// MigratedSkill is the outcome of moving one skill from a target into the store.
+type MigratedSkill struct {
+	Skill  string
+	Target string
+	// Source is the path, relative to the target root, of the migrate source
+	// entry the skill was made from (empty for the target's skills directory)
+	Source string
+	// Action is moved, adopted (identical to the store copy), skipped (the
+	// store copy was kept), replaced or renamed (see MigrateOptions.Conflict),
+	// removed (a duplicate of a skill moved from another target), conflict
+	// (on a dry run, a copy that differs from the store copy), or error
+	Action string
+	Err    error
+}
+
+// Migrate moves skills written directly into target directories, such as
+// ~/.claude/skills, into the store and syncs them back, as skillet migrate
+// does.
+func (c *Client) Migrate(ctx context.Context, opts MigrateOptions) (*MigrateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if opts.Scope == "" {
+		opts.Scope = ScopeGlobal
+	}
+	scope, err := c.optionalScope(opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if *scope != skill.ScopeGlobal && *scope != skill.ScopeProject {
+		return nil, fmt.Errorf("skills can only be migrated into the global or project store, not %s", opts.Scope)
+	}
+
+	root := ""
+	if *scope == skill.ScopeProject {
+		root = c.root
+	}
+	svc := usecase.NewMigrateService(c.fs, c.cfg, root, usecase.NewSyncService(c.fs, c.cfg, root))
+	migrateOpts := usecase.MigrateOptions{Scope: *scope, ProjectRoot: root, DryRun: opts.DryRun, AllSources: opts.AllSources}
+	if opts.Conflict != "" {
+		if migrateOpts.Conflict, err = usecase.ParseMigrateConflict(opts.Conflict); err != nil {
+			return nil, err
+		}
+	}
+	found := svc.FindSkillsToMigrate(migrateOpts)
+	if len(found) == 0 && (!opts.AllSources || len(svc.FindSourcesToMigrate(migrateOpts)) == 0) {
+		return &MigrateResult{Found: found}, nil
+	}
+
+	result, err := svc.Migrate(ctx, migrateOpts, found)
+	if err != nil {
+		return nil, fmt.Errorf("migrate failed: %w", err)
+	}
+	converted := &MigrateResult{Found: result.Found, Synced: publicSyncResults(result.SyncResults)}
+	for _, m := range result.MoveResults {
+		converted.Moved = append(converted.Moved, MigratedSkill{
+			Skill:  m.SkillName,
+			Target: m.FromTarget,
+			Source: m.Source,
+			Action: string(m.Action),
+			Err:    m.Error,
+		})
+	}
+	return converted, nil
+}
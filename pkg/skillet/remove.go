@@ -0,0 +1,83 @@
+package skillet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// RemoveOptions contains options for Remove.
+type RemoveOptions struct {
+	// Names are the skills to remove, by name or by glob pattern such as
+	// "legacy-*"
+	Names []string
+	// All removes every skill in Scope instead of Names
+	All bool
+	// Scope limits the removal to one scope (empty for the skill that takes
+	// effect; required with All)
+	Scope Scope
+	// Target uninstalls the skills from this target only, keeping them in
+	// the store
+	Target string
+	// KeepStore uninstalls the skills from every target, keeping them in the store
+	KeepStore bool
+	// DryRun only reports what would be removed, without making changes
+	DryRun bool
+}
+
+// RemoveResult is the outcome of removing one skill.
+type RemoveResult struct {
+	Skill string
+	Scope Scope
+	// StoreRemoved is true when the skill was deleted from the store
+	StoreRemoved bool
+	// Uninstalled lists the targets the skill was uninstalled from
+	Uninstalled []string
+	// Err is set when the skill or one of its installs could not be removed
+	Err error
+}
+
+// Remove uninstalls skills from targets and deletes them from the store, as
+// skillet remove does, without asking for confirmation. Results follow the
+// order of opts.Names.
+func (c *Client) Remove(ctx context.Context, opts RemoveOptions) ([]RemoveResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	scope, err := c.optionalScope(opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+	results, err := usecase.NewRemoveService(c.fs, c.cfg, c.root).Remove(usecase.RemoveOptions{
+		Names:     opts.Names,
+		All:       opts.All,
+		Scope:     scope,
+		Target:    opts.Target,
+		KeepStore: opts.KeepStore,
+		DryRun:    opts.DryRun,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remove failed: %w", err)
+	}
+
+	converted := make([]RemoveResult, 0, len(results))
+	for _, r := range results {
+		result := RemoveResult{
+			Skill:        r.SkillName,
+			Scope:        Scope(r.Scope.String()),
+			StoreRemoved: r.StoreRemoved,
+			Err:          r.Error,
+		}
+		for _, tr := range r.TargetResults {
+			if tr.Error != nil {
+				result.Err = errors.Join(result.Err, fmt.Errorf("%s: %w", tr.Target, tr.Error))
+			} else if tr.Removed {
+				result.Uninstalled = append(result.Uninstalled, tr.Target)
+			}
+		}
+		converted = append(converted, result)
+	}
+	return converted, nil
+}
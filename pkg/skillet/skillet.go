@@ -0,0 +1,284 @@
+// Package skillet is the Go API of skillet. It lists the skills in the store
+// and syncs, checks, removes, and migrates them the way the skillet command
+// does, reading the same config and project settings.
+//
+// The types in this package are stable across releases: fields may be added,
+// but not removed or changed. Every operation takes a context. Canceling it
+// stops syncs and migrations between skills and kills running hook commands;
+// a file operation that has begun runs to completion.
+package skillet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/skill"
+)
+
+// Scope is where a skill is stored.
+type Scope string
+
+const (
+	// ScopeSystem is the read-only machine-wide store (systemPath in the config).
+	ScopeSystem Scope = "system"
+	// ScopeGlobal is the user's store (~/.local/share/skillet by default).
+	ScopeGlobal Scope = "global"
+	// ScopeOrg is the shared organization store (orgPath in the config).
+	ScopeOrg Scope = "org"
+	// ScopeProject is the store in a project's .agents directory.
+	ScopeProject Scope = "project"
+)
+
+// Options configures a Client.
+type Options struct {
+	// ConfigPath is the config file to read. Empty reads
+	// ~/.config/skillet/config.yaml, or uses the defaults when it does not exist.
+	ConfigPath string
+	// ProjectRoot is the project to work in. Empty discovers it from WorkDir
+	// as the command does; outside a project, project scope is unavailable.
+	ProjectRoot string
+	// WorkDir is where project discovery starts (empty for the current directory).
+	WorkDir string
+}
+
+// Client runs skillet operations against one config and project.
+type Client struct {
+	fs   platformfs.FileSystem
+	cfg  *config.Config
+	root string
+}
+
+// New creates a Client from opts, loading the config and the project's
+// .agents/skillet.yaml if there is one.
+func New(opts Options) (*Client, error) {
+	fsys := platformfs.NewFileSystem()
+	store := config.NewStore(fsys)
+
+	cfg, err := store.Load(opts.ConfigPath)
+	if err != nil {
+		if opts.ConfigPath != "" {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		path, pathErr := store.GlobalConfigPath()
+		if pathErr != nil || fsys.Exists(path) {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = config.DefaultConfig()
+	}
+	store.SetProjectDiscovery(cfg.Discovery)
+
+	root, err := findProjectRoot(fsys, store, opts)
+	if err != nil {
+		return nil, err
+	}
+	if root != "" {
+		projectCfg, err := store.LoadProject(root)
+		if err != nil {
+			return nil, err
+		}
+		if projectCfg != nil {
+			cfg = cfg.WithProject(projectCfg)
+		}
+	}
+	return newClient(fsys, cfg, root), nil
+}
+
+// newClient creates a Client for cfg and the project at root ("" for none).
+func newClient(fsys platformfs.FileSystem, cfg *config.Config, root string) *Client {
+	return &Client{fs: fsys, cfg: cfg, root: root}
+}
+
+// findProjectRoot returns the project root opts select, or "" outside a project.
+func findProjectRoot(fsys platformfs.FileSystem, store *config.Store, opts Options) (string, error) {
+	if opts.ProjectRoot != "" {
+		expanded, err := config.ExpandPath(fsys, opts.ProjectRoot)
+		if err != nil {
+			return "", err
+		}
+		root, err := fsys.Abs(expanded)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve project root: %w", err)
+		}
+		if !fsys.IsDir(config.ProjectAgentsDir(root, fsys)) {
+			return "", fmt.Errorf("no .agents directory found in project root: %s", root)
+		}
+		return root, nil
+	}
+
+	dir := opts.WorkDir
+	if dir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get current directory: %w", err)
+		}
+		dir = cwd
+	}
+	root, err := store.FindProjectRootFrom(dir)
+	if err != nil {
+		return "", nil
+	}
+	return filepath.Clean(root), nil
+}
+
+// ProjectRoot returns the root of the project the client works in, or ""
+// outside a project.
+func (c *Client) ProjectRoot() string {
+	return c.root
+}
+
+// Skill is a skill in the store.
+type Skill struct {
+	// Name is the skill's name, collection/name for skills in a collection
+	Name        string
+	Description string
+	// Path is the skill's directory in the store
+	Path  string
+	Scope Scope
+	// Optional is true for skills under skills/optional/, which are installed
+	// only into the targets that enable them
+	Optional bool
+	// Draft is true for skills that sync skips until they are published
+	Draft   bool
+	Version string
+	// Tags, Author, and License come from the skill's frontmatter
+	Tags    []string
+	Author  string
+	License string
+	// Targets lists the only targets the skill works with (empty for all)
+	Targets []string
+	// Requires lists the skills this skill needs installed with it
+	Requires []string
+}
+
+// SkillsOptions selects the skills Skills returns.
+type SkillsOptions struct {
+	// Scope limits the skills to one scope (empty for the skills in effect
+	// across all scopes)
+	Scope Scope
+	// All includes skills overridden by a skill of the same name in a
+	// higher-priority scope
+	All bool
+}
+
+// Skills returns the skills in the store, sorted by name and then by scope
+// priority. Without opts.All, each name appears once, as the skill that takes
+// effect.
+func (c *Client) Skills(ctx context.Context, opts SkillsOptions) ([]Skill, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	store := skill.NewStore(c.fs, c.cfg, c.root)
+
+	var skills []*skill.Skill
+	var err error
+	switch {
+	case opts.Scope != "":
+		scope, scopeErr := internalScope(opts.Scope)
+		if scopeErr != nil {
+			return nil, scopeErr
+		}
+		skills, err = store.GetByScope(scope)
+	case opts.All:
+		skills, err = store.GetAll()
+	default:
+		skills, err = store.GetResolved()
+		var conflictErr *skill.ConflictError
+		if errors.As(err, &conflictErr) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	result := make([]Skill, 0, len(skills))
+	for _, sk := range skills {
+		result = append(result, publicSkill(sk))
+	}
+	slices.SortStableFunc(result, func(a, b Skill) int {
+		if n := strings.Compare(a.Name, b.Name); n != 0 {
+			return n
+		}
+		return scopePriority(b.Scope) - scopePriority(a.Scope)
+	})
+	return result, nil
+}
+
+// Skill returns the skill with the given name that takes effect.
+func (c *Client) Skill(ctx context.Context, name string) (Skill, error) {
+	if err := ctx.Err(); err != nil {
+		return Skill{}, err
+	}
+	sk, err := skill.NewStore(c.fs, c.cfg, c.root).GetByName(name)
+	if err != nil {
+		return Skill{}, fmt.Errorf("skill not found: %w", err)
+	}
+	return publicSkill(sk), nil
+}
+
+// publicSkill converts a store skill to a Skill.
+func publicSkill(sk *skill.Skill) Skill {
+	meta := sk.Metadata()
+	return Skill{
+		Name:        sk.Name,
+		Description: meta.Description,
+		Path:        sk.Path,
+		Scope:       Scope(sk.Scope.String()),
+		Optional:    sk.Category == skill.CategoryOptional,
+		Draft:       meta.Draft,
+		Version:     meta.Version,
+		Tags:        slices.Clone(meta.Tags),
+		Author:      meta.Author,
+		License:     meta.License,
+		Targets:     slices.Clone(meta.Targets),
+		Requires:    slices.Clone(meta.Requires),
+	}
+}
+
+// internalScope converts a Scope to the store's scope.
+func internalScope(s Scope) (skill.Scope, error) {
+	switch s {
+	case ScopeSystem:
+		return skill.ScopeSystem, nil
+	case ScopeGlobal:
+		return skill.ScopeGlobal, nil
+	case ScopeOrg:
+		return skill.ScopeOrg, nil
+	case ScopeProject:
+		return skill.ScopeProject, nil
+	default:
+		return 0, fmt.Errorf("unknown scope %q (use system, global, org, or project)", s)
+	}
+}
+
+// optionalScope converts an optional Scope ("" for all scopes) to the
+// store's scope, checking that project scope is available.
+func (c *Client) optionalScope(s Scope) (*skill.Scope, error) {
+	if s == "" {
+		return nil, nil
+	}
+	scope, err := internalScope(s)
+	if err != nil {
+		return nil, err
+	}
+	if scope == skill.ScopeProject && c.root == "" {
+		return nil, fmt.Errorf("not in a project directory")
+	}
+	return &scope, nil
+}
+
+// scopePriority returns the priority of a scope; higher wins.
+func scopePriority(s Scope) int {
+	scope, err := internalScope(s)
+	if err != nil {
+		return 0
+	}
+	return (&skill.Skill{Scope: scope}).Priority()
+}
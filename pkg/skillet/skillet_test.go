@@ -0,0 +1,144 @@
+package skillet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/wwwyo/skillet/internal/config"
+	platformfs "github.com/wwwyo/skillet/internal/platform/fs"
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+func newTestClient() (*platformfs.MockFileSystem, *Client) {
+	mock := platformfs.NewMockFileSystem()
+	mock.HomeDir = "/home/test"
+	for _, dir := range []string{"/home/test/.agents", "/home/test/.agents/skills", "/home/test/.claude", "/home/test/.claude/skills", "/project", "/project/.agents", "/project/.agents/skills"} {
+		mock.Dirs[dir] = true
+	}
+	addSkill := func(dir, name, frontmatter string) {
+		mock.Dirs[dir+"/"+name] = true
+		mock.Files[dir+"/"+name+"/SKILL.md"] = []byte("---\nname: " + name + "\n" + frontmatter + "---\n")
+	}
+	addSkill("/home/test/.agents/skills", "review", "description: Review code\n")
+	addSkill("/home/test/.agents/skills", "shared", "")
+	addSkill("/project/.agents/skills", "shared", "requires: [review]\n")
+
+	cfg := config.DefaultConfig()
+	cfg.Targets = map[string]config.TargetConfig{"claude": {Enabled: true}}
+	return mock, newClient(mock, cfg, "/project")
+}
+
+func TestClientSkills(t *testing.T) {
+	_, c := newTestClient()
+	ctx := context.Background()
+
+	skills, err := c.Skills(ctx, SkillsOptions{})
+	if err != nil {
+		t.Fatalf("Skills() error = %v", err)
+	}
+	if len(skills) != 2 || skills[0].Name != "review" || skills[0].Description != "Review code" {
+		t.Fatalf("Skills() = %+v, want review and shared", skills)
+	}
+	if skills[1].Scope != ScopeProject || len(skills[1].Requires) != 1 {
+		t.Errorf("shared = %+v, want the project copy requiring review", skills[1])
+	}
+
+	all, err := c.Skills(ctx, SkillsOptions{All: true})
+	if err != nil {
+		t.Fatalf("Skills(All) error = %v", err)
+	}
+	if len(all) != 3 || all[1].Scope != ScopeProject || all[2].Scope != ScopeGlobal {
+		t.Errorf("Skills(All) = %+v, want both copies of shared, project first", all)
+	}
+	global, err := c.Skills(ctx, SkillsOptions{Scope: ScopeGlobal})
+	if err != nil || len(global) != 2 {
+		t.Errorf("Skills(global) = %+v, %v; want two skills", global, err)
+	}
+	if _, err := c.Skills(ctx, SkillsOptions{Scope: "team"}); err == nil {
+		t.Error("Skills() with an unknown scope should fail")
+	}
+
+	sk, err := c.Skill(ctx, "shared")
+	if err != nil || sk.Scope != ScopeProject {
+		t.Errorf("Skill(shared) = %+v, %v; want the project copy", sk, err)
+	}
+}
+
+func TestClientSyncStatusRemove(t *testing.T) {
+	mock, c := newTestClient()
+	ctx := context.Background()
+
+	status, err := c.Status(ctx, StatusOptions{})
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if len(status) != 1 || status[0].InSync || len(status[0].Missing) != 2 {
+		t.Fatalf("Status() = %+v, want claude missing both skills", status)
+	}
+
+	results, err := c.Sync(ctx, SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	var installs int
+	for _, r := range results {
+		if r.Action == SyncInstall {
+			installs++
+		}
+	}
+	if installs != 2 || !mock.IsSymlink("/home/test/.claude/skills/review") || !mock.IsSymlink("/project/.claude/skills/shared") {
+		t.Fatalf("Sync() = %+v, want review and shared installed", results)
+	}
+	if status, err := c.Status(ctx, StatusOptions{}); err != nil || !status[0].InSync {
+		t.Errorf("Status() after sync = %+v, %v; want in sync", status, err)
+	}
+
+	removed, err := c.Remove(ctx, RemoveOptions{Names: []string{"review"}})
+	if err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if len(removed) != 1 || !removed[0].StoreRemoved || removed[0].Err != nil || len(removed[0].Uninstalled) != 1 {
+		t.Errorf("Remove() = %+v, want review removed from the store and claude", removed)
+	}
+	if mock.Exists("/home/test/.agents/skills/review") || mock.Exists("/home/test/.claude/skills/review") {
+		t.Error("review should be removed")
+	}
+}
+
+func TestClientSyncRunLocked(t *testing.T) {
+	mock, c := newTestClient()
+	host, _ := os.Hostname()
+	started := time.Now().UTC().Truncate(time.Second)
+	data, err := json.Marshal(map[string]any{"pid": os.Getpid(), "host": host, "started": started})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lockPath := "/home/test/.agents/" + usecase.RunLockFileName
+	mock.Files[lockPath] = data
+
+	_, err = c.Sync(context.Background(), SyncOptions{})
+	var locked *RunLockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("Sync() error = %v, want *RunLockedError", err)
+	}
+	if locked.Path != lockPath || locked.PID != os.Getpid() || locked.Host != host || !locked.Started.Equal(started) {
+		t.Errorf("RunLockedError = %+v, want pid %d on %s at %s", locked, os.Getpid(), host, lockPath)
+	}
+}
+
+func TestClientCanceledContext(t *testing.T) {
+	_, c := newTestClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.Sync(ctx, SyncOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Sync() error = %v, want context.Canceled", err)
+	}
+	if _, err := c.Skills(ctx, SkillsOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Skills() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,199 @@
+package skillet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wwwyo/skillet/internal/usecase"
+)
+
+// SyncAction is what a sync did with a skill in a target.
+type SyncAction string
+
+const (
+	SyncInstall   SyncAction = "install"
+	SyncUpdate    SyncAction = "update"
+	SyncUninstall SyncAction = "uninstall"
+	SyncSkip      SyncAction = "skip"
+	SyncError     SyncAction = "error"
+	// SyncManifest reports a regenerated target manifest; the result's Skill
+	// holds the manifest path.
+	SyncManifest SyncAction = "manifest"
+	// SyncExtra reports a skill in a target that is not in the store. Sync
+	// leaves it in place.
+	SyncExtra SyncAction = "extra"
+	// SyncExcluded reports a skill that its frontmatter or the config keeps
+	// out of the target.
+	SyncExcluded SyncAction = "excluded"
+)
+
+// ErrSyncRolledBack is returned by an atomic Sync that undid its changes
+// because a skill failed or ctx was canceled.
+var ErrSyncRolledBack = usecase.ErrSyncRolledBack
+
+// RunLockedError is returned by Sync while another skillet run syncs the
+// same agents directories.
+type RunLockedError struct {
+	// Path is the lock file the other run holds
+	Path string
+	// PID, Host, and Started identify the other run (zero when its lock
+	// could not be read)
+	PID     int
+	Host    string
+	Started time.Time
+}
+
+func (e *RunLockedError) Error() string {
+	locked := usecase.RunLockedError{Path: e.Path, PID: e.PID, Host: e.Host, Started: e.Started}
+	return locked.Error()
+}
+
+// SyncOptions contains options for Sync.
+type SyncOptions struct {
+	// DryRun reports what would be done without making changes
+	DryRun bool
+	// Force reinstalls skills that are already installed
+	Force bool
+	// Target limits the sync to one target (empty for every enabled target)
+	Target string
+	// Scope limits the sync to the skills of one scope (empty for all)
+	Scope Scope
+	// Names limits the sync to these skills and the skills they require
+	// (empty for all)
+	Names []string
+	// SkipMissingCommands skips skills whose required commands are not on PATH
+	SkipMissingCommands bool
+	// Atomic stops at the first skill that fails and undoes the changes made
+	// to the targets so far
+	Atomic bool
+	// WaitForLock waits for another skillet run syncing the same agents
+	// directories to finish, instead of failing with a *RunLockedError
+	WaitForLock bool
+}
+
+// SyncResult is the outcome of syncing one skill to one target.
+type SyncResult struct {
+	Skill  string
+	Target string
+	Action SyncAction
+	// Err is set when Action is SyncError
+	Err error
+	// Warnings holds problems that did not stop the sync, such as missing
+	// required commands
+	Warnings []string
+	// RolledBack is set when an atomic sync undid the change
+	RolledBack bool
+}
+
+// Sync installs the skills in the store into the enabled targets, as
+// skillet sync does, including its hooks and lock file. Results are ordered
+// by target, then by skill name. When ctx is canceled, the results of the
+// skills synced so far are returned with ctx's error. An atomic sync that
+// fails returns its results with an error matching ErrSyncRolledBack.
+func (c *Client) Sync(ctx context.Context, opts SyncOptions) ([]SyncResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	scope, err := c.optionalScope(opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+	results, err := usecase.NewSyncService(c.fs, c.cfg, c.root).Sync(ctx, usecase.SyncOptions{
+		DryRun:              opts.DryRun,
+		Force:               opts.Force,
+		Target:              opts.Target,
+		Scope:               scope,
+		Names:               opts.Names,
+		SkipMissingCommands: opts.SkipMissingCommands,
+		Atomic:              opts.Atomic,
+		WaitForLock:         opts.WaitForLock,
+	})
+	if err != nil {
+		return publicSyncResults(results), fmt.Errorf("sync failed: %w", publicSyncError(err))
+	}
+	return publicSyncResults(results), nil
+}
+
+// publicSyncError converts a run lock error from the sync engine to a
+// *RunLockedError. Other errors are returned as they are.
+func publicSyncError(err error) error {
+	var locked *usecase.RunLockedError
+	if !errors.As(err, &locked) {
+		return err
+	}
+	return &RunLockedError{Path: locked.Path, PID: locked.PID, Host: locked.Host, Started: locked.Started}
+}
+
+// publicSyncResults converts sync engine results to SyncResults.
+func publicSyncResults(results []usecase.SyncResult) []SyncResult {
+	converted := make([]SyncResult, 0, len(results))
+	for _, r := range results {
+		converted = append(converted, SyncResult{
+			Skill:      r.SkillName,
+			Target:     r.Target,
+			Action:     SyncAction(r.Action),
+			Err:        r.Error,
+			Warnings:   r.Warnings,
+			RolledBack: r.RolledBack,
+		})
+	}
+	return converted
+}
+
+// StatusOptions contains options for Status.
+type StatusOptions struct {
+	// Scope limits the status to the skills of one scope (empty for all)
+	Scope Scope
+}
+
+// TargetStatus is how far a target is from the store.
+type TargetStatus struct {
+	Target string
+	// Installed lists the skills installed as the store expects
+	Installed []string
+	// Missing lists the skills the target should have but does not
+	Missing []string
+	// Extra lists entries for skills not in the store, and optional skills
+	// installed but not enabled for the target
+	Extra []string
+	// Stale lists copies whose content differs from the store
+	Stale []string
+	// InSync is true when a sync would change nothing
+	InSync bool
+	// Err is set when the target could not be checked
+	Err error
+}
+
+// Status reports the state of every enabled target, ordered by target name.
+func (c *Client) Status(ctx context.Context, opts StatusOptions) ([]TargetStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	scope, err := c.optionalScope(opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := usecase.NewStatusService(c.fs, c.cfg, c.root).GetStatus(usecase.StatusOptions{Scope: scope})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status: %w", err)
+	}
+
+	result := make([]TargetStatus, 0, len(statuses))
+	for _, st := range statuses {
+		status := TargetStatus{
+			Target:    st.Target,
+			Installed: st.Installed,
+			Missing:   st.Missing,
+			Extra:     st.Extra,
+			InSync:    st.InSync,
+			Err:       st.Error,
+		}
+		for _, stale := range st.Stale {
+			status.Stale = append(status.Stale, stale.SkillName)
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}